@@ -47,6 +47,8 @@ type (
 	Path          = dbtype.Path
 	Record        = db.Record
 	InvalidValue  = dbtype.InvalidValue
+	UnknownValue  = dbtype.UnknownValue
+	StreamedList  = dbtype.StreamedList
 )
 
 // DateOf creates a neo4j.Date from time.Time.
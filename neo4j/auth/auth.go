@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package auth provides the authentication tokens and the token managers used by drivers to authenticate their
+// connections.
+package auth
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Token contains credentials to be sent over to the neo4j server.
+// Tokens are created with the functions of the neo4j package, like neo4j.BasicAuth, which keep the credentials
+// private to the driver. A Token is a TokenManager which always supplies itself.
+type Token interface {
+	TokenManager
+}
+
+// TokenManager supplies the drivers with authentication tokens on demand.
+// Connections are authenticated with the token returned by GetAuthToken when they are created and, if the server
+// supports re-authentication, every time they are borrowed from the pool with a token different from their own.
+// Implementations must be safe for concurrent use.
+type TokenManager interface {
+	// GetAuthToken returns the token to authenticate connections with.
+	// It is called often, implementations should cache the token rather than fetch a new one for every call.
+	GetAuthToken(ctx context.Context) (Token, error)
+	// OnTokenExpired is called when the server notifies that the given token has expired.
+	// Subsequent calls to GetAuthToken are expected to return a fresh token.
+	OnTokenExpired(ctx context.Context, token Token) error
+}
+
+// ExpirationBasedTokenManager returns a TokenManager which caches the token returned by provider until its
+// expiration time passes or the server notifies that it expired, and calls provider again to get a fresh one.
+// A nil expiration time means the token only expires when the server says so.
+func ExpirationBasedTokenManager(provider func(context.Context) (Token, *time.Time, error)) TokenManager {
+	return &expirationBasedTokenManager{provider: provider, now: time.Now}
+}
+
+type expirationBasedTokenManager struct {
+	provider   func(context.Context) (Token, *time.Time, error)
+	token      Token
+	expiration *time.Time
+	mut        sync.Mutex
+	now        func() time.Time
+}
+
+func (m *expirationBasedTokenManager) GetAuthToken(ctx context.Context) (Token, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	if m.token == nil || (m.expiration != nil && !m.now().Before(*m.expiration)) {
+		token, expiration, err := m.provider(ctx)
+		if err != nil {
+			return nil, err
+		}
+		m.token = token
+		m.expiration = expiration
+	}
+	return m.token, nil
+}
+
+func (m *expirationBasedTokenManager) OnTokenExpired(_ context.Context, token Token) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	// another connection may already have caused the token to be refreshed
+	if m.token != nil && reflect.DeepEqual(m.token, token) {
+		m.token = nil
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// testToken is a Token whose credentials can be read by the tests
+type testToken struct {
+	credentials any
+}
+
+func (t testToken) GetAuthToken(context.Context) (Token, error) {
+	return t, nil
+}
+
+func (t testToken) OnTokenExpired(context.Context, Token) error {
+	return nil
+}
+
+func credentialsOf(token Token) any {
+	return token.(testToken).credentials
+}
+
+func TestExpirationBasedTokenManager(outer *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	newManager := func(expiresIn *time.Duration) (*expirationBasedTokenManager, *int) {
+		calls := 0
+		manager := ExpirationBasedTokenManager(func(context.Context) (Token, *time.Time, error) {
+			calls++
+			token := testToken{credentials: calls}
+			if expiresIn == nil {
+				return token, nil, nil
+			}
+			expiration := now.Add(*expiresIn)
+			return token, &expiration, nil
+		}).(*expirationBasedTokenManager)
+		manager.now = func() time.Time { return now }
+		return manager, &calls
+	}
+
+	outer.Run("Caches the token until it expires", func(t *testing.T) {
+		expiresIn := time.Minute
+		manager, calls := newManager(&expiresIn)
+
+		first, _ := manager.GetAuthToken(ctx)
+		second, _ := manager.GetAuthToken(ctx)
+		if *calls != 1 || credentialsOf(first) != credentialsOf(second) {
+			t.Errorf("Expected the token to be cached but the provider was called %d times", *calls)
+		}
+
+		manager.now = func() time.Time { return now.Add(expiresIn) }
+		third, _ := manager.GetAuthToken(ctx)
+		if *calls != 2 || credentialsOf(third) != 2 {
+			t.Errorf("Expected a fresh token once expired but got %v", third)
+		}
+	})
+
+	outer.Run("Fetches a fresh token when notified of its expiration", func(t *testing.T) {
+		manager, calls := newManager(nil)
+		token, _ := manager.GetAuthToken(ctx)
+
+		if err := manager.OnTokenExpired(ctx, token); err != nil {
+			t.Errorf("Expected no error but got %v", err)
+		}
+		fresh, _ := manager.GetAuthToken(ctx)
+
+		if *calls != 2 || credentialsOf(fresh) != 2 {
+			t.Errorf("Expected a fresh token but got %v", fresh)
+		}
+	})
+
+	outer.Run("Ignores expiration of already replaced tokens", func(t *testing.T) {
+		manager, calls := newManager(nil)
+		stale, _ := manager.GetAuthToken(ctx)
+		_ = manager.OnTokenExpired(ctx, stale)
+		_, _ = manager.GetAuthToken(ctx)
+
+		_ = manager.OnTokenExpired(ctx, stale)
+		_, _ = manager.GetAuthToken(ctx)
+
+		if *calls != 2 {
+			t.Errorf("Expected the current token to be kept but the provider was called %d times", *calls)
+		}
+	})
+
+	outer.Run("Returns provider errors", func(t *testing.T) {
+		providerErr := errors.New("unreachable identity provider")
+		manager := ExpirationBasedTokenManager(func(context.Context) (Token, *time.Time, error) {
+			return nil, nil, providerErr
+		})
+
+		_, err := manager.GetAuthToken(ctx)
+
+		if err != providerErr {
+			t.Errorf("Expected provider error but got %v", err)
+		}
+	})
+}
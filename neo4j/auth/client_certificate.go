@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+)
+
+// ClientCertificateProvider supplies the drivers with the client certificate presented to the server during the TLS
+// handshake, when the server requires mutual TLS.
+// GetClientCertificate is called for every new connection, which allows implementations to rotate certificates
+// without restarting the driver. Connections already established keep the certificate they were created with.
+// Implementations must be safe for concurrent use.
+type ClientCertificateProvider interface {
+	// GetClientCertificate returns the certificate, along with its private key, to present to the server.
+	GetClientCertificate(ctx context.Context) (*tls.Certificate, error)
+}
+
+// NewRotatingClientCertificateProvider returns a ClientCertificateProvider which supplies the given certificate
+// until it is replaced with UpdateCertificate.
+func NewRotatingClientCertificateProvider(certificate tls.Certificate) *RotatingClientCertificateProvider {
+	return &RotatingClientCertificateProvider{certificate: certificate}
+}
+
+// RotatingClientCertificateProvider is a ClientCertificateProvider whose certificate can be replaced at any time,
+// for instance when the certificate files are renewed.
+type RotatingClientCertificateProvider struct {
+	certificate tls.Certificate
+	mut         sync.RWMutex
+}
+
+// GetClientCertificate returns the current certificate
+func (p *RotatingClientCertificateProvider) GetClientCertificate(context.Context) (*tls.Certificate, error) {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+	certificate := p.certificate
+	return &certificate, nil
+}
+
+// UpdateCertificate replaces the certificate presented by the connections created from now on
+func (p *RotatingClientCertificateProvider) UpdateCertificate(certificate tls.Certificate) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.certificate = certificate
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+)
+
+func TestRotatingClientCertificateProvider(outer *testing.T) {
+	ctx := context.Background()
+
+	outer.Run("Supplies the initial certificate", func(t *testing.T) {
+		provider := NewRotatingClientCertificateProvider(tls.Certificate{Certificate: [][]byte{[]byte("first")}})
+
+		certificate, err := provider.GetClientCertificate(ctx)
+
+		if err != nil || string(certificate.Certificate[0]) != "first" {
+			t.Errorf("Expected the initial certificate, got %v (error: %v)", certificate, err)
+		}
+	})
+
+	outer.Run("Supplies the updated certificate", func(t *testing.T) {
+		provider := NewRotatingClientCertificateProvider(tls.Certificate{Certificate: [][]byte{[]byte("first")}})
+		previous, _ := provider.GetClientCertificate(ctx)
+
+		provider.UpdateCertificate(tls.Certificate{Certificate: [][]byte{[]byte("second")}})
+		certificate, err := provider.GetClientCertificate(ctx)
+
+		if err != nil || string(certificate.Certificate[0]) != "second" {
+			t.Errorf("Expected the updated certificate, got %v (error: %v)", certificate, err)
+		}
+		if string(previous.Certificate[0]) != "first" {
+			t.Errorf("Expected the previously supplied certificate to be left untouched")
+		}
+	})
+}
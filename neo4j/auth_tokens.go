@@ -19,11 +19,49 @@
 
 package neo4j
 
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/auth"
+)
+
 // AuthToken contains credentials to be sent over to the neo4j server.
+// AuthToken is an auth.Token, i.e. an auth.TokenManager which always supplies the same token, see
+// auth.ExpirationBasedTokenManager for tokens that need to be rotated.
 type AuthToken struct {
 	tokens map[string]any
 }
 
+// GetAuthToken returns the token itself
+func (t AuthToken) GetAuthToken(context.Context) (auth.Token, error) {
+	return t, nil
+}
+
+// OnTokenExpired is a no-op, a static token cannot be refreshed
+func (t AuthToken) OnTokenExpired(context.Context, auth.Token) error {
+	return nil
+}
+
+// authTokens returns the credentials of a token created by the functions of this package
+func authTokens(token auth.Token) (map[string]any, error) {
+	authToken, ok := token.(AuthToken)
+	if !ok {
+		return nil, &UsageError{Message: fmt.Sprintf("Unsupported auth token type %T, use the token functions of "+
+			"the neo4j package like BasicAuth", token)}
+	}
+	return authToken.tokens, nil
+}
+
+// getAuthTokens returns the credentials of the current token of the given manager
+func getAuthTokens(ctx context.Context, manager auth.TokenManager) (map[string]any, error) {
+	token, err := manager.GetAuthToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return authTokens(token)
+}
+
 const keyScheme = "scheme"
 const schemeNone = "none"
 const schemeBasic = "basic"
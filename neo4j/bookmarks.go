@@ -44,6 +44,15 @@ type BookmarkManager interface {
 	GetBookmarks(ctx context.Context) (Bookmarks, error)
 }
 
+// BookmarkForgetter is implemented by bookmark managers able to discard some of the bookmarks they track, like the
+// ones created by NewBookmarkManager. This allows the driver to recover from bookmarks the server does not know,
+// see ExecuteQueryWithInvalidBookmarksRecovery.
+// This API is experimental and may be changed or removed without prior notice
+type BookmarkForgetter interface {
+	// ForgetBookmarks stops tracking the given bookmarks
+	ForgetBookmarks(ctx context.Context, bookmarks Bookmarks) error
+}
+
 // BookmarkManagerConfig is an experimental API and may be changed or removed
 // without prior notice
 type BookmarkManagerConfig struct {
@@ -57,12 +66,38 @@ type BookmarkManagerConfig struct {
 	// The hook is called with the database and the new bookmarks
 	// Note: the order of the supplied bookmark slice is not guaranteed
 	BookmarkConsumer func(ctx context.Context, bookmarks Bookmarks) error
+
+	// ErrorPolicy defines how errors of BookmarkSupplier and BookmarkConsumer are handled
+	// By default, they are returned to the caller, failing the ongoing session operation
+	ErrorPolicy BookmarkManagerErrorPolicy
+
+	// Hook called whenever BookmarkSupplier or BookmarkConsumer fails, regardless of ErrorPolicy
+	// This allows tracking the failures of an external bookmark store, including the tolerated ones
+	OnError func(ctx context.Context, err error)
 }
 
+// BookmarkManagerErrorPolicy defines how a bookmark manager created with NewBookmarkManager handles the errors of
+// its BookmarkSupplier and BookmarkConsumer
+// This API is experimental and may be changed or removed without prior notice
+type BookmarkManagerErrorPolicy int
+
+const (
+	// FailOnBookmarkErrors returns the errors to the caller, failing the ongoing session operation
+	FailOnBookmarkErrors BookmarkManagerErrorPolicy = iota
+	// ContinueOnBookmarkErrors ignores the errors, after reporting them to BookmarkManagerConfig.OnError.
+	// When BookmarkSupplier fails, only the bookmarks tracked by the bookmark manager are used: causal consistency is
+	// then only guaranteed with respect to the work tracked by this bookmark manager.
+	// When BookmarkConsumer fails, the bookmark manager still tracks the new bookmarks.
+	// This prevents a flaky external bookmark store from failing all the queries.
+	ContinueOnBookmarkErrors
+)
+
 type bookmarkManager struct {
 	bookmarks        collection.Set[string]
 	supplyBookmarks  func(context.Context) (Bookmarks, error)
 	consumeBookmarks func(context.Context, Bookmarks) error
+	errorPolicy      BookmarkManagerErrorPolicy
+	onError          func(context.Context, error)
 	mutex            sync.RWMutex
 }
 
@@ -77,7 +112,9 @@ func (b *bookmarkManager) UpdateBookmarks(ctx context.Context, previousBookmarks
 	b.bookmarks.AddAll(newBookmarks)
 	bookmarksToNotify = b.bookmarks.Values()
 	if b.consumeBookmarks != nil {
-		return b.consumeBookmarks(ctx, bookmarksToNotify)
+		if err := b.consumeBookmarks(ctx, bookmarksToNotify); err != nil {
+			return b.handleError(ctx, err)
+		}
 	}
 	return nil
 }
@@ -87,7 +124,10 @@ func (b *bookmarkManager) GetBookmarks(ctx context.Context) (Bookmarks, error) {
 	if b.supplyBookmarks != nil {
 		bookmarks, err := b.supplyBookmarks(ctx)
 		if err != nil {
-			return nil, err
+			if err = b.handleError(ctx, err); err != nil {
+				return nil, err
+			}
+			bookmarks = nil
 		}
 		extraBookmarks = bookmarks
 	}
@@ -104,14 +144,128 @@ func (b *bookmarkManager) GetBookmarks(ctx context.Context) (Bookmarks, error) {
 	return bookmarks.Values(), nil
 }
 
+func (b *bookmarkManager) ForgetBookmarks(ctx context.Context, bookmarks Bookmarks) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.bookmarks.RemoveAll(bookmarks)
+	if b.consumeBookmarks != nil {
+		if err := b.consumeBookmarks(ctx, b.bookmarks.Values()); err != nil {
+			return b.handleError(ctx, err)
+		}
+	}
+	return nil
+}
+
+// handleError reports the given supplier or consumer error and returns it, unless the error policy tolerates it
+func (b *bookmarkManager) handleError(ctx context.Context, err error) error {
+	if b.onError != nil {
+		b.onError(ctx, err)
+	}
+	if b.errorPolicy == ContinueOnBookmarkErrors {
+		return nil
+	}
+	return err
+}
+
 func NewBookmarkManager(config BookmarkManagerConfig) BookmarkManager {
 	return &bookmarkManager{
 		bookmarks:        collection.NewSet(config.InitialBookmarks),
 		supplyBookmarks:  config.BookmarkSupplier,
 		consumeBookmarks: config.BookmarkConsumer,
+		errorPolicy:      config.ErrorPolicy,
+		onError:          config.OnError,
+	}
+}
+
+// BookmarkManagerEventType identifies the kind of operation reported by an observable bookmark manager
+// This API is experimental and may be changed or removed without prior notice
+type BookmarkManagerEventType int
+
+const (
+	// BookmarksSupplied is emitted when bookmarks are supplied to a bookmark holder (like a Session) via
+	// BookmarkManager.GetBookmarks
+	BookmarksSupplied BookmarkManagerEventType = iota
+	// BookmarksReplaced is emitted when a bookmark holder (like a Session) replaces its previous bookmarks with new ones
+	// via BookmarkManager.UpdateBookmarks
+	BookmarksReplaced
+)
+
+func (t BookmarkManagerEventType) String() string {
+	switch t {
+	case BookmarksSupplied:
+		return "supplied"
+	case BookmarksReplaced:
+		return "replaced"
+	default:
+		return "unknown"
 	}
 }
 
+// BookmarkManagerEvent describes an operation performed by the bookmark manager wrapped by
+// NewObservableBookmarkManager
+// This API is experimental and may be changed or removed without prior notice
+type BookmarkManagerEvent struct {
+	Type BookmarkManagerEventType
+	// PreviousBookmarks are the bookmarks being replaced, only set for BookmarksReplaced events
+	PreviousBookmarks Bookmarks
+	// Bookmarks are the supplied bookmarks for BookmarksSupplied events and the new bookmarks for BookmarksReplaced
+	// events
+	Bookmarks Bookmarks
+	// Err is the error returned by the wrapped bookmark manager, if any
+	Err error
+}
+
+type observableBookmarkManager struct {
+	delegate BookmarkManager
+	onEvent  func(context.Context, BookmarkManagerEvent)
+}
+
+// NewObservableBookmarkManager wraps the given bookmark manager so that the provided callback is called after every
+// bookmark supply and replacement.
+// This helps debugging causal consistency issues without instrumenting every call site, for instance:
+//
+//	bookmarkManager := neo4j.NewObservableBookmarkManager(
+//		neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{}),
+//		func(_ context.Context, event neo4j.BookmarkManagerEvent) {
+//			log.Printf("bookmarks %s: %v -> %v (error: %v)", event.Type, event.PreviousBookmarks, event.Bookmarks, event.Err)
+//		})
+//
+// The callback is called synchronously and must be safe for concurrent use if the bookmark manager is shared across
+// sessions.
+// This API is experimental and may be changed or removed without prior notice
+func NewObservableBookmarkManager(delegate BookmarkManager, onEvent func(context.Context, BookmarkManagerEvent)) BookmarkManager {
+	return &observableBookmarkManager{delegate: delegate, onEvent: onEvent}
+}
+
+func (o *observableBookmarkManager) UpdateBookmarks(ctx context.Context, previousBookmarks, newBookmarks Bookmarks) error {
+	err := o.delegate.UpdateBookmarks(ctx, previousBookmarks, newBookmarks)
+	o.onEvent(ctx, BookmarkManagerEvent{
+		Type:              BookmarksReplaced,
+		PreviousBookmarks: previousBookmarks,
+		Bookmarks:         newBookmarks,
+		Err:               err,
+	})
+	return err
+}
+
+func (o *observableBookmarkManager) GetBookmarks(ctx context.Context) (Bookmarks, error) {
+	bookmarks, err := o.delegate.GetBookmarks(ctx)
+	o.onEvent(ctx, BookmarkManagerEvent{
+		Type:      BookmarksSupplied,
+		Bookmarks: bookmarks,
+		Err:       err,
+	})
+	return bookmarks, err
+}
+
+// ForgetBookmarks forwards to the wrapped bookmark manager, if it implements BookmarkForgetter
+func (o *observableBookmarkManager) ForgetBookmarks(ctx context.Context, bookmarks Bookmarks) error {
+	if forgetter, ok := o.delegate.(BookmarkForgetter); ok {
+		return forgetter.ForgetBookmarks(ctx, bookmarks)
+	}
+	return nil
+}
+
 // CombineBookmarks is a helper method to combine []Bookmarks into a single Bookmarks instance.
 // Let s1, s2, s3 be Session interfaces. You can easily causally chain the sessions like so:
 // ```go
@@ -136,6 +290,45 @@ func CombineBookmarks(bookmarks ...Bookmarks) Bookmarks {
 	return res
 }
 
+// NewChainedSession creates a new session with the provided configuration that is causally chained to the specified
+// previous sessions.
+// This codifies the recommended read-your-writes pattern:
+//
+//	writeSession := driver.NewSession(ctx, neo4j.SessionConfig{})
+//	// [...] write something within writeSession
+//	readSession, err := neo4j.NewChainedSession(ctx, driver, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead}, writeSession)
+//	// [...] whatever writeSession wrote is visible to readSession
+//
+// The initial bookmarks of the new session are made of the bookmarks already set in the configuration, combined with
+// the LastBookmarks of each previous session.
+// If the configuration does not define a BookmarkManager, the bookmarks tracked by the BookmarkManager of each
+// previous session (if any) are included as well. This ensures that the chaining also covers work done against other
+// databases by sessions sharing that BookmarkManager.
+//
+// An error is returned if the bookmarks of a previous session's BookmarkManager cannot be retrieved.
+func NewChainedSession(ctx context.Context, driver DriverWithContext, config SessionConfig,
+	previousSessions ...SessionWithContext) (SessionWithContext, error) {
+
+	bookmarks := collection.NewSet(config.Bookmarks)
+	for _, previousSession := range previousSessions {
+		bookmarks.AddAll(previousSession.LastBookmarks())
+		if config.BookmarkManager != nil {
+			continue
+		}
+		session, ok := previousSession.(*sessionWithContext)
+		if !ok || session.bookmarks.bookmarkManager == nil {
+			continue
+		}
+		managedBookmarks, err := session.bookmarks.bookmarkManager.GetBookmarks(ctx)
+		if err != nil {
+			return nil, err
+		}
+		bookmarks.AddAll(managedBookmarks)
+	}
+	config.Bookmarks = bookmarks.Values()
+	return driver.NewSession(ctx, config), nil
+}
+
 // BookmarksToRawValues exposes the raw server-side bookmarks.
 // You should not need to use this method unless you want to serialize bookmarks.
 // See Session.LastBookmarks and CombineBookmarks for alternatives.
@@ -2,6 +2,8 @@ package neo4j_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
 	"testing"
@@ -214,4 +216,236 @@ func TestBookmarkManager(outer *testing.T) {
 			t.Errorf("notify hook should have been called")
 		}
 	})
+
+	outer.Run("fails on supplier errors by default", func(t *testing.T) {
+		supplierErr := errors.New("store unavailable")
+		var reportedErrs []error
+		bookmarkManager := neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{
+			InitialBookmarks: neo4j.Bookmarks{"a"},
+			BookmarkSupplier: func(context.Context) (neo4j.Bookmarks, error) {
+				return nil, supplierErr
+			},
+			OnError: func(_ context.Context, err error) {
+				reportedErrs = append(reportedErrs, err)
+			},
+		})
+
+		_, err := bookmarkManager.GetBookmarks(ctx)
+
+		AssertDeepEquals(t, err, supplierErr)
+		AssertDeepEquals(t, reportedErrs, []error{supplierErr})
+	})
+
+	outer.Run("continues with tracked bookmarks on supplier errors when configured", func(t *testing.T) {
+		supplierErr := errors.New("store unavailable")
+		var reportedErrs []error
+		bookmarkManager := neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{
+			InitialBookmarks: neo4j.Bookmarks{"a"},
+			BookmarkSupplier: func(context.Context) (neo4j.Bookmarks, error) {
+				return neo4j.Bookmarks{"partial"}, supplierErr
+			},
+			ErrorPolicy: neo4j.ContinueOnBookmarkErrors,
+			OnError: func(_ context.Context, err error) {
+				reportedErrs = append(reportedErrs, err)
+			},
+		})
+
+		bookmarks, err := bookmarkManager.GetBookmarks(ctx)
+
+		AssertNoError(t, err)
+		AssertEqualsInAnyOrder(t, bookmarks, []string{"a"})
+		AssertDeepEquals(t, reportedErrs, []error{supplierErr})
+	})
+
+	outer.Run("fails on consumer errors by default", func(t *testing.T) {
+		consumerErr := errors.New("store unavailable")
+		bookmarkManager := neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{
+			BookmarkConsumer: func(context.Context, neo4j.Bookmarks) error {
+				return consumerErr
+			},
+		})
+
+		err := bookmarkManager.UpdateBookmarks(ctx, nil, []string{"a"})
+
+		AssertDeepEquals(t, err, consumerErr)
+	})
+
+	outer.Run("keeps tracking bookmarks on consumer errors when configured", func(t *testing.T) {
+		consumerErr := errors.New("store unavailable")
+		var reportedErrs []error
+		bookmarkManager := neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{
+			BookmarkConsumer: func(context.Context, neo4j.Bookmarks) error {
+				return consumerErr
+			},
+			ErrorPolicy: neo4j.ContinueOnBookmarkErrors,
+			OnError: func(_ context.Context, err error) {
+				reportedErrs = append(reportedErrs, err)
+			},
+		})
+
+		err := bookmarkManager.UpdateBookmarks(ctx, nil, []string{"a"})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, reportedErrs, []error{consumerErr})
+		bookmarks, err := bookmarkManager.GetBookmarks(ctx)
+		AssertNoError(t, err)
+		AssertEqualsInAnyOrder(t, bookmarks, []string{"a"})
+	})
+
+	outer.Run("forgets bookmarks", func(t *testing.T) {
+		var notifiedBookmarks neo4j.Bookmarks
+		bookmarkManager := neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{
+			InitialBookmarks: neo4j.Bookmarks{"a", "b"},
+			BookmarkConsumer: func(_ context.Context, bookmarks neo4j.Bookmarks) error {
+				notifiedBookmarks = bookmarks
+				return nil
+			},
+		})
+
+		err := bookmarkManager.(neo4j.BookmarkForgetter).ForgetBookmarks(ctx, neo4j.Bookmarks{"a"})
+
+		AssertNoError(t, err)
+		bookmarks, err := bookmarkManager.GetBookmarks(ctx)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, bookmarks, neo4j.Bookmarks{"b"})
+		AssertDeepEquals(t, notifiedBookmarks, neo4j.Bookmarks{"b"})
+	})
+}
+
+func TestObservableBookmarkManager(outer *testing.T) {
+	ctx := context.Background()
+
+	outer.Run("emits supplied bookmarks", func(t *testing.T) {
+		var events []neo4j.BookmarkManagerEvent
+		bookmarkManager := neo4j.NewObservableBookmarkManager(
+			neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{InitialBookmarks: neo4j.Bookmarks{"a"}}),
+			func(_ context.Context, event neo4j.BookmarkManagerEvent) {
+				events = append(events, event)
+			})
+
+		bookmarks, err := bookmarkManager.GetBookmarks(ctx)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, events, []neo4j.BookmarkManagerEvent{
+			{Type: neo4j.BookmarksSupplied, Bookmarks: bookmarks},
+		})
+	})
+
+	outer.Run("emits replaced bookmarks", func(t *testing.T) {
+		var events []neo4j.BookmarkManagerEvent
+		bookmarkManager := neo4j.NewObservableBookmarkManager(
+			neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{InitialBookmarks: neo4j.Bookmarks{"a"}}),
+			func(_ context.Context, event neo4j.BookmarkManagerEvent) {
+				events = append(events, event)
+			})
+
+		err := bookmarkManager.UpdateBookmarks(ctx, neo4j.Bookmarks{"a"}, neo4j.Bookmarks{"b"})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, events, []neo4j.BookmarkManagerEvent{
+			{Type: neo4j.BookmarksReplaced, PreviousBookmarks: neo4j.Bookmarks{"a"}, Bookmarks: neo4j.Bookmarks{"b"}},
+		})
+	})
+
+	outer.Run("emits errors of the wrapped bookmark manager", func(t *testing.T) {
+		supplierErr := fmt.Errorf("supplier error")
+		var events []neo4j.BookmarkManagerEvent
+		bookmarkManager := neo4j.NewObservableBookmarkManager(
+			neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{
+				BookmarkSupplier: func(context.Context) (neo4j.Bookmarks, error) {
+					return nil, supplierErr
+				},
+			}),
+			func(_ context.Context, event neo4j.BookmarkManagerEvent) {
+				events = append(events, event)
+			})
+
+		_, err := bookmarkManager.GetBookmarks(ctx)
+
+		AssertDeepEquals(t, err, supplierErr)
+		AssertDeepEquals(t, events, []neo4j.BookmarkManagerEvent{
+			{Type: neo4j.BookmarksSupplied, Err: supplierErr},
+		})
+	})
+
+	outer.Run("forgets bookmarks of the wrapped bookmark manager", func(t *testing.T) {
+		delegate := neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{InitialBookmarks: neo4j.Bookmarks{"a"}})
+		bookmarkManager := neo4j.NewObservableBookmarkManager(delegate, func(context.Context, neo4j.BookmarkManagerEvent) {})
+
+		err := bookmarkManager.(neo4j.BookmarkForgetter).ForgetBookmarks(ctx, neo4j.Bookmarks{"a"})
+
+		AssertNoError(t, err)
+		bookmarks, err := delegate.GetBookmarks(ctx)
+		AssertNoError(t, err)
+		AssertLen(t, bookmarks, 0)
+	})
+}
+
+func TestNewChainedSession(outer *testing.T) {
+	ctx := context.Background()
+
+	outer.Parallel()
+
+	driver, err := neo4j.NewDriverWithContext("bolt://localhost:7687", neo4j.NoAuth())
+	AssertNoError(outer, err)
+
+	outer.Run("chains last bookmarks of previous sessions", func(t *testing.T) {
+		previousSession1 := driver.NewSession(ctx, neo4j.SessionConfig{Bookmarks: neo4j.Bookmarks{"a"}})
+		previousSession2 := driver.NewSession(ctx, neo4j.SessionConfig{Bookmarks: neo4j.Bookmarks{"b", "c"}})
+
+		session, err := neo4j.NewChainedSession(ctx, driver, neo4j.SessionConfig{Bookmarks: neo4j.Bookmarks{"c", "d"}},
+			previousSession1, previousSession2)
+
+		AssertNoError(t, err)
+		AssertEqualsInAnyOrder(t, session.LastBookmarks(), []string{"a", "b", "c", "d"})
+	})
+
+	outer.Run("includes bookmarks from previous session bookmark manager", func(t *testing.T) {
+		bookmarkManager := neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{
+			InitialBookmarks: neo4j.Bookmarks{"other-db-bookmark"},
+		})
+		previousSession := driver.NewSession(ctx, neo4j.SessionConfig{
+			Bookmarks:       neo4j.Bookmarks{"a"},
+			BookmarkManager: bookmarkManager,
+		})
+
+		session, err := neo4j.NewChainedSession(ctx, driver, neo4j.SessionConfig{}, previousSession)
+
+		AssertNoError(t, err)
+		AssertEqualsInAnyOrder(t, session.LastBookmarks(), []string{"a", "other-db-bookmark"})
+	})
+
+	outer.Run("does not eagerly fetch bookmarks when a bookmark manager is configured", func(t *testing.T) {
+		previousBookmarkManager := neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{
+			BookmarkSupplier: func(context.Context) (neo4j.Bookmarks, error) {
+				t.Errorf("bookmark supplier should not be called")
+				return nil, nil
+			},
+		})
+		previousSession := driver.NewSession(ctx, neo4j.SessionConfig{
+			Bookmarks:       neo4j.Bookmarks{"a"},
+			BookmarkManager: previousBookmarkManager,
+		})
+
+		session, err := neo4j.NewChainedSession(ctx, driver, neo4j.SessionConfig{
+			BookmarkManager: neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{}),
+		}, previousSession)
+
+		AssertNoError(t, err)
+		AssertEqualsInAnyOrder(t, session.LastBookmarks(), []string{"a"})
+	})
+
+	outer.Run("fails when previous session bookmark manager fails", func(t *testing.T) {
+		previousSession := driver.NewSession(ctx, neo4j.SessionConfig{
+			BookmarkManager: neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{
+				BookmarkSupplier: func(context.Context) (neo4j.Bookmarks, error) {
+					return nil, fmt.Errorf("oopsie")
+				},
+			}),
+		})
+
+		_, err := neo4j.NewChainedSession(ctx, driver, neo4j.SessionConfig{}, previousSession)
+
+		AssertErrorMessageContains(t, err, "oopsie")
+	})
 }
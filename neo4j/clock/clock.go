@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package clock defines the time abstraction used by the driver to measure and wait between transaction retries.
+// It allows tests to simulate the passing of time without actually sleeping.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time and waits for durations to elapse.
+// Implementations must be safe for concurrent use.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// Sleep blocks until the given duration has elapsed
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// System returns the Clock backed by the time package
+func System() Clock {
+	return systemClock{}
+}
+
+// Fake is a Clock whose time only moves forward when it is told to.
+// Sleeping on a Fake does not block but advances its time by the slept duration, so that time-based behaviors like
+// the maximum transaction retry time can be exercised instantly.
+type Fake struct {
+	mut   sync.Mutex
+	now   time.Time
+	slept []time.Duration
+}
+
+// NewFake creates a Fake clock starting at the given time
+func NewFake(start time.Time) *Fake {
+	return &Fake{now: start}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	return f.now
+}
+
+func (f *Fake) Sleep(d time.Duration) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.slept = append(f.slept, d)
+	f.now = f.now.Add(d)
+}
+
+// Advance moves the time of the clock forward by the given duration
+func (f *Fake) Advance(d time.Duration) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Set changes the time of the clock
+func (f *Fake) Set(now time.Time) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.now = now
+}
+
+// Slept returns the durations passed to Sleep so far, in call order
+func (f *Fake) Slept() []time.Duration {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	result := make([]time.Duration, len(f.slept))
+	copy(result, f.slept)
+	return result
+}
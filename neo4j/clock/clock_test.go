@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestFake(outer *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	outer.Run("starts at the given time", func(t *testing.T) {
+		fake := clock.NewFake(start)
+
+		AssertTrue(t, fake.Now().Equal(start))
+	})
+
+	outer.Run("sleeping advances time without blocking", func(t *testing.T) {
+		fake := clock.NewFake(start)
+
+		fake.Sleep(time.Hour)
+		fake.Sleep(time.Minute)
+
+		AssertTrue(t, fake.Now().Equal(start.Add(time.Hour+time.Minute)))
+		AssertDeepEquals(t, fake.Slept(), []time.Duration{time.Hour, time.Minute})
+	})
+
+	outer.Run("advances and sets time", func(t *testing.T) {
+		fake := clock.NewFake(start)
+
+		fake.Advance(time.Second)
+		AssertTrue(t, fake.Now().Equal(start.Add(time.Second)))
+
+		fake.Set(start)
+		AssertTrue(t, fake.Now().Equal(start))
+		AssertLen(t, fake.Slept(), 0)
+	})
+}
@@ -21,8 +21,11 @@ package neo4j
 
 import (
 	"math"
+	"net/url"
 	"testing"
 	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -36,14 +39,26 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("should have max connection pool size set to 100 by default")
 	}
 
+	if config.MinConnectionPoolSize != 0 {
+		t.Errorf("should not warm up the connection pool by default")
+	}
+
 	if config.MaxConnectionLifetime != 1*time.Hour {
 		t.Errorf("should have max connection lifetime set to 1 hour by default")
 	}
 
+	if config.MaxConnectionIdleTime != 0 {
+		t.Errorf("should keep idle connections by default")
+	}
+
 	if config.ConnectionAcquisitionTimeout != 1*time.Minute {
 		t.Errorf("should have connection acquisition timeout set to 1 minute by default")
 	}
 
+	if config.MaxConnectionAcquisitionWaiters != 0 {
+		t.Errorf("should not bound connection acquisition waiters by default")
+	}
+
 	if config.SocketConnectTimeout != 5*time.Second {
 		t.Errorf("should have socket connect timeout set to 5 seconds by default")
 	}
@@ -51,6 +66,144 @@ func TestDefaultConfig(t *testing.T) {
 	if config.SocketKeepalive != true {
 		t.Errorf("should have socket keep alive enabled by default")
 	}
+
+	if config.ConnectionAffinity {
+		t.Errorf("should have connection affinity disabled by default")
+	}
+
+	if config.TestConnectionOnBorrow {
+		t.Errorf("should have connection test on borrow disabled by default")
+	}
+
+	if config.DefaultAccessMode != AccessModeWrite {
+		t.Errorf("should have default access mode set to write by default")
+	}
+
+	if config.RotateConnectionsOnTerminationNotice != false {
+		t.Errorf("should have connection rotation on termination notice disabled by default")
+	}
+
+	if config.RetryBudgetRatio != 0 || config.RetryBudgetWindow != 10*time.Second {
+		t.Errorf("should have retry budget disabled with a 10 seconds window by default")
+	}
+
+	if config.ContinueOnHydrationError != false {
+		t.Errorf("should fail on hydration errors by default")
+	}
+
+	if config.DecodeUnknownValues != false {
+		t.Errorf("should not decode unknown values by default")
+	}
+
+	if config.RawRecords != false {
+		t.Errorf("should decode record values by default")
+	}
+
+	if config.CoerceParameterStrings != false {
+		t.Errorf("should not coerce parameter strings by default")
+	}
+
+	if config.OnInlinedLiterals != nil {
+		t.Errorf("should not report inlined literals by default")
+	}
+
+	if config.PrefetchRoutingTableDatabases != nil {
+		t.Errorf("should not prefetch routing tables by default")
+	}
+
+	if config.IsRetryable != nil {
+		t.Errorf("should use the built-in retryability classification by default")
+	}
+
+	if config.UserAgent != UserAgent || config.UserAgentSuffix != "" {
+		t.Errorf("should have the driver user agent without suffix by default")
+	}
+
+	if config.DefaultTransactionConfigurers != nil {
+		t.Errorf("should not have default transaction configuration functions by default")
+	}
+
+	if config.TransactionInterceptors != nil {
+		t.Errorf("should not have transaction interceptors by default")
+	}
+
+	if config.QueryLogger != nil || config.QueryLogParameters != nil {
+		t.Errorf("should not log queries by default")
+	}
+
+	if config.EstimateServerClockSkew != false {
+		t.Errorf("should not estimate server clock skew by default")
+	}
+
+	if config.UnconsumedResultsOnCommit != UnconsumedResultsDiscard {
+		t.Errorf("should discard unconsumed results on commit by default")
+	}
+
+	if config.NotificationsMinSeverity != NotificationsServerDefault || config.NotificationsDisabledCategories != nil {
+		t.Errorf("should let the server filter notifications by default")
+	}
+
+	if config.DatabaseResolver != nil {
+		t.Errorf("should not have database resolver by default")
+	}
+
+	if config.ProxyURL != nil {
+		t.Errorf("should not have proxy by default")
+	}
+
+	if config.Dialer != nil {
+		t.Errorf("should not have custom dialer by default")
+	}
+
+	if config.ClientCertificateProvider != nil {
+		t.Errorf("should not present client certificates by default")
+	}
+
+	if config.Clock != clock.System() {
+		t.Errorf("should have system clock by default")
+	}
+
+	if config.QueryCacheMaxEntries != 0 || config.QueryCacheTTL != 1*time.Minute {
+		t.Errorf("should have query cache disabled with a 1 minute TTL by default")
+	}
+	if config.MaxInFlightResults != 0 {
+		t.Errorf("should not limit in-flight results by default")
+	}
+
+	if config.FaultInjection != nil {
+		t.Errorf("should not inject faults by default")
+	}
+
+	if config.SessionCloseTimeout != 30*time.Second {
+		t.Errorf("should have session close timeout set to 30 seconds by default")
+	}
+}
+
+func TestAuraDefaults(t *testing.T) {
+	config := defaultConfig()
+	config.SocketKeepalive = false
+
+	AuraDefaults()(config)
+
+	if config.SocketKeepalive != true {
+		t.Errorf("should have socket keep alive enabled")
+	}
+
+	if config.MaxConnectionLifetime != 30*time.Minute {
+		t.Errorf("should have max connection lifetime set to 30 minutes")
+	}
+
+	if config.MaxTransactionRetryTime != 1*time.Minute {
+		t.Errorf("should have max transaction retry duration set to 1 minute")
+	}
+
+	if config.RotateConnectionsOnTerminationNotice != true {
+		t.Errorf("should have connection rotation on termination notice enabled")
+	}
+
+	if config.MaxConnectionPoolSize != 100 {
+		t.Errorf("should have left max connection pool size untouched")
+	}
 }
 
 func TestValidateAndNormaliseConfig(rt *testing.T) {
@@ -88,6 +241,26 @@ func TestValidateAndNormaliseConfig(rt *testing.T) {
 		}
 	})
 
+	rt.Run("MinConnectionPoolSize less than zero", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.MinConnectionPoolSize = -1
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("MinConnectionPoolSize is negative but never returned an error")
+		}
+	})
+
+	rt.Run("MinConnectionPoolSize greater than MaxConnectionPoolSize", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.MinConnectionPoolSize = 101
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("MinConnectionPoolSize is greater than MaxConnectionPoolSize but never returned an error")
+		}
+	})
+
 	rt.Run("ConnectionAcquisitionTimeout less than zero", func(t *testing.T) {
 		config := defaultConfig()
 
@@ -101,6 +274,16 @@ func TestValidateAndNormaliseConfig(rt *testing.T) {
 		}
 	})
 
+	rt.Run("MaxConnectionAcquisitionWaiters less than zero", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.MaxConnectionAcquisitionWaiters = -1
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("MaxConnectionAcquisitionWaiters is negative but never returned an error")
+		}
+	})
+
 	rt.Run("SocketConnectTimeout less than zero", func(t *testing.T) {
 		config := defaultConfig()
 
@@ -113,4 +296,120 @@ func TestValidateAndNormaliseConfig(rt *testing.T) {
 			t.Errorf("SocketConnectTimeout should be set to (0 * time.Nanosecond) when negative")
 		}
 	})
+
+	rt.Run("RetryBudgetRatio greater than one", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.RetryBudgetRatio = 1.5
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("RetryBudgetRatio is greater than 1 but never returned an error")
+		}
+	})
+
+	rt.Run("RetryBudgetWindow not positive with budget", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.RetryBudgetRatio = 0.1
+		config.RetryBudgetWindow = 0
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("RetryBudgetWindow is 0 but never returned an error")
+		}
+	})
+
+	rt.Run("QueryCacheTTL not positive with cache", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.QueryCacheMaxEntries = 10
+		config.QueryCacheTTL = 0
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("QueryCacheTTL is 0 but never returned an error")
+		}
+	})
+
+	rt.Run("MaxInFlightResults less than zero", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.MaxInFlightResults = -1
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("MaxInFlightResults is negative but never returned an error")
+		}
+	})
+
+	rt.Run("Clock nil", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.Clock = nil
+		err := validateAndNormaliseConfig(config)
+		if err != nil {
+			t.Errorf("Clock is nil but returned an error: %v", err)
+		}
+		if config.Clock != clock.System() {
+			t.Errorf("Clock is nil but was not normalized to the system clock")
+		}
+	})
+
+	rt.Run("ContinueOnHydrationError", func(t *testing.T) {
+		config := defaultConfig()
+
+		err := validateAndNormaliseConfig(config)
+		if err != nil {
+			t.Errorf("ContinueOnHydrationError is not set but returned an error: %v", err)
+		}
+		if config.ContinueOnHydrationError != false {
+			t.Errorf("should fail on hydration errors by default")
+		}
+
+		config.ContinueOnHydrationError = true
+		err = validateAndNormaliseConfig(config)
+		if err != nil {
+			t.Errorf("ContinueOnHydrationError is set but returned an error: %v", err)
+		}
+		if config.ContinueOnHydrationError != true {
+			t.Errorf("ContinueOnHydrationError is set but was not kept")
+		}
+	})
+
+	rt.Run("ProxyURL with unsupported scheme", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.ProxyURL = &url.URL{Scheme: "https", Host: "proxy:3128"}
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("ProxyURL has an unsupported scheme but never returned an error")
+		}
+	})
+
+	rt.Run("UnconsumedResultsOnCommit invalid", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.UnconsumedResultsOnCommit = 42
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("UnconsumedResultsOnCommit is invalid but never returned an error")
+		}
+	})
+
+	rt.Run("NotificationsMinSeverity invalid", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.NotificationsMinSeverity = "LOUD"
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("NotificationsMinSeverity is invalid but never returned an error")
+		}
+	})
+
+	rt.Run("DefaultAccessMode invalid", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.DefaultAccessMode = 42
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("DefaultAccessMode is invalid but never returned an error")
+		}
+	})
 }
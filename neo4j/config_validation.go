@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/auth"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/connector"
+)
+
+// ConfigValidationError is returned by ValidateConfig and lists all the problems found in the driver configuration.
+type ConfigValidationError struct {
+	Problems []error
+}
+
+func (e *ConfigValidationError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		messages[i] = problem.Error()
+	}
+	return fmt.Sprintf("Invalid driver configuration, %d problem(s) found: %s", len(e.Problems), strings.Join(messages, "; "))
+}
+
+// ValidateConfig checks the URI, token manager and configuration functions a driver would be created with, like
+// NewDriverWithContext does, but without creating the driver: no socket is opened and no host name is resolved.
+// The URI is fully parsed, its scheme resolved to a routing and TLS mode, and the configuration validated.
+// Unlike NewDriverWithContext, which fails on the first problem, all the problems are reported at once as a
+// *ConfigValidationError.
+// Combinations NewDriverWithContext accepts but ignores are reported as well, like TLS settings along with a URI
+// scheme that does not encrypt (bolt, neo4j, http) or routing table prefetching with a URI scheme that does not route.
+// This is useful to check configurations at CI time.
+//
+//	err := neo4j.ValidateConfig(uri, neo4j.BasicAuth(username, password, ""), configurers...)
+func ValidateConfig(target string, tokenManager auth.TokenManager, configurers ...func(*Config)) error {
+	var problems []error
+	parsedTarget, err := parseDriverTarget(target)
+	if err != nil {
+		problems = append(problems, err)
+	}
+	if tokenManager == nil {
+		problems = append(problems, &UsageError{Message: "Auth token manager cannot be nil"})
+	}
+
+	config := defaultConfig()
+	for _, configurer := range configurers {
+		configurer(config)
+	}
+	problems = append(problems, configProblems(config)...)
+	if parsedTarget != nil {
+		if _, err := routingContextFromUrl(parsedTarget.routing, parsedTarget.url); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if config.ProxyURL == nil {
+		if _, _, err := connector.ProxyFromEnvironment(); err != nil {
+			problems = append(problems, &UsageError{Message: fmt.Sprintf("Invalid ALL_PROXY environment variable: %s", err)})
+		}
+	}
+	if parsedTarget != nil {
+		problems = append(problems, ignoredConfigProblems(parsedTarget, config)...)
+	}
+
+	if len(problems) > 0 {
+		return &ConfigValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// ignoredConfigProblems reports the settings that have no effect with the URI scheme of the driver
+func ignoredConfigProblems(target *driverTarget, config *Config) []error {
+	var problems []error
+	scheme := target.url.Scheme
+	if target.skipEncryption {
+		//lint:ignore SA1019 RootCAs is still supported until 6.0
+		if config.TlsConfig != nil || config.RootCAs != nil {
+			problems = append(problems, &UsageError{Message: fmt.Sprintf("TLS configuration is ignored with unencrypted URI scheme %s", scheme)})
+		}
+		if config.ClientCertificateProvider != nil {
+			problems = append(problems, &UsageError{Message: fmt.Sprintf("Client certificates are ignored with unencrypted URI scheme %s", scheme)})
+		}
+	}
+	if !target.routing && len(config.PrefetchRoutingTableDatabases) > 0 {
+		problems = append(problems, &UsageError{Message: fmt.Sprintf("Routing tables are not prefetched with non-routing URI scheme %s", scheme)})
+	}
+	return problems
+}
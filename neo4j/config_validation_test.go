@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"crypto/tls"
+	"testing"
+
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestValidateConfig(outer *testing.T) {
+	token := BasicAuth("neo4j", "pass", "")
+
+	problemsOf := func(t *testing.T, err error) []string {
+		t.Helper()
+		validationErr, ok := err.(*ConfigValidationError)
+		if !ok {
+			t.Fatalf("expected a *ConfigValidationError but got %T: %v", err, err)
+		}
+		messages := make([]string, len(validationErr.Problems))
+		for i, problem := range validationErr.Problems {
+			AssertSameType(t, problem, &UsageError{})
+			messages[i] = problem.Error()
+		}
+		return messages
+	}
+
+	outer.Run("accepts valid configurations", func(t *testing.T) {
+		err := ValidateConfig("neo4j+s://cluster?policy=eu", token, func(config *Config) {
+			config.TlsConfig = &tls.Config{}
+			config.PrefetchRoutingTableDatabases = []string{"movies"}
+		})
+
+		AssertNoError(t, err)
+	})
+
+	outer.Run("reports all problems at once", func(t *testing.T) {
+		err := ValidateConfig("bolt://localhost?policy=eu", nil, func(config *Config) {
+			config.MaxConnectionPoolSize = 0
+			config.RetryBudgetRatio = 2
+			config.TlsConfig = &tls.Config{}
+		})
+
+		AssertDeepEquals(t, problemsOf(t, err), []string{
+			"Auth token manager cannot be nil",
+			"Maximum connection pool cannot be 0",
+			"Retry budget ratio cannot be greater than 1",
+			"Routing context is not supported for URL scheme bolt",
+			"TLS configuration is ignored with unencrypted URI scheme bolt",
+		})
+		AssertErrorMessageContains(t, err, "5 problem(s) found")
+	})
+
+	outer.Run("reports unsupported URI schemes", func(t *testing.T) {
+		err := ValidateConfig("bolt+tcp://localhost", token)
+
+		AssertDeepEquals(t, problemsOf(t, err), []string{"URI scheme bolt+tcp is not supported"})
+	})
+
+	outer.Run("reports invalid routing contexts", func(t *testing.T) {
+		err := ValidateConfig("neo4j://localhost?policy=eu&policy=us", token)
+
+		AssertDeepEquals(t, problemsOf(t, err), []string{"Duplicated routing context key 'policy'"})
+	})
+
+	outer.Run("reports ignored settings", func(t *testing.T) {
+		err := ValidateConfig("http://localhost", token, func(config *Config) {
+			config.PrefetchRoutingTableDatabases = []string{"movies"}
+		})
+
+		AssertDeepEquals(t, problemsOf(t, err), []string{"Routing tables are not prefetched with non-routing URI scheme http"})
+	})
+}
@@ -20,6 +20,8 @@
 package neo4j
 
 import (
+	"io"
+
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 )
 
@@ -50,3 +52,9 @@ func ConsoleLogger(level LogLevel) *log.Console {
 func ConsoleBoltLogger() *log.ConsoleBoltLogger {
 	return &log.ConsoleBoltLogger{}
 }
+
+// JSONBoltLogger returns a Bolt logger writing one JSON object per Bolt message to the given writer, see
+// log.JSONBoltLogger for the format
+func JSONBoltLogger(writer io.Writer) *log.JSONBoltLogger {
+	return log.NewJSONBoltLogger(writer)
+}
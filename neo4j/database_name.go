@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	minDatabaseNameLength = 3
+	maxDatabaseNameLength = 63
+)
+
+// NormalizeDatabaseName returns the normalized form of the given database name, or database alias.
+// Names are case-insensitive: the server resolves "Movies" and "movies" to the same database, so the normalized form
+// is lower case.
+// The name is not validated, see ValidateDatabaseName.
+//
+// The driver normalizes SessionConfig.DatabaseName with this function, so that routing tables and bookmarks are not
+// split between spellings of the same database name.
+func NormalizeDatabaseName(name string) string {
+	return strings.ToLower(name)
+}
+
+// ValidateDatabaseName checks that the given database name, or database alias, follows the naming rules of the
+// server for unquoted names, and returns a UsageError otherwise.
+// Valid names are 3 to 63 characters long, start with an ASCII letter and only contain ASCII letters, digits, dots
+// and dashes. Dots allow names such as the aliases of composite database constituents, e.g. "composite.constituent".
+// The empty name, which designates the default (or home) database, is valid.
+//
+// The driver does not apply this check to SessionConfig.DatabaseName: the server accepts other names too, like the
+// aliases created with backtick-quoting that contain underscores, and reports the names it does not know.
+// Applications can use it to check user-supplied names before sending them to the server.
+func ValidateDatabaseName(name string) error {
+	if name == "" {
+		return nil
+	}
+	if err := checkDatabaseName(name); err != nil {
+		return &UsageError{Message: fmt.Sprintf("Invalid database name %q: %s", name, err)}
+	}
+	return nil
+}
+
+func checkDatabaseName(name string) error {
+	if len(name) < minDatabaseNameLength || len(name) > maxDatabaseNameLength {
+		return fmt.Errorf("length must be between %d and %d characters, got %d",
+			minDatabaseNameLength, maxDatabaseNameLength, len(name))
+	}
+	if !isAsciiLetter(name[0]) {
+		return fmt.Errorf("first character must be an ASCII letter, got %q", name[0])
+	}
+	for i := 1; i < len(name); i++ {
+		char := name[i]
+		if !isAsciiLetter(char) && !isAsciiDigit(char) && char != '.' && char != '-' {
+			return fmt.Errorf("character %q at position %d is not an ASCII letter, digit, dot or dash", char, i)
+		}
+	}
+	return nil
+}
+
+func isAsciiLetter(char byte) bool {
+	return ('a' <= char && char <= 'z') || ('A' <= char && char <= 'Z')
+}
+
+func isAsciiDigit(char byte) bool {
+	return '0' <= char && char <= '9'
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"testing"
+
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestNormalizeDatabaseName(outer *testing.T) {
+	outer.Parallel()
+
+	testCases := map[string]string{
+		"":                      "",
+		"neo4j":                 "neo4j",
+		"Movies":                "movies",
+		"MOVIES-2023":           "movies-2023",
+		"composite.Constituent": "composite.constituent",
+		"My_Movies":             "my_movies",
+	}
+	for name, expected := range testCases {
+		outer.Run(name, func(t *testing.T) {
+			AssertStringEqual(t, NormalizeDatabaseName(name), expected)
+		})
+	}
+}
+
+func TestValidateDatabaseName(outer *testing.T) {
+	outer.Parallel()
+
+	outer.Run("accepts valid names", func(inner *testing.T) {
+		for _, name := range []string{"", "neo4j", "system", "Movies", "MOVIES-2023", "composite.Constituent", "abc"} {
+			inner.Run(name, func(t *testing.T) {
+				AssertNoError(t, ValidateDatabaseName(name))
+			})
+		}
+	})
+
+	outer.Run("rejects invalid names", func(inner *testing.T) {
+		testCases := map[string]string{
+			"too short":             "db",
+			"too long":              "a123456789012345678901234567890123456789012345678901234567890123",
+			"leading digit":         "1movies",
+			"leading dot":           ".movies",
+			"underscore":            "my_movies",
+			"space":                 "my movies",
+			"non-ASCII letter":      "filmé",
+			"backtick quoted alias": "`movies`",
+		}
+		for description, name := range testCases {
+			inner.Run(description, func(t *testing.T) {
+				err := ValidateDatabaseName(name)
+
+				AssertSameType(t, err, &UsageError{})
+				AssertStringContain(t, err.Error(), name)
+			})
+		}
+	})
+}
@@ -19,26 +19,59 @@
 
 package db
 
+import "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/keyindex"
+
 type Record struct {
 	// Values contains all the values in the record.
+	// Values is nil when the record is obtained raw, see Raw.
 	Values []any
+	// Raw contains the packstream encoded list of the values of the record, as received from the server, when raw
+	// records are requested with Config.RawRecords. The values are not decoded then.
+	Raw []byte
 	// Keys contains names of the values in the record.
 	// Should not be modified. Same instance is used for all records within the same result.
 	Keys []string
+	// keyIndex optionally maps the keys to the position of their value.
+	// It is built once per result and shared by all its records, so that wide records are accessed by key in
+	// constant time.
+	keyIndex keyindex.Index
+}
+
+func init() {
+	keyindex.Attach = func(record any, index keyindex.Index) {
+		record.(*Record).keyIndex = index
+	}
 }
 
 // Get returns the value corresponding to the given key along with a boolean that is true if
 // a value was found and false if there were no key with the given name.
+// No value is found in raw records, whose values are not decoded.
 //
-// If there are a lot of keys in combination with a lot of records to iterate, consider to retrieve
-// values from Values slice directly or make a key -> index map before iterating. This implementation
-// does not make or use a key -> index map since the overhead of making the map might not be beneficial
-// for small and few records.
+// The value is looked up in an index shared by the records of results with many keys, and by scanning Keys
+// otherwise.
 func (r Record) Get(key string) (any, bool) {
+	// Raw records have keys but no values
+	if i, found := r.position(key); found && i < len(r.Values) {
+		return r.Values[i], true
+	}
+	return nil, false
+}
+
+// Has returns true if the record has a value, possibly nil, for the given key.
+func (r Record) Has(key string) bool {
+	_, found := r.position(key)
+	return found
+}
+
+func (r Record) position(key string) (int, bool) {
+	if r.keyIndex != nil {
+		i, found := r.keyIndex[key]
+		return i, found
+	}
 	for i, ckey := range r.Keys {
 		if key == ckey {
-			return r.Values[i], true
+			return i, true
 		}
 	}
-	return nil, false
+	return -1, false
 }
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/keyindex"
+)
+
+func TestRecord(outer *testing.T) {
+	outer.Parallel()
+
+	wideKeys := make([]string, 16)
+	wideValues := make([]any, len(wideKeys))
+	for i := range wideKeys {
+		wideKeys[i] = fmt.Sprintf("k%d", i)
+		wideValues[i] = i
+	}
+	wideValues[1] = nil
+
+	indexed := &Record{Keys: wideKeys, Values: wideValues}
+	keyindex.Attach(indexed, keyindex.New(wideKeys))
+	records := map[string]Record{
+		"narrow":  {Keys: []string{"k0", "k1", "k2"}, Values: []any{0, nil, 2}},
+		"indexed": *indexed,
+	}
+
+	outer.Run("attaches the key index", func(t *testing.T) {
+		if indexed.keyIndex == nil {
+			t.Errorf("expected the key index to be attached")
+		}
+	})
+
+	outer.Run("gets no values of raw records", func(t *testing.T) {
+		raw := Record{Keys: []string{"k0"}, Raw: []byte{0x91, 0x01}}
+
+		if _, found := raw.Get("k0"); found {
+			t.Errorf("expected no value to be found in a raw record")
+		}
+	})
+
+	for name, record := range records {
+		outer.Run(fmt.Sprintf("gets values of %s records", name), func(t *testing.T) {
+			value, found := record.Get("k2")
+			if !found || value != 2 {
+				t.Errorf("expected value 2 to be found, got %v (found: %t)", value, found)
+			}
+			if _, found := record.Get("missing"); found {
+				t.Errorf("expected missing key not to be found")
+			}
+		})
+
+		outer.Run(fmt.Sprintf("checks keys of %s records", name), func(t *testing.T) {
+			if !record.Has("k1") {
+				t.Errorf("expected key with nil value to be present")
+			}
+			if record.Has("missing") {
+				t.Errorf("expected missing key to be absent")
+			}
+		})
+	}
+}
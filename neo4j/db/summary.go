@@ -106,6 +106,23 @@ type Notification struct {
 	Position *InputPosition
 	// Severity contains the severity level of this notification.
 	Severity string
+	// Category contains the category of this notification (like DEPRECATION), only sent by Neo4j 5.7+.
+	Category string
+}
+
+// GqlStatusObject represents a GQL-compliant status sent by the server, notifications included.
+type GqlStatusObject struct {
+	// GqlStatus contains the GQLSTATUS code of this status, like 01N42.
+	GqlStatus string
+	// StatusDescription contains the description of this status.
+	StatusDescription string
+	// Neo4jCode contains the legacy notification code of this status, it is empty if the status is not a notification.
+	Neo4jCode string
+	// Title contains the legacy notification title of this status, it is empty if the status is not a notification.
+	Title string
+	// DiagnosticRecord contains additional information about the status, like its severity (_severity) and
+	// classification (_classification).
+	DiagnosticRecord map[string]any
 }
 
 // InputPosition contains information about a specific position in a statement
@@ -136,6 +153,7 @@ type Summary struct {
 	Plan                  *Plan
 	ProfiledPlan          *ProfiledPlan
 	Notifications         []Notification
+	GqlStatusObjects      []GqlStatusObject
 	Database              string
 	ContainsSystemUpdates *bool
 	ContainsUpdates       *bool
@@ -48,6 +48,54 @@ func (n Node) GetProperties() map[string]any {
 	return n.Props
 }
 
+// HasLabel returns true if the node has the given label.
+// Labels are matched case-sensitively, like in Cypher.
+func (n Node) HasLabel(label string) bool {
+	for _, nodeLabel := range n.Labels {
+		if nodeLabel == label {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnyLabel returns true if the node has at least one of the given labels.
+// Labels are matched case-sensitively, like in Cypher.
+func (n Node) HasAnyLabel(labels ...string) bool {
+	for _, label := range labels {
+		if n.HasLabel(label) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllLabels returns true if the node has every one of the given labels, which is always the case when no label
+// is given.
+// Labels are matched case-sensitively, like in Cypher.
+func (n Node) HasAllLabels(labels ...string) bool {
+	if len(labels) == 0 {
+		return true
+	}
+	nodeLabels := n.LabelsSet()
+	for _, label := range labels {
+		if _, found := nodeLabels[label]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+// LabelsSet returns the labels of the node as a set, for repeated constant-time lookups.
+// The returned set is a copy, modifying it does not affect the node.
+func (n Node) LabelsSet() map[string]struct{} {
+	result := make(map[string]struct{}, len(n.Labels))
+	for _, label := range n.Labels {
+		result[label] = struct{}{}
+	}
+	return result
+}
+
 // Relationship represents a relationship in the neo4j graph database
 type Relationship struct {
 	// Deprecated: Id is deprecated and will be removed in 6.0. Use ElementId instead.
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dbtype
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNodeLabels(t *testing.T) {
+	node := Node{Labels: []string{"Person", "Actor"}}
+
+	t.Run("HasLabel", func(t *testing.T) {
+		if !node.HasLabel("Person") {
+			t.Errorf("Expected node to have label Person")
+		}
+		if node.HasLabel("person") {
+			t.Errorf("Expected label matching to be case-sensitive")
+		}
+		if node.HasLabel("Director") {
+			t.Errorf("Expected node not to have label Director")
+		}
+	})
+
+	t.Run("HasAnyLabel", func(t *testing.T) {
+		if !node.HasAnyLabel("Director", "Actor") {
+			t.Errorf("Expected node to have one of Director, Actor")
+		}
+		if node.HasAnyLabel("Director", "actor") {
+			t.Errorf("Expected node not to have any of Director, actor")
+		}
+		if node.HasAnyLabel() {
+			t.Errorf("Expected node not to match an empty list of labels")
+		}
+	})
+
+	t.Run("HasAllLabels", func(t *testing.T) {
+		if !node.HasAllLabels("Actor", "Person") {
+			t.Errorf("Expected node to have all of Actor, Person")
+		}
+		if node.HasAllLabels("Actor", "Director") {
+			t.Errorf("Expected node not to have all of Actor, Director")
+		}
+		if !node.HasAllLabels() {
+			t.Errorf("Expected node to match an empty list of labels")
+		}
+	})
+
+	t.Run("LabelsSet", func(t *testing.T) {
+		actual := node.LabelsSet()
+		expect := map[string]struct{}{"Person": {}, "Actor": {}}
+		if !reflect.DeepEqual(actual, expect) {
+			t.Errorf("Expected %v but was %v", expect, actual)
+		}
+
+		delete(actual, "Person")
+		if !node.HasLabel("Person") {
+			t.Errorf("Expected node labels to be unaffected by set modifications")
+		}
+	})
+}
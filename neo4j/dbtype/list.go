@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package dbtype
+
+// StreamedList is a list query parameter whose items are produced one at a time while the query is encoded, instead
+// of being held in a slice.
+// This keeps the memory footprint of ingest jobs that UNWIND millions of rows per transaction close to the size of the
+// encoded message, which is still buffered in full before being sent to the server.
+//
+// Next returns the next item and true, or false once the list is exhausted. An error aborts the query and discards
+// the connection, since the message being sent is incomplete.
+//
+// A StreamedList must be passed as a pointer and can only be encoded once: encoding it again fails with a
+// StreamedListReusedError instead of sending an empty list. It therefore cannot be used with queries the driver may
+// send more than once, like the ones run by ExecuteQuery or by transaction functions when they are retried. Within
+// transaction functions, a new StreamedList must be created for every attempt.
+type StreamedList struct {
+	Next func() (any, bool, error)
+}
+
+// StreamedListReusedError is returned when a StreamedList is encoded more than once, for instance when the query it
+// is a parameter of is retried.
+type StreamedListReusedError struct{}
+
+func (e *StreamedListReusedError) Error() string {
+	return "StreamedList has already been encoded, a new StreamedList must be created for every attempt"
+}
+
+// StreamedListFromChannel creates a StreamedList producing the items received from the given channel, until the
+// channel is closed.
+func StreamedListFromChannel(items <-chan any) *StreamedList {
+	return &StreamedList{Next: func() (any, bool, error) {
+		item, ok := <-items
+		return item, ok, nil
+	}}
+}
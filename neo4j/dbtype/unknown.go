@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package dbtype
+
+import "fmt"
+
+// UnknownValue is a structure received from the server that the driver does not know how to decode, typically a
+// value type introduced by a newer server version.
+// The fields of the structure are decoded as regular values.
+type UnknownValue struct {
+	Tag    byte  // Tag of the structure, identifying its type
+	Fields []any // Fields of the structure, in the order the server sent them
+}
+
+func (u *UnknownValue) String() string {
+	return fmt.Sprintf("UnknownValue{tag: %#x, fields: %v}", u.Tag, u.Fields)
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+)
+
+// DeprecationNotice describes a deprecation notification returned by the server for a query, see
+// Config.OnDeprecationNotice
+type DeprecationNotice struct {
+	// Query is the query text, as passed to the driver
+	Query string
+	// QueryFingerprint identifies the query regardless of its literal values, see QueryFingerprint
+	QueryFingerprint string
+	// QueryName is the name of the prepared query with the same fingerprint, empty if there is none, see
+	// DriverWithContext.Prepare
+	QueryName string
+	// Notification is the deprecation notification
+	Notification Notification
+}
+
+// notifyDeprecations calls the given callback for every deprecation notification found in the summary
+func notifyDeprecations(onDeprecationNotice func(DeprecationNotice), cypher string, summary *db.Summary) {
+	if onDeprecationNotice == nil || summary == nil {
+		return
+	}
+	var fingerprint string
+	for i := range summary.Notifications {
+		if !isDeprecation(&summary.Notifications[i]) {
+			continue
+		}
+		if fingerprint == "" {
+			fingerprint = QueryFingerprint(cypher)
+		}
+		onDeprecationNotice(DeprecationNotice{
+			Query:            cypher,
+			QueryFingerprint: fingerprint,
+			Notification:     &notification{notification: &summary.Notifications[i]},
+		})
+	}
+}
+
+// isDeprecation relies on the notification category when sent by the server, on the notification code otherwise
+func isDeprecation(notification *db.Notification) bool {
+	if notification.Category != "" {
+		return notification.Category == "DEPRECATION"
+	}
+	return strings.Contains(notification.Code, "Deprecat")
+}
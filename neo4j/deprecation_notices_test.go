@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestDeprecationNotices(outer *testing.T) {
+	ctx := context.Background()
+	cypher := "MATCH (n) WHERE id(n) = 42 RETURN n"
+	summary := &db.Summary{Notifications: []db.Notification{
+		{Code: "Neo.ClientNotification.Statement.FeatureDeprecationWarning", Title: "id is deprecated"},
+		{Code: "Neo.ClientNotification.Statement.CartesianProduct", Title: "cartesian product"},
+		{Code: "Neo.ClientNotification.Statement.UnknownLabelWarning", Category: "UNRECOGNIZED"},
+		{Code: "Neo.ClientNotification.Statement.SomeNewCode", Title: "deprecated by category", Category: "DEPRECATION"},
+	}}
+	newResult := func(conn *ConnFake, notices *[]DeprecationNotice) *resultWithContext {
+		result := newResultWithContext(conn, idb.StreamHandle(0), cypher, nil, nil)
+		result.onDeprecationNotice = func(notice DeprecationNotice) {
+			*notices = append(*notices, notice)
+		}
+		return result
+	}
+	assertNotices := func(t *testing.T, notices []DeprecationNotice) {
+		t.Helper()
+		AssertLen(t, notices, 2)
+		AssertStringEqual(t, notices[0].Notification.Title(), "id is deprecated")
+		AssertStringEqual(t, notices[1].Notification.Title(), "deprecated by category")
+		for _, notice := range notices {
+			AssertStringEqual(t, notice.Query, cypher)
+			AssertStringEqual(t, notice.QueryFingerprint, QueryFingerprint(cypher))
+		}
+	}
+
+	outer.Run("notifies deprecations once iterated past last record", func(t *testing.T) {
+		var notices []DeprecationNotice
+		conn := &ConnFake{Nexts: []Next{{Record: &db.Record{}}, {Summary: summary}}}
+		result := newResult(conn, &notices)
+
+		AssertTrue(t, result.Next(ctx))
+		AssertLen(t, notices, 0)
+		AssertFalse(t, result.Next(ctx))
+
+		assertNotices(t, notices)
+	})
+
+	outer.Run("notifies deprecations once when consumed after iteration", func(t *testing.T) {
+		var notices []DeprecationNotice
+		conn := &ConnFake{Nexts: []Next{{Summary: summary}}, ConsumeSum: summary}
+		result := newResult(conn, &notices)
+
+		AssertFalse(t, result.Next(ctx))
+		_, err := result.Consume(ctx)
+
+		AssertNoError(t, err)
+		assertNotices(t, notices)
+	})
+
+	outer.Run("notifies deprecations when consumed", func(t *testing.T) {
+		var notices []DeprecationNotice
+		conn := &ConnFake{ConsumeSum: summary}
+		result := newResult(conn, &notices)
+
+		_, err := result.Consume(ctx)
+
+		AssertNoError(t, err)
+		assertNotices(t, notices)
+	})
+
+	outer.Run("does not notify without callback", func(t *testing.T) {
+		conn := &ConnFake{Nexts: []Next{{Summary: summary}}}
+		result := newResultWithContext(conn, idb.StreamHandle(0), cypher, nil, nil)
+
+		AssertFalse(t, result.Next(ctx))
+	})
+}
@@ -20,11 +20,21 @@
 package neo4j
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/auth"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/faults"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/racing"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/router"
 	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 )
 
 func assertNoRouter(t *testing.T, d Driver) {
@@ -100,6 +110,8 @@ func TestDriverURISchemes(t *testing.T) {
 		{"neo4j", "neo4j://localhost:7687", true, true, false, "tcp", ""},
 		{"neo4j+s", "neo4j+s://localhost:7687", true, false, false, "tcp", ""},
 		{"neo4j+ssc", "neo4j+ssc://localhost:7687", true, false, true, "tcp", ""},
+		{"http", "http://localhost:7474", false, true, false, "tcp", "localhost:7474"},
+		{"https", "https://localhost:7473", false, false, false, "tcp", "localhost:7473"},
 	}
 
 	for _, tt := range uriSchemeTests {
@@ -144,6 +156,53 @@ func TestDriverInvalidURISchemes(t *testing.T) {
 	}
 }
 
+func TestDriverNilTokenManager(t *testing.T) {
+	_, err := NewDriverWithContext("bolt://localhost:7687", nil)
+
+	assertUsageError(t, err)
+	AssertStringContain(t, err.Error(), "Auth token manager cannot be nil")
+}
+
+// foreignToken is an auth.Token not created by the token functions of the neo4j package
+type foreignToken struct{}
+
+func (t foreignToken) GetAuthToken(context.Context) (auth.Token, error) {
+	return t, nil
+}
+
+func (t foreignToken) OnTokenExpired(context.Context, auth.Token) error {
+	return nil
+}
+
+func TestDriverAuthTokens(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("supplies the credentials of static tokens", func(t *testing.T) {
+		tokens, err := getAuthTokens(ctx, BearerAuth("sso"))
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, tokens, map[string]any{"scheme": "bearer", "credentials": "sso"})
+	})
+
+	t.Run("supplies the credentials of managed tokens", func(t *testing.T) {
+		manager := auth.ExpirationBasedTokenManager(func(context.Context) (auth.Token, *time.Time, error) {
+			return BearerAuth("sso"), nil, nil
+		})
+
+		tokens, err := getAuthTokens(ctx, manager)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, tokens, map[string]any{"scheme": "bearer", "credentials": "sso"})
+	})
+
+	t.Run("rejects tokens of other types", func(t *testing.T) {
+		_, err := getAuthTokens(ctx, foreignToken{})
+
+		assertUsageError(t, err)
+		AssertStringContain(t, err.Error(), "neo4j.foreignToken")
+	})
+}
+
 func TestDriverURIRoutingContext(t *testing.T) {
 	t.Run("Extracts keys", func(t1 *testing.T) {
 		driver, err := NewDriver("neo4j://localhost:7687?x=y&a=b", NoAuth())
@@ -176,6 +235,26 @@ func TestDriverDefaultPort(t *testing.T) {
 		AssertStringEqual(t1, driverTarget.Port(), "7687")
 		assertRouterContext(t1, driver, map[string]string{"address": "localhost:7687"})
 	})
+
+	t.Run("http://localhost should default to port 7474", func(t1 *testing.T) {
+		driver, err := NewDriver("http://localhost", NoAuth())
+
+		driverTarget := driver.Target()
+
+		AssertNoError(t1, err)
+		AssertStringEqual(t1, driverTarget.Port(), "7474")
+		assertNoRouterAddress(t1, driver, "localhost:7474")
+	})
+
+	t.Run("https://localhost should default to port 7473", func(t1 *testing.T) {
+		driver, err := NewDriver("https://localhost", NoAuth())
+
+		driverTarget := driver.Target()
+
+		AssertNoError(t1, err)
+		AssertStringEqual(t1, driverTarget.Port(), "7473")
+		assertNoRouterAddress(t1, driver, "localhost:7473")
+	})
 }
 
 func TestNewDriverAndClose(t *testing.T) {
@@ -242,3 +321,246 @@ func TestDriverSessionCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestDriverSessionCreationWithInvalidConfig(t *testing.T) {
+	driver, err := NewDriverWithContext("bolt://localhost:7687", NoAuth())
+	AssertNoError(t, err)
+	ctx := context.Background()
+
+	t.Run("reports all invalid settings", func(t *testing.T) {
+		session := driver.NewSession(ctx, SessionConfig{AccessMode: 42, FetchSize: -2})
+
+		_, err := session.Run(ctx, "RETURN 42", nil)
+
+		if !IsUsageError(err) {
+			t.Fatalf("expected usage error, got %v", err)
+		}
+		AssertErrorMessageContains(t, err, "access mode must be AccessModeWrite or AccessModeRead, got 42")
+		AssertErrorMessageContains(t, err, "fetch size must be positive, FetchDefault or FetchAll, got -2")
+	})
+
+	t.Run("accepts fetching all records", func(t *testing.T) {
+		session := driver.NewSession(ctx, SessionConfig{FetchSize: FetchAll})
+
+		if _, isErrored := session.(*erroredSessionWithContext); isErrored {
+			t.Errorf("expected valid session")
+		}
+	})
+}
+
+func TestDriverDatabaseResolver(outer *testing.T) {
+	type tenantKey struct{}
+	driver, err := NewDriverWithContext("bolt://localhost:7687", NoAuth(), func(config *Config) {
+		config.DatabaseResolver = func(ctx context.Context) string {
+			tenant, _ := ctx.Value(tenantKey{}).(string)
+			return tenant
+		}
+	})
+	AssertNoError(outer, err)
+	tenantCtx := context.WithValue(context.Background(), tenantKey{}, "Tenant-A")
+
+	outer.Run("resolves the database of the tenant", func(t *testing.T) {
+		session := driver.NewSession(tenantCtx, SessionConfig{})
+
+		sess := session.(*sessionWithContext)
+		AssertStringEqual(t, sess.databaseName, "tenant-a")
+		AssertFalse(t, sess.resolveHomeDb)
+	})
+
+	outer.Run("does not override the session database", func(t *testing.T) {
+		session := driver.NewSession(tenantCtx, SessionConfig{DatabaseName: "shared"})
+
+		AssertStringEqual(t, session.(*sessionWithContext).databaseName, "shared")
+	})
+
+	outer.Run("falls back to the home database", func(t *testing.T) {
+		session := driver.NewSession(context.Background(), SessionConfig{})
+
+		sess := session.(*sessionWithContext)
+		AssertStringEqual(t, sess.databaseName, "")
+		AssertTrue(t, sess.resolveHomeDb)
+	})
+}
+
+func TestDriverFaultInjection(t *testing.T) {
+	driver, err := NewDriverWithContext("bolt://localhost:7687", NoAuth(), func(config *Config) {
+		config.FaultInjection = &FaultInjection{DropConnectionAfterMessages: 3}
+	})
+	AssertNoError(t, err)
+
+	injector := driver.(*driverWithContext).connector.FaultInjector
+	if faults.Enabled {
+		AssertDeepEquals(t, injector, faults.Rules{DropConnectionAfterMessages: 3})
+	} else if injector != nil {
+		t.Errorf("should ignore fault injection without the neo4j_fault_injection build tag")
+	}
+}
+
+type warmUpPoolFake struct {
+	servers []string
+	minSize int
+}
+
+func (p *warmUpPoolFake) WarmUp(_ context.Context, serverNames []string, minSize int) error {
+	p.servers, p.minSize = serverNames, minSize
+	return nil
+}
+
+func TestDriverWarmUp(outer *testing.T) {
+	newDriver := func(routerFake *RouterFake) *driverWithContext {
+		config := defaultConfig()
+		config.MinConnectionPoolSize = 2
+		return &driverWithContext{config: config, router: routerFake, log: &log.Void{}}
+	}
+
+	outer.Run("warms up writers and readers of the default database", func(t *testing.T) {
+		var databases []string
+		routerFake := &RouterFake{
+			GetNameOfDefaultDbHook: func(string) (string, error) { return "neo4j", nil },
+			WritersHook: func(_ func(context.Context) ([]string, error), database string) ([]string, error) {
+				databases = append(databases, database)
+				return []string{"writer"}, nil
+			},
+			ReadersHook: func(_ func(context.Context) ([]string, error), database string) ([]string, error) {
+				databases = append(databases, database)
+				return []string{"reader1", "reader2"}, nil
+			},
+		}
+		pool := &warmUpPoolFake{}
+
+		newDriver(routerFake).warmUp(context.Background(), pool)
+
+		AssertDeepEquals(t, databases, []string{"neo4j", "neo4j"})
+		AssertDeepEquals(t, pool.servers, []string{"writer", "reader1", "reader2"})
+		AssertIntEqual(t, pool.minSize, 2)
+	})
+
+	outer.Run("warms up readers without writers", func(t *testing.T) {
+		routerFake := &RouterFake{
+			WritersHook: func(func(context.Context) ([]string, error), string) ([]string, error) {
+				return nil, errors.New("no writers")
+			},
+			ReadersRet: []string{"reader"},
+		}
+		pool := &warmUpPoolFake{}
+
+		newDriver(routerFake).warmUp(context.Background(), pool)
+
+		AssertDeepEquals(t, pool.servers, []string{"reader"})
+	})
+
+	outer.Run("is cancelled when the driver is closed", func(t *testing.T) {
+		driver := newDriver(&RouterFake{})
+		driver.mut = racing.NewMutex()
+		ctx, stop := context.WithCancel(context.Background())
+		driver.stopWarmUp = stop
+
+		AssertNoError(t, driver.Close(context.Background()))
+
+		AssertError(t, ctx.Err())
+		AssertNil(t, driver.stopWarmUp)
+	})
+}
+
+type routingTablePrefetcherFake struct {
+	prefetches chan string
+	validFor   time.Duration
+}
+
+func (p *routingTablePrefetcherFake) Prefetch(_ context.Context, database string, validFor time.Duration) error {
+	p.validFor = validFor
+	p.prefetches <- database
+	return errors.New("prefetch failed")
+}
+
+func TestDriverPrefetchRoutingTables(t *testing.T) {
+	config := defaultConfig()
+	config.PrefetchRoutingTableDatabases = []string{"", "movies"}
+	driver := &driverWithContext{config: config, log: &log.Void{}}
+	prefetcher := &routingTablePrefetcherFake{prefetches: make(chan string)}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		driver.prefetchRoutingTables(prefetcher, time.Millisecond, stop)
+		close(done)
+	}()
+
+	// failures do not stop the prefetching of the other databases, nor the next refresh
+	var databases []string
+	for i := 0; i < 4; i++ {
+		databases = append(databases, <-prefetcher.prefetches)
+	}
+	close(stop)
+	go func() {
+		for range prefetcher.prefetches {
+		}
+	}()
+	<-done
+	close(prefetcher.prefetches)
+
+	AssertDeepEquals(t, databases, []string{"", "movies", "", "movies"})
+	AssertDeepEquals(t, prefetcher.validFor, 2*time.Millisecond)
+}
+
+type infoRecorder struct {
+	log.Void
+	infos []string
+}
+
+func (l *infoRecorder) Infof(_, _ string, msg string, args ...any) {
+	l.infos = append(l.infos, fmt.Sprintf(msg, args...))
+}
+
+func TestDriverLogPoolState(outer *testing.T) {
+	ctx := context.Background()
+
+	outer.Run("logs every pooled connection", func(t *testing.T) {
+		logger := &infoRecorder{}
+		connect := func(_ context.Context, name string, _ log.BoltLogger) (idb.Connection, error) {
+			return &ConnFake{Name: name, Alive: true, Birth: time.Now(), LastErr: errors.New("connection reset by peer")}, nil
+		}
+		connectionPool := pool.New(1, time.Hour, connect, &log.Void{}, "pool id")
+		defer connectionPool.Close(ctx)
+		conn, err := connectionPool.Borrow(ctx, []string{"srv1"}, false, nil, pool.DefaultLivenessCheckThreshold)
+		AssertNoError(t, err)
+		AssertNoError(t, connectionPool.Return(ctx, conn))
+		driver := &driverWithContext{config: defaultConfig(), pool: connectionPool, log: logger, mut: racing.NewMutex()}
+
+		err = driver.LogPoolState(ctx)
+
+		AssertNoError(t, err)
+		AssertLen(t, logger.infos, 2)
+		AssertStringEqual(t, logger.infos[0], "Pool state { connections: 1 }")
+		AssertStringContain(t, logger.infos[1], "Pooled connection { server: srv1, status: idle, age: ")
+		AssertStringContain(t, logger.infos[1], "last error: connection reset by peer }")
+	})
+
+	outer.Run("does not report the last error of busy connections", func(t *testing.T) {
+		logger := &infoRecorder{}
+		connect := func(context.Context, string, log.BoltLogger) (idb.Connection, error) {
+			return &ConnFake{Alive: true, Birth: time.Now(), LastErr: errors.New("connection reset by peer")}, nil
+		}
+		connectionPool := pool.New(1, time.Hour, connect, &log.Void{}, "pool id")
+		defer connectionPool.Close(ctx)
+		conn, err := connectionPool.Borrow(ctx, []string{"srv1"}, false, nil, pool.DefaultLivenessCheckThreshold)
+		AssertNoError(t, err)
+		defer connectionPool.Return(ctx, conn)
+		driver := &driverWithContext{config: defaultConfig(), pool: connectionPool, log: logger, mut: racing.NewMutex()}
+
+		err = driver.LogPoolState(ctx)
+
+		AssertNoError(t, err)
+		AssertLen(t, logger.infos, 2)
+		AssertStringContain(t, logger.infos[1], "Pooled connection { server: srv1, status: busy, age: ")
+		AssertStringContain(t, logger.infos[1], "idle time: 0s, last error: unknown }")
+	})
+
+	outer.Run("fails on closed driver", func(t *testing.T) {
+		driver := &driverWithContext{config: defaultConfig(), log: &log.Void{}, mut: racing.NewMutex()}
+
+		err := driver.LogPoolState(ctx)
+
+		AssertSameType(t, err, &UsageError{})
+	})
+}
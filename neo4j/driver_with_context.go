@@ -24,13 +24,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/auth"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/errorutil"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/faults"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/racing"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/retry"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/connector"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
@@ -74,6 +78,23 @@ type DriverWithContext interface {
 	// or error describing the problem.
 	// Contexts terminating too early negatively affect connection pooling and degrade the driver performance.
 	VerifyConnectivity(ctx context.Context) error
+	// VerifyAuthentication checks that the given token is accepted by the remote server or cluster, without
+	// creating a session. Returns nil if successful or error describing the problem.
+	// Authentication failures are reported as *Neo4jError for which IsAuthenticationFailed returns true, while
+	// failures to reach the server are reported as *ConnectivityError.
+	// Note that a connection authenticated with the given token may be kept in the pool. Depending on the server
+	// version, it is either re-authenticated or replaced when it is next borrowed.
+	// Contexts terminating too early negatively affect connection pooling and degrade the driver performance.
+	VerifyAuthentication(ctx context.Context, auth *AuthToken) error
+	// SetWritesPaused pauses or resumes the write transactions of all the sessions of the driver, including the ones
+	// of ExecuteQuery. The driver does not inspect queries, so a write transaction is any transaction started in the
+	// write access mode: ExecuteWrite, as well as BeginTransaction and Run on sessions configured with
+	// AccessModeWrite, the default. While writes are paused, starting such a transaction fails fast with a
+	// WritesPausedError without reaching the server, even if its queries only read. ExecuteRead, sessions configured
+	// with AccessModeRead and ExecuteQuery with ExecuteQueryWithReadersRouting are unaffected, whatever their queries
+	// do. Transactions already started are not interrupted.
+	// This lets applications participate in planned failovers or maintenance windows without being redeployed.
+	SetWritesPaused(paused bool)
 	// Close the driver and all underlying connections
 	Close(ctx context.Context) error
 	// IsEncrypted determines whether the driver communication with the server
@@ -84,6 +105,43 @@ type DriverWithContext interface {
 	// deployment
 	// Contexts terminating too early negatively affect connection pooling and degrade the driver performance.
 	GetServerInfo(ctx context.Context) (ServerInfo, error)
+	// QueryStatistics returns a snapshot of the statistics aggregated so far about the queries executed by this driver.
+	// Statistics are only collected when Config.CollectQueryStatistics is enabled, the zero value is returned otherwise.
+	QueryStatistics() QueryStatistics
+	// RoutingTableStates returns when the routing table of each database has been fetched and when it expires.
+	// Only the routing tables currently cached by the driver are included, ordered by database name.
+	// This is useful to diagnose stale cluster topology issues. Direct drivers (bolt:// URI schemes) never route and
+	// always return an empty slice.
+	RoutingTableStates(ctx context.Context) ([]RoutingTableState, error)
+	// LogPoolState logs, at the info level, a one-shot snapshot of every connection held by the pool: its server,
+	// whether it is idle or busy, its age and, for idle connections, how long it has been idle and the last error it
+	// failed with.
+	// This is meant for incident response, when the state of the pool needs to be captured on demand.
+	// Contexts terminating too early negatively affect connection pooling and degrade the driver performance.
+	LogPoolState(ctx context.Context) error
+	// QueryCache returns the cache holding the results of the read queries run by ExecuteQuery with
+	// ExecuteQueryWithCache, so that they can be explicitly invalidated.
+	// The cache is only enabled when Config.QueryCacheMaxEntries is greater than 0, nil is returned otherwise.
+	QueryCache() *QueryCache
+	// Prepare registers a named query along with the schema its parameters are validated against.
+	// Preparing the same name again returns the already registered query if the query and schema are identical, and
+	// fails otherwise.
+	// The name is reported in DeprecationNotice and QueryPlanNotice for all queries sharing its fingerprint (see
+	// QueryFingerprint), which keeps metrics and audit trails stable when literal values change.
+	Prepare(name string, cypher string, schema ParameterSchema) (*PreparedQuery, error)
+	// PreparedQuery returns the query registered with Prepare under the given name, if any
+	PreparedQuery(name string) (*PreparedQuery, bool)
+}
+
+// RoutingTableState describes the routing table cached by the driver for a given database.
+type RoutingTableState struct {
+	// DatabaseName is the name of the database the routing table belongs to.
+	// It is empty for the routing table of the default database, when no database name has been resolved.
+	DatabaseName string
+	// FetchedAt is the time at which the routing table was fetched.
+	FetchedAt time.Time
+	// ExpiresAt is the time after which the routing table is considered stale and refreshed upon next use.
+	ExpiresAt time.Time
 }
 
 // ResultTransformer is a record accumulator that produces an instance of T when the processing of records is over.
@@ -113,63 +171,43 @@ type ResultTransformer[T any] interface {
 //
 //	driver, err = NewDriverWithContext("neo4j://core.db.server:7687", BasicAuth(username, password))
 //
+// Where the Bolt port cannot be reached, queries can be sent to the HTTP Query API of Neo4j 5.19+ instead, by passing
+// a URI with scheme 'http' or 'https'. Such drivers never route, fully receive query results before returning them,
+// and fail with a UsageError when transaction timeouts or metadata are configured. Sessions target the 'neo4j'
+// database unless a database name is configured.
+//
+//	driver, err = NewDriverWithContext("https://db.server:7473", BasicAuth(username, password))
+//
 // You can override default configuration options by providing a configuration function(s)
 //
 //	driver, err = NewDriverWithContext(uri, BasicAuth(username, password), function (config *Config) {
 //		config.MaxConnectionPoolSize = 10
 //	})
-func NewDriverWithContext(target string, auth AuthToken, configurers ...func(*Config)) (DriverWithContext, error) {
-	parsed, err := url.Parse(target)
+//
+// Tokens that expire, such as SSO tokens, can be rotated by passing an auth.TokenManager instead of an AuthToken.
+// The driver then asks the manager for a fresh token when the server reports the current one as expired, retries
+// the affected transaction functions, and re-authenticates pooled connections with LOGOFF/LOGON when the server
+// speaks Bolt 5.1 or later. Connections to older servers are replaced instead.
+//
+//	driver, err = NewDriverWithContext(uri, auth.ExpirationBasedTokenManager(fetchToken))
+func NewDriverWithContext(target string, tokenManager auth.TokenManager, configurers ...func(*Config)) (DriverWithContext, error) {
+	parsedTarget, err := parseDriverTarget(target)
 	if err != nil {
 		return nil, err
 	}
-
-	d := driverWithContext{target: parsed, mut: racing.NewMutex()}
-
-	routing := true
-	d.connector.Network = "tcp"
-	address := parsed.Host
-	switch parsed.Scheme {
-	case "bolt":
-		routing = false
-		d.connector.SkipEncryption = true
-	case "bolt+unix":
-		// bolt+unix://<path to socket>
-		routing = false
-		d.connector.SkipEncryption = true
-		d.connector.Network = "unix"
-		if parsed.Host != "" {
-			return nil, &UsageError{
-				Message: fmt.Sprintf("Host part should be empty for scheme %s", parsed.Scheme),
-			}
-		}
-		address = parsed.Path
-	case "bolt+s":
-		routing = false
-	case "bolt+ssc":
-		d.connector.SkipVerify = true
-		routing = false
-	case "neo4j":
-		d.connector.SkipEncryption = true
-	case "neo4j+ssc":
-		d.connector.SkipVerify = true
-	case "neo4j+s":
-	default:
-		return nil, &UsageError{
-			Message: fmt.Sprintf("URI scheme %s is not supported", parsed.Scheme),
-		}
+	if tokenManager == nil {
+		return nil, &UsageError{Message: "Auth token manager cannot be nil"}
 	}
+	parsed := parsedTarget.url
+	routing := parsedTarget.routing
+	address := parsedTarget.address
 
-	if parsed.Host != "" && parsed.Port() == "" {
-		address += ":7687"
-		parsed.Host = address
-	}
-
-	if !routing && len(parsed.RawQuery) > 0 {
-		return nil, &UsageError{
-			Message: fmt.Sprintf("Routing context is not supported for URL scheme %s", parsed.Scheme),
-		}
-	}
+	d := driverWithContext{target: parsed, mut: racing.NewMutex()}
+	d.connector.Network = parsedTarget.network
+	d.connector.Scheme = parsed.Scheme
+	d.connector.SkipEncryption = parsedTarget.skipEncryption
+	d.connector.SkipVerify = parsedTarget.skipVerify
+	d.connector.Http = parsedTarget.http
 
 	// Apply client hooks for setting up configuration
 	d.config = defaultConfig()
@@ -196,16 +234,74 @@ func NewDriverWithContext(target string, auth AuthToken, configurers ...func(*Co
 	// Continue to setup connector
 	d.connector.DialTimeout = d.config.SocketConnectTimeout
 	d.connector.SocketKeepAlive = d.config.SocketKeepalive
-	d.connector.UserAgent = d.config.UserAgent
+	d.connector.Dialer = d.config.Dialer
+	d.connector.Proxy = d.config.ProxyURL
+	if d.connector.Proxy == nil {
+		d.connector.Proxy, d.connector.NoProxy, err = connector.ProxyFromEnvironment()
+		if err != nil {
+			return nil, &UsageError{Message: fmt.Sprintf("Invalid ALL_PROXY environment variable: %s", err)}
+		}
+	}
+	d.connector.UserAgent = userAgentOf(d.config)
 	//lint:ignore SA1019 RootCAs is still supported until 6.0
 	d.connector.RootCAs = d.config.RootCAs
 	d.connector.TlsConfig = d.config.TlsConfig
+	d.connector.ClientCertificateProvider = d.config.ClientCertificateProvider
 	d.connector.Log = d.log
-	d.connector.Auth = auth.tokens
+	d.connector.Auth = func(ctx context.Context) (map[string]any, error) {
+		return getAuthTokens(ctx, tokenManager)
+	}
+	d.authManager = tokenManager
 	d.connector.RoutingContext = routingContext
+	d.connector.Notifications = notificationConfig(d.config.NotificationsMinSeverity, d.config.NotificationsDisabledCategories)
+	d.connector.UnsupportedFeature = &connector.FeatureError{Clock: d.config.Clock}
+	d.connector.ReportInvalidValues = d.config.ContinueOnHydrationError
+	d.connector.DecodeUnknownValues = d.config.DecodeUnknownValues
+	d.connector.RawRecords = d.config.RawRecords
+	if d.config.FaultInjection != nil {
+		if !faults.Enabled {
+			d.log.Warnf(log.Driver, d.logId, "Ignoring fault injection, the driver is not built with the neo4j_fault_injection build tag")
+		} else if d.connector.Http {
+			d.log.Warnf(log.Driver, d.logId, "Ignoring fault injection, faults are only injected in Bolt traffic")
+		} else {
+			d.connector.FaultInjector = d.config.FaultInjection.injector()
+		}
+	}
+	if d.config.CollectQueryStatistics {
+		d.statistics = newQueryStatisticsCollector()
+		d.connector.OnBytesReceived = d.statistics.onBytesReceived
+	}
+
+	if d.config.RetryBudgetRatio > 0 {
+		d.retryBudget = retry.NewBudget(d.config.RetryBudgetRatio, d.config.RetryBudgetWindow)
+	}
+
+	d.preparedQueries = newPreparedQueries()
+	if d.config.OnDeprecationNotice != nil {
+		d.config.OnDeprecationNotice = d.preparedQueries.nameDeprecationNotices(d.config.OnDeprecationNotice)
+	}
+	if d.config.OnQueryPlan != nil {
+		d.config.OnQueryPlan = d.preparedQueries.nameQueryPlans(d.config.OnQueryPlan)
+		d.explainer = newQueryExplainer(d.config.OnQueryPlan)
+	}
+	if d.config.OnInlinedLiterals != nil {
+		d.config.OnInlinedLiterals = d.preparedQueries.nameInlinedLiterals(d.config.OnInlinedLiterals)
+		d.linter = newLiteralLinter(d.config.OnInlinedLiterals)
+	}
+
+	if d.config.QueryCacheMaxEntries > 0 {
+		d.queryCache = newQueryCache(d.config.QueryCacheMaxEntries, d.config.QueryCacheTTL, d.config.Clock)
+	}
 
 	// Let the pool use the same log ID as the driver to simplify log reading.
-	d.pool = pool.New(d.config.MaxConnectionPoolSize, d.config.MaxConnectionLifetime, d.connector.Connect, d.log, d.logId)
+	connectionPool := pool.New(d.config.MaxConnectionPoolSize, d.config.MaxConnectionLifetime, d.connector.Connect, d.log, d.logId)
+	connectionPool.RotateOnTerminationNotice = d.config.RotateConnectionsOnTerminationNotice
+	connectionPool.MaxWaiters = d.config.MaxConnectionAcquisitionWaiters
+	connectionPool.GetAuth = d.connector.Auth
+	if d.config.MaxConnectionIdleTime > 0 {
+		connectionPool.StartIdleReaper(d.config.MaxConnectionIdleTime, d.config.MinConnectionPoolSize)
+	}
+	d.pool = connectionPool
 
 	if !routing {
 		d.router = &directRouter{address: address}
@@ -223,17 +319,169 @@ func NewDriverWithContext(target string, auth AuthToken, configurers ...func(*Co
 			}
 		}
 		// Let the router use the same log ID as the driver to simplify log reading.
-		d.router = router.New(address, routersResolver, routingContext, d.pool, d.log, d.logId)
+		r := router.New(address, routersResolver, routingContext, d.pool, d.log, d.logId)
+		r.PreferReadReplicas = d.config.PreferReadReplicas
+		d.router = r
+		if len(d.config.PrefetchRoutingTableDatabases) > 0 {
+			d.stopPrefetch = make(chan struct{})
+			go d.prefetchRoutingTables(r, routingTablePrefetchInterval, d.stopPrefetch)
+		}
+	}
+
+	if d.config.MinConnectionPoolSize > 0 {
+		var warmUpCtx context.Context
+		warmUpCtx, d.stopWarmUp = context.WithCancel(context.Background())
+		go d.warmUp(warmUpCtx, connectionPool)
 	}
 
 	d.log.Infof(log.Driver, d.logId, "Created { target: %s }", address)
 	return &d, nil
 }
 
+// driverTarget is the outcome of parsing the URI a driver is created with
+type driverTarget struct {
+	url            *url.URL
+	address        string
+	network        string
+	routing        bool
+	skipEncryption bool
+	skipVerify     bool
+	http           bool
+}
+
+func parseDriverTarget(target string) (*driverTarget, error) {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &driverTarget{url: parsed, address: parsed.Host, network: "tcp", routing: true}
+	defaultPort := "7687"
+	switch parsed.Scheme {
+	case "bolt":
+		result.routing = false
+		result.skipEncryption = true
+	case "bolt+unix":
+		// bolt+unix://<path to socket>
+		result.routing = false
+		result.skipEncryption = true
+		result.network = "unix"
+		if parsed.Host != "" {
+			return nil, &UsageError{
+				Message: fmt.Sprintf("Host part should be empty for scheme %s", parsed.Scheme),
+			}
+		}
+		result.address = parsed.Path
+	case "bolt+s":
+		result.routing = false
+	case "bolt+ssc":
+		result.skipVerify = true
+		result.routing = false
+	case "neo4j":
+		result.skipEncryption = true
+	case "neo4j+ssc":
+		result.skipVerify = true
+	case "neo4j+s":
+	case "http":
+		result.routing = false
+		defaultPort = "7474"
+		result.http = true
+		result.skipEncryption = true
+	case "https":
+		result.routing = false
+		defaultPort = "7473"
+		result.http = true
+	default:
+		return nil, &UsageError{
+			Message: fmt.Sprintf("URI scheme %s is not supported", parsed.Scheme),
+		}
+	}
+
+	if parsed.Host != "" && parsed.Port() == "" {
+		result.address += ":" + defaultPort
+		parsed.Host = result.address
+	}
+	return result, nil
+}
+
+type warmUpPool interface {
+	WarmUp(ctx context.Context, serverNames []string, minSize int) error
+}
+
+// warmUp establishes Config.MinConnectionPoolSize connections to each writer and reader of the default database,
+// until the given context is cancelled when the driver is closed.
+// Failures are only logged, connections are then established on demand.
+func (d *driverWithContext) warmUp(ctx context.Context, connectionPool warmUpPool) {
+	if timeout := d.config.ConnectionAcquisitionTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	database, err := d.router.GetNameOfDefaultDatabase(ctx, nil, "", nil)
+	if err != nil {
+		d.log.Warnf(log.Driver, d.logId, "Could not warm up the connection pool: %s", err)
+		return
+	}
+	noBookmarks := func(context.Context) ([]string, error) { return nil, nil }
+	writers, err := d.router.Writers(ctx, noBookmarks, database, nil)
+	if err != nil {
+		d.log.Warnf(log.Driver, d.logId, "Could not warm up the connection pool to writers: %s", err)
+	}
+	readers, err := d.router.Readers(ctx, noBookmarks, database, nil)
+	if err != nil {
+		d.log.Warnf(log.Driver, d.logId, "Could not warm up the connection pool to readers: %s", err)
+	}
+	if err := connectionPool.WarmUp(ctx, append(writers, readers...), d.config.MinConnectionPoolSize); err != nil {
+		d.log.Warnf(log.Driver, d.logId, "Could not fully warm up the connection pool: %s", err)
+	}
+}
+
+type routingTablePrefetcher interface {
+	Prefetch(ctx context.Context, database string, validFor time.Duration) error
+}
+
+const routingTablePrefetchInterval = 10 * time.Second
+
+// prefetchRoutingTables keeps the routing tables of Config.PrefetchRoutingTableDatabases fresh, checking them every
+// interval until stop is closed. Tables are refreshed when they expire within the next two intervals.
+func (d *driverWithContext) prefetchRoutingTables(prefetcher routingTablePrefetcher, interval time.Duration,
+	stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, database := range d.config.PrefetchRoutingTableDatabases {
+			d.prefetchRoutingTable(prefetcher, database, 2*interval)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *driverWithContext) prefetchRoutingTable(prefetcher routingTablePrefetcher, database string,
+	validFor time.Duration) {
+	ctx := context.Background()
+	if timeout := d.config.ConnectionAcquisitionTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := prefetcher.Prefetch(ctx, database, validFor); err != nil {
+		d.log.Warnf(log.Driver, d.logId, "Could not prefetch the routing table of '%s': %s", database, err)
+	}
+}
+
 const routingContextAddressKey = "address"
 
 func routingContextFromUrl(useRouting bool, u *url.URL) (map[string]string, error) {
 	if !useRouting {
+		if len(u.RawQuery) > 0 {
+			return nil, &UsageError{
+				Message: fmt.Sprintf("Routing context is not supported for URL scheme %s", u.Scheme),
+			}
+		}
 		return nil, nil
 	}
 	queryValues := u.Query()
@@ -280,6 +528,8 @@ type sessionRouter interface {
 	GetNameOfDefaultDatabase(ctx context.Context, bookmarks []string, user string, boltLogger log.BoltLogger) (string, error)
 	Invalidate(ctx context.Context, database string) error
 	CleanUp(ctx context.Context) error
+	// TableStates returns the state of the routing tables currently cached, if any.
+	TableStates(ctx context.Context) ([]db.RoutingTableState, error)
 	InvalidateWriter(ctx context.Context, name string, server string) error
 	InvalidateReader(ctx context.Context, name string, server string) error
 }
@@ -298,6 +548,26 @@ type driverWithContext struct {
 	// instance of the bookmark manager only used by default by managed sessions of ExecuteQuery
 	// this is *not* used by default by user-created session (see NewSession)
 	defaultExecuteQueryBookmarkManager BookmarkManager
+	// nil unless Config.CollectQueryStatistics is enabled
+	statistics *queryStatisticsCollector
+	// retryBudget is shared by all the sessions of the driver, see Config.RetryBudgetRatio
+	retryBudget *retry.Budget
+	// writeFence is shared by all the sessions of the driver, see SetWritesPaused
+	writeFence writeFence
+	// nil unless Config.OnQueryPlan is set
+	explainer *queryExplainer
+	// nil unless Config.OnInlinedLiterals is set
+	linter *literalLinter
+	// stopPrefetch stops the prefetching of routing tables, nil unless Config.PrefetchRoutingTableDatabases is set
+	stopPrefetch chan struct{}
+	// stopWarmUp cancels the warm-up of the connection pool, nil unless Config.MinConnectionPoolSize is set
+	stopWarmUp context.CancelFunc
+	// nil unless Config.QueryCacheMaxEntries is greater than 0
+	queryCache *QueryCache
+	// registry of the queries registered with Prepare
+	preparedQueries *preparedQueries
+	// authManager supplies the tokens connections are authenticated with
+	authManager auth.TokenManager
 }
 
 func (d *driverWithContext) Target() url.URL {
@@ -305,6 +575,9 @@ func (d *driverWithContext) Target() url.URL {
 }
 
 func (d *driverWithContext) NewSession(ctx context.Context, config SessionConfig) SessionWithContext {
+	if config.DatabaseName == "" && d.config.DatabaseResolver != nil {
+		config.DatabaseName = d.config.DatabaseResolver(ctx)
+	}
 	if config.DatabaseName == "" {
 		config.DatabaseName = db.DefaultDatabase
 	}
@@ -318,7 +591,17 @@ func (d *driverWithContext) NewSession(ctx context.Context, config SessionConfig
 		return &erroredSessionWithContext{
 			err: &UsageError{Message: "Trying to create session on closed driver"}}
 	}
-	return newSessionWithContext(d.config, config, d.router, d.pool, d.log)
+	if err := validateSessionConfig(config); err != nil {
+		return &erroredSessionWithContext{err: err}
+	}
+	session := newSessionWithContext(d.config, config, d.router, d.pool, d.log)
+	session.statistics = d.statistics.newChild()
+	session.retryBudget = d.retryBudget
+	session.writeFence = &d.writeFence
+	session.explainer = d.explainer
+	session.linter = d.linter
+	session.authManager = d.authManager
+	return session
 }
 
 func (d *driverWithContext) VerifyConnectivity(ctx context.Context) error {
@@ -326,6 +609,22 @@ func (d *driverWithContext) VerifyConnectivity(ctx context.Context) error {
 	return err
 }
 
+func (d *driverWithContext) VerifyAuthentication(ctx context.Context, auth *AuthToken) (err error) {
+	if auth == nil {
+		return &UsageError{Message: "Cannot verify authentication without a token"}
+	}
+	session := d.NewSession(ctx, SessionConfig{Auth: auth})
+	defer func() {
+		err = deferredClose(ctx, session, err)
+	}()
+	_, err = session.getServerInfo(ctx)
+	return err
+}
+
+func (d *driverWithContext) SetWritesPaused(paused bool) {
+	d.writeFence.setPaused(paused)
+}
+
 func (d *driverWithContext) IsEncrypted() bool {
 	return !d.connector.SkipEncryption
 }
@@ -338,18 +637,90 @@ func (d *driverWithContext) GetServerInfo(ctx context.Context) (_ ServerInfo, er
 	return session.getServerInfo(ctx)
 }
 
+func (d *driverWithContext) QueryStatistics() QueryStatistics {
+	return d.statistics.snapshot()
+}
+
+func (d *driverWithContext) QueryCache() *QueryCache {
+	return d.queryCache
+}
+
+func (d *driverWithContext) Prepare(name string, cypher string, schema ParameterSchema) (*PreparedQuery, error) {
+	return d.preparedQueries.prepare(name, cypher, schema)
+}
+
+func (d *driverWithContext) PreparedQuery(name string) (*PreparedQuery, bool) {
+	return d.preparedQueries.get(name)
+}
+
+func (d *driverWithContext) RoutingTableStates(ctx context.Context) ([]RoutingTableState, error) {
+	states, err := d.router.TableStates(ctx)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	result := make([]RoutingTableState, len(states))
+	for i, state := range states {
+		result[i] = RoutingTableState{
+			DatabaseName: state.DatabaseName,
+			FetchedAt:    state.FetchedAt,
+			ExpiresAt:    state.ExpiresAt,
+		}
+	}
+	return result, nil
+}
+
+func (d *driverWithContext) LogPoolState(ctx context.Context) error {
+	if !d.mut.TryLock(ctx) {
+		return racing.LockTimeoutError("could not acquire lock in time when logging pool state")
+	}
+	defer d.mut.Unlock()
+	if d.pool == nil {
+		return &UsageError{Message: "Trying to log the pool state of a closed driver"}
+	}
+	states, err := d.pool.ConnectionStates(ctx)
+	if err != nil {
+		return wrapError(err)
+	}
+	d.log.Infof(log.Driver, d.logId, "Pool state { connections: %d }", len(states))
+	for _, state := range states {
+		status, lastError := "idle", "none"
+		if state.Busy {
+			status, lastError = "busy", "unknown"
+		}
+		if state.LastError != nil {
+			lastError = state.LastError.Error()
+		}
+		d.log.Infof(log.Driver, d.logId, "Pooled connection { server: %s, status: %s, age: %s, idle time: %s, last error: %s }",
+			state.Server, status, state.Age, state.IdleTime, lastError)
+	}
+	return nil
+}
+
 func (d *driverWithContext) Close(ctx context.Context) error {
 	if !d.mut.TryLock(ctx) {
 		return racing.LockTimeoutError("could not acquire lock in time when closing driver")
 	}
 	defer d.mut.Unlock()
 	// Safeguard against closing more than once
+	if d.stopPrefetch != nil {
+		close(d.stopPrefetch)
+		d.stopPrefetch = nil
+	}
+	if d.stopWarmUp != nil {
+		d.stopWarmUp()
+		d.stopWarmUp = nil
+	}
 	if d.pool != nil {
 		if err := d.pool.Close(ctx); err != nil {
 			return err
 		}
 	}
 	d.pool = nil
+	if d.statistics != nil {
+		stats := d.statistics.snapshot()
+		d.log.Infof(log.Driver, d.logId, "Query statistics { queries: %d, failures: %d, failures by code: %v, bytes received: %d, records decoded: %d }",
+			stats.Queries, stats.Failures, stats.FailuresByCode, stats.BytesReceived, stats.RecordsDecoded)
+	}
 	d.log.Infof(log.Driver, d.logId, "Closed")
 	return nil
 }
@@ -443,6 +814,9 @@ func (d *driverWithContext) Close(ctx context.Context) error {
 // Since ResultTransformer implementations are inherently stateful, the function must return a new ResultTransformer
 // instance every time it is called.
 //
+// Results of read queries can be cached driver-wide by passing the neo4j.ExecuteQueryWithCache callback, see
+// Config.QueryCacheMaxEntries.
+//
 // Contexts terminating too early negatively affect connection pooling and degrade the driver performance.
 func ExecuteQuery[T any](
 	ctx context.Context,
@@ -465,19 +839,132 @@ func ExecuteQuery[T any](
 	for _, setter := range settings {
 		setter(configuration)
 	}
+	var cache *QueryCache
+	var cacheKey string
+	if configuration.UseCache {
+		if cache = driver.QueryCache(); cache != nil {
+			if configuration.Routing != Readers {
+				return *new(T), &UsageError{Message: "only queries routed to readers can be cached"}
+			}
+			cacheKey = queryCacheKey[T](configuration, query, parameters)
+			if cached, found := cache.get(cacheKey); found {
+				return cached.(T), nil
+			}
+		}
+	}
+	sessionConfiguration := configuration
+	var recorder *suppliedBookmarksRecorder
+	if _, ok := configuration.BookmarkManager.(BookmarkForgetter); ok && configuration.RecoverFromInvalidBookmarks {
+		recorder = &suppliedBookmarksRecorder{BookmarkManager: configuration.BookmarkManager}
+		recordingConfiguration := *configuration
+		recordingConfiguration.BookmarkManager = recorder
+		sessionConfiguration = &recordingConfiguration
+	}
+	result, err := executeQueryInSession(ctx, driver, sessionConfiguration, query, parameters, newResultTransformer)
+	if err != nil && recorder != nil && isInvalidBookmarkError(err) {
+		if recovered := forgetInvalidBookmarks(ctx, configuration, recorder.lastSupplied(), err); recovered {
+			result, err = executeQueryInSession(ctx, driver, configuration, query, parameters, newResultTransformer)
+		}
+	}
+	if result == nil {
+		return *new(T), err
+	}
+	if cache != nil && err == nil {
+		cache.put(cacheKey, query, result)
+	}
+	return result.(T), err
+}
+
+// executeQueryInSession runs the query in a new session, the result is returned even if the session fails to close
+func executeQueryInSession[T any](
+	ctx context.Context,
+	driver DriverWithContext,
+	configuration *ExecuteQueryConfiguration,
+	query string,
+	parameters map[string]any,
+	newResultTransformer func() ResultTransformer[T]) (result any, err error) {
+
 	session := driver.NewSession(ctx, configuration.toSessionConfig())
 	defer func() {
 		err = errorutil.CombineAllErrors(err, session.Close(ctx))
 	}()
 	txFunction, err := configuration.selectTxFunctionApi(session)
 	if err != nil {
-		return *new(T), err
+		return nil, err
 	}
-	result, err := txFunction(ctx, executeQueryCallback(ctx, query, parameters, newResultTransformer))
-	if err != nil {
-		return *new(T), err
+	var txConfigurers []func(*TransactionConfig)
+	if configuration.FallbackToWriters {
+		txConfigurers = append(txConfigurers, withWritersFallback())
 	}
-	return result.(T), err
+	return txFunction(ctx, executeQueryCallback(ctx, query, parameters, newResultTransformer), txConfigurers...)
+}
+
+// suppliedBookmarksRecorder remembers the bookmarks its bookmark manager last supplied, that is the bookmarks sent
+// with the last transaction attempt of ExecuteQuery
+type suppliedBookmarksRecorder struct {
+	BookmarkManager
+	mut      sync.Mutex
+	supplied Bookmarks
+}
+
+func (r *suppliedBookmarksRecorder) GetBookmarks(ctx context.Context) (Bookmarks, error) {
+	bookmarks, err := r.BookmarkManager.GetBookmarks(ctx)
+	if err == nil {
+		r.mut.Lock()
+		r.supplied = bookmarks
+		r.mut.Unlock()
+	}
+	return bookmarks, err
+}
+
+func (r *suppliedBookmarksRecorder) lastSupplied() Bookmarks {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return r.supplied
+}
+
+// isInvalidBookmarkError reports whether the server rejected the bookmarks of the transaction, either because it
+// does not know them or because it timed out waiting to catch up with them
+func isInvalidBookmarkError(err error) bool {
+	var neo4jErr *Neo4jError
+	if !errors.As(err, &neo4jErr) {
+		return false
+	}
+	return neo4jErr.Code == "Neo.ClientError.Transaction.InvalidBookmark" ||
+		neo4jErr.Code == "Neo.TransientError.Transaction.BookmarkTimeout"
+}
+
+// offendingBookmarks returns the bookmarks named by the server error among the ones sent with the failed transaction,
+// or all of them when the error names none
+func offendingBookmarks(supplied Bookmarks, cause error) Bookmarks {
+	var neo4jErr *Neo4jError
+	errors.As(cause, &neo4jErr)
+	var named Bookmarks
+	for _, bookmark := range supplied {
+		if bookmark != "" && strings.Contains(neo4jErr.Msg, bookmark) {
+			named = append(named, bookmark)
+		}
+	}
+	if len(named) > 0 {
+		return named
+	}
+	return supplied
+}
+
+// forgetInvalidBookmarks removes the offending bookmarks from the bookmark manager of ExecuteQuery after the server
+// rejected them, and reports whether the query can be retried
+func forgetInvalidBookmarks(ctx context.Context, configuration *ExecuteQueryConfiguration, supplied Bookmarks, cause error) bool {
+	bookmarks := offendingBookmarks(supplied, cause)
+	if len(bookmarks) == 0 {
+		return false
+	}
+	if err := configuration.BookmarkManager.(BookmarkForgetter).ForgetBookmarks(ctx, bookmarks); err != nil {
+		return false
+	}
+	if configuration.OnInvalidBookmarks != nil {
+		configuration.OnInvalidBookmarks(ctx, bookmarks, cause)
+	}
+	return true
 }
 
 func (d *driverWithContext) DefaultExecuteQueryBookmarkManager() BookmarkManager {
@@ -606,14 +1093,72 @@ func ExecuteQueryWithoutBookmarkManager() ExecuteQueryConfigurationOption {
 	}
 }
 
+// ExecuteQueryWithCache configures DriverWithContext.ExecuteQuery to serve the result from the driver query cache
+// when a fresh result of the same query, with the same parameters, database and impersonated user, is cached.
+// Otherwise, the query is run and its result is cached for Config.QueryCacheTTL.
+//
+// This option has no effect when the driver cache is disabled (see Config.QueryCacheMaxEntries). Otherwise, only
+// queries routed to readers (see ExecuteQueryWithReadersRouting) can be cached, ExecuteQuery returns a UsageError for
+// the others. Failed queries, including those whose session fails to close, are not cached.
+// Cached results are served without contacting the server, hence without any bookmark: they may be stale with
+// respect to previous writes until they expire or are invalidated via DriverWithContext.QueryCache.
+// The same cached value is returned to every caller and must therefore not be modified.
+//
+// This API is currently experimental and may change or be removed at any time.
+func ExecuteQueryWithCache() ExecuteQueryConfigurationOption {
+	return func(configuration *ExecuteQueryConfiguration) {
+		configuration.UseCache = true
+	}
+}
+
+// ExecuteQueryWithWritersFallback configures DriverWithContext.ExecuteQuery to retry the query against writer members
+// of the cluster when it is routed to readers (see ExecuteQueryWithReadersRouting) and no reader is available.
+// This suits small deployments, such as single-instance ones, where the only server may temporarily not be advertised
+// as a reader.
+// The fallback happens within the retry loop of the query, it is therefore bound by Config.MaxTransactionRetryTime.
+// This option has no effect when the query is routed to writers.
+//
+// This API is currently experimental and may change or be removed at any time.
+func ExecuteQueryWithWritersFallback() ExecuteQueryConfigurationOption {
+	return func(configuration *ExecuteQueryConfiguration) {
+		configuration.FallbackToWriters = true
+	}
+}
+
+// ExecuteQueryWithInvalidBookmarksRecovery configures DriverWithContext.ExecuteQuery to recover when the server
+// rejects the bookmarks of the query, with Neo.ClientError.Transaction.InvalidBookmark when it does not know them,
+// which typically happens after a database has been restored from a backup, or with
+// Neo.TransientError.Transaction.BookmarkTimeout when it cannot catch up with them in time.
+// The offending bookmarks are removed from the bookmark manager and the query is retried once, without them.
+// The offending bookmarks are the ones sent with the query that the server error names, or all the ones sent with the
+// query if the error names none. Bookmarks the query was not sent with are kept.
+// The optional onInvalidBookmarks callback is called with the removed bookmarks and the server error before retrying.
+//
+// Recovery requires the bookmark manager to implement BookmarkForgetter, as the ones created by NewBookmarkManager
+// do, the server error is returned as is otherwise.
+// Note that the retried query is not causally chained with the previous queries anymore.
+//
+// This API is currently experimental and may change or be removed at any time.
+func ExecuteQueryWithInvalidBookmarksRecovery(onInvalidBookmarks func(context.Context, Bookmarks, error)) ExecuteQueryConfigurationOption {
+	return func(configuration *ExecuteQueryConfiguration) {
+		configuration.RecoverFromInvalidBookmarks = true
+		configuration.OnInvalidBookmarks = onInvalidBookmarks
+	}
+}
+
 // ExecuteQueryConfiguration holds all the possible configuration settings for DriverWithContext.ExecuteQuery
 //
 // This API is currently experimental and may change or be removed at any time.
 type ExecuteQueryConfiguration struct {
-	Routing          RoutingControl
-	ImpersonatedUser string
-	Database         string
-	BookmarkManager  BookmarkManager
+	Routing           RoutingControl
+	ImpersonatedUser  string
+	Database          string
+	BookmarkManager   BookmarkManager
+	UseCache          bool
+	FallbackToWriters bool
+	// RecoverFromInvalidBookmarks and OnInvalidBookmarks are set by ExecuteQueryWithInvalidBookmarksRecovery
+	RecoverFromInvalidBookmarks bool
+	OnInvalidBookmarks          func(context.Context, Bookmarks, error)
 }
 
 // RoutingControl specifies how the query executed by DriverWithContext.ExecuteQuery is to be routed
@@ -653,7 +1198,8 @@ func (c *ExecuteQueryConfiguration) selectTxFunctionApi(session SessionWithConte
 		"but got: %d", Writers, Readers, c.Routing)
 }
 
-// EagerResult holds the result and result metadata of the query executed via DriverWithContext.ExecuteQuery
+// EagerResult holds the result and result metadata of the query executed via DriverWithContext.ExecuteQuery or
+// SessionWithContext.RunBatch
 //
 // This API is currently experimental and may change or be removed at any time.
 type EagerResult struct {
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/racing"
 	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sync"
 	"sync/atomic"
@@ -444,6 +447,331 @@ func TestDriverExecuteQuery(outer *testing.T) {
 			t.Errorf("expected pointer address %v to be seen %d time(s), got these instead %v", address, count, addressCounts)
 		}
 	})
+
+	outer.Run("caches results", func(inner *testing.T) {
+		newDriver := func(sessionCount *int) *driverDelegate {
+			return &driverDelegate{
+				newSession: func(_ context.Context, config SessionConfig) SessionWithContext {
+					*sessionCount++
+					return &fakeSession{
+						executeReadTransactionResult: &fakeResult{
+							nextIndex:   -1,
+							keys:        keys,
+							nextRecords: records,
+							summary:     summary,
+						}}
+				},
+				delegate: &driverWithContext{
+					defaultExecuteQueryBookmarkManager: defaultBookmarkManager,
+					mut:                                racing.NewMutex(),
+					queryCache:                         newQueryCache(10, time.Minute, clock.NewFake(time.Now())),
+				},
+			}
+		}
+
+		inner.Run("serves cached read results", func(t *testing.T) {
+			sessionCount := 0
+			driver := newDriver(&sessionCount)
+
+			first, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithReadersRouting(), ExecuteQueryWithCache())
+			AssertNoError(t, err)
+			second, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithReadersRouting(), ExecuteQueryWithCache())
+			AssertNoError(t, err)
+
+			AssertIntEqual(t, sessionCount, 1)
+			AssertTrue(t, first == second)
+		})
+
+		inner.Run("runs invalidated queries again", func(t *testing.T) {
+			sessionCount := 0
+			driver := newDriver(&sessionCount)
+
+			_, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithReadersRouting(), ExecuteQueryWithCache())
+			AssertNoError(t, err)
+			driver.QueryCache().Invalidate("RETURN 42")
+			_, err = ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithReadersRouting(), ExecuteQueryWithCache())
+			AssertNoError(t, err)
+
+			AssertIntEqual(t, sessionCount, 2)
+		})
+
+		inner.Run("does not cache without option", func(t *testing.T) {
+			sessionCount := 0
+			driver := newDriver(&sessionCount)
+
+			_, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithReadersRouting())
+			AssertNoError(t, err)
+
+			AssertIntEqual(t, driver.QueryCache().Len(), 0)
+		})
+
+		inner.Run("rejects write queries", func(t *testing.T) {
+			sessionCount := 0
+			driver := newDriver(&sessionCount)
+
+			_, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithCache())
+
+			AssertSameType(t, err, &UsageError{})
+			AssertIntEqual(t, sessionCount, 0)
+		})
+
+		inner.Run("does not cache failed results", func(t *testing.T) {
+			sessionCount := 0
+			driver := newDriver(&sessionCount)
+			newSession := driver.newSession
+			driver.newSession = func(ctx context.Context, config SessionConfig) SessionWithContext {
+				session := newSession(ctx, config).(*fakeSession)
+				session.closeErr = errors.New("close failed")
+				return session
+			}
+
+			result, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithReadersRouting(), ExecuteQueryWithCache())
+
+			AssertErrorMessageContains(t, err, "close failed")
+			AssertNotNil(t, result)
+			AssertIntEqual(t, driver.QueryCache().Len(), 0)
+		})
+
+		inner.Run("ignores the option when the cache is disabled", func(t *testing.T) {
+			sessionCount := 0
+			driver := newDriver(&sessionCount)
+			driver.delegate.queryCache = nil
+			newSession := driver.newSession
+			driver.newSession = func(ctx context.Context, config SessionConfig) SessionWithContext {
+				session := newSession(ctx, config).(*fakeSession)
+				session.executeWriteTransactionResult = session.executeReadTransactionResult
+				return session
+			}
+
+			_, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithCache())
+
+			AssertNoError(t, err)
+			AssertIntEqual(t, sessionCount, 1)
+		})
+	})
+
+	outer.Run("invalid bookmarks recovery", func(inner *testing.T) {
+		invalidBookmarkErr := &Neo4jError{Code: "Neo.ClientError.Transaction.InvalidBookmark", Msg: "unknown bookmark"}
+		newDriver := func(sessions ...*fakeSession) (*driverDelegate, *int) {
+			sessionCount := 0
+			return &driverDelegate{
+				newSession: func(ctx context.Context, config SessionConfig) SessionWithContext {
+					// fake sessions do not begin transactions, the bookmarks are supplied here instead
+					if config.BookmarkManager != nil {
+						_, _ = config.BookmarkManager.GetBookmarks(ctx)
+					}
+					session := sessions[sessionCount]
+					sessionCount++
+					return session
+				},
+				delegate: &driverWithContext{mut: racing.NewMutex()},
+			}, &sessionCount
+		}
+		failingSession := func() *fakeSession {
+			return &fakeSession{executeWriteErr: invalidBookmarkErr}
+		}
+		failingSessionWith := func(err error) *fakeSession {
+			return &fakeSession{executeWriteErr: err}
+		}
+		succeedingSession := func() *fakeSession {
+			return &fakeSession{executeWriteTransactionResult: &fakeResult{
+				nextIndex:   -1,
+				keys:        keys,
+				nextRecords: records,
+				summary:     summary,
+			}}
+		}
+
+		inner.Run("clears bookmarks and retries once", func(t *testing.T) {
+			driver, sessionCount := newDriver(failingSession(), succeedingSession())
+			bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{InitialBookmarks: Bookmarks{"restored"}})
+			var clearedBookmarks Bookmarks
+			var reportedErr error
+
+			result, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithBookmarkManager(bookmarkManager),
+				ExecuteQueryWithInvalidBookmarksRecovery(func(_ context.Context, bookmarks Bookmarks, err error) {
+					clearedBookmarks = bookmarks
+					reportedErr = err
+				}))
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, result.Records, records)
+			AssertIntEqual(t, *sessionCount, 2)
+			AssertDeepEquals(t, clearedBookmarks, Bookmarks{"restored"})
+			AssertDeepEquals(t, reportedErr, invalidBookmarkErr)
+			bookmarks, err := bookmarkManager.GetBookmarks(ctx)
+			AssertNoError(t, err)
+			AssertLen(t, bookmarks, 0)
+		})
+
+		inner.Run("recovers from bookmark timeouts", func(t *testing.T) {
+			timeoutErr := &Neo4jError{Code: "Neo.TransientError.Transaction.BookmarkTimeout", Msg: "not up to date"}
+			driver, sessionCount := newDriver(failingSessionWith(timeoutErr), succeedingSession())
+			bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{InitialBookmarks: Bookmarks{"ahead"}})
+			var clearedBookmarks Bookmarks
+
+			_, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithBookmarkManager(bookmarkManager),
+				ExecuteQueryWithInvalidBookmarksRecovery(func(_ context.Context, bookmarks Bookmarks, _ error) {
+					clearedBookmarks = bookmarks
+				}))
+
+			AssertNoError(t, err)
+			AssertIntEqual(t, *sessionCount, 2)
+			AssertDeepEquals(t, clearedBookmarks, Bookmarks{"ahead"})
+		})
+
+		inner.Run("removes only the bookmarks named by the server", func(t *testing.T) {
+			namingErr := &Neo4jError{
+				Code: "Neo.ClientError.Transaction.InvalidBookmark",
+				Msg:  "Supplied bookmark 'FB:restored' is unknown",
+			}
+			driver, _ := newDriver(failingSessionWith(namingErr), succeedingSession())
+			bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{
+				InitialBookmarks: Bookmarks{"FB:valid", "FB:restored"},
+			})
+			var clearedBookmarks Bookmarks
+
+			_, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithBookmarkManager(bookmarkManager),
+				ExecuteQueryWithInvalidBookmarksRecovery(func(_ context.Context, bookmarks Bookmarks, _ error) {
+					clearedBookmarks = bookmarks
+				}))
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, clearedBookmarks, Bookmarks{"FB:restored"})
+			bookmarks, err := bookmarkManager.GetBookmarks(ctx)
+			AssertNoError(t, err)
+			AssertDeepEquals(t, bookmarks, Bookmarks{"FB:valid"})
+		})
+
+		inner.Run("keeps the bookmarks the query was not sent with", func(t *testing.T) {
+			bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{InitialBookmarks: Bookmarks{"restored"}})
+			sessionCount := 0
+			driver := &driverDelegate{
+				newSession: func(ctx context.Context, config SessionConfig) SessionWithContext {
+					sessionCount++
+					if sessionCount == 1 {
+						_, _ = config.BookmarkManager.GetBookmarks(ctx)
+						// another query completes while the first one fails
+						_ = bookmarkManager.UpdateBookmarks(ctx, nil, Bookmarks{"concurrent"})
+						return failingSession()
+					}
+					return succeedingSession()
+				},
+				delegate: &driverWithContext{mut: racing.NewMutex()},
+			}
+
+			_, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithBookmarkManager(bookmarkManager), ExecuteQueryWithInvalidBookmarksRecovery(nil))
+
+			AssertNoError(t, err)
+			bookmarks, err := bookmarkManager.GetBookmarks(ctx)
+			AssertNoError(t, err)
+			AssertDeepEquals(t, bookmarks, Bookmarks{"concurrent"})
+		})
+
+		inner.Run("fails when the retry fails", func(t *testing.T) {
+			driver, sessionCount := newDriver(failingSession(), failingSession())
+			bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{InitialBookmarks: Bookmarks{"restored"}})
+
+			_, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithBookmarkManager(bookmarkManager), ExecuteQueryWithInvalidBookmarksRecovery(nil))
+
+			AssertDeepEquals(t, err, invalidBookmarkErr)
+			AssertIntEqual(t, *sessionCount, 2)
+		})
+
+		inner.Run("does not retry when no bookmarks were sent", func(t *testing.T) {
+			driver, sessionCount := newDriver(failingSession())
+
+			_, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithBookmarkManager(NewBookmarkManager(BookmarkManagerConfig{})),
+				ExecuteQueryWithInvalidBookmarksRecovery(nil))
+
+			AssertDeepEquals(t, err, invalidBookmarkErr)
+			AssertIntEqual(t, *sessionCount, 1)
+		})
+
+		inner.Run("does not retry by default", func(t *testing.T) {
+			driver, sessionCount := newDriver(failingSession())
+
+			_, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer)
+
+			AssertDeepEquals(t, err, invalidBookmarkErr)
+			AssertIntEqual(t, *sessionCount, 1)
+		})
+
+		inner.Run("does not retry when bookmarks cannot be cleared", func(t *testing.T) {
+			driver, sessionCount := newDriver(failingSession())
+
+			_, err := ExecuteQuery[*EagerResult](ctx, driver, "RETURN 42", nil, EagerResultTransformer,
+				ExecuteQueryWithBookmarkManager(&fakeBookmarkManager{}), ExecuteQueryWithInvalidBookmarksRecovery(nil))
+
+			AssertDeepEquals(t, err, invalidBookmarkErr)
+			AssertIntEqual(t, *sessionCount, 1)
+		})
+	})
+}
+
+func TestDriverHttpQueryApi(outer *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"neo4j_version": "5.20.0"}`))
+		case "/db/neo4j/query/v2/tx":
+			_, _ = w.Write([]byte(`{"transaction": {"id": "1"}}`))
+		case "/db/neo4j/query/v2/tx/1":
+			_, _ = w.Write([]byte(`{"data": {"fields": ["n"], "values": [[{"$type": "Integer", "_value": "1"}]]}}`))
+		case "/db/neo4j/query/v2/tx/1/commit":
+			_, _ = w.Write([]byte(`{"bookmarks": ["FB:1"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	driver, err := NewDriverWithContext(server.URL, NoAuth())
+	AssertNoError(outer, err)
+	defer driver.Close(ctx)
+
+	outer.Run("executes queries", func(t *testing.T) {
+		result, err := ExecuteQuery(ctx, driver, "RETURN 1 AS n", nil, EagerResultTransformer)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, result.Keys, []string{"n"})
+		AssertLen(t, result.Records, 1)
+		AssertDeepEquals(t, result.Records[0].Values, []any{int64(1)})
+		AssertStringEqual(t, result.Summary.Server().Agent(), "Neo4j/5.20.0")
+	})
+
+	outer.Run("fails with transaction timeouts", func(t *testing.T) {
+		session := driver.NewSession(ctx, SessionConfig{})
+		defer session.Close(ctx)
+
+		_, err := session.Run(ctx, "RETURN 1", nil, WithTxTimeout(time.Second))
+
+		AssertTrue(t, IsUsageError(err))
+	})
+}
+
+func TestDriverVerifyAuthentication(outer *testing.T) {
+	outer.Run("rejects missing token", func(t *testing.T) {
+		driver := &driverWithContext{mut: racing.NewMutex()}
+
+		err := driver.VerifyAuthentication(context.Background(), nil)
+
+		AssertSameType(t, err, &UsageError{})
+	})
 }
 
 func callExecuteQueryOrBookmarkManagerGetter(driver DriverWithContext, i int) {
@@ -518,10 +846,42 @@ func (d *driverDelegate) IsEncrypted() bool {
 	return d.delegate.IsEncrypted()
 }
 
+func (d *driverDelegate) VerifyAuthentication(ctx context.Context, auth *AuthToken) error {
+	return d.delegate.VerifyAuthentication(ctx, auth)
+}
+
+func (d *driverDelegate) SetWritesPaused(paused bool) {
+	d.delegate.SetWritesPaused(paused)
+}
+
 func (d *driverDelegate) GetServerInfo(ctx context.Context) (ServerInfo, error) {
 	return d.delegate.GetServerInfo(ctx)
 }
 
+func (d *driverDelegate) QueryStatistics() QueryStatistics {
+	return d.delegate.QueryStatistics()
+}
+
+func (d *driverDelegate) QueryCache() *QueryCache {
+	return d.delegate.QueryCache()
+}
+
+func (d *driverDelegate) Prepare(name string, cypher string, schema ParameterSchema) (*PreparedQuery, error) {
+	return d.delegate.Prepare(name, cypher, schema)
+}
+
+func (d *driverDelegate) PreparedQuery(name string) (*PreparedQuery, bool) {
+	return d.delegate.PreparedQuery(name)
+}
+
+func (d *driverDelegate) RoutingTableStates(ctx context.Context) ([]RoutingTableState, error) {
+	return d.delegate.RoutingTableStates(ctx)
+}
+
+func (d *driverDelegate) LogPoolState(ctx context.Context) error {
+	return d.delegate.LogPoolState(ctx)
+}
+
 type fakeSession struct {
 	executeReadTransactionResult   *fakeResult
 	executeReadErr                 error
@@ -533,10 +893,22 @@ type fakeSession struct {
 	closeErr                       error
 }
 
+func (s *fakeSession) RunBatch(context.Context, []BatchQuery, ...func(*TransactionConfig)) ([]*EagerResult, error) {
+	panic("implement me")
+}
+
 func (s *fakeSession) LastBookmarks() Bookmarks {
 	panic("implement me")
 }
 
+func (s *fakeSession) SetBookmarks(context.Context, Bookmarks) error {
+	panic("implement me")
+}
+
+func (s *fakeSession) Stats() SessionStats {
+	panic("implement me")
+}
+
 func (s *fakeSession) lastBookmark() string {
 	panic("implement me")
 }
@@ -641,6 +1013,14 @@ func (f *fakeResult) Collect(context.Context) ([]*Record, error) {
 	panic("implement me")
 }
 
+func (f *fakeResult) CollectWithLimit(context.Context, int, int64) ([]*Record, error) {
+	panic("implement me")
+}
+
+func (f *fakeResult) NextPage(context.Context, int) ([]*Record, error) {
+	panic("implement me")
+}
+
 func (f *fakeResult) Single(context.Context) (*Record, error) {
 	panic("implement me")
 }
@@ -693,6 +1073,10 @@ func (sum *fakeSummary) Notifications() []Notification {
 	panic("implement me")
 }
 
+func (sum *fakeSummary) GqlStatusObjects() []GqlStatusObject {
+	panic("implement me")
+}
+
 func (sum *fakeSummary) ResultAvailableAfter() time.Duration {
 	return sum.resultAvailableAfter
 }
@@ -701,6 +1085,10 @@ func (sum *fakeSummary) ResultConsumedAfter() time.Duration {
 	panic("implement me")
 }
 
+func (sum *fakeSummary) ClientDurations() ClientDurations {
+	panic("implement me")
+}
+
 func (sum *fakeSummary) Database() DatabaseInfo {
 	panic("implement me")
 }
@@ -26,12 +26,15 @@ import (
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/bolt"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/connector"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/errorutil"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/retry"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/router"
 	"io"
 	"net"
+	"strings"
+	"time"
 )
 
 // IsRetryable determines whether an operation can be retried based on the error
@@ -51,6 +54,26 @@ func IsRetryable(err error) bool {
 	return retry.IsRetryable(err)
 }
 
+// RetryableError marks the given error as retryable, so that transaction functions returning it are retried like
+// they are after transient server errors, e.g. when the work detects a conflict with a concurrent business operation.
+//
+//	session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+//		...
+//		if stock < quantity {
+//			return nil, neo4j.RetryableError(ErrStockNotReplenishedYet)
+//		}
+//		...
+//	})
+//
+// The returned error wraps the given one, so that errors.Is and errors.As see through it, and is recognized by
+// IsRetryable. RetryableError returns nil when the given error is nil.
+func RetryableError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retry.RetryableError{Inner: err}
+}
+
 // Neo4jError represents errors originating from Neo4j service.
 // Alias for convenience. This error is defined in db package and
 // used internally.
@@ -96,6 +119,110 @@ func (e *TransactionExecutionLimit) Error() string {
 	return fmt.Sprintf("TransactionExecutionLimit: %s after %d attempts, last error: %s", cause, len(e.Errors), err)
 }
 
+// ResultOutOfScopeError is returned when a result is accessed after the transaction or session it originates from
+// is over.
+// This error is only returned when Config.ResultScopeBehavior is set to ResultScopeStrict.
+type ResultOutOfScopeError struct{}
+
+func (e *ResultOutOfScopeError) Error() string {
+	return "ResultOutOfScopeError: result cannot be accessed after its transaction or session is over"
+}
+
+// InFlightResultsLimitError is returned when a query is started while Config.MaxInFlightResults results
+// of the same session or transaction are still open, i.e. not fully consumed, or when a batch of more queries than
+// Config.MaxInFlightResults is run.
+type InFlightResultsLimitError struct {
+	// Limit is the configured maximum number of in-flight results
+	Limit int
+	// OpenResultStack is the stack trace of the creation of the oldest result still open, to help find the code that
+	// left it open.
+	// It is empty when no result is open and the error is caused by a batch of too many queries.
+	OpenResultStack string
+}
+
+func (e *InFlightResultsLimitError) Error() string {
+	if e.OpenResultStack == "" {
+		return fmt.Sprintf("InFlightResultsLimitError: cannot start more than %d queries at once, "+
+			"split the batch", e.Limit)
+	}
+	return fmt.Sprintf("InFlightResultsLimitError: cannot start a query while %d result(s) are still open, "+
+		"consume them first; the oldest open result was created at:\n%s", e.Limit, e.OpenResultStack)
+}
+
+// UnconsumedResultsError is returned by ExplicitTransaction.Commit when Config.UnconsumedResultsOnCommit is
+// UnconsumedResultsFail and some results of the transaction are not fully consumed.
+// The transaction is not committed and remains open.
+type UnconsumedResultsError struct {
+	// Count is the number of results that are not fully consumed
+	Count int
+}
+
+func (e *UnconsumedResultsError) Error() string {
+	return fmt.Sprintf("UnconsumedResultsError: cannot commit while %d result(s) of the transaction are not fully "+
+		"consumed, consume them first or roll the transaction back", e.Count)
+}
+
+// CollectLimitError is returned by ResultWithContext.CollectWithLimit when the remaining records of a result exceed
+// the given limits.
+// The records collected within the limits are returned alongside this error and the result is left open.
+type CollectLimitError struct {
+	// MaxRecords is the maximum number of records passed to CollectWithLimit
+	MaxRecords int
+	// MaxBytes is the maximum approximate size of the records passed to CollectWithLimit
+	MaxBytes int64
+	// Records is the number of records collected before the limit was exceeded
+	Records int
+	// Bytes is the approximate size of the records collected before the limit was exceeded
+	Bytes int64
+}
+
+func (e *CollectLimitError) Error() string {
+	if e.MaxRecords > 0 && e.Records >= e.MaxRecords {
+		return fmt.Sprintf("CollectLimitError: result contains more than %d record(s)", e.MaxRecords)
+	}
+	return fmt.Sprintf("CollectLimitError: result records exceed %d byte(s), %d record(s) of %d byte(s) collected",
+		e.MaxBytes, e.Records, e.Bytes)
+}
+
+// SessionCloseTimeoutError is returned by SessionWithContext.Close when cleaning up one of the components of the
+// driver takes longer than Config.SessionCloseTimeout.
+// The session is closed regardless, and the unfinished clean up is cancelled.
+type SessionCloseTimeoutError struct {
+	// Component is the driver component whose clean up timed out, either "connection pool" or "router"
+	Component string
+	// Timeout is the configured Config.SessionCloseTimeout
+	Timeout time.Duration
+}
+
+func (e *SessionCloseTimeoutError) Error() string {
+	return fmt.Sprintf("SessionCloseTimeoutError: %s clean up did not complete within %s", e.Component, e.Timeout)
+}
+
+// WritesPausedError is returned when a transaction is started in the write access mode while the writes of the
+// driver are paused, see DriverWithContext.SetWritesPaused.
+// The transaction is not retried, it is up to the application to retry it once writes are resumed.
+type WritesPausedError struct{}
+
+func (e *WritesPausedError) Error() string {
+	return "WritesPausedError: writes are paused, cannot start a write transaction"
+}
+
+// StatementTypeError is returned by ExpectStatementType when the type of the executed statement does not match any of
+// the expected statement types.
+type StatementTypeError struct {
+	Expected []StatementType
+	Actual   StatementType
+}
+
+func (e *StatementTypeError) Error() string {
+	expected := make([]string, len(e.Expected))
+	for i, statementType := range e.Expected {
+		expected[i] = statementType.String()
+	}
+	return fmt.Sprintf("StatementTypeError: expected statement type to be one of [%s] but was %q",
+		strings.Join(expected, ", "), e.Actual.String())
+}
+
 // ConnectivityError represent errors caused by the driver not being able to connect to Neo4j services,
 // or lost connections.
 type ConnectivityError struct {
@@ -106,6 +233,126 @@ func (e *ConnectivityError) Error() string {
 	return fmt.Sprintf("ConnectivityError: %s", e.inner.Error())
 }
 
+func (e *ConnectivityError) Unwrap() error {
+	return e.inner
+}
+
+// CommitAmbiguousError is returned when the connection is lost after a transaction commit was sent to the server and
+// before its outcome was received.
+// The transaction may or may not have been committed: blindly retrying its work may apply it twice.
+// Callers should instead check whether the work was applied before deciding what to do, or make the work idempotent
+// (see WithTxIdempotencyKey).
+// The driver never retries transaction functions failing with this error.
+type CommitAmbiguousError struct {
+	inner error
+}
+
+func (e *CommitAmbiguousError) Error() string {
+	return fmt.Sprintf("CommitAmbiguousError: connection lost during commit, "+
+		"the transaction may or may not have been committed: %s", e.inner.Error())
+}
+
+func (e *CommitAmbiguousError) Unwrap() error {
+	return e.inner
+}
+
+// ConnectionAcquisitionPhase is a step of the acquisition of a connection by a session
+type ConnectionAcquisitionPhase int
+
+const (
+	// HomeDatabaseResolutionPhase is the resolution of the home database of the user, when the session does not
+	// target a specific database
+	HomeDatabaseResolutionPhase ConnectionAcquisitionPhase = iota
+	// RoutingPhase is the retrieval of the servers to connect to, including routing table fetches
+	RoutingPhase
+	// PoolWaitPhase is the time spent waiting for a connection of the pool to become available
+	PoolWaitPhase
+	// DialPhase is the establishment of new network connections
+	DialPhase
+	// HandshakePhase is the TLS and Bolt handshake of new connections
+	HandshakePhase
+)
+
+func (p ConnectionAcquisitionPhase) String() string {
+	switch p {
+	case HomeDatabaseResolutionPhase:
+		return "home database resolution"
+	case RoutingPhase:
+		return "routing"
+	case PoolWaitPhase:
+		return "pool wait"
+	case DialPhase:
+		return "dial"
+	case HandshakePhase:
+		return "handshake"
+	default:
+		return "unknown"
+	}
+}
+
+// ConnectionAcquisitionTimeoutError is the cause of the ConnectivityError returned when a session cannot acquire a
+// connection within Config.ConnectionAcquisitionTimeout, and can be retrieved with errors.As.
+// The timeout spans all the phases of the acquisition: PhaseDurations and Phase tell an exhausted connection pool
+// (PoolWaitPhase) apart from an unreachable cluster (RoutingPhase, DialPhase).
+type ConnectionAcquisitionTimeoutError struct {
+	// Timeout is the configured Config.ConnectionAcquisitionTimeout
+	Timeout time.Duration
+	// Elapsed is the time spent acquiring the connection
+	Elapsed time.Duration
+	// Phase is the phase that consumed the largest part of the time
+	Phase ConnectionAcquisitionPhase
+	// PhaseDurations is the time spent in each phase.
+	// Dial and handshake only cover the acquired connection, connections opened to fetch routing tables or to
+	// resolve the home database are accounted for in their own phase.
+	PhaseDurations map[ConnectionAcquisitionPhase]time.Duration
+	cause          error
+}
+
+func newConnectionAcquisitionTimeoutError(timeout, elapsed time.Duration, timings *idb.AcquisitionTimings, cause error) *ConnectionAcquisitionTimeoutError {
+	if connectivityErr, ok := cause.(*ConnectivityError); ok {
+		cause = connectivityErr.inner
+	}
+	durations := map[ConnectionAcquisitionPhase]time.Duration{
+		HomeDatabaseResolutionPhase: timings.Duration(idb.HomeDatabaseResolutionPhase),
+		RoutingPhase:                timings.Duration(idb.RoutingPhase),
+		PoolWaitPhase:               timings.PoolWait(),
+		DialPhase:                   timings.Duration(idb.DialPhase),
+		HandshakePhase:              timings.Duration(idb.HandshakePhase),
+	}
+	phase := HomeDatabaseResolutionPhase
+	for candidate := RoutingPhase; candidate <= HandshakePhase; candidate++ {
+		if durations[candidate] > durations[phase] {
+			phase = candidate
+		}
+	}
+	return &ConnectionAcquisitionTimeoutError{
+		Timeout:        timeout,
+		Elapsed:        elapsed,
+		Phase:          phase,
+		PhaseDurations: durations,
+		cause:          cause,
+	}
+}
+
+func (e *ConnectionAcquisitionTimeoutError) Error() string {
+	// phases that were never reached are left out rather than reported as instantaneous
+	var breakdown []string
+	for phase := HomeDatabaseResolutionPhase; phase <= HandshakePhase; phase++ {
+		if duration := e.PhaseDurations[phase]; duration > 0 {
+			breakdown = append(breakdown, fmt.Sprintf("%s: %s", phase, duration))
+		}
+	}
+	if len(breakdown) == 0 {
+		return fmt.Sprintf("connection acquisition timed out after %s (timeout: %s): %s", e.Elapsed, e.Timeout, e.cause)
+	}
+	return fmt.Sprintf("connection acquisition timed out after %s (timeout: %s), mostly spent in %s (%s): %s",
+		e.Elapsed, e.Timeout, e.Phase, strings.Join(breakdown, ", "), e.cause)
+}
+
+func (e *ConnectionAcquisitionTimeoutError) Unwrap() error {
+	return e.cause
+}
+
 // IsNeo4jError returns true if the provided error is an instance of Neo4jError.
 func IsNeo4jError(err error) bool {
 	_, is := err.(*Neo4jError)
@@ -124,6 +371,54 @@ func IsConnectivityError(err error) bool {
 	return is
 }
 
+// IsCommitAmbiguousError returns true if the provided error is an instance of CommitAmbiguousError.
+func IsCommitAmbiguousError(err error) bool {
+	_, is := err.(*CommitAmbiguousError)
+	return is
+}
+
+// IsResultOutOfScopeError returns true if the provided error is an instance of ResultOutOfScopeError.
+func IsResultOutOfScopeError(err error) bool {
+	_, is := err.(*ResultOutOfScopeError)
+	return is
+}
+
+// IsCollectLimitError returns true if the provided error is an instance of CollectLimitError.
+func IsCollectLimitError(err error) bool {
+	_, is := err.(*CollectLimitError)
+	return is
+}
+
+// IsSessionCloseTimeoutError returns true if the provided error is an instance of SessionCloseTimeoutError.
+func IsSessionCloseTimeoutError(err error) bool {
+	_, is := err.(*SessionCloseTimeoutError)
+	return is
+}
+
+// IsInFlightResultsLimitError returns true if the provided error is an instance of InFlightResultsLimitError.
+func IsInFlightResultsLimitError(err error) bool {
+	_, is := err.(*InFlightResultsLimitError)
+	return is
+}
+
+// IsUnconsumedResultsError returns true if the provided error is an instance of UnconsumedResultsError.
+func IsUnconsumedResultsError(err error) bool {
+	_, is := err.(*UnconsumedResultsError)
+	return is
+}
+
+// IsWritesPausedError returns true if the provided error is an instance of WritesPausedError.
+func IsWritesPausedError(err error) bool {
+	_, is := err.(*WritesPausedError)
+	return is
+}
+
+// IsStatementTypeError returns true if the provided error is an instance of StatementTypeError.
+func IsStatementTypeError(err error) bool {
+	_, is := err.(*StatementTypeError)
+	return is
+}
+
 // IsTransactionExecutionLimit returns true if the provided error is an instance of TransactionExecutionLimit.
 func IsTransactionExecutionLimit(err error) bool {
 	_, is := err.(*TransactionExecutionLimit)
@@ -141,6 +436,8 @@ func (e *TokenExpiredError) Error() string {
 	return fmt.Sprintf("TokenExpiredError: %s (%s)", e.Code, e.Message)
 }
 
+const tokenExpiredCode = "Neo.ClientError.Security.TokenExpired"
+
 func wrapError(err error) error {
 	if err == nil {
 		return nil
@@ -155,20 +452,20 @@ func wrapError(err error) error {
 		return &UsageError{Message: err.Error()}
 	case *pool.PoolClosed:
 		return &UsageError{Message: err.Error()}
-	case *connector.TlsError, net.Error:
+	case *connector.TlsError, *connector.ProxyError, net.Error:
 		return &ConnectivityError{inner: err}
-	case *pool.PoolTimeout, *pool.PoolFull:
+	case *pool.PoolTimeout, *pool.PoolFull, *pool.PoolWaitQueueFull:
 		return &ConnectivityError{inner: err}
 	case *router.ReadRoutingTableError:
 		return &ConnectivityError{inner: err}
 	case *retry.CommitFailedDeadError:
-		return &ConnectivityError{inner: err}
+		return &CommitAmbiguousError{inner: err}
 	case *bolt.ConnectionReadTimeout:
 		return &ConnectivityError{inner: err}
 	case *bolt.ConnectionWriteTimeout:
 		return &ConnectivityError{inner: err}
 	case *db.Neo4jError:
-		if e.Code == "Neo.ClientError.Security.TokenExpired" {
+		if e.Code == tokenExpiredCode {
 			return &TokenExpiredError{Code: e.Code, Message: e.Msg}
 		}
 	}
@@ -20,10 +20,15 @@
 package neo4j
 
 import (
+	"errors"
 	"fmt"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/retry"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestIsRetryable(outer *testing.T) {
@@ -48,10 +53,14 @@ func TestIsRetryable(outer *testing.T) {
 			Code: "Neo.ClientError.General.ForbiddenOnReadOnlyDatabase",
 			Msg:  "One does not simply write to a read-only database.",
 		}},
+		{true, RetryableError(fmt.Errorf("try again later"))},
 		{false, nil},
 		{false, &ConnectivityError{
 			inner: &retry.CommitFailedDeadError{},
 		}},
+		{false, &CommitAmbiguousError{
+			inner: &retry.CommitFailedDeadError{},
+		}},
 		{false, &db.Neo4jError{
 			Code: "Neo.TransientError.Transaction.Terminated",
 			Msg:  "Don't mess with TX",
@@ -80,3 +89,30 @@ func TestIsRetryable(outer *testing.T) {
 	}
 
 }
+
+func TestConnectionAcquisitionTimeoutError(outer *testing.T) {
+	outer.Run("does not report negative pool wait", func(t *testing.T) {
+		timings := &idb.AcquisitionTimings{}
+		now := time.Now()
+		timings.Track(idb.BorrowPhase, now.Add(-10*time.Millisecond))
+		timings.Track(idb.DialPhase, now.Add(-30*time.Millisecond))
+
+		err := newConnectionAcquisitionTimeoutError(time.Millisecond, 30*time.Millisecond, timings, errors.New("oops"))
+
+		AssertDeepEquals(t, err.PhaseDurations[PoolWaitPhase], time.Duration(0))
+		AssertDeepEquals(t, err.Phase, DialPhase)
+		AssertStringContain(t, err.Error(), "mostly spent in dial (dial: ")
+		AssertFalse(t, strings.Contains(err.Error(), "pool wait"))
+	})
+
+	outer.Run("leaves out phases that were not reached", func(t *testing.T) {
+		timings := &idb.AcquisitionTimings{}
+		timings.Track(idb.RoutingPhase, time.Now().Add(-20*time.Millisecond))
+
+		err := newConnectionAcquisitionTimeoutError(time.Millisecond, 20*time.Millisecond, timings, errors.New("oops"))
+
+		AssertStringContain(t, err.Error(), "mostly spent in routing (routing: ")
+		AssertFalse(t, strings.Contains(err.Error(), "handshake"))
+		AssertStringContain(t, err.Error(), ": oops")
+	})
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/faults"
+	"time"
+)
+
+// FaultInjection describes the failures the driver simulates on all its connections, so that applications can test
+// their resilience against realistic driver failure modes without a proxy.
+//
+// Fault injection only takes effect when the application is built with the neo4j_fault_injection build tag, for
+// instance with:
+//
+//	go test -tags neo4j_fault_injection ./...
+//
+// Otherwise, it is ignored and a warning is logged when creating the driver.
+// Messages are counted per connection, starting at 1, and include the messages sent and received when connecting.
+type FaultInjection struct {
+	// DropConnectionAfterMessages closes connections instead of sending their next message once they have sent that
+	// many messages to the server.
+	// 0 disables it.
+	DropConnectionAfterMessages int
+	// PullResponseDelay delays the responses to the requests pulling (or discarding) records, as a slow server would.
+	// 0 disables it.
+	PullResponseDelay time.Duration
+	// CorruptChunkOfMessage corrupts the first chunk of the nth message received by connections, which makes the
+	// message fail to decode.
+	// 0 disables it.
+	CorruptChunkOfMessage int
+}
+
+func (f *FaultInjection) injector() faults.Injector {
+	return faults.Rules{
+		DropConnectionAfterMessages: f.DropConnectionAfterMessages,
+		PullResponseDelay:           f.PullResponseDelay,
+		CorruptChunkOfMessage:       f.CorruptChunkOfMessage,
+	}
+}
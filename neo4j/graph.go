@@ -24,7 +24,7 @@ import (
 )
 
 type PropertyValue interface {
-	bool | int64 | float64 | string |
+	bool | int | int64 | float64 | string |
 		Point2D | Point3D |
 		Date | LocalTime | LocalDateTime | Time | Duration | /* OffsetTime == Time == dbtype.Time */
 		[]byte | []any
@@ -34,17 +34,14 @@ type PropertyValue interface {
 // The property type T must adhere to neo4j.PropertyValue
 // If the property does not exist, an error is returned
 // If the property type does not match the type specification, an error is returned
+// Integers and floats are only converted to another numeric type (int, int64 or float64) according to the optional
+// NumberCoercion policy, StrictNumbers being the default
 //
 // Note: due to the current limited generics support, any property array value other than byte array is typed as []any.
-func GetProperty[T PropertyValue](entity Entity, key string) (T, error) {
+func GetProperty[T PropertyValue](entity Entity, key string, coercion ...NumberCoercion) (T, error) {
 	rawValue, found := entity.GetProperties()[key]
 	if !found {
 		return *new(T), fmt.Errorf("could not find any property named %s", key)
 	}
-	value, ok := rawValue.(T)
-	if !ok {
-		zeroValue := *new(T)
-		return zeroValue, fmt.Errorf("expected value to have type %T but found type %T", zeroValue, rawValue)
-	}
-	return value, nil
+	return coerceValue[T](rawValue, coercion)
 }
@@ -0,0 +1,284 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ingest provides a helper to bulk import large amounts of rows into Neo4j.
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// RowsParameter is the name of the query parameter holding the rows of each batch
+const RowsParameter = "rows"
+
+const (
+	defaultBatchSize   = 1000
+	defaultConcurrency = 4
+)
+
+// Config configures an import run with Import
+type Config[T any] struct {
+	// Query is run once per batch of rows, the rows of the batch being available as the $rows list parameter,
+	// for instance:
+	//
+	//	UNWIND $rows AS row MERGE (p:Person {id: row.id}) SET p.name = row.name
+	Query string
+	// ToParameter converts each row to a query parameter value.
+	// When nil, rows are sent as is and must therefore be of a type supported as query parameter.
+	//
+	// default: nil
+	ToParameter func(T) (any, error)
+	// BatchSize is the maximum number of rows imported by each query.
+	//
+	// default: 1000
+	BatchSize int
+	// Concurrency is the number of write sessions importing batches concurrently.
+	//
+	// default: 4
+	Concurrency int
+	// SessionConfig configures the write sessions, for instance their target database.
+	// The access mode is ignored since batches are always imported with SessionWithContext.ExecuteWrite.
+	SessionConfig neo4j.SessionConfig
+	// ContinueOnError makes the import go on when a batch fails to be imported, after the driver retries are
+	// exhausted.
+	// By default, the import stops on the first failed batch.
+	//
+	// default: false
+	ContinueOnError bool
+	// OnEvent is called whenever a batch is imported or fails to be imported, and can be used to report progress.
+	// Calls are serialized, OnEvent does not need to be safe for concurrent use but should return quickly since it
+	// delays the import of other batches.
+	//
+	// default: nil
+	OnEvent func(Event)
+}
+
+// EventType identifies the kind of event reported to Config.OnEvent
+type EventType int
+
+const (
+	// BatchImported is emitted when a batch has been imported
+	BatchImported EventType = iota
+	// BatchFailed is emitted when a batch could not be imported
+	BatchFailed
+)
+
+func (t EventType) String() string {
+	switch t {
+	case BatchImported:
+		return "imported"
+	case BatchFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes the outcome of the import of a batch
+type Event struct {
+	Type EventType
+	// Batch is the sequence number of the batch, starting at 0, in the order rows are received
+	Batch int
+	// Rows is the number of rows of the batch
+	Rows int
+	// Summary is the progress of the whole import, this batch included
+	Summary Summary
+	// Err is the reason why the batch failed, only set for BatchFailed events
+	Err error
+}
+
+// Summary reports the progress of an import
+type Summary struct {
+	ImportedBatches int
+	ImportedRows    int64
+	FailedBatches   int
+	FailedRows      int64
+}
+
+// Import reads rows from the given channel until it is closed, and imports them in batches with Config.Query.
+//
+// Rows are read only as fast as the batches are imported: when all the Config.Concurrency sessions are busy, Import
+// stops reading rows and sending to the channel blocks. This back-pressure bounds the memory used by the import,
+// however large the number of rows is.
+// Each batch is imported in its own transaction function, and therefore retried on transient failures (see
+// neo4j.Config.MaxTransactionRetryTime). Batches are imported concurrently, their order of import is not guaranteed.
+//
+// Import returns once the channel is closed and all batches are processed, or as soon as a batch fails unless
+// Config.ContinueOnError is set, or when the context is done. In the latter cases, the remaining rows are not read:
+// producers should stop sending rows once Import returns, typically by selecting on a context canceled at that point.
+// The returned error is the first batch failure, or the context error. Failures tolerated by Config.ContinueOnError
+// are only reported via Config.OnEvent and the returned Summary.
+func Import[T any](ctx context.Context, driver neo4j.DriverWithContext, rows <-chan T, config Config[T]) (Summary, error) {
+	if err := normalizeConfig(&config); err != nil {
+		return Summary{}, err
+	}
+	importCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	imp := &importer[T]{config: config, cancel: cancel}
+	batches := make(chan batch[T])
+	var workers sync.WaitGroup
+	for i := 0; i < config.Concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			imp.work(ctx, importCtx, driver, batches)
+		}()
+	}
+	imp.dispatch(importCtx, rows, batches)
+	workers.Wait()
+
+	if imp.err != nil {
+		return imp.summary, imp.err
+	}
+	return imp.summary, ctx.Err()
+}
+
+func normalizeConfig[T any](config *Config[T]) error {
+	if config.Query == "" {
+		return &neo4j.UsageError{Message: "Import query cannot be empty"}
+	}
+	if config.BatchSize < 0 {
+		return &neo4j.UsageError{Message: fmt.Sprintf("Import batch size cannot be negative, got %d", config.BatchSize)}
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.Concurrency < 0 {
+		return &neo4j.UsageError{Message: fmt.Sprintf("Import concurrency cannot be negative, got %d", config.Concurrency)}
+	}
+	if config.Concurrency == 0 {
+		config.Concurrency = defaultConcurrency
+	}
+	return nil
+}
+
+type batch[T any] struct {
+	sequence int
+	rows     []T
+}
+
+type importer[T any] struct {
+	config  Config[T]
+	cancel  context.CancelFunc
+	mutex   sync.Mutex
+	summary Summary
+	err     error
+}
+
+// dispatch groups rows in batches until the rows channel is closed or the import is stopped
+func (i *importer[T]) dispatch(ctx context.Context, rows <-chan T, batches chan<- batch[T]) {
+	defer close(batches)
+	sequence := 0
+	current := make([]T, 0, i.config.BatchSize)
+	send := func() bool {
+		select {
+		case batches <- batch[T]{sequence: sequence, rows: current}:
+			sequence++
+			current = make([]T, 0, i.config.BatchSize)
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	for {
+		select {
+		case row, ok := <-rows:
+			if !ok {
+				if len(current) > 0 {
+					send()
+				}
+				return
+			}
+			current = append(current, row)
+			if len(current) == i.config.BatchSize && !send() {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// work imports batches with its own session until there are no batches left
+// The session is closed with the caller context, so that it is properly closed even after the import is stopped.
+func (i *importer[T]) work(ctx, importCtx context.Context, driver neo4j.DriverWithContext, batches <-chan batch[T]) {
+	session := driver.NewSession(ctx, i.config.SessionConfig)
+	defer session.Close(ctx)
+	for b := range batches {
+		i.report(b, i.importBatch(importCtx, session, b))
+	}
+}
+
+func (i *importer[T]) importBatch(ctx context.Context, session neo4j.SessionWithContext, b batch[T]) error {
+	parameters, err := i.toParameters(b.rows)
+	if err != nil {
+		return err
+	}
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, i.config.Query, map[string]any{RowsParameter: parameters})
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(ctx)
+	})
+	return err
+}
+
+func (i *importer[T]) toParameters(rows []T) ([]any, error) {
+	parameters := make([]any, len(rows))
+	for index, row := range rows {
+		if i.config.ToParameter == nil {
+			parameters[index] = row
+			continue
+		}
+		parameter, err := i.config.ToParameter(row)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert row %d of batch: %w", index, err)
+		}
+		parameters[index] = parameter
+	}
+	return parameters, nil
+}
+
+func (i *importer[T]) report(b batch[T], err error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	event := Event{Type: BatchImported, Batch: b.sequence, Rows: len(b.rows), Err: err}
+	if err == nil {
+		i.summary.ImportedBatches++
+		i.summary.ImportedRows += int64(len(b.rows))
+	} else {
+		event.Type = BatchFailed
+		i.summary.FailedBatches++
+		i.summary.FailedRows += int64(len(b.rows))
+		if !i.config.ContinueOnError && i.err == nil {
+			i.err = fmt.Errorf("could not import batch %d: %w", b.sequence, err)
+			i.cancel()
+		}
+	}
+	event.Summary = i.summary
+	if i.config.OnEvent != nil {
+		i.config.OnEvent(event)
+	}
+}
@@ -0,0 +1,286 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ingest
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestImport(outer *testing.T) {
+	outer.Parallel()
+
+	ctx := context.Background()
+	query := "UNWIND $rows AS row CREATE (:Number {value: row})"
+
+	rowsOf := func(count int) <-chan int {
+		rows := make(chan int)
+		go func() {
+			defer close(rows)
+			for i := 0; i < count; i++ {
+				rows <- i
+			}
+		}()
+		return rows
+	}
+
+	outer.Run("imports rows in batches", func(t *testing.T) {
+		driver := &fakeDriver{}
+
+		summary, err := Import(ctx, driver, rowsOf(10), Config[int]{Query: query, BatchSize: 4, Concurrency: 2})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, summary, Summary{ImportedBatches: 3, ImportedRows: 10})
+		AssertDeepEquals(t, driver.importedRows(), []any{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+		AssertIntEqual(t, driver.sessionCount, 2)
+		for _, query := range driver.queries {
+			AssertStringEqual(t, query, "UNWIND $rows AS row CREATE (:Number {value: row})")
+		}
+	})
+
+	outer.Run("converts rows to parameters", func(t *testing.T) {
+		driver := &fakeDriver{}
+
+		_, err := Import(ctx, driver, rowsOf(2), Config[int]{
+			Query: query,
+			ToParameter: func(row int) (any, error) {
+				return map[string]any{"value": row}, nil
+			},
+		})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, driver.importedRows(), []any{
+			map[string]any{"value": 0},
+			map[string]any{"value": 1},
+		})
+	})
+
+	outer.Run("configures sessions", func(t *testing.T) {
+		driver := &fakeDriver{}
+		sessionConfig := neo4j.SessionConfig{DatabaseName: "numbers"}
+
+		_, err := Import(ctx, driver, rowsOf(1), Config[int]{Query: query, Concurrency: 1, SessionConfig: sessionConfig})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, driver.sessionConfigs, []neo4j.SessionConfig{sessionConfig})
+	})
+
+	outer.Run("reports progress", func(t *testing.T) {
+		var events []Event
+
+		_, err := Import(ctx, &fakeDriver{}, rowsOf(3), Config[int]{
+			Query:       query,
+			BatchSize:   2,
+			Concurrency: 1,
+			OnEvent: func(event Event) {
+				events = append(events, event)
+			},
+		})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, events, []Event{
+			{Type: BatchImported, Batch: 0, Rows: 2, Summary: Summary{ImportedBatches: 1, ImportedRows: 2}},
+			{Type: BatchImported, Batch: 1, Rows: 1, Summary: Summary{ImportedBatches: 2, ImportedRows: 3}},
+		})
+	})
+
+	outer.Run("stops on first failure", func(t *testing.T) {
+		failure := errors.New("oopsie")
+		driver := &fakeDriver{err: failure}
+		rows := make(chan int)
+		go func() {
+			for i := 0; ; i++ {
+				select {
+				case rows <- i:
+				case <-time.After(time.Second):
+					return
+				}
+			}
+		}()
+
+		summary, err := Import(ctx, driver, rows, Config[int]{Query: query, BatchSize: 1, Concurrency: 1})
+
+		AssertTrue(t, errors.Is(err, failure))
+		AssertIntEqual(t, summary.ImportedBatches, 0)
+		AssertTrue(t, summary.FailedBatches >= 1)
+	})
+
+	outer.Run("continues on failure when configured", func(t *testing.T) {
+		failure := errors.New("oopsie")
+		var events []Event
+
+		summary, err := Import(ctx, &fakeDriver{err: failure}, rowsOf(3), Config[int]{
+			Query:           query,
+			BatchSize:       1,
+			ContinueOnError: true,
+			OnEvent: func(event Event) {
+				events = append(events, event)
+			},
+		})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, summary, Summary{FailedBatches: 3, FailedRows: 3})
+		AssertLen(t, events, 3)
+		for _, event := range events {
+			AssertDeepEquals(t, event.Type, BatchFailed)
+			AssertDeepEquals(t, event.Err, failure)
+		}
+	})
+
+	outer.Run("fails batches with rows that cannot be converted", func(t *testing.T) {
+		failure := errors.New("not a number")
+
+		summary, err := Import(ctx, &fakeDriver{}, rowsOf(1), Config[int]{
+			Query: query,
+			ToParameter: func(int) (any, error) {
+				return nil, failure
+			},
+		})
+
+		AssertTrue(t, errors.Is(err, failure))
+		AssertDeepEquals(t, summary, Summary{FailedBatches: 1, FailedRows: 1})
+	})
+
+	outer.Run("applies back-pressure", func(t *testing.T) {
+		release := make(chan struct{})
+		driver := &fakeDriver{onRun: func() { <-release }}
+		rows := make(chan int)
+		done := make(chan error)
+		go func() {
+			_, err := Import(ctx, driver, rows, Config[int]{Query: query, BatchSize: 1, Concurrency: 1})
+			done <- err
+		}()
+
+		rows <- 1 // imported by the single session, which blocks
+		rows <- 2 // read by the dispatcher, waiting for the session to become available
+		select {
+		case rows <- 3:
+			t.Errorf("expected rows not to be read while the session is busy")
+		case <-time.After(20 * time.Millisecond):
+		}
+		close(release)
+		close(rows)
+
+		AssertNoError(t, <-done)
+	})
+
+	outer.Run("stops when the context is done", func(t *testing.T) {
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := Import(canceledCtx, &fakeDriver{}, make(chan int), Config[int]{Query: query})
+
+		AssertTrue(t, errors.Is(err, context.Canceled))
+	})
+
+	outer.Run("rejects invalid configurations", func(inner *testing.T) {
+		testCases := map[string]Config[int]{
+			"empty query":          {},
+			"negative batch size":  {Query: query, BatchSize: -1},
+			"negative concurrency": {Query: query, Concurrency: -1},
+		}
+		for description, config := range testCases {
+			inner.Run(description, func(t *testing.T) {
+				_, err := Import(ctx, &fakeDriver{}, rowsOf(0), config)
+
+				AssertTrue(t, neo4j.IsUsageError(err))
+			})
+		}
+	})
+}
+
+type fakeDriver struct {
+	neo4j.DriverWithContext
+	err            error
+	onRun          func()
+	mutex          sync.Mutex
+	sessionCount   int
+	sessionConfigs []neo4j.SessionConfig
+	queries        []string
+	rows           []any
+}
+
+func (d *fakeDriver) NewSession(_ context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.sessionCount++
+	d.sessionConfigs = append(d.sessionConfigs, config)
+	return &fakeSession{driver: d}
+}
+
+// importedRows returns the rows of all the batches, sorted since batches are imported concurrently
+func (d *fakeDriver) importedRows() []any {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	rows := append([]any(nil), d.rows...)
+	sort.Slice(rows, func(i, j int) bool {
+		first, firstIsInt := rows[i].(int)
+		second, secondIsInt := rows[j].(int)
+		return firstIsInt && secondIsInt && first < second
+	})
+	return rows
+}
+
+type fakeSession struct {
+	neo4j.SessionWithContext
+	driver *fakeDriver
+}
+
+func (s *fakeSession) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork, _ ...func(*neo4j.TransactionConfig)) (any, error) {
+	return work(&fakeTransaction{driver: s.driver})
+}
+
+func (s *fakeSession) Close(context.Context) error {
+	return nil
+}
+
+type fakeTransaction struct {
+	neo4j.ManagedTransaction
+	driver *fakeDriver
+}
+
+func (tx *fakeTransaction) Run(_ context.Context, query string, params map[string]any) (neo4j.ResultWithContext, error) {
+	if tx.driver.onRun != nil {
+		tx.driver.onRun()
+	}
+	if tx.driver.err != nil {
+		return nil, tx.driver.err
+	}
+	tx.driver.mutex.Lock()
+	defer tx.driver.mutex.Unlock()
+	tx.driver.queries = append(tx.driver.queries, query)
+	tx.driver.rows = append(tx.driver.rows, params[RowsParameter].([]any)...)
+	return &fakeResult{}, nil
+}
+
+type fakeResult struct {
+	neo4j.ResultWithContext
+}
+
+func (r *fakeResult) Consume(context.Context) (neo4j.ResultSummary, error) {
+	return nil, nil
+}
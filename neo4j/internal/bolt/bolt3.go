@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/keyindex"
 	"net"
 	"time"
 
@@ -83,7 +84,9 @@ type bolt3 struct {
 	birthDate     time.Time
 	log           log.Logger
 	err           error // Last fatal error
+	lastErr       error // Last error, kept across resets
 	minor         int
+	auth          map[string]any // Token the connection is authenticated with
 	idleDate      time.Time
 }
 
@@ -131,6 +134,19 @@ func (b *bolt3) ServerVersion() string {
 	return b.serverVersion
 }
 
+func (b *bolt3) AuthToken() map[string]any {
+	return b.auth
+}
+
+// ReAuth is not supported before Bolt 5.1, the connection has to be replaced instead
+func (b *bolt3) ReAuth(context.Context, map[string]any) error {
+	return idb.ErrReAuthNotSupported
+}
+
+func (b *bolt3) SupportsReAuth() bool {
+	return false
+}
+
 // Sets b.err and b.state on failure
 func (b *bolt3) receiveMsg(ctx context.Context) any {
 	msg, err := b.in.next(ctx, b.conn)
@@ -174,10 +190,13 @@ func (b *bolt3) receiveSuccess(ctx context.Context) *success {
 	}
 }
 
-func (b *bolt3) Connect(ctx context.Context, minor int, auth map[string]any, userAgent string, _ map[string]string) error {
+func (b *bolt3) Connect(ctx context.Context, minor int, auth map[string]any, userAgent string, _ map[string]string, notificationConfig idb.NotificationConfig) error {
 	if err := b.assertState(bolt3_unauthorized); err != nil {
 		return err
 	}
+	if err := b.checkNotificationFiltering(notificationConfig); err != nil {
+		return err
+	}
 
 	hello := map[string]any{
 		"user_agent": userAgent,
@@ -212,6 +231,7 @@ func (b *bolt3) Connect(ctx context.Context, minor int, auth map[string]any, use
 	// Transition into ready state
 	b.state = bolt3_ready
 	b.minor = minor
+	b.auth = auth
 	b.log.Infof(log.Bolt3, b.logId, "Connected")
 	return nil
 }
@@ -231,6 +251,9 @@ func (b *bolt3) TxBegin(ctx context.Context, txConfig idb.TxConfig) (idb.
 	if err := b.checkImpersonation(txConfig.ImpersonatedUser); err != nil {
 		return 0, err
 	}
+	if err := b.checkNotificationFiltering(txConfig.Notifications); err != nil {
+		return 0, err
+	}
 
 	tx := &internalTx3{
 		mode:      txConfig.Mode,
@@ -437,7 +460,7 @@ func (b *bolt3) run(ctx context.Context, cypher string, params map[string]any, t
 		b.state = bolt3_streamingtx
 	}
 
-	b.currStream = &stream{keys: succ.fields}
+	b.currStream = &stream{keys: succ.fields, keyIndex: keyindex.New(succ.fields)}
 	return b.currStream, nil
 }
 
@@ -449,6 +472,9 @@ func (b *bolt3) Run(ctx context.Context, runCommand idb.Command,
 	if err := b.checkImpersonation(txConfig.ImpersonatedUser); err != nil {
 		return nil, err
 	}
+	if err := b.checkNotificationFiltering(txConfig.Notifications); err != nil {
+		return nil, err
+	}
 
 	tx := internalTx3{
 		mode:      txConfig.Mode,
@@ -565,6 +591,7 @@ func (b *bolt3) receiveNext(ctx context.Context) (*db.Record, *db.Summary, error
 	switch x := res.(type) {
 	case *db.Record:
 		x.Keys = b.currStream.keys
+		keyindex.Attach(x, b.currStream.keyIndex)
 		return x, nil, nil
 	case *success:
 		// End of stream, parse summary
@@ -621,6 +648,33 @@ func (b *bolt3) Bookmark() string {
 	return b.bookmark
 }
 
+func (b *bolt3) ReportInvalidValues() {
+	b.in.hyd.reportInvalidValues = true
+}
+
+func (b *bolt3) BytesReceived() int64 {
+	return b.in.received
+}
+
+func (b *bolt3) LastError() error {
+	if b.err != nil {
+		return b.err
+	}
+	return b.lastErr
+}
+
+// clearErr clears the pending error, which is kept as the last error of the connection
+func (b *bolt3) clearErr() {
+	if b.err != nil {
+		b.lastErr = b.err
+	}
+	b.err = nil
+}
+
+func (b *bolt3) DecodeUnknownValues() {
+	b.in.hyd.decodeUnknownValues = true
+}
+
 func (b *bolt3) IsAlive() bool {
 	return b.state != bolt3_dead
 }
@@ -643,7 +697,7 @@ func (b *bolt3) Reset(ctx context.Context) {
 		b.txId = 0
 		b.currStream = nil
 		b.bookmark = ""
-		b.err = nil
+		b.clearErr()
 	}()
 
 	if b.state == bolt3_ready || b.state == bolt3_dead {
@@ -668,7 +722,7 @@ func (b *bolt3) ForceReset(ctx context.Context) {
 	}
 	// Send the reset message to the server
 	// Need to clear any pending error
-	b.err = nil
+	b.clearErr()
 	b.out.appendReset()
 	if b.out.send(ctx, b.conn); b.err != nil {
 		return
@@ -701,6 +755,13 @@ func (b *bolt3) checkImpersonation(impersonatedUser string) error {
 	return nil
 }
 
+func (b *bolt3) checkNotificationFiltering(notificationConfig idb.NotificationConfig) error {
+	if !notificationConfig.IsDefault() {
+		return &db.FeatureNotSupportedError{Server: b.serverName, Feature: "notification filtering", Reason: "requires at least server v5.7"}
+	}
+	return nil
+}
+
 func (b *bolt3) GetRoutingTable(ctx context.Context,
 	routingContext map[string]string, _ []string, database, impersonatedUser string) (*idb.RoutingTable, error) {
 	if err := b.assertState(bolt3_ready); err != nil {
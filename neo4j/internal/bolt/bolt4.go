@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/keyindex"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/slices"
 	"net"
 	"time"
@@ -96,9 +97,13 @@ type bolt4 struct {
 	log           log.Logger
 	databaseName  string
 	err           error // Last fatal error
+	lastErr       error // Last error, kept across resets
 	minor         int
-	lastQid       int64 // Last seen qid
+	auth          map[string]any // Token the connection is authenticated with
+	lastQid       int64          // Last seen qid
 	idleDate      time.Time
+	// Whether the server notified that it is terminating
+	terminationNotified bool
 }
 
 func NewBolt4(serverName string, conn net.Conn, logger log.Logger, boltLog log.BoltLogger) *bolt4 {
@@ -152,6 +157,19 @@ func (b *bolt4) ServerVersion() string {
 	return b.serverVersion
 }
 
+func (b *bolt4) AuthToken() map[string]any {
+	return b.auth
+}
+
+// ReAuth is not supported before Bolt 5.1, the connection has to be replaced instead
+func (b *bolt4) ReAuth(context.Context, map[string]any) error {
+	return idb.ErrReAuthNotSupported
+}
+
+func (b *bolt4) SupportsReAuth() bool {
+	return false
+}
+
 // Sets b.err and b.state to bolt4_failed or bolt4_dead when fatal is true.
 func (b *bolt4) setError(err error, fatal bool) {
 	// Has no effect, can reduce nested ifs
@@ -165,6 +183,10 @@ func (b *bolt4) setError(err error, fatal bool) {
 		b.state = bolt4_failed
 	}
 
+	if isTerminationNotice(err) {
+		b.terminationNotified = true
+	}
+
 	// Increase severity even if it was a previous error
 	if fatal {
 		if ctxErr := handleTerminatedContextError(err, b.conn); ctxErr != nil {
@@ -227,10 +249,13 @@ func (b *bolt4) receiveSuccess(ctx context.Context) *success {
 	}
 }
 
-func (b *bolt4) Connect(ctx context.Context, minor int, auth map[string]any, userAgent string, routingContext map[string]string) error {
+func (b *bolt4) Connect(ctx context.Context, minor int, auth map[string]any, userAgent string, routingContext map[string]string, notificationConfig idb.NotificationConfig) error {
 	if err := b.assertState(bolt4_unauthorized); err != nil {
 		return err
 	}
+	if err := b.checkNotificationFiltering(notificationConfig); err != nil {
+		return err
+	}
 
 	// Prepare hello message
 	hello := map[string]any{
@@ -280,6 +305,7 @@ func (b *bolt4) Connect(ctx context.Context, minor int, auth map[string]any, use
 	// Transition into ready state
 	b.state = bolt4_ready
 	b.minor = minor
+	b.auth = auth
 	b.streams.reset()
 	b.log.Infof(log.Bolt4, b.logId, "Connected")
 	return nil
@@ -292,6 +318,13 @@ func (b *bolt4) checkImpersonationAndVersion(impersonatedUser string) error {
 	return nil
 }
 
+func (b *bolt4) checkNotificationFiltering(notificationConfig idb.NotificationConfig) error {
+	if !notificationConfig.IsDefault() {
+		return &db.FeatureNotSupportedError{Server: b.serverName, Feature: "notification filtering", Reason: "requires at least server v5.7"}
+	}
+	return nil
+}
+
 func (b *bolt4) TxBegin(ctx context.Context, txConfig idb.TxConfig) (idb.TxHandle, error) {
 	// Ok, to begin transaction while streaming auto-commit, just empty the stream and continue.
 	if b.state == bolt4_streaming {
@@ -309,6 +342,9 @@ func (b *bolt4) TxBegin(ctx context.Context, txConfig idb.TxConfig) (idb.TxHandl
 	if err := b.checkImpersonationAndVersion(txConfig.ImpersonatedUser); err != nil {
 		return 0, err
 	}
+	if err := b.checkNotificationFiltering(txConfig.Notifications); err != nil {
+		return 0, err
+	}
 
 	tx := internalTx4{
 		mode:             txConfig.Mode,
@@ -594,7 +630,7 @@ func (b *bolt4) run(ctx context.Context, cypher string, params map[string]any, f
 	}
 
 	// Create a stream representation, set it to current and track it
-	stream := &stream{keys: succ.fields, qid: succ.qid, fetchSize: fetchSize}
+	stream := &stream{keys: succ.fields, keyIndex: keyindex.New(succ.fields), qid: succ.qid, fetchSize: fetchSize}
 	b.streams.attach(stream)
 	// No need to check streams state, we know we are streaming
 
@@ -610,6 +646,9 @@ func (b *bolt4) Run(ctx context.Context, cmd idb.Command,
 	if err := b.checkImpersonationAndVersion(txConfig.ImpersonatedUser); err != nil {
 		return 0, err
 	}
+	if err := b.checkNotificationFiltering(txConfig.Notifications); err != nil {
+		return 0, err
+	}
 
 	tx := internalTx4{
 		mode:             txConfig.Mode,
@@ -784,6 +823,7 @@ func (b *bolt4) receiveNext(ctx context.Context) (*db.Record, bool, *db.Summary)
 	case *db.Record:
 		// A new record
 		x.Keys = b.streams.curr.keys
+		keyindex.Attach(x, b.streams.curr.keyIndex)
 		return x, false, nil
 	case *success:
 		// End of batch or end of stream?
@@ -820,10 +860,41 @@ func (b *bolt4) Bookmark() string {
 	return b.bookmark
 }
 
+func (b *bolt4) ReportInvalidValues() {
+	b.in.hyd.reportInvalidValues = true
+}
+
+func (b *bolt4) BytesReceived() int64 {
+	return b.in.received
+}
+
+func (b *bolt4) LastError() error {
+	if b.err != nil {
+		return b.err
+	}
+	return b.lastErr
+}
+
+// clearErr clears the pending error, which is kept as the last error of the connection
+func (b *bolt4) clearErr() {
+	if b.err != nil {
+		b.lastErr = b.err
+	}
+	b.err = nil
+}
+
+func (b *bolt4) DecodeUnknownValues() {
+	b.in.hyd.decodeUnknownValues = true
+}
+
 func (b *bolt4) IsAlive() bool {
 	return b.state != bolt4_dead
 }
 
+func (b *bolt4) TerminationNotified() bool {
+	return b.terminationNotified
+}
+
 func (b *bolt4) HasFailed() bool {
 	return b.state == bolt4_failed
 }
@@ -842,7 +913,7 @@ func (b *bolt4) Reset(ctx context.Context) {
 		b.txId = 0
 		b.bookmark = ""
 		b.databaseName = idb.DefaultDatabase
-		b.err = nil
+		b.clearErr()
 		b.lastQid = -1
 		b.streams.reset()
 	}()
@@ -860,7 +931,7 @@ func (b *bolt4) ForceReset(ctx context.Context) {
 	}
 	// Reset any pending error, should be matching bolt4_failed so
 	// it should be recoverable.
-	b.err = nil
+	b.clearErr()
 
 	// Send the reset message to the server
 	b.out.appendReset()
@@ -1026,3 +1097,10 @@ func isFatalError(err *db.Neo4jError) bool {
 	// Treat expired auth as fatal so that pool is cleaned up of old connections
 	return err != nil && err.Code == "Status.Security.AuthorizationExpired"
 }
+
+// isTerminationNotice tells whether the error notifies that the server is going away, as happens during the rolling
+// restarts of managed deployments like Aura
+func isTerminationNotice(err error) bool {
+	dbErr, ok := err.(*db.Neo4jError)
+	return ok && dbErr.Code == "Neo.TransientError.General.DatabaseUnavailable"
+}
@@ -105,7 +105,7 @@ func TestBolt4(outer *testing.T) {
 		tcpConn, srv, cleanup := setupBolt4Pipe(t)
 		go serverJob(srv)
 
-		c, err := Connect(context.Background(), "serverName", tcpConn, auth, "007", nil, logger, nil)
+		c, err := Connect(context.Background(), "serverName", tcpConn, auth, "007", nil, idb.NotificationConfig{}, logger, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -219,7 +219,7 @@ func TestBolt4(outer *testing.T) {
 			}
 			srv.acceptHello()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", routingContext, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", routingContext, idb.NotificationConfig{}, logger, nil)
 		AssertNoError(t, err)
 		bolt.Close(context.Background())
 	})
@@ -237,7 +237,7 @@ func TestBolt4(outer *testing.T) {
 			}
 			srv.acceptHello()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, idb.NotificationConfig{}, logger, nil)
 		AssertNoError(t, err)
 		bolt.Close(context.Background())
 	})
@@ -256,7 +256,7 @@ func TestBolt4(outer *testing.T) {
 			}
 			srv.acceptHello()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", routingContext, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", routingContext, idb.NotificationConfig{}, logger, nil)
 		AssertNoError(t, err)
 		bolt.Close(context.Background())
 	})
@@ -271,7 +271,7 @@ func TestBolt4(outer *testing.T) {
 			srv.waitForHello()
 			srv.rejectHelloUnauthorized()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, idb.NotificationConfig{}, logger, nil)
 		AssertNil(t, bolt)
 		AssertError(t, err)
 		dbErr, isDbErr := err.(*db.Neo4jError)
@@ -993,6 +993,21 @@ func TestBolt4(outer *testing.T) {
 		AssertError(t, err)
 	})
 
+	outer.Run("Database unavailable error is a termination notice", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt4server) {
+			srv.accept(4)
+			srv.sendFailureMsg("Neo.TransientError.General.DatabaseUnavailable", "database is shutting down")
+		})
+		defer cleanup()
+
+		AssertFalse(t, bolt.TerminationNotified())
+		_, err := bolt.Run(context.Background(), idb.Command{Cypher: "MATCH (" +
+			"n) RETURN n"}, idb.TxConfig{Mode: idb.ReadMode})
+		assertBoltState(t, bolt4_failed, bolt)
+		AssertError(t, err)
+		AssertTrue(t, bolt.TerminationNotified())
+	})
+
 	outer.Run("Immediately expired authentication token error triggers a connection failure", func(t *testing.T) {
 		bolt, cleanup := connectToServer(t, func(srv *bolt4server) {
 			srv.accept(4)
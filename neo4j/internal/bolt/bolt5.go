@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/keyindex"
 	"net"
 	"time"
 
@@ -52,6 +53,7 @@ type internalTx5 struct {
 	txMeta           map[string]any
 	databaseName     string
 	impersonatedUser string
+	notifications    idb.NotificationConfig
 }
 
 func (i *internalTx5) toMeta() map[string]any {
@@ -75,6 +77,7 @@ func (i *internalTx5) toMeta() map[string]any {
 	if i.impersonatedUser != "" {
 		meta["imp_user"] = i.impersonatedUser
 	}
+	i.notifications.ToMeta(meta)
 	return meta
 }
 
@@ -95,9 +98,13 @@ type bolt5 struct {
 	log           log.Logger
 	databaseName  string
 	err           error // Last fatal error
+	lastErr       error // Last error, kept across resets
 	minor         int
-	lastQid       int64 // Last seen qid
+	auth          map[string]any // Token the connection is authenticated with
+	lastQid       int64          // Last seen qid
 	idleDate      time.Time
+	// Whether the server notified that it is terminating
+	terminationNotified bool
 }
 
 func NewBolt5(serverName string, conn net.Conn, logger log.Logger, boltLog log.BoltLogger) *bolt5 {
@@ -153,6 +160,35 @@ func (b *bolt5) ServerVersion() string {
 	return b.serverVersion
 }
 
+func (b *bolt5) AuthToken() map[string]any {
+	return b.auth
+}
+
+func (b *bolt5) SupportsReAuth() bool {
+	return b.minor >= 1
+}
+
+// ReAuth logs the connection off and on again with the given token, which is supported since Bolt 5.1
+func (b *bolt5) ReAuth(ctx context.Context, auth map[string]any) error {
+	if b.minor < 1 {
+		return idb.ErrReAuthNotSupported
+	}
+	if err := b.assertState(bolt5Ready); err != nil {
+		return err
+	}
+	b.out.appendLogoff()
+	b.out.appendLogon(auth)
+	b.out.send(ctx, b.conn)
+	if b.receiveSuccess(ctx); b.err != nil {
+		return b.err
+	}
+	if b.receiveSuccess(ctx); b.err != nil {
+		return b.err
+	}
+	b.auth = auth
+	return nil
+}
+
 // Sets b.err and b.state to bolt5Failed or bolt5Dead when fatal is true.
 func (b *bolt5) setError(err error, fatal bool) {
 	// Has no effect, can reduce nested ifs
@@ -166,6 +202,10 @@ func (b *bolt5) setError(err error, fatal bool) {
 		b.state = bolt5Failed
 	}
 
+	if isTerminationNotice(err) {
+		b.terminationNotified = true
+	}
+
 	// Increase severity even if it was a previous error
 	if fatal {
 		if ctxErr := handleTerminatedContextError(err, b.conn); ctxErr != nil {
@@ -228,10 +268,13 @@ func (b *bolt5) receiveSuccess(ctx context.Context) *success {
 	}
 }
 
-func (b *bolt5) Connect(ctx context.Context, minor int, auth map[string]any, userAgent string, routingContext map[string]string) error {
+func (b *bolt5) Connect(ctx context.Context, minor int, auth map[string]any, userAgent string, routingContext map[string]string, notificationConfig idb.NotificationConfig) error {
 	if err := b.assertState(bolt5Unauthorized); err != nil {
 		return err
 	}
+	if err := b.checkNotificationFilteringAndVersion(notificationConfig, minor); err != nil {
+		return err
+	}
 
 	// Prepare hello message
 	hello := map[string]any{
@@ -240,21 +283,33 @@ func (b *bolt5) Connect(ctx context.Context, minor int, auth map[string]any, use
 	if routingContext != nil {
 		hello["routing"] = routingContext
 	}
-	// Merge authentication keys into hello, avoid overwriting existing keys
-	for k, v := range auth {
-		_, exists := hello[k]
-		if !exists {
-			hello[k] = v
+	notificationConfig.ToMeta(hello)
+	// Since 5.1 authentication is sent in a separate LOGON message, so that it can be changed later on
+	if minor < 1 {
+		// Merge authentication keys into hello, avoid overwriting existing keys
+		for k, v := range auth {
+			_, exists := hello[k]
+			if !exists {
+				hello[k] = v
+			}
 		}
 	}
 
-	// Send hello message and wait for confirmation
+	// Send hello message, followed by logon when needed, and wait for confirmation
 	b.out.appendHello(hello)
+	if minor >= 1 {
+		b.out.appendLogon(auth)
+	}
 	b.out.send(ctx, b.conn)
 	succ := b.receiveSuccess(ctx)
 	if b.err != nil {
 		return b.err
 	}
+	if minor >= 1 {
+		if b.receiveSuccess(ctx); b.err != nil {
+			return b.err
+		}
+	}
 
 	b.connId = succ.connectionId
 	b.serverVersion = succ.server
@@ -269,6 +324,7 @@ func (b *bolt5) Connect(ctx context.Context, minor int, auth map[string]any, use
 	// Transition into ready state
 	b.state = bolt5Ready
 	b.minor = minor
+	b.auth = auth
 	b.streams.reset()
 	b.log.Infof(log.Bolt5, b.logId, "Connected")
 	return nil
@@ -287,6 +343,9 @@ func (b *bolt5) TxBegin(ctx context.Context, txConfig idb.TxConfig) (idb.TxHandl
 	if err := b.assertState(bolt5Ready); err != nil {
 		return 0, err
 	}
+	if err := b.checkNotificationFilteringAndVersion(txConfig.Notifications, b.minor); err != nil {
+		return 0, err
+	}
 
 	tx := internalTx5{
 		mode:             txConfig.Mode,
@@ -295,6 +354,7 @@ func (b *bolt5) TxBegin(ctx context.Context, txConfig idb.TxConfig) (idb.TxHandl
 		txMeta:           txConfig.Meta,
 		databaseName:     b.databaseName,
 		impersonatedUser: txConfig.ImpersonatedUser,
+		notifications:    txConfig.Notifications,
 	}
 
 	b.out.appendBegin(tx.toMeta())
@@ -308,6 +368,14 @@ func (b *bolt5) TxBegin(ctx context.Context, txConfig idb.TxConfig) (idb.TxHandl
 	return b.txId, nil
 }
 
+// checkNotificationFilteringAndVersion is given the minor version since it is also called before the connection is set up
+func (b *bolt5) checkNotificationFilteringAndVersion(notificationConfig idb.NotificationConfig, minor int) error {
+	if !notificationConfig.IsDefault() && minor < 2 {
+		return &db.FeatureNotSupportedError{Server: b.serverName, Feature: "notification filtering", Reason: "requires at least server v5.7"}
+	}
+	return nil
+}
+
 // Should NOT set b.err or change b.state as this is used to guard against
 // misuse from clients that stick to their connections when they shouldn't.
 func (b *bolt5) assertTxHandle(h1, h2 idb.TxHandle) error {
@@ -572,7 +640,7 @@ func (b *bolt5) run(ctx context.Context, cypher string, params map[string]any, f
 	}
 
 	// Create a stream representation, set it to current and track it
-	stream := &stream{keys: succ.fields, qid: succ.qid, fetchSize: fetchSize}
+	stream := &stream{keys: succ.fields, keyIndex: keyindex.New(succ.fields), qid: succ.qid, fetchSize: fetchSize}
 	b.streams.attach(stream)
 	// No need to check streams state, we know we are streaming
 
@@ -584,6 +652,9 @@ func (b *bolt5) Run(ctx context.Context, cmd idb.Command,
 	if err := b.assertState(bolt5Streaming, bolt5Ready); err != nil {
 		return nil, err
 	}
+	if err := b.checkNotificationFilteringAndVersion(txConfig.Notifications, b.minor); err != nil {
+		return nil, err
+	}
 
 	tx := internalTx5{
 		mode:             txConfig.Mode,
@@ -592,6 +663,7 @@ func (b *bolt5) Run(ctx context.Context, cmd idb.Command,
 		txMeta:           txConfig.Meta,
 		databaseName:     b.databaseName,
 		impersonatedUser: txConfig.ImpersonatedUser,
+		notifications:    txConfig.Notifications,
 	}
 	stream, err := b.run(ctx, cmd.Cypher, cmd.Params, cmd.FetchSize, &tx)
 	if err != nil {
@@ -600,6 +672,58 @@ func (b *bolt5) Run(ctx context.Context, cmd idb.Command,
 	return stream, nil
 }
 
+func (b *bolt5) RunPipelined(ctx context.Context, cmds []idb.Command,
+	txConfig idb.TxConfig) ([]idb.StreamHandle, error) {
+	if err := b.assertState(bolt5Streaming, bolt5Ready); err != nil {
+		return nil, err
+	}
+	if err := b.checkNotificationFilteringAndVersion(txConfig.Notifications, b.minor); err != nil {
+		return nil, err
+	}
+	// If already streaming, consume the whole thing first
+	if b.state == bolt5Streaming {
+		if b.bufferStream(ctx); b.err != nil {
+			return nil, b.err
+		}
+	}
+
+	tx := internalTx5{
+		mode:             txConfig.Mode,
+		bookmarks:        txConfig.Bookmarks,
+		timeout:          txConfig.Timeout,
+		txMeta:           txConfig.Meta,
+		databaseName:     b.databaseName,
+		impersonatedUser: txConfig.ImpersonatedUser,
+		notifications:    txConfig.Notifications,
+	}
+	meta := tx.toMeta()
+	// Send all run and pull messages at once
+	for _, cmd := range cmds {
+		b.out.appendRun(cmd.Cypher, cmd.Params, meta)
+		b.out.appendPullN(-1)
+	}
+	b.out.send(ctx, b.conn)
+
+	// Receive the responses in order. Once a command fails, the server ignores the following messages until the
+	// connection is reset.
+	streams := make([]idb.StreamHandle, 0, len(cmds))
+	for range cmds {
+		succ := b.receiveSuccess(ctx)
+		if b.err != nil {
+			return streams, b.err
+		}
+		b.tfirst = succ.tfirst
+		b.state = bolt5Streaming
+		stream := &stream{keys: succ.fields, keyIndex: keyindex.New(succ.fields), qid: succ.qid, fetchSize: -1}
+		b.streams.attach(stream)
+		streams = append(streams, stream)
+		if b.bufferStream(ctx); b.err != nil {
+			return streams, b.err
+		}
+	}
+	return streams, nil
+}
+
 func (b *bolt5) RunTx(ctx context.Context, txh idb.TxHandle, cmd idb.Command) (idb.StreamHandle, error) {
 	if err := b.assertTxHandle(b.txId, txh); err != nil {
 		return nil, err
@@ -757,6 +881,7 @@ func (b *bolt5) receiveNext(ctx context.Context) (*db.Record, bool, *db.Summary)
 	case *db.Record:
 		// A new record
 		x.Keys = b.streams.curr.keys
+		keyindex.Attach(x, b.streams.curr.keyIndex)
 		return x, false, nil
 	case *success:
 		// End of batch or end of stream?
@@ -793,10 +918,45 @@ func (b *bolt5) Bookmark() string {
 	return b.bookmark
 }
 
+func (b *bolt5) ReportInvalidValues() {
+	b.in.hyd.reportInvalidValues = true
+}
+
+func (b *bolt5) BytesReceived() int64 {
+	return b.in.received
+}
+
+func (b *bolt5) LastError() error {
+	if b.err != nil {
+		return b.err
+	}
+	return b.lastErr
+}
+
+// clearErr clears the pending error, which is kept as the last error of the connection
+func (b *bolt5) clearErr() {
+	if b.err != nil {
+		b.lastErr = b.err
+	}
+	b.err = nil
+}
+
+func (b *bolt5) DecodeUnknownValues() {
+	b.in.hyd.decodeUnknownValues = true
+}
+
+func (b *bolt5) ProvideRawRecords() {
+	b.in.hyd.rawRecords = true
+}
+
 func (b *bolt5) IsAlive() bool {
 	return b.state != bolt5Dead
 }
 
+func (b *bolt5) TerminationNotified() bool {
+	return b.terminationNotified
+}
+
 func (b *bolt5) HasFailed() bool {
 	return b.state == bolt5Failed
 }
@@ -815,7 +975,7 @@ func (b *bolt5) Reset(ctx context.Context) {
 		b.txId = 0
 		b.bookmark = ""
 		b.databaseName = idb.DefaultDatabase
-		b.err = nil
+		b.clearErr()
 		b.lastQid = -1
 		b.streams.reset()
 	}()
@@ -835,7 +995,7 @@ func (b *bolt5) ForceReset(ctx context.Context) {
 
 	// Reset any pending error, should be matching bolt5_failed, so
 	// it should be recoverable.
-	b.err = nil
+	b.clearErr()
 
 	// Send the reset message to the server
 	b.out.appendReset()
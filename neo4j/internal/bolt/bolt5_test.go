@@ -105,7 +105,7 @@ func TestBolt5(outer *testing.T) {
 		tcpConn, srv, cleanup := setupBolt5Pipe(t)
 		go serverJob(srv)
 
-		c, err := Connect(context.Background(), "serverName", tcpConn, auth, "007", nil, logger, nil)
+		c, err := Connect(context.Background(), "serverName", tcpConn, auth, "007", nil, idb.NotificationConfig{}, logger, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -189,8 +189,10 @@ func TestBolt5(outer *testing.T) {
 				panic("Routing contexts differ")
 			}
 			srv.acceptHello()
+			srv.waitForLogon()
+			srv.acceptLogon()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", routingContext, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", routingContext, idb.NotificationConfig{}, logger, nil)
 		AssertNoError(t, err)
 		bolt.Close(context.Background())
 	})
@@ -207,12 +209,104 @@ func TestBolt5(outer *testing.T) {
 				panic("Should be no routing entry")
 			}
 			srv.acceptHello()
+			srv.waitForLogon()
+			srv.acceptLogon()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, idb.NotificationConfig{}, logger, nil)
 		AssertNoError(t, err)
 		bolt.Close(context.Background())
 	})
 
+	outer.Run("Notification filtering in hello since 5.2", func(t *testing.T) {
+		notificationConfig := idb.NotificationConfig{MinSeverity: "WARNING", DisabledCategories: []string{"HINT"}}
+		conn, srv, cleanup := setupBolt5Pipe(t)
+		defer cleanup()
+		go func() {
+			srv.waitForHandshake()
+			srv.acceptVersion(5, 2)
+			hmap := srv.waitForHello()
+			if hmap["notifications_minimum_severity"] != "WARNING" {
+				panic(fmt.Sprintf("Expected minimum severity in hello but got %v", hmap))
+			}
+			if !reflect.DeepEqual(hmap["notifications_disabled_categories"], []any{"HINT"}) {
+				panic(fmt.Sprintf("Expected disabled categories in hello but got %v", hmap))
+			}
+			srv.acceptHello()
+			srv.waitForLogon()
+			srv.acceptLogon()
+		}()
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, notificationConfig, logger, nil)
+		AssertNoError(t, err)
+		bolt.Close(context.Background())
+	})
+
+	outer.Run("Notification filtering not supported before 5.2", func(t *testing.T) {
+		notificationConfig := idb.NotificationConfig{DisabledCategories: []string{}}
+		conn, srv, cleanup := setupBolt5Pipe(t)
+		defer cleanup()
+		defer conn.Close()
+		go func() {
+			srv.waitForHandshake()
+			srv.acceptVersion(5, 1)
+		}()
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, notificationConfig, logger, nil)
+		AssertNil(t, bolt)
+		AssertSameType(t, err, &db.FeatureNotSupportedError{})
+	})
+
+	outer.Run("Authentication in logon since 5.1", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.waitForHandshake()
+			srv.acceptVersion(5, 1)
+			srv.waitForHello()
+			srv.acceptHello()
+			logon := srv.waitForLogon()
+			if !reflect.DeepEqual(logon, auth) {
+				panic(fmt.Sprintf("Expected logon with %v but got %v", auth, logon))
+			}
+			srv.acceptLogon()
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		AssertDeepEquals(t, bolt.AuthToken(), auth)
+	})
+
+	outer.Run("Re-authentication with logoff and logon", func(t *testing.T) {
+		newAuth := map[string]any{"scheme": "bearer", "credentials": "fresh"}
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.acceptWithMinor(5, 1)
+			srv.waitForLogoff()
+			logon := srv.waitForLogon()
+			if logon["credentials"] != "fresh" {
+				panic(fmt.Sprintf("Expected logon with the new token but got %v", logon))
+			}
+			srv.acceptLogon()
+			srv.acceptLogon()
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		err := bolt.ReAuth(context.Background(), newAuth)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, bolt.AuthToken(), newAuth)
+		assertBoltState(t, bolt5Ready, bolt)
+	})
+
+	outer.Run("Re-authentication not supported before 5.1", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.acceptWithMinor(5, 0)
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		err := bolt.ReAuth(context.Background(), map[string]any{"scheme": "bearer", "credentials": "fresh"})
+
+		AssertTrue(t, err == idb.ErrReAuthNotSupported)
+		AssertDeepEquals(t, bolt.AuthToken(), auth)
+	})
+
 	outer.Run("Failed authentication", func(t *testing.T) {
 		conn, srv, cleanup := setupBolt5Pipe(t)
 		defer cleanup()
@@ -223,7 +317,7 @@ func TestBolt5(outer *testing.T) {
 			srv.waitForHello()
 			srv.rejectHelloUnauthorized()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, idb.NotificationConfig{}, logger, nil)
 		AssertNil(t, bolt)
 		AssertError(t, err)
 		dbErr, isDbErr := err.(*db.Neo4jError)
@@ -262,6 +356,100 @@ func TestBolt5(outer *testing.T) {
 		assertBoltState(t, bolt5Ready, bolt)
 	})
 
+	outer.Run("Run auto-commit with notification filtering", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.acceptWithMinor(5, 2)
+			srv.serveRun(runResponse, func(fields []any) {
+				meta := fields[2].(map[string]any)
+				AssertStringEqual(t, meta["notifications_minimum_severity"].(string), "OFF")
+				_, exists := meta["notifications_disabled_categories"]
+				AssertFalse(t, exists)
+			})
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		str, err := bolt.Run(context.Background(), idb.Command{Cypher: "MATCH (n)"},
+			idb.TxConfig{Mode: idb.ReadMode, Notifications: idb.NotificationConfig{MinSeverity: "OFF"}})
+		AssertNoError(t, err)
+		assertRunResponseOk(t, bolt, str)
+	})
+
+	outer.Run("Counts received bytes", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.accept(5)
+			srv.serveRun(runResponse, nil)
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+		afterHello := bolt.BytesReceived()
+
+		str, _ := bolt.Run(context.Background(), idb.Command{Cypher: "MATCH (n)"}, idb.TxConfig{Mode: idb.ReadMode})
+		assertRunResponseOk(t, bolt, str)
+
+		AssertTrue(t, afterHello > 0)
+		AssertTrue(t, bolt.BytesReceived() > afterHello)
+	})
+
+	outer.Run("Run pipelined auto-commits", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.accept(5)
+			// All messages are sent before any response is awaited
+			srv.waitForRun(func(fields []any) {
+				AssertStringEqual(t, fields[0].(string), "RETURN 1")
+			})
+			srv.waitForPullN(-1)
+			srv.waitForRun(func(fields []any) {
+				AssertStringEqual(t, fields[0].(string), "RETURN 2")
+			})
+			srv.waitForPullN(-1)
+			for _, x := range append(runResponse, runResponse...) {
+				srv.send(x.tag, x.fields...)
+			}
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		streams, err := bolt.RunPipelined(context.Background(),
+			[]idb.Command{{Cypher: "RETURN 1"}, {Cypher: "RETURN 2"}}, idb.TxConfig{Mode: idb.ReadMode})
+
+		AssertNoError(t, err)
+		AssertLen(t, streams, 2)
+		assertBoltState(t, bolt5Ready, bolt)
+		for _, stream := range streams {
+			skeys, _ := bolt.Keys(stream)
+			assertKeys(t, runKeys, skeys)
+			assertRunResponseOk(t, bolt, stream)
+		}
+	})
+
+	outer.Run("Run pipelined auto-commits stops at first failure", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.accept(5)
+			srv.waitForRun(nil)
+			srv.waitForPullN(-1)
+			srv.waitForRun(nil)
+			srv.waitForPullN(-1)
+			srv.sendFailureMsg("Neo.ClientError.Statement.SyntaxError", "oopsie")
+			srv.sendIgnoredMsg()
+			srv.sendIgnoredMsg()
+			srv.sendIgnoredMsg()
+			srv.waitForReset()
+			srv.sendSuccess(map[string]any{})
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		streams, err := bolt.RunPipelined(context.Background(),
+			[]idb.Command{{Cypher: "RETURN"}, {Cypher: "RETURN 2"}}, idb.TxConfig{Mode: idb.ReadMode})
+
+		AssertError(t, err)
+		AssertLen(t, streams, 0)
+		assertBoltState(t, bolt5Failed, bolt)
+		bolt.Reset(context.Background())
+		assertBoltState(t, bolt5Ready, bolt)
+	})
+
 	outer.Run("Run auto-commit with impersonation", func(t *testing.T) {
 		cypherText := "MATCH (n)"
 		impersonatedUser := "a user"
@@ -912,6 +1100,21 @@ func TestBolt5(outer *testing.T) {
 		AssertError(t, err)
 	})
 
+	outer.Run("Database unavailable error is a termination notice", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.accept(5)
+			srv.sendFailureMsg("Neo.TransientError.General.DatabaseUnavailable", "database is shutting down")
+		})
+		defer cleanup()
+
+		AssertFalse(t, bolt.TerminationNotified())
+		_, err := bolt.Run(context.Background(), idb.Command{Cypher: "MATCH (" +
+			"n) RETURN n"}, idb.TxConfig{Mode: idb.ReadMode})
+		assertBoltState(t, bolt5Failed, bolt)
+		AssertError(t, err)
+		AssertTrue(t, bolt.TerminationNotified())
+	})
+
 	outer.Run("Immediately expired authentication token error triggers a connection failure", func(t *testing.T) {
 		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
 			srv.accept(5)
@@ -37,6 +37,7 @@ type bolt5server struct {
 	conn     net.Conn
 	unpacker *packstream.Unpacker
 	out      *outgoing
+	minor    byte
 }
 
 func newBolt5Server(conn net.Conn) *bolt5server {
@@ -82,11 +83,14 @@ func (s *bolt5server) waitForHello() map[string]any {
 	msg := s.receiveMsg()
 	s.assertStructType(msg, msgHello)
 	m := msg.fields[0].(map[string]any)
-	// Hello should contain some musts
+	// Hello should contain some musts, authentication is sent with LOGON since 5.1
 	_, exists := m["scheme"]
-	if !exists {
+	if !exists && s.minor < 1 {
 		s.sendFailureMsg("?", "Missing scheme in hello")
 	}
+	if exists && s.minor >= 1 {
+		s.sendFailureMsg("?", "Unexpected scheme in hello")
+	}
 	_, exists = m["user_agent"]
 	if !exists {
 		s.sendFailureMsg("?", "Missing user_agent in hello")
@@ -94,6 +98,22 @@ func (s *bolt5server) waitForHello() map[string]any {
 	return m
 }
 
+// Returns the logon fields
+func (s *bolt5server) waitForLogon() map[string]any {
+	msg := s.receiveMsg()
+	s.assertStructType(msg, msgLogon)
+	m := msg.fields[0].(map[string]any)
+	if _, exists := m["scheme"]; !exists {
+		s.sendFailureMsg("?", "Missing scheme in logon")
+	}
+	return m
+}
+
+func (s *bolt5server) waitForLogoff() {
+	msg := s.receiveMsg()
+	s.assertStructType(msg, msgLogoff)
+}
+
 func (s *bolt5server) receiveMsg() *testStruct {
 	_, buf, err := dechunkMessage(context.Background(), s.conn, []byte{}, -1)
 	if err != nil {
@@ -178,6 +198,7 @@ func (s *bolt5server) waitForRoute(assertRoute func(fields []any)) {
 
 func (s *bolt5server) acceptVersion(major, minor byte) {
 	acceptedVer := []byte{0x00, 0x00, minor, major}
+	s.minor = minor
 	_, err := s.conn.Write(acceptedVer)
 	if err != nil {
 		panic(err)
@@ -204,6 +225,10 @@ func (s *bolt5server) acceptHello() {
 	})
 }
 
+func (s *bolt5server) acceptLogon() {
+	s.send(msgSuccess, map[string]any{})
+}
+
 func (s *bolt5server) acceptHelloWithHints(hints map[string]any) {
 	s.send(msgSuccess, map[string]any{
 		"connection_id": "cid",
@@ -232,6 +257,10 @@ func (s *bolt5server) acceptWithMinor(major, minor byte) {
 	s.acceptVersion(major, minor)
 	s.waitForHello()
 	s.acceptHello()
+	if minor >= 1 {
+		s.waitForLogon()
+		s.acceptLogon()
+	}
 }
 
 // Utility to wait and serve an auto commit query
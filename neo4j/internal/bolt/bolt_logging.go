@@ -16,7 +16,7 @@ func (d loggableDictionary) String() string {
 			d["credentials"] = credentials
 		}()
 	}
-	return serializeTrace(d)
+	return serializeTrace(map[string]any(d))
 }
 
 type loggableStringDictionary map[string]string
@@ -28,19 +28,19 @@ func (sd loggableStringDictionary) String() string {
 			sd["credentials"] = credentials
 		}()
 	}
-	return serializeTrace(sd)
+	return serializeTrace(map[string]string(sd))
 }
 
 type loggableList []any
 
 func (l loggableList) String() string {
-	return serializeTrace(l)
+	return serializeTrace([]any(l))
 }
 
 type loggableStringList []string
 
 func (s loggableStringList) String() string {
-	return serializeTrace(s)
+	return serializeTrace([]string(s))
 }
 
 type loggableSuccess success
@@ -110,6 +110,31 @@ func (f loggableFailure) String() string {
 	})
 }
 
+// MarshalJSON lets JSON Bolt loggers embed the sanitized message fields as is
+func (d loggableDictionary) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (sd loggableStringDictionary) MarshalJSON() ([]byte, error) {
+	return []byte(sd.String()), nil
+}
+
+func (l loggableList) MarshalJSON() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (s loggableStringList) MarshalJSON() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s loggableSuccess) MarshalJSON() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (f loggableFailure) MarshalJSON() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
 func serializeTrace(v any) string {
 	builder := strings.Builder{}
 	encoder := json.NewEncoder(&builder)
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bolt
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+)
+
+func TestJSONBoltLogging(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	logger := log.NewJSONBoltLogger(buffer)
+	hello := loggableDictionary{"scheme": "basic", "credentials": "secret"}
+
+	logger.LogClientMessage("", "<MAGIC> %#010X", []byte{0x60, 0x60, 0xB0, 0x17})
+	logger.LogClientMessage("bolt-1", "HELLO %s", hello)
+	logger.LogClientMessage("bolt-1", "RUN %q %s %s", "RETURN $x", loggableDictionary{"x": 1}, loggableDictionary{})
+	logger.LogClientMessage("bolt-1", "PULL ALL")
+	logger.LogServerMessage("bolt-1", "FAILURE %s", loggableFailure(db.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError", Msg: "oops"}))
+
+	var messages []map[string]any
+	scanner := bufio.NewScanner(buffer)
+	for scanner.Scan() {
+		message := map[string]any{}
+		AssertNoError(t, json.Unmarshal(scanner.Bytes(), &message))
+		_, hasTime := message["time"]
+		AssertTrue(t, hasTime)
+		delete(message, "time")
+		messages = append(messages, message)
+	}
+	AssertDeepEquals(t, messages, []map[string]any{
+		{"direction": "client", "type": "MAGIC", "fields": []any{"0X6060B017"}},
+		{"id": "bolt-1", "direction": "client", "type": "HELLO", "fields": []any{
+			map[string]any{"scheme": "basic", "credentials": "<redacted>"},
+		}},
+		{"id": "bolt-1", "direction": "client", "type": "RUN", "fields": []any{
+			"RETURN $x", map[string]any{"x": 1.0}, map[string]any{},
+		}},
+		{"id": "bolt-1", "direction": "client", "type": "PULL ALL", "fields": []any{}},
+		{"id": "bolt-1", "direction": "server", "type": "FAILURE", "fields": []any{
+			map[string]any{"code": "Neo.ClientError.Statement.SyntaxError", "message": "oops"},
+		}},
+	})
+	AssertDeepEquals(t, hello["credentials"], "secret")
+}
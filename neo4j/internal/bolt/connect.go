@@ -38,7 +38,7 @@ type protocolVersion struct {
 
 // Supported versions in priority order
 var versions = [4]protocolVersion{
-	{major: 5, minor: 0},
+	{major: 5, minor: 2, back: 2},
 	{major: 4, minor: 4, back: 2},
 	{major: 4, minor: 1},
 	{major: 3, minor: 0},
@@ -46,7 +46,7 @@ var versions = [4]protocolVersion{
 
 // Connect initiates the negotiation of the Bolt protocol version.
 // Returns the instance of bolt protocol implementing the low-level Connection interface.
-func Connect(ctx context.Context, serverName string, conn net.Conn, auth map[string]any, userAgent string, routingContext map[string]string, logger log.Logger, boltLog log.BoltLogger) (db.Connection, error) {
+func Connect(ctx context.Context, serverName string, conn net.Conn, auth map[string]any, userAgent string, routingContext map[string]string, notificationConfig db.NotificationConfig, logger log.Logger, boltLog log.BoltLogger) (db.Connection, error) {
 	// Perform Bolt handshake to negotiate version
 	// Send handshake to server
 	handshake := []byte{
@@ -91,7 +91,7 @@ func Connect(ctx context.Context, serverName string, conn net.Conn, auth map[str
 	default:
 		return nil, fmt.Errorf("server responded with unsupported version %d.%d", major, minor)
 	}
-	if err = boltConn.Connect(ctx, int(minor), auth, userAgent, routingContext); err != nil {
+	if err = boltConn.Connect(ctx, int(minor), auth, userAgent, routingContext, notificationConfig); err != nil {
 		return nil, err
 	}
 	return boltConn, nil
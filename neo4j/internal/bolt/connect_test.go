@@ -23,6 +23,7 @@ import (
 	"context"
 	"testing"
 
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 )
@@ -50,7 +51,7 @@ func TestConnect(ot *testing.T) {
 			srv.closeConnection()
 		}()
 
-		_, err := Connect(context.Background(), "servername", conn, auth, "007", nil, logger, nil)
+		_, err := Connect(context.Background(), "servername", conn, auth, "007", nil, idb.NotificationConfig{}, logger, nil)
 		AssertError(t, err)
 	})
 
@@ -65,7 +66,7 @@ func TestConnect(ot *testing.T) {
 			srv.acceptVersion(1, 0)
 		}()
 
-		boltconn, err := Connect(context.Background(), "servername", conn, auth, "007", nil, logger, nil)
+		boltconn, err := Connect(context.Background(), "servername", conn, auth, "007", nil, idb.NotificationConfig{}, logger, nil)
 		AssertError(t, err)
 		if boltconn != nil {
 			t.Error("Shouldn't returned conn")
@@ -51,6 +51,7 @@ type success struct {
 	plan               *db.Plan
 	profile            *db.ProfiledPlan
 	notifications      []db.Notification
+	statuses           []db.GqlStatusObject
 	routingTable       *idb.RoutingTable
 	num                uint32
 	configurationHints map[string]any
@@ -80,6 +81,7 @@ func (s *success) summary() *db.Summary {
 		Plan:                  s.plan,
 		ProfiledPlan:          s.profile,
 		Notifications:         s.notifications,
+		GqlStatusObjects:      s.statuses,
 		Database:              s.db,
 		ContainsSystemUpdates: extractBoolPointer(s.counters, containsSystemUpdatesKey),
 		ContainsUpdates:       extractBoolPointer(s.counters, containsUpdatesKey),
@@ -118,6 +120,14 @@ type hydrator struct {
 	logId         string
 	boltMajor     int
 	useUtc        bool
+	// reportInvalidValues makes values that cannot be hydrated show up as dbtype.InvalidValue instead of failing the
+	// whole message
+	reportInvalidValues bool
+	// decodeUnknownValues makes structures with an unknown tag show up as dbtype.UnknownValue instead of failing the
+	// whole message
+	decodeUnknownValues bool
+	// rawRecords makes records hold their packstream encoded values instead of the hydrated values
+	rawRecords bool
 }
 
 func (h *hydrator) setErr(err error) {
@@ -278,6 +288,9 @@ func (h *hydrator) success(n uint32) *success {
 		case "notifications":
 			l := h.array()
 			succ.notifications = parseNotifications(l)
+		case "statuses":
+			l := h.array()
+			succ.statuses = parseGqlStatusObjects(l)
 		case "rt":
 			succ.routingTable = h.routingTable()
 		case "hints":
@@ -421,6 +434,14 @@ func (h *hydrator) record(n uint32) *db.Record {
 		return nil
 	}
 	rec := db.Record{}
+	if h.rawRecords {
+		// The buffer is reused for the next messages
+		rec.Raw = append([]byte(nil), h.unp.Remaining()...)
+		if h.boltLogger != nil {
+			h.boltLogger.LogServerMessage(h.logId, "RECORD <%d raw bytes>", len(rec.Raw))
+		}
+		return &rec
+	}
 	h.unp.Next() // Detect array
 	n = h.unp.Len()
 	rec.Values = make([]any, n)
@@ -434,6 +455,26 @@ func (h *hydrator) record(n uint32) *db.Record {
 	return &rec
 }
 
+// DecodeRawRecord hydrates the packstream encoded list of values of a raw record, see db.Record.Raw
+func DecodeRawRecord(raw []byte) ([]any, error) {
+	h := hydrator{boltMajor: 5, useUtc: true}
+	h.unp = &h.unpacker
+	h.unp.Reset(raw)
+	h.unp.Next()
+	if h.unp.Curr != packstream.PackedArray {
+		return nil, &db.ProtocolError{MessageType: "record", Err: "expected a list of values"}
+	}
+	values := make([]any, h.unp.Len())
+	for i := range values {
+		h.unp.Next()
+		values[i] = h.value()
+	}
+	if err := h.getErr(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
 func (h *hydrator) value() any {
 	valueType := h.unp.Curr
 	switch valueType {
@@ -470,22 +511,22 @@ func (h *hydrator) value() any {
 			return h.point3d(n)
 		case 'F':
 			if h.useUtc {
-				return h.unknownStructError(t)
+				return h.unknownStructError(t, n)
 			}
 			return h.dateTimeOffset(n)
 		case 'I':
 			if !h.useUtc {
-				return h.unknownStructError(t)
+				return h.unknownStructError(t, n)
 			}
 			return h.utcDateTimeOffset(n)
 		case 'f':
 			if h.useUtc {
-				return h.unknownStructError(t)
+				return h.unknownStructError(t, n)
 			}
 			return h.dateTimeNamedZone(n)
 		case 'i':
 			if !h.useUtc {
-				return h.unknownStructError(t)
+				return h.unknownStructError(t, n)
 			}
 			return h.utcDateTimeNamedZone(n)
 		case 'd':
@@ -499,7 +540,7 @@ func (h *hydrator) value() any {
 		case 'E':
 			return h.duration(n)
 		default:
-			return h.unknownStructError(t)
+			return h.unknownStructError(t, n)
 		}
 	case packstream.PackedByteArray:
 		return h.unp.ByteArray()
@@ -884,6 +925,27 @@ func parseNotifications(notificationsx []any) []db.Notification {
 	return notifications
 }
 
+func parseGqlStatusObjects(statusesx []any) []db.GqlStatusObject {
+	statuses := make([]db.GqlStatusObject, 0, len(statusesx))
+	for _, x := range statusesx {
+		statusx, ok := x.(map[string]any)
+		if ok {
+			statuses = append(statuses, parseGqlStatusObject(statusx))
+		}
+	}
+	return statuses
+}
+
+func parseGqlStatusObject(m map[string]any) db.GqlStatusObject {
+	s := db.GqlStatusObject{}
+	s.GqlStatus, _ = m["gql_status"].(string)
+	s.StatusDescription, _ = m["status_description"].(string)
+	s.Neo4jCode, _ = m["neo4j_code"].(string)
+	s.Title, _ = m["title"].(string)
+	s.DiagnosticRecord, _ = m["diagnostic_record"].(map[string]any)
+	return s
+}
+
 func parsePlanOpIdArgsChildren(planx map[string]any) (string, []string, map[string]any, []any) {
 	operator, _ := planx["operatorType"].(string)
 	identifiersx, _ := planx["identifiers"].([]any)
@@ -964,6 +1026,7 @@ func parseNotification(m map[string]any) db.Notification {
 	n.Description = m["description"].(string)
 	n.Severity, _ = m["severity"].(string)
 	n.Title, _ = m["title"].(string)
+	n.Category, _ = m["category"].(string)
 	posx, exists := m["position"].(map[string]any)
 	if exists {
 		pos := &db.InputPosition{}
@@ -979,9 +1042,28 @@ func parseNotification(m map[string]any) db.Notification {
 	return n
 }
 
-func (h *hydrator) unknownStructError(t byte) any {
-	h.setErr(&db.ProtocolError{
+func (h *hydrator) unknownStructError(t byte, n uint32) any {
+	if h.decodeUnknownValues {
+		fields := make([]any, n)
+		for i := range fields {
+			h.unp.Next()
+			fields[i] = h.value()
+		}
+		return &dbtype.UnknownValue{Tag: t, Fields: fields}
+	}
+	err := &db.ProtocolError{
 		Err: fmt.Sprintf("Received unknown struct tag: %d", t),
-	})
-	return nil
+	}
+	if !h.reportInvalidValues {
+		h.setErr(err)
+		return nil
+	}
+	for i := uint32(0); i < n; i++ {
+		h.unp.Next()
+		h.trash()
+	}
+	return &dbtype.InvalidValue{
+		Message: "unknownStruct",
+		Err:     err,
+	}
 }
@@ -38,6 +38,12 @@ type hydratorTestCase struct {
 	x      any    // Expected hydrated
 	err    error
 	useUtc bool
+	// reportInvalidValues makes the hydrator report values it fails to hydrate as dbtype.InvalidValue
+	reportInvalidValues bool
+	// decodeUnknownValues makes the hydrator decode structures with an unknown tag as dbtype.UnknownValue
+	decodeUnknownValues bool
+	// rawRecords makes the hydrator keep the packstream encoded values of records
+	rawRecords bool
 }
 
 func TestHydrator(outer *testing.T) {
@@ -170,6 +176,22 @@ func TestHydrator(outer *testing.T) {
 			},
 			x: &success{tlast: 124, tfirst: -1, bookmark: "b", qtype: db.StatementTypeWrite, db: "s", qid: -1, num: 4},
 		},
+		{
+			name: "Success pull response with unknown statement type",
+			build: func() {
+				packer.StructHeader(byte(msgSuccess), 1)
+				packer.MapHeader(2)
+				packer.String("bookmark")
+				packer.String("b")
+				packer.String("type")
+				packer.String("whatever")
+			},
+			err: &db.ProtocolError{
+				MessageType: "success",
+				Field:       "type",
+				Err:         "unrecognized success statement type whatever",
+			},
+		},
 		{
 			name: "Success summary with plan",
 			build: func() {
@@ -293,7 +315,7 @@ func TestHydrator(outer *testing.T) {
 				packer.Int(2)
 				packer.String("column")
 				packer.Int(3)
-				packer.MapHeader(4) // Notification map
+				packer.MapHeader(5) // Notification map
 				packer.String("code")
 				packer.String("c2")
 				packer.String("title")
@@ -302,11 +324,50 @@ func TestHydrator(outer *testing.T) {
 				packer.String("d2")
 				packer.String("severity")
 				packer.String("s2")
+				packer.String("category")
+				packer.String("DEPRECATION")
 			},
 			x: &success{tlast: -1, tfirst: -1, bookmark: "bm", db: "sys", qid: -1, num: 4,
 				notifications: []db.Notification{
 					{Code: "c1", Title: "t1", Description: "d1", Severity: "s1", Position: &db.InputPosition{Offset: 1, Line: 2, Column: 3}},
-					{Code: "c2", Title: "t2", Description: "d2", Severity: "s2"},
+					{Code: "c2", Title: "t2", Description: "d2", Severity: "s2", Category: "DEPRECATION"},
+				}},
+		},
+		{
+			name: "Success summary with GQL statuses",
+			build: func() {
+				packer.StructHeader(byte(msgSuccess), 1)
+				packer.MapHeader(2)
+				packer.String("has_more")
+				packer.Bool(false)
+				packer.String("statuses") // Array
+				packer.ArrayHeader(2)
+				packer.MapHeader(2) // Status map
+				packer.String("gql_status")
+				packer.String("00000")
+				packer.String("status_description")
+				packer.String("note: successful completion")
+				packer.MapHeader(5) // Status map
+				packer.String("gql_status")
+				packer.String("01N42")
+				packer.String("status_description")
+				packer.String("warn: feature deprecated")
+				packer.String("neo4j_code")
+				packer.String("c1")
+				packer.String("title")
+				packer.String("t1")
+				packer.String("diagnostic_record")
+				packer.MapHeader(2)
+				packer.String("_severity")
+				packer.String("WARNING")
+				packer.String("_classification")
+				packer.String("DEPRECATION")
+			},
+			x: &success{tlast: -1, tfirst: -1, qid: -1, num: 2,
+				statuses: []db.GqlStatusObject{
+					{GqlStatus: "00000", StatusDescription: "note: successful completion"},
+					{GqlStatus: "01N42", StatusDescription: "warn: feature deprecated", Neo4jCode: "c1", Title: "t1",
+						DiagnosticRecord: map[string]any{"_severity": "WARNING", "_classification": "DEPRECATION"}},
 				}},
 		},
 		{
@@ -455,6 +516,17 @@ func TestHydrator(outer *testing.T) {
 			},
 			x: &db.Record{Values: []any{int64(1), int64(2), int64(3), int64(4), int64(5)}},
 		},
+		{
+			name: "Raw record",
+			build: func() {
+				packer.StructHeader(byte(msgRecord), 1)
+				packer.ArrayHeader(2)
+				packer.Int(1)
+				packer.String("a")
+			},
+			x:          &db.Record{Raw: []byte{0x92, 0x01, 0x81, 'a'}},
+			rawRecords: true,
+		},
 		{
 			name: "Record of spatials",
 			build: func() {
@@ -783,6 +855,55 @@ func TestHydrator(outer *testing.T) {
 				},
 			}},
 		},
+		{
+			name: "Record of unknown struct",
+			build: func() {
+				packer.StructHeader(byte(msgRecord), 1)
+				packer.ArrayHeader(2)
+				packer.StructHeader('Z', 2)
+				packer.Int64(42)
+				packer.String("future")
+				packer.Int64(43)
+			},
+			err: &db.ProtocolError{Err: "Received unknown struct tag: 90"},
+		},
+		{
+			name:                "Record of unknown struct when reporting invalid values",
+			reportInvalidValues: true,
+			build: func() {
+				packer.StructHeader(byte(msgRecord), 1)
+				packer.ArrayHeader(2)
+				packer.StructHeader('Z', 2)
+				packer.Int64(42)
+				packer.String("future")
+				packer.Int64(43)
+			},
+			x: &db.Record{Values: []any{
+				&dbtype.InvalidValue{
+					Message: "unknownStruct",
+					Err:     &db.ProtocolError{Err: "Received unknown struct tag: 90"},
+				},
+				int64(43),
+			}},
+		},
+		{
+			name:                "Record of unknown struct when decoding unknown values",
+			decodeUnknownValues: true,
+			reportInvalidValues: true,
+			build: func() {
+				packer.StructHeader(byte(msgRecord), 1)
+				packer.ArrayHeader(2)
+				packer.StructHeader('Z', 2)
+				packer.Int64(42)
+				packer.ArrayHeader(1)
+				packer.String("future")
+				packer.Int64(43)
+			},
+			x: &db.Record{Values: []any{
+				&dbtype.UnknownValue{Tag: 'Z', Fields: []any{int64(42), []any{"future"}}},
+				int64(43),
+			}},
+		},
 	}
 
 	// Shared among calls in real usage so we do the same while testing it.
@@ -793,6 +914,9 @@ func TestHydrator(outer *testing.T) {
 				hydrator.err = nil
 			}()
 			hydrator.useUtc = c.useUtc
+			hydrator.reportInvalidValues = c.reportInvalidValues
+			hydrator.decodeUnknownValues = c.decodeUnknownValues
+			hydrator.rawRecords = c.rawRecords
 			if (c.x != nil) == (c.err != nil) {
 				t.Fatalf("test case needs to define either expected result or error (xor)")
 			}
@@ -29,6 +29,7 @@ type incoming struct {
 	buf             []byte // Reused buffer
 	hyd             hydrator
 	connReadTimeout time.Duration
+	received        int64 // Number of message bytes received so far
 }
 
 func (i *incoming) next(ctx context.Context, rd net.Conn) (any, error) {
@@ -39,5 +40,6 @@ func (i *incoming) next(ctx context.Context, rd net.Conn) (any, error) {
 	if err != nil {
 		return nil, err
 	}
+	i.received += int64(len(msg))
 	return i.hyd.hydrate(msg)
 }
@@ -38,4 +38,6 @@ const (
 	msgCommit     byte = 0x12
 	msgRollback   byte = 0x13
 	msgRoute      byte = 0x66 // > 4.2
+	msgLogon      byte = 0x6A // >= 5.1
+	msgLogoff     byte = 0x6B // >= 5.1
 )
@@ -65,6 +65,25 @@ func (o *outgoing) appendHello(hello map[string]any) {
 	o.end()
 }
 
+func (o *outgoing) appendLogon(auth map[string]any) {
+	if o.boltLogger != nil {
+		o.boltLogger.LogClientMessage(o.logId, "LOGON %s", loggableDictionary(auth))
+	}
+	o.begin()
+	o.packer.StructHeader(byte(msgLogon), 1)
+	o.packMap(auth)
+	o.end()
+}
+
+func (o *outgoing) appendLogoff() {
+	if o.boltLogger != nil {
+		o.boltLogger.LogClientMessage(o.logId, "LOGOFF")
+	}
+	o.begin()
+	o.packer.StructHeader(byte(msgLogoff), 0)
+	o.end()
+}
+
 func (o *outgoing) appendBegin(meta map[string]any) {
 	if o.boltLogger != nil {
 		o.boltLogger.LogClientMessage(o.logId, "BEGIN %s", loggableDictionary(meta))
@@ -254,6 +273,8 @@ func (o *outgoing) packMap(m map[string]any) {
 
 func (o *outgoing) packStruct(x any) {
 	switch v := x.(type) {
+	case *dbtype.StreamedList:
+		o.packStreamedList(v)
 	case *dbtype.Point2D:
 		o.packer.StructHeader('X', 3)
 		o.packer.Uint32(v.SpatialRefId)
@@ -332,6 +353,29 @@ func (o *outgoing) packStruct(x any) {
 	}
 }
 
+func (o *outgoing) packStreamedList(list *dbtype.StreamedList) {
+	// The items can only be produced once, make any later encoding fail instead of silently sending an empty list
+	next := list.Next
+	list.Next = func() (any, bool, error) {
+		return nil, false, &dbtype.StreamedListReusedError{}
+	}
+	position := o.packer.OpenArray()
+	num := 0
+	for {
+		item, ok, err := next()
+		if err != nil {
+			o.onErr(err)
+			return
+		}
+		if !ok {
+			break
+		}
+		o.packX(item)
+		num++
+	}
+	o.packer.CloseArray(position, num)
+}
+
 func (o *outgoing) packX(x any) {
 	if x == nil {
 		o.packer.Nil()
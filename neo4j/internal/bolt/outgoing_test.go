@@ -21,6 +21,7 @@ package bolt
 
 import (
 	"context"
+	"errors"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"net"
 	"reflect"
@@ -522,6 +523,23 @@ func TestOutgoing(ot *testing.T) {
 				"custom map of ints":  map[string]any{"l": int64(1)},
 			},
 		},
+		{
+			name: "map of streamed lists",
+			inp: map[string]any{
+				"empty":    streamedListOf(),
+				"rows":     streamedListOf(map[string]any{"id": 1}, map[string]any{"id": 2}),
+				"channel":  dbtype.StreamedListFromChannel(closedChannelOf("a", "b")),
+				"nested":   streamedListOf(streamedListOf(1, 2), []int{3}),
+				"20 items": streamedListOf(make([]any, 20)...),
+			},
+			expect: map[string]any{
+				"empty":    []any{},
+				"rows":     []any{map[string]any{"id": int64(1)}, map[string]any{"id": int64(2)}},
+				"channel":  []any{"a", "b"},
+				"nested":   []any{[]any{int64(1), int64(2)}, []any{int64(3)}},
+				"20 items": make([]any, 20),
+			},
+		},
 		{
 			name: "map of pointer types",
 			inp: map[string]any{
@@ -563,6 +581,29 @@ func TestOutgoing(ot *testing.T) {
 			},
 			err: &db.UnsupportedTypeError{},
 		},
+		{
+			name: "a failing streamed list",
+			inp: map[string]any{
+				"m": &dbtype.StreamedList{Next: func() (any, bool, error) {
+					return nil, false, errors.New("oopsie")
+				}},
+			},
+			err: errors.New("oopsie"),
+		},
+		{
+			name: "a streamed list value",
+			inp: map[string]any{
+				"m": dbtype.StreamedList{Next: streamedListOf(int64(1)).Next},
+			},
+			err: &db.UnsupportedTypeError{},
+		},
+		{
+			name: "an already encoded streamed list",
+			inp: map[string]any{
+				"m": encodedStreamedList(),
+			},
+			err: &dbtype.StreamedListReusedError{},
+		},
 		{
 			name: "a random struct",
 			inp: map[string]any{
@@ -595,3 +636,32 @@ func TestOutgoing(ot *testing.T) {
 		})
 	}
 }
+
+func streamedListOf(items ...any) *dbtype.StreamedList {
+	i := 0
+	return &dbtype.StreamedList{Next: func() (any, bool, error) {
+		if i == len(items) {
+			return nil, false, nil
+		}
+		i++
+		return items[i-1], true, nil
+	}}
+}
+
+func encodedStreamedList() *dbtype.StreamedList {
+	list := streamedListOf(int64(1))
+	out := &outgoing{chunker: newChunker(), packer: packstream.Packer{}, onErr: func(error) {}}
+	out.begin()
+	out.packX(list)
+	out.end()
+	return list
+}
+
+func closedChannelOf(items ...any) <-chan any {
+	channel := make(chan any, len(items))
+	for _, item := range items {
+		channel <- item
+	}
+	close(channel)
+	return channel
+}
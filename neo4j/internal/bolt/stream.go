@@ -23,6 +23,7 @@ import (
 	"container/list"
 	"errors"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/keyindex"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
@@ -30,6 +31,7 @@ import (
 
 type stream struct {
 	keys      []string
+	keyIndex  keyindex.Index
 	fifo      list.List
 	sum       *db.Summary
 	err       error
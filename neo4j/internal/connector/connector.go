@@ -25,12 +25,21 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"fmt"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/auth"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
+	neo4jdb "github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/faults"
 	"io"
 	"net"
+	"net/http"
+	"net/url"
+	"sync"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/bolt"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/httpquery"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 )
 
@@ -41,28 +50,133 @@ type Connector struct {
 	RootCAs         *x509.CertPool
 	DialTimeout     time.Duration
 	SocketKeepAlive bool
-	Auth            map[string]any
-	Log             log.Logger
-	UserAgent       string
-	RoutingContext  map[string]string
-	Network         string
-	TlsConfig       *tls.Config
+	// Dialer optionally replaces the net.Dialer configured with DialTimeout and SocketKeepAlive
+	Dialer Dialer
+	// Proxy optionally sets the SOCKS5 or HTTP proxy connections are established through, see ValidateProxyURL
+	Proxy *url.URL
+	// NoProxy lists the hosts, or domains, connections to which do not go through the proxy
+	NoProxy []string
+	// Auth supplies the token new connections are authenticated with
+	Auth           func(context.Context) (map[string]any, error)
+	Log            log.Logger
+	UserAgent      string
+	RoutingContext map[string]string
+	// Notifications defines the notifications the server sends on new connections
+	Notifications db.NotificationConfig
+	Network       string
+	TlsConfig     *tls.Config
+	// ClientCertificateProvider optionally supplies the certificate presented in the TLS handshake of new connections
+	ClientCertificateProvider auth.ClientCertificateProvider
+	// OnBytesReceived is optionally called with the number of bytes read from the network for every read
+	OnBytesReceived func(n int)
+	// ReportInvalidValues makes connections report the values they fail to hydrate as dbtype.InvalidValue
+	ReportInvalidValues bool
+	// DecodeUnknownValues makes connections decode structures with an unknown tag as dbtype.UnknownValue
+	DecodeUnknownValues bool
+	// RawRecords makes connections provide records as their packstream encoded values, see db.Record.Raw
+	RawRecords bool
+	// FaultInjector optionally injects faults in the Bolt traffic of connections, for resilience testing
+	FaultInjector faults.Injector
+	// Http makes connections use the HTTP Query API instead of Bolt, over TLS unless SkipEncryption is set
+	Http bool
+	// Scheme is the URI scheme the driver was created with, reported in TLS errors
+	Scheme string
+	// UnsupportedFeature optionally remembers, for FeatureErrorTTL, the error of a connection failing because the
+	// server does not support the configuration, e.g. Notifications, so that new connections to the same server fail
+	// right away instead of reaching it again
+	UnsupportedFeature *FeatureError
+}
+
+// FeatureErrorTTL is how long FeatureError remembers that a server does not support a feature.
+// Servers can be upgraded in the meantime, e.g. during the rolling upgrade of a cluster.
+const FeatureErrorTTL = time.Minute
+
+// FeatureError holds the errors of features not supported by servers, by server address, see
+// Connector.UnsupportedFeature
+type FeatureError struct {
+	// Clock tells when the errors expire, clock.System() when nil
+	Clock clock.Clock
+	mut   sync.Mutex
+	errs  map[string]featureError
+}
+
+type featureError struct {
+	err     error
+	expires time.Time
+}
+
+func (f *FeatureError) get(address string) error {
+	if f == nil {
+		return nil
+	}
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	remembered, found := f.errs[address]
+	if !found {
+		return nil
+	}
+	if !f.now().Before(remembered.expires) {
+		delete(f.errs, address)
+		return nil
+	}
+	return remembered.err
+}
+
+func (f *FeatureError) remember(address string, err error) {
+	var featureErr *neo4jdb.FeatureNotSupportedError
+	if f == nil || !errors.As(err, &featureErr) {
+		return
+	}
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	if f.errs == nil {
+		f.errs = make(map[string]featureError)
+	}
+	f.errs[address] = featureError{err: err, expires: f.now().Add(FeatureErrorTTL)}
+}
+
+func (f *FeatureError) now() time.Time {
+	if f.Clock == nil {
+		return time.Now()
+	}
+	return f.Clock.Now()
 }
 
 func (c Connector) Connect(ctx context.Context, address string, boltLogger log.BoltLogger) (db.Connection, error) {
-	dialer := net.Dialer{Timeout: c.DialTimeout}
-	if !c.SocketKeepAlive {
-		dialer.KeepAlive = -1 * time.Second // Turns keep-alive off
+	if err := c.UnsupportedFeature.get(address); err != nil {
+		return nil, err
 	}
+	conn, err := c.connect(ctx, address, boltLogger)
+	c.UnsupportedFeature.remember(address, err)
+	return conn, err
+}
 
+func (c Connector) connect(ctx context.Context, address string, boltLogger log.BoltLogger) (db.Connection, error) {
+	auth, err := c.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.Http {
+		return c.connectHttp(ctx, address, auth, boltLogger)
+	}
+	dialer := c.dialer()
+
+	timings := db.AcquisitionTimingsFrom(ctx)
+	dialStart := time.Now()
 	conn, err := dialer.DialContext(ctx, c.Network, address)
+	timings.Track(db.DialPhase, dialStart)
 	if err != nil {
 		return nil, err
 	}
+	handshakeStart := time.Now()
+	defer timings.Track(db.HandshakePhase, handshakeStart)
+	if c.OnBytesReceived != nil {
+		conn = &countingConn{Conn: conn, onRead: c.OnBytesReceived}
+	}
 
 	// TLS not requested, perform Bolt handshake
 	if c.SkipEncryption {
-		return bolt.Connect(ctx, address, conn, c.Auth, c.UserAgent, c.RoutingContext, c.Log, boltLogger)
+		return c.configure(c.boltConnect(ctx, address, conn, auth, boltLogger))
 	}
 
 	// TLS requested, continue with handshake
@@ -79,10 +193,102 @@ func (c Connector) Connect(ctx context.Context, address string, boltLogger log.B
 			err = errors.New("remote end closed the connection, check that TLS is enabled on the server")
 		}
 		conn.Close()
-		return nil, &TlsError{inner: err}
+		return nil, &TlsError{inner: err, details: c.describeVerificationError(err)}
 	}
 	// Perform Bolt handshake
-	return bolt.Connect(ctx, address, tlsConn, c.Auth, c.UserAgent, c.RoutingContext, c.Log, boltLogger)
+	return c.configure(c.boltConnect(ctx, address, tlsConn, auth, boltLogger))
+}
+
+// boltConnect performs the Bolt handshake over the given network connection, which is closed if it fails
+func (c Connector) boltConnect(ctx context.Context, address string, conn net.Conn, auth map[string]any, boltLogger log.BoltLogger) (db.Connection, error) {
+	connection, err := bolt.Connect(ctx, address, c.injectFaults(conn), auth, c.UserAgent, c.RoutingContext, c.Notifications, c.Log, boltLogger)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return connection, nil
+}
+
+// connectHttp creates a connection to the HTTP Query API, with its own HTTP transport so that connections of the pool
+// map to network connections like Bolt connections do
+func (c Connector) connectHttp(ctx context.Context, address string, auth map[string]any, boltLogger log.BoltLogger) (db.Connection, error) {
+	dialer := c.dialer()
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, addr string) (net.Conn, error) {
+			conn, err := dialer.DialContext(ctx, c.Network, addr)
+			if err != nil || c.OnBytesReceived == nil {
+				return conn, err
+			}
+			return &countingConn{Conn: conn, onRead: c.OnBytesReceived}, nil
+		},
+		MaxIdleConnsPerHost: 1,
+	}
+	scheme := "http"
+	if !c.SkipEncryption {
+		serverName, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = c.tlsConfig(serverName)
+		scheme = "https"
+	}
+	client := &http.Client{Transport: transport}
+	return c.configure(httpquery.Connect(ctx, address, scheme+"://"+address, client, auth, c.UserAgent, c.Notifications, c.Log, boltLogger))
+}
+
+// auth returns the token of the session the connection is created for, or the current token of the driver
+func (c Connector) auth(ctx context.Context) (map[string]any, error) {
+	if auth, ok := db.SessionAuthFrom(ctx); ok {
+		return auth, nil
+	}
+	return c.Auth(ctx)
+}
+
+// Dialer establishes network connections
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+func (c Connector) dialer() Dialer {
+	dialer := c.directDialer()
+	if c.Proxy != nil {
+		return &proxyDialer{proxy: c.Proxy, noProxy: c.NoProxy, forward: dialer}
+	}
+	return dialer
+}
+
+func (c Connector) directDialer() Dialer {
+	if c.Dialer != nil {
+		return c.Dialer
+	}
+	dialer := &net.Dialer{Timeout: c.DialTimeout}
+	if !c.SocketKeepAlive {
+		dialer.KeepAlive = -1 * time.Second // Turns keep-alive off
+	}
+	return dialer
+}
+
+func (c Connector) configure(conn db.Connection, err error) (db.Connection, error) {
+	if err != nil {
+		return nil, err
+	}
+	if reporter, ok := conn.(db.InvalidValueReporter); ok && c.ReportInvalidValues {
+		reporter.ReportInvalidValues()
+	}
+	if decoder, ok := conn.(db.UnknownValueDecoder); ok && c.DecodeUnknownValues {
+		decoder.DecodeUnknownValues()
+	}
+	if provider, ok := conn.(db.RawRecordsProvider); ok && c.RawRecords {
+		provider.ProvideRawRecords()
+	}
+	return conn, nil
+}
+
+func (c Connector) injectFaults(conn net.Conn) net.Conn {
+	if c.FaultInjector == nil {
+		return conn
+	}
+	return faults.Wrap(conn, c.FaultInjector)
 }
 
 func (c Connector) tlsConfig(serverName string) *tls.Config {
@@ -90,24 +296,51 @@ func (c Connector) tlsConfig(serverName string) *tls.Config {
 	if c.TlsConfig == nil {
 		config = &tls.Config{RootCAs: c.RootCAs}
 	} else {
-		config = c.TlsConfig
+		// the caller's config may be shared, it must not be modified
+		config = c.TlsConfig.Clone()
 	}
 	if config.MinVersion == 0 {
 		config.MinVersion = tls.VersionTLS12
 	}
 	config.InsecureSkipVerify = c.SkipVerify
 	config.ServerName = serverName
+	if c.ClientCertificateProvider != nil {
+		provider := c.ClientCertificateProvider
+		config.Certificates = nil
+		config.GetClientCertificate = func(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return provider.GetClientCertificate(info.Context())
+		}
+	}
 	return config
 }
 
+// countingConn reports the number of bytes read from the underlying connection
+type countingConn struct {
+	net.Conn
+	onRead func(n int)
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.onRead(n)
+	}
+	return n, err
+}
+
 // TlsError encapsulates all errors related to TLS connection creation
 // This is needed since the tls package does not provide a common error type
 // à la net.Error, and a common type is needed to properly classify the error
 // for Testkit
 type TlsError struct {
 	inner error
+	// details describe the failed verification of the server certificate, if any
+	details string
 }
 
 func (e *TlsError) Error() string {
+	if e.details != "" {
+		return fmt.Sprintf("%s (%s)", e.inner, e.details)
+	}
 	return e.inner.Error()
 }
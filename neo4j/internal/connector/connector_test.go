@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connector
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+type dialerFake struct {
+	network string
+	address string
+	conn    net.Conn
+	err     error
+	dials   int
+}
+
+func (d *dialerFake) DialContext(_ context.Context, network, address string) (net.Conn, error) {
+	d.network = network
+	d.address = address
+	d.dials++
+	return d.conn, d.err
+}
+
+// bolt44Server accepts the Bolt handshake with version 4.4, and reports whether the connection is then closed
+func bolt44Server(conn net.Conn) <-chan bool {
+	closed := make(chan bool, 1)
+	go func() {
+		handshake := make([]byte, 20)
+		if _, err := io.ReadFull(conn, handshake); err != nil {
+			closed <- false
+			return
+		}
+		if _, err := conn.Write([]byte{0x00, 0x00, 0x04, 0x04}); err != nil {
+			closed <- false
+			return
+		}
+		_, err := conn.Read(make([]byte, 1))
+		closed <- err == io.EOF
+	}()
+	return closed
+}
+
+func noAuth(context.Context) (map[string]any, error) {
+	return map[string]any{"scheme": "none"}, nil
+}
+
+func TestConnector(outer *testing.T) {
+	outer.Run("Dials with the custom dialer", func(t *testing.T) {
+		dialErr := errors.New("no route to host")
+		dialer := &dialerFake{err: dialErr}
+		connector := Connector{
+			Network:        "tcp",
+			SkipEncryption: true,
+			Auth:           noAuth,
+			Dialer:         dialer,
+		}
+
+		_, err := connector.Connect(context.Background(), "localhost:7687", nil)
+
+		AssertDeepEquals(t, err, dialErr)
+		AssertStringEqual(t, dialer.network, "tcp")
+		AssertStringEqual(t, dialer.address, "localhost:7687")
+	})
+
+	outer.Run("Closes the connection when the server does not support the notification filters", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer server.Close()
+		closed := bolt44Server(server)
+		dialer := &dialerFake{conn: client}
+		connector := Connector{
+			Network:            "tcp",
+			SkipEncryption:     true,
+			Auth:               noAuth,
+			Dialer:             dialer,
+			Notifications:      idb.NotificationConfig{MinSeverity: "WARNING"},
+			UnsupportedFeature: &FeatureError{},
+		}
+
+		_, err := connector.Connect(context.Background(), "localhost:7687", nil)
+
+		AssertSameType(t, err, &db.FeatureNotSupportedError{})
+		AssertTrue(t, <-closed)
+
+		_, err2 := connector.Connect(context.Background(), "localhost:7687", nil)
+
+		AssertDeepEquals(t, err2, err)
+		AssertIntEqual(t, dialer.dials, 1)
+	})
+
+	outer.Run("Remembers unsupported features per server for a limited time", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer server.Close()
+		bolt44Server(server)
+		dialer := &dialerFake{conn: client}
+		fakeClock := clock.NewFake(time.Now())
+		connector := Connector{
+			Network:            "tcp",
+			SkipEncryption:     true,
+			Auth:               noAuth,
+			Dialer:             dialer,
+			Notifications:      idb.NotificationConfig{MinSeverity: "WARNING"},
+			UnsupportedFeature: &FeatureError{Clock: fakeClock},
+		}
+		_, err := connector.Connect(context.Background(), "old:7687", nil)
+		AssertSameType(t, err, &db.FeatureNotSupportedError{})
+		dialer.conn = nil
+		dialer.err = errors.New("connection refused")
+
+		_, err = connector.Connect(context.Background(), "new:7687", nil)
+
+		AssertErrorMessageContains(t, err, "connection refused")
+		AssertIntEqual(t, dialer.dials, 2)
+
+		fakeClock.Advance(FeatureErrorTTL)
+		_, err = connector.Connect(context.Background(), "old:7687", nil)
+
+		AssertErrorMessageContains(t, err, "connection refused")
+		AssertIntEqual(t, dialer.dials, 3)
+	})
+
+	outer.Run("Does not modify the custom TLS config", func(t *testing.T) {
+		custom := &tls.Config{}
+		connector := Connector{TlsConfig: custom, SkipVerify: true}
+
+		config := connector.tlsConfig("localhost")
+
+		AssertStringEqual(t, config.ServerName, "localhost")
+		AssertTrue(t, config.InsecureSkipVerify)
+		AssertIntEqual(t, int(config.MinVersion), tls.VersionTLS12)
+		AssertStringEqual(t, custom.ServerName, "")
+		AssertFalse(t, custom.InsecureSkipVerify)
+		AssertIntEqual(t, int(custom.MinVersion), 0)
+	})
+}
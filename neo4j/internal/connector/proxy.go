@@ -0,0 +1,312 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connector
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProxyError is returned when a connection cannot be established through the configured proxy
+type ProxyError struct {
+	Proxy string
+	inner error
+}
+
+func (e *ProxyError) Error() string {
+	return fmt.Sprintf("could not connect through proxy %s: %s", e.Proxy, e.inner)
+}
+
+func (e *ProxyError) Unwrap() error {
+	return e.inner
+}
+
+// ValidateProxyURL checks that connections can be established through the given proxy
+func ValidateProxyURL(proxy *url.URL) error {
+	switch proxy.Scheme {
+	case "socks5", "socks5h", "http":
+	default:
+		return fmt.Errorf("proxy scheme %q is not supported, use socks5, socks5h or http", proxy.Scheme)
+	}
+	if proxy.Hostname() == "" {
+		return fmt.Errorf("proxy URL %q has no host", proxy.Redacted())
+	}
+	return nil
+}
+
+// ProxyFromEnvironment returns the proxy set in the ALL_PROXY (or all_proxy) environment variable, if any, along with
+// the hosts excluded by the NO_PROXY (or no_proxy) environment variable
+func ProxyFromEnvironment() (*url.URL, []string, error) {
+	rawProxy := getenv("ALL_PROXY", "all_proxy")
+	if rawProxy == "" {
+		return nil, nil, nil
+	}
+	proxy, err := url.Parse(rawProxy)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ValidateProxyURL(proxy); err != nil {
+		return nil, nil, err
+	}
+	var noProxy []string
+	for _, host := range strings.Split(getenv("NO_PROXY", "no_proxy"), ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			noProxy = append(noProxy, strings.ToLower(host))
+		}
+	}
+	return proxy, noProxy, nil
+}
+
+func getenv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// proxyDialer establishes connections through a SOCKS5 or HTTP CONNECT proxy, using forward to reach the proxy
+type proxyDialer struct {
+	proxy   *url.URL
+	noProxy []string
+	forward Dialer
+}
+
+func (d *proxyDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if network != "tcp" || d.bypass(address) {
+		return d.forward.DialContext(ctx, network, address)
+	}
+	proxyAddress := d.proxy.Host
+	if d.proxy.Port() == "" {
+		proxyAddress = net.JoinHostPort(d.proxy.Hostname(), d.defaultPort())
+	}
+	conn, err := d.forward.DialContext(ctx, network, proxyAddress)
+	if err != nil {
+		return nil, &ProxyError{Proxy: d.proxy.Redacted(), inner: err}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	tunnel := conn
+	if d.proxy.Scheme == "http" {
+		tunnel, err = d.connectHttp(conn, address)
+	} else {
+		err = d.connectSocks5(conn, address)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, &ProxyError{Proxy: d.proxy.Redacted(), inner: err}
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return tunnel, nil
+}
+
+// bypass returns true when the host of the given address is excluded by NO_PROXY
+func (d *proxyDialer) bypass(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	host = strings.ToLower(host)
+	for _, excluded := range d.noProxy {
+		if excluded == "*" || host == excluded || strings.HasSuffix(host, "."+strings.TrimPrefix(excluded, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *proxyDialer) defaultPort() string {
+	if d.proxy.Scheme == "http" {
+		return "80"
+	}
+	return "1080"
+}
+
+// connectHttp opens a tunnel to the given address with an HTTP CONNECT request
+func (d *proxyDialer) connectHttp(conn net.Conn, address string) (net.Conn, error) {
+	request := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: http.Header{},
+	}
+	if user := d.proxy.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		request.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := request.Write(conn); err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, request)
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy responded to CONNECT with %s", response.Status)
+	}
+	if reader.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn reads the bytes buffered while reading the response of the proxy before reading from the connection
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+const (
+	socks5Version          = 0x05
+	socks5NoAuth           = 0x00
+	socks5UserPassword     = 0x02
+	socks5NoAcceptable     = 0xFF
+	socks5Connect          = 0x01
+	socks5AddressIPv4      = 0x01
+	socks5AddressName      = 0x03
+	socks5AddressIPv6      = 0x04
+	socks5UserPasswordAuth = 0x01
+)
+
+// connectSocks5 opens a tunnel to the given address with the SOCKS5 protocol (RFC 1928 and RFC 1929)
+func (d *proxyDialer) connectSocks5(conn net.Conn, address string) error {
+	host, rawPort, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.ParseUint(rawPort, 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid port %q", rawPort)
+	}
+
+	// Method negotiation
+	methods := []byte{socks5NoAuth}
+	if d.proxy.User != nil {
+		methods = append(methods, socks5UserPassword)
+	}
+	if _, err := conn.Write(append([]byte{socks5Version, byte(len(methods))}, methods...)); err != nil {
+		return err
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[0] != socks5Version {
+		return fmt.Errorf("unexpected SOCKS version %d", reply[0])
+	}
+	switch reply[1] {
+	case socks5NoAuth:
+	case socks5UserPassword:
+		if err := d.authenticateSocks5(conn); err != nil {
+			return err
+		}
+	case socks5NoAcceptable:
+		return fmt.Errorf("no acceptable SOCKS authentication method")
+	default:
+		return fmt.Errorf("unsupported SOCKS authentication method %d", reply[1])
+	}
+
+	// Connection request
+	request := []byte{socks5Version, socks5Connect, 0x00}
+	if ip := net.ParseIP(host); ip == nil {
+		if len(host) > 255 {
+			return fmt.Errorf("host name %q is too long", host)
+		}
+		request = append(request, socks5AddressName, byte(len(host)))
+		request = append(request, host...)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		request = append(request, socks5AddressIPv4)
+		request = append(request, ip4...)
+	} else {
+		request = append(request, socks5AddressIPv6)
+		request = append(request, ip.To16()...)
+	}
+	request = append(request, byte(port>>8), byte(port))
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return err
+	}
+	if header[1] != 0x00 {
+		return fmt.Errorf("SOCKS connection request failed with code %d", header[1])
+	}
+	// Skip the bound address and port
+	var boundLength int
+	switch header[3] {
+	case socks5AddressIPv4:
+		boundLength = net.IPv4len
+	case socks5AddressIPv6:
+		boundLength = net.IPv6len
+	case socks5AddressName:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return err
+		}
+		boundLength = int(length[0])
+	default:
+		return fmt.Errorf("unsupported SOCKS address type %d", header[3])
+	}
+	_, err = io.ReadFull(conn, make([]byte, boundLength+2))
+	return err
+}
+
+func (d *proxyDialer) authenticateSocks5(conn net.Conn) error {
+	username := d.proxy.User.Username()
+	password, _ := d.proxy.User.Password()
+	if len(username) > 255 || len(password) > 255 {
+		return fmt.Errorf("SOCKS credentials are too long")
+	}
+	request := []byte{socks5UserPasswordAuth, byte(len(username))}
+	request = append(request, username...)
+	request = append(request, byte(len(password)))
+	request = append(request, password...)
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[1] != 0x00 {
+		return fmt.Errorf("SOCKS authentication failed")
+	}
+	return nil
+}
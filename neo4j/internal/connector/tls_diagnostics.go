@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connector
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// describeVerificationError returns actionable details about the given TLS handshake error when it is caused by the
+// verification of the server certificate, or an empty string otherwise
+func (c Connector) describeVerificationError(err error) string {
+	var (
+		unknownAuthorityErr x509.UnknownAuthorityError
+		hostnameErr         x509.HostnameError
+		invalidErr          x509.CertificateInvalidError
+		certificate         *x509.Certificate
+		hint                string
+	)
+	switch {
+	case errors.As(err, &unknownAuthorityErr):
+		certificate = unknownAuthorityErr.Cert
+		if c.hasCustomRootCAs() {
+			hint = "the server certificate is not signed by the configured CA, check that it is the CA of the server"
+		} else {
+			hint = "the server certificate is not signed by a CA trusted by the system, configure the CA of the " +
+				"server in Config.TlsConfig or use the +ssc scheme variant for self-signed certificates"
+		}
+	case errors.As(err, &hostnameErr):
+		certificate = hostnameErr.Certificate
+		hint = fmt.Sprintf("the server certificate is not valid for %q, connect with a name covered by the "+
+			"certificate or reissue the certificate with that name", hostnameErr.Host)
+	case errors.As(err, &invalidErr):
+		certificate = invalidErr.Cert
+		if invalidErr.Reason == x509.Expired {
+			hint = "the server certificate is expired or not yet valid, renew it or check the clock of this machine"
+		} else {
+			hint = "the server certificate is not valid, check that it can be used to authenticate a server"
+		}
+	default:
+		return ""
+	}
+	details := []string{
+		fmt.Sprintf("scheme: %s", c.schemeOrUnknown()),
+		fmt.Sprintf("trusted CAs: %s", c.trustedCAs()),
+	}
+	if certificate != nil {
+		details = append(details, fmt.Sprintf("server certificate: subject %q, issuer %q, SANs %v",
+			certificate.Subject, certificate.Issuer, subjectAlternativeNames(certificate)))
+	}
+	details = append(details, fmt.Sprintf("hint: %s", hint))
+	return strings.Join(details, "; ")
+}
+
+func (c Connector) hasCustomRootCAs() bool {
+	return c.RootCAs != nil || (c.TlsConfig != nil && c.TlsConfig.RootCAs != nil)
+}
+
+func (c Connector) trustedCAs() string {
+	if c.hasCustomRootCAs() {
+		return "custom"
+	}
+	return "system"
+}
+
+func (c Connector) schemeOrUnknown() string {
+	if c.Scheme == "" {
+		return "unknown"
+	}
+	return c.Scheme
+}
+
+func subjectAlternativeNames(certificate *x509.Certificate) []string {
+	names := make([]string, 0, len(certificate.DNSNames)+len(certificate.IPAddresses))
+	names = append(names, certificate.DNSNames...)
+	for _, ip := range certificate.IPAddresses {
+		names = append(names, ip.String())
+	}
+	return names
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connector
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestDescribeVerificationError(outer *testing.T) {
+	certificate := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "db.internal"},
+		Issuer:      pkix.Name{CommonName: "Internal CA"},
+		DNSNames:    []string{"db.internal"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+	}
+
+	outer.Run("describes unknown authorities", func(t *testing.T) {
+		connector := Connector{Scheme: "neo4j+s"}
+
+		details := connector.describeVerificationError(x509.UnknownAuthorityError{Cert: certificate})
+
+		AssertStringEqual(t, details, `scheme: neo4j+s; trusted CAs: system; `+
+			`server certificate: subject "CN=db.internal", issuer "CN=Internal CA", SANs [db.internal 10.0.0.1]; `+
+			`hint: the server certificate is not signed by a CA trusted by the system, configure the CA of the `+
+			`server in Config.TlsConfig or use the +ssc scheme variant for self-signed certificates`)
+	})
+
+	outer.Run("describes unknown authorities with custom CAs", func(t *testing.T) {
+		connector := Connector{Scheme: "bolt+s", TlsConfig: &tls.Config{RootCAs: x509.NewCertPool()}}
+
+		details := connector.describeVerificationError(
+			fmt.Errorf("handshake: %w", x509.UnknownAuthorityError{Cert: certificate}))
+
+		AssertStringContain(t, details, "trusted CAs: custom")
+		AssertStringContain(t, details, "not signed by the configured CA")
+	})
+
+	outer.Run("describes host name mismatches", func(t *testing.T) {
+		connector := Connector{Scheme: "neo4j+s"}
+
+		details := connector.describeVerificationError(x509.HostnameError{Certificate: certificate, Host: "db"})
+
+		AssertStringContain(t, details, `the server certificate is not valid for "db"`)
+	})
+
+	outer.Run("describes expired certificates", func(t *testing.T) {
+		connector := Connector{Scheme: "neo4j+s"}
+
+		details := connector.describeVerificationError(
+			x509.CertificateInvalidError{Cert: certificate, Reason: x509.Expired})
+
+		AssertStringContain(t, details, "expired or not yet valid")
+	})
+
+	outer.Run("does not describe other errors", func(t *testing.T) {
+		connector := Connector{Scheme: "neo4j+s"}
+
+		details := connector.describeVerificationError(errors.New("remote error: tls: handshake failure"))
+
+		AssertEmptyString(t, details)
+	})
+
+	outer.Run("includes details in TLS errors", func(t *testing.T) {
+		err := &TlsError{inner: errors.New("x509: certificate signed by unknown authority"), details: "scheme: neo4j+s"}
+
+		AssertStringEqual(t, err.Error(), "x509: certificate signed by unknown authority (scheme: neo4j+s)")
+	})
+}
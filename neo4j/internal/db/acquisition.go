@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AcquisitionPhase is a step of the acquisition of a connection
+type AcquisitionPhase int
+
+const (
+	HomeDatabaseResolutionPhase AcquisitionPhase = iota
+	RoutingPhase
+	// BorrowPhase spans the whole connection borrowing from the pool, DialPhase and HandshakePhase included
+	BorrowPhase
+	DialPhase
+	HandshakePhase
+	acquisitionPhaseCount
+)
+
+// AcquisitionTimings records the time spent in each phase of a connection acquisition.
+// A nil *AcquisitionTimings is valid and records nothing.
+type AcquisitionTimings struct {
+	durations [acquisitionPhaseCount]time.Duration
+}
+
+type acquisitionTimingsKey struct{}
+
+// WithAcquisitionTimings returns a context carrying the given timings, so that the layers involved in the connection
+// acquisition (routing, pool, connector) can record the time they spend
+func WithAcquisitionTimings(ctx context.Context, timings *AcquisitionTimings) context.Context {
+	return context.WithValue(ctx, acquisitionTimingsKey{}, timings)
+}
+
+// AcquisitionTimingsFrom returns the timings carried by the given context, nil if there are none
+func AcquisitionTimingsFrom(ctx context.Context) *AcquisitionTimings {
+	timings, _ := ctx.Value(acquisitionTimingsKey{}).(*AcquisitionTimings)
+	return timings
+}
+
+// Track adds the time elapsed since start to the given phase
+func (t *AcquisitionTimings) Track(phase AcquisitionPhase, start time.Time) {
+	if t == nil {
+		return
+	}
+	t.durations[phase] += time.Since(start)
+}
+
+// Duration returns the time spent in the given phase so far
+func (t *AcquisitionTimings) Duration(phase AcquisitionPhase) time.Duration {
+	if t == nil {
+		return 0
+	}
+	return t.durations[phase]
+}
+
+// PoolWait returns the time spent borrowing from the pool, excluding the time spent establishing new connections
+func (t *AcquisitionTimings) PoolWait() time.Duration {
+	wait := t.Duration(BorrowPhase) - t.Duration(DialPhase) - t.Duration(HandshakePhase)
+	if wait < 0 {
+		// the phases are measured separately, a dial or handshake still in flight can outlast the borrow
+		return 0
+	}
+	return wait
+}
+
+func (t *AcquisitionTimings) String() string {
+	return fmt.Sprintf("home database resolution: %s, routing: %s, pool wait: %s, dial: %s, handshake: %s",
+		t.Duration(HomeDatabaseResolutionPhase),
+		t.Duration(RoutingPhase),
+		t.PoolWait(),
+		t.Duration(DialPhase),
+		t.Duration(HandshakePhase))
+}
@@ -22,6 +22,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 	"math"
@@ -53,13 +54,36 @@ type TxConfig struct {
 	Timeout          time.Duration
 	ImpersonatedUser string
 	Meta             map[string]any
+	Notifications    NotificationConfig
+}
+
+// NotificationConfig defines the notifications the server sends, the zero value lets the server decide.
+// A nil DisabledCategories lets the server decide, while an empty one enables all categories.
+type NotificationConfig struct {
+	MinSeverity        string
+	DisabledCategories []string
+}
+
+// IsDefault returns true if the configuration lets the server decide which notifications to send
+func (n NotificationConfig) IsDefault() bool {
+	return n.MinSeverity == "" && n.DisabledCategories == nil
+}
+
+// ToMeta adds the configuration to the metadata of a HELLO, BEGIN or RUN message
+func (n NotificationConfig) ToMeta(meta map[string]any) {
+	if n.MinSeverity != "" {
+		meta["notifications_minimum_severity"] = n.MinSeverity
+	}
+	if n.DisabledCategories != nil {
+		meta["notifications_disabled_categories"] = n.DisabledCategories
+	}
 }
 
 const DefaultTxConfigTimeout = math.MinInt
 
 // Connection defines an abstract database server connection.
 type Connection interface {
-	Connect(ctx context.Context, minor int, auth map[string]any, userAgent string, routingContext map[string]string) error
+	Connect(ctx context.Context, minor int, auth map[string]any, userAgent string, routingContext map[string]string, notificationConfig NotificationConfig) error
 
 	TxBegin(ctx context.Context, txConfig TxConfig) (TxHandle, error)
 	TxRollback(ctx context.Context, tx TxHandle) error
@@ -130,6 +154,13 @@ type RoutingTable struct {
 	Writers      []string
 }
 
+// RoutingTableState describes when a cached routing table was fetched and when it expires.
+type RoutingTableState struct {
+	DatabaseName string
+	FetchedAt    time.Time
+	ExpiresAt    time.Time
+}
+
 // Marker for using the default database instance.
 const DefaultDatabase = ""
 
@@ -140,3 +171,78 @@ type DatabaseSelector interface {
 	// databases without a reset in-between.
 	SelectDatabase(database string)
 }
+
+// Pipeliner is implemented by database server connections that can send several auto-commit commands at once,
+// before receiving their responses, which saves a network round trip per command.
+type Pipeliner interface {
+	// RunPipelined runs the given commands as independent auto-commit transactions and fully buffers their results.
+	// The returned streams are in the order of the commands, up to and including the first failing one, if any.
+	// Commands following a failing command are not executed.
+	RunPipelined(ctx context.Context, cmds []Command, txConfig TxConfig) ([]StreamHandle, error)
+}
+
+// TerminationNotifier is implemented by database server connections that can tell whether the server notified that
+// it is terminating, in which case the connection should be rotated rather than reused.
+type TerminationNotifier interface {
+	TerminationNotified() bool
+}
+
+// InvalidValueReporter is implemented by database server connections that can report the values they fail to hydrate,
+// like structures with an unknown tag, as dbtype.InvalidValue instead of failing the whole stream.
+type InvalidValueReporter interface {
+	ReportInvalidValues()
+}
+
+// UnknownValueDecoder is implemented by database server connections that can decode structures with an unknown tag
+// as dbtype.UnknownValue instead of failing the whole stream.
+type UnknownValueDecoder interface {
+	DecodeUnknownValues()
+}
+
+// RawRecordsProvider is implemented by database server connections that can provide records as their packstream
+// encoded values, without hydrating them.
+type RawRecordsProvider interface {
+	ProvideRawRecords()
+}
+
+// BytesReceivedCounter is implemented by database server connections that count the bytes of the messages they
+// receive.
+type BytesReceivedCounter interface {
+	BytesReceived() int64
+}
+
+// LastErrorReporter is implemented by database server connections that remember the last error they failed with,
+// even after being reset.
+type LastErrorReporter interface {
+	LastError() error
+}
+
+// ReAuthenticator is implemented by database server connections that keep track of the token they are authenticated
+// with, so that pooled connections can be re-authenticated when the token changes.
+type ReAuthenticator interface {
+	// AuthToken returns the token the connection is currently authenticated with
+	AuthToken() map[string]any
+	// ReAuth authenticates the connection with the given token.
+	// ErrReAuthNotSupported is returned when the server does not support re-authentication, in which case the
+	// connection should be replaced.
+	ReAuth(ctx context.Context, auth map[string]any) error
+	// SupportsReAuth returns whether ReAuth can succeed, i.e. does not fail with ErrReAuthNotSupported
+	SupportsReAuth() bool
+}
+
+// ErrReAuthNotSupported is returned by ReAuthenticator.ReAuth when the server does not support re-authentication
+var ErrReAuthNotSupported = errors.New("re-authentication is not supported by the server")
+
+type sessionAuthKey struct{}
+
+// WithSessionAuth returns a context carrying the token of a session, so that the pool and the connector authenticate
+// the connections acquired for the session with it instead of the token of the driver
+func WithSessionAuth(ctx context.Context, auth map[string]any) context.Context {
+	return context.WithValue(ctx, sessionAuthKey{}, auth)
+}
+
+// SessionAuthFrom returns the session token carried by the given context, if any
+func SessionAuthFrom(ctx context.Context) (map[string]any, bool) {
+	auth, ok := ctx.Value(sessionAuthKey{}).(map[string]any)
+	return auth, ok
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package faults simulates failures on driver connections, so that applications can test their resilience without
+// a proxy between the driver and the server.
+package faults
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// Bolt message tags of the requests whose responses can be delayed
+const (
+	msgPull    byte = 0x3f
+	msgDiscard byte = 0x2f
+)
+
+// Sizes of the Bolt handshake exchanged before any chunked message
+const (
+	handshakeRequestSize  = 20
+	handshakeResponseSize = 4
+)
+
+// Injector decides which faults to inject on a connection.
+// Messages are counted per connection, starting at 1, and include the messages exchanged when connecting.
+type Injector interface {
+	// DropBefore reports whether the connection should be closed instead of sending the nth client message with
+	// the given tag
+	DropBefore(n int, tag byte) bool
+	// ResponseDelay returns how long to wait before reading the responses following the client message with the
+	// given tag
+	ResponseDelay(tag byte) time.Duration
+	// Corrupt reports whether the first chunk of the nth server message should be corrupted
+	Corrupt(n int) bool
+}
+
+// Rules is an Injector applying the same faults to every connection
+type Rules struct {
+	// DropConnectionAfterMessages closes connections once they have sent that many messages, 0 disables it
+	DropConnectionAfterMessages int
+	// PullResponseDelay delays the responses to PULL and DISCARD requests, 0 disables it
+	PullResponseDelay time.Duration
+	// CorruptChunkOfMessage corrupts the first chunk of the nth message received by connections, 0 disables it
+	CorruptChunkOfMessage int
+}
+
+func (r Rules) DropBefore(n int, _ byte) bool {
+	return r.DropConnectionAfterMessages > 0 && n > r.DropConnectionAfterMessages
+}
+
+func (r Rules) ResponseDelay(tag byte) time.Duration {
+	if tag == msgPull || tag == msgDiscard {
+		return r.PullResponseDelay
+	}
+	return 0
+}
+
+func (r Rules) Corrupt(n int) bool {
+	return r.CorruptChunkOfMessage > 0 && n == r.CorruptChunkOfMessage
+}
+
+// Wrap returns a connection injecting the faults decided by injector in the Bolt traffic of conn.
+// conn must not have exchanged the Bolt handshake yet.
+func Wrap(conn net.Conn, injector Injector) net.Conn {
+	return &faultyConn{
+		Conn:     conn,
+		injector: injector,
+		out:      framing{skip: handshakeRequestSize},
+		in:       framing{skip: handshakeResponseSize},
+	}
+}
+
+type faultyConn struct {
+	net.Conn
+	injector Injector
+	mut      sync.Mutex
+	out      framing
+	in       framing
+	delay    time.Duration
+	dropped  bool
+}
+
+func (c *faultyConn) Write(b []byte) (int, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.dropped {
+		return 0, net.ErrClosed
+	}
+	for i := range b {
+		if !c.out.next(b[i]) {
+			continue
+		}
+		// b[i] starts the payload of a new message: the marker is followed by the tag
+		tag := byte(0)
+		if i+1 < len(b) {
+			tag = b[i+1]
+		}
+		if c.injector.DropBefore(c.out.messages, tag) {
+			// Only send what precedes the chunk header of the dropped message
+			end := i - 2
+			if end < 0 {
+				end = 0
+			}
+			n, err := c.Conn.Write(b[:end])
+			c.dropped = true
+			_ = c.Conn.Close()
+			if err != nil {
+				return n, err
+			}
+			return n, net.ErrClosed
+		}
+		if delay := c.injector.ResponseDelay(tag); delay > c.delay {
+			c.delay = delay
+		}
+	}
+	return c.Conn.Write(b)
+}
+
+func (c *faultyConn) Read(b []byte) (int, error) {
+	c.mut.Lock()
+	delay := c.delay
+	c.delay = 0
+	c.mut.Unlock()
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	n, err := c.Conn.Read(b)
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	for i := 0; i < n; i++ {
+		if c.in.next(b[i]) && c.injector.Corrupt(c.in.messages) {
+			// Not a packstream structure anymore, the message cannot be hydrated
+			b[i] = 0x00
+		}
+	}
+	return n, err
+}
+
+// framing follows the chunk framing of Bolt messages, one byte at a time
+type framing struct {
+	skip      int
+	header    []byte
+	remaining int
+	inMessage bool
+	starting  bool
+	messages  int
+}
+
+// next consumes a byte of the stream and reports whether it is the first byte of a new message
+func (f *framing) next(b byte) bool {
+	if f.skip > 0 {
+		f.skip--
+		return false
+	}
+	if f.remaining > 0 {
+		f.remaining--
+		first := f.starting
+		f.starting = false
+		return first
+	}
+	f.header = append(f.header, b)
+	if len(f.header) < 2 {
+		return false
+	}
+	size := int(f.header[0])<<8 | int(f.header[1])
+	f.header = f.header[:0]
+	if size == 0 {
+		// End of message marker, or no-op chunk between messages
+		f.inMessage = false
+		return false
+	}
+	f.remaining = size
+	if !f.inMessage {
+		f.inMessage = true
+		f.starting = true
+		f.messages++
+	}
+	return false
+}
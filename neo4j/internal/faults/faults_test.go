@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package faults_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/faults"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestFaultyConnection(outer *testing.T) {
+	handshake := make([]byte, 20)
+	handshakeResponse := []byte{0x00, 0x00, 0x00, 0x05}
+	hello := message(0x01)
+	run := message(0x10)
+	pull := message(0x3f)
+	success := message(0x70)
+	record := message(0x71)
+
+	outer.Run("drops the connection after the configured number of messages", func(t *testing.T) {
+		client, server := net.Pipe()
+		conn := faults.Wrap(client, faults.Rules{DropConnectionAfterMessages: 2})
+		received := make(chan []byte)
+		go func() {
+			data, _ := io.ReadAll(server)
+			received <- data
+		}()
+
+		_, err := conn.Write(concat(handshake, hello, run, pull))
+
+		AssertTrue(t, errors.Is(err, net.ErrClosed))
+		AssertDeepEquals(t, <-received, concat(handshake, hello, run))
+		_, err = conn.Write(pull)
+		AssertTrue(t, errors.Is(err, net.ErrClosed))
+	})
+
+	outer.Run("delays the responses following PULL requests", func(t *testing.T) {
+		delay := 50 * time.Millisecond
+		client, server := net.Pipe()
+		defer server.Close()
+		conn := faults.Wrap(client, faults.Rules{PullResponseDelay: delay})
+		go func() {
+			buf := make([]byte, 1024)
+			for {
+				n, err := server.Read(buf)
+				if err != nil {
+					return
+				}
+				if _, err = server.Write(buf[:n]); err != nil {
+					return
+				}
+			}
+		}()
+		buf := make([]byte, 1024)
+
+		start := time.Now()
+		writeAndRead(t, conn, concat(handshake, run), buf)
+		AssertTrue(t, time.Since(start) < delay)
+
+		start = time.Now()
+		writeAndRead(t, conn, pull, buf)
+		AssertTrue(t, time.Since(start) >= delay)
+
+		start = time.Now()
+		writeAndRead(t, conn, run, buf)
+		AssertTrue(t, time.Since(start) < delay)
+	})
+
+	outer.Run("corrupts the first chunk of the configured message", func(t *testing.T) {
+		client, server := net.Pipe()
+		conn := faults.Wrap(client, faults.Rules{CorruptChunkOfMessage: 2})
+		sent := concat(handshakeResponse, success, record, success)
+		go func() {
+			_, _ = server.Write(sent)
+			_ = server.Close()
+		}()
+
+		received, err := io.ReadAll(conn)
+
+		AssertNoError(t, err)
+		corrupted := concat(record)
+		corrupted[2] = 0x00
+		AssertDeepEquals(t, received, concat(handshakeResponse, success, corrupted, success))
+	})
+}
+
+// message returns a chunked Bolt message with the given tag and no field
+func message(tag byte) []byte {
+	return []byte{0x00, 0x02, 0xb0, tag, 0x00, 0x00}
+}
+
+func concat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+func writeAndRead(t *testing.T, conn net.Conn, data, buf []byte) {
+	t.Helper()
+	_, err := conn.Write(data)
+	AssertNoError(t, err)
+	_, err = io.ReadFull(conn, buf[:len(data)])
+	AssertNoError(t, err)
+}
@@ -0,0 +1,595 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package httpquery implements database server connections on top of the HTTP Query API of Neo4j, as an alternative
+// to Bolt where the Bolt port cannot be reached.
+// Query results are fully received with the response to each query, and transaction timeouts, transaction metadata
+// and routing are not available over HTTP.
+package httpquery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/keyindex"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+)
+
+const (
+	// contentType selects the typed JSON format, which preserves the Cypher types of parameters and values
+	contentType = "application/vnd.neo4j.query"
+	// clusterAffinityHeader routes the requests of a transaction to the cluster member hosting it
+	clusterAffinityHeader = "neo4j-cluster-affinity"
+	// DefaultDatabase is the database queried when none is selected, since the Query API requires a database name
+	DefaultDatabase = "neo4j"
+)
+
+type connection struct {
+	serverName    string
+	baseUrl       string
+	client        *http.Client
+	authorization string
+	auth          map[string]any
+	userAgent     string
+	serverVersion string
+	databaseName  string
+	bookmark      string
+	birthDate     time.Time
+	idleDate      time.Time
+	alive         bool
+	failed        bool
+	// txId is the identifier of the open explicit transaction, if any
+	txId       string
+	txAffinity string
+	txHandle   idb.TxHandle
+	log        log.Logger
+	logId      string
+	boltLogger log.BoltLogger
+}
+
+// Connect creates a connection sending its requests to the Query API at baseUrl through the specified client.
+// The server version is retrieved from the discovery endpoint, which also checks that the server can be reached.
+func Connect(ctx context.Context, serverName, baseUrl string, client *http.Client, auth map[string]any, userAgent string, notificationConfig idb.NotificationConfig, logger log.Logger, boltLogger log.BoltLogger) (idb.Connection, error) {
+	now := time.Now()
+	c := &connection{
+		serverName:   serverName,
+		baseUrl:      strings.TrimSuffix(baseUrl, "/"),
+		client:       client,
+		databaseName: idb.DefaultDatabase,
+		birthDate:    now,
+		idleDate:     now,
+		log:          logger,
+		logId:        log.NewId(),
+		boltLogger:   boltLogger,
+	}
+	if err := c.Connect(ctx, 0, auth, userAgent, nil, notificationConfig); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *connection) Connect(ctx context.Context, _ int, auth map[string]any, userAgent string, _ map[string]string, notificationConfig idb.NotificationConfig) error {
+	if !notificationConfig.IsDefault() {
+		return &db.FeatureNotSupportedError{Server: c.serverName, Feature: "notification filtering", Reason: "not available over HTTP"}
+	}
+	authorization, err := c.authorizationOf(auth)
+	if err != nil {
+		return err
+	}
+	c.authorization = authorization
+	c.auth = auth
+	c.userAgent = userAgent
+
+	var discovery struct {
+		Version string `json:"neo4j_version"`
+	}
+	if err := c.request(ctx, http.MethodGet, "/", "", nil, &discovery); err != nil {
+		return err
+	}
+	c.serverVersion = "Neo4j/" + discovery.Version
+	c.alive = true
+	c.log.Infof(log.Http, c.logId, "Connected to Query API of %s", c.serverName)
+	return nil
+}
+
+func (c *connection) AuthToken() map[string]any {
+	return c.auth
+}
+
+func (c *connection) SupportsReAuth() bool {
+	return true
+}
+
+// ReAuth swaps the credentials sent along with the next requests, every request being authenticated on its own
+func (c *connection) ReAuth(_ context.Context, auth map[string]any) error {
+	authorization, err := c.authorizationOf(auth)
+	if err != nil {
+		return err
+	}
+	c.authorization = authorization
+	c.auth = auth
+	return nil
+}
+
+func (c *connection) authorizationOf(auth map[string]any) (string, error) {
+	credentials, _ := auth["credentials"].(string)
+	switch scheme, _ := auth["scheme"].(string); scheme {
+	case "", "none":
+		return "", nil
+	case "basic":
+		principal, _ := auth["principal"].(string)
+		request := http.Request{Header: http.Header{}}
+		request.SetBasicAuth(principal, credentials)
+		return request.Header.Get("Authorization"), nil
+	case "bearer":
+		return "Bearer " + credentials, nil
+	default:
+		return "", &db.FeatureNotSupportedError{Server: c.serverName, Feature: fmt.Sprintf("%s authentication", scheme), Reason: "not available over HTTP"}
+	}
+}
+
+// queryRequest is the body of the requests running queries and beginning transactions
+type queryRequest struct {
+	Statement        string                `json:"statement,omitempty"`
+	Parameters       map[string]typedValue `json:"parameters,omitempty"`
+	Bookmarks        []string              `json:"bookmarks,omitempty"`
+	AccessMode       string                `json:"accessMode,omitempty"`
+	ImpersonatedUser string                `json:"impersonatedUser,omitempty"`
+	IncludeCounters  bool                  `json:"includeCounters,omitempty"`
+}
+
+// queryResponse is the body of all Query API responses
+type queryResponse struct {
+	Data *struct {
+		Fields []string            `json:"fields"`
+		Values [][]json.RawMessage `json:"values"`
+	} `json:"data"`
+	Counters      map[string]any `json:"counters"`
+	Notifications []notification `json:"notifications"`
+	Bookmarks     []string       `json:"bookmarks"`
+	Transaction   *struct {
+		Id string `json:"id"`
+	} `json:"transaction"`
+	Errors []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type notification struct {
+	Code        string            `json:"code"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Severity    string            `json:"severity"`
+	Category    string            `json:"category"`
+	Position    *db.InputPosition `json:"position"`
+}
+
+func (c *connection) TxBegin(ctx context.Context, txConfig idb.TxConfig) (idb.TxHandle, error) {
+	if c.txId != "" {
+		return 0, fmt.Errorf("transaction already open on %s", c.serverName)
+	}
+	body, err := c.queryRequest(idb.Command{}, txConfig)
+	if err != nil {
+		return 0, err
+	}
+	var response queryResponse
+	affinity, err := c.post(ctx, c.databasePath()+"/tx", "", body, &response)
+	if err != nil {
+		return 0, err
+	}
+	if response.Transaction == nil || response.Transaction.Id == "" {
+		return 0, fmt.Errorf("no transaction identifier received from %s", c.serverName)
+	}
+	c.txId = response.Transaction.Id
+	c.txAffinity = affinity
+	c.txHandle++
+	return c.txHandle, nil
+}
+
+func (c *connection) TxRollback(ctx context.Context, tx idb.TxHandle) error {
+	if err := c.checkTx(tx); err != nil {
+		return err
+	}
+	path := c.txPath()
+	affinity := c.txAffinity
+	c.txId, c.txAffinity = "", ""
+	return c.request(ctx, http.MethodDelete, path, affinity, nil, nil)
+}
+
+func (c *connection) TxCommit(ctx context.Context, tx idb.TxHandle) error {
+	if err := c.checkTx(tx); err != nil {
+		return err
+	}
+	path := c.txPath() + "/commit"
+	affinity := c.txAffinity
+	c.txId, c.txAffinity = "", ""
+	var response queryResponse
+	if _, err := c.post(ctx, path, affinity, queryRequest{}, &response); err != nil {
+		return err
+	}
+	c.retrieveBookmark(response)
+	return nil
+}
+
+func (c *connection) Run(ctx context.Context, cmd idb.Command, txConfig idb.TxConfig) (idb.StreamHandle, error) {
+	if c.txId != "" {
+		return nil, fmt.Errorf("cannot run an auto-commit query while a transaction is open on %s", c.serverName)
+	}
+	body, err := c.queryRequest(cmd, txConfig)
+	if err != nil {
+		return nil, err
+	}
+	var response queryResponse
+	if _, err := c.post(ctx, c.databasePath(), "", body, &response); err != nil {
+		return nil, err
+	}
+	c.retrieveBookmark(response)
+	return c.newStream(response)
+}
+
+func (c *connection) RunTx(ctx context.Context, tx idb.TxHandle, cmd idb.Command) (idb.StreamHandle, error) {
+	if err := c.checkTx(tx); err != nil {
+		return nil, err
+	}
+	params, err := encodeParams(cmd.Params)
+	if err != nil {
+		return nil, err
+	}
+	var response queryResponse
+	body := queryRequest{Statement: cmd.Cypher, Parameters: params, IncludeCounters: true}
+	if _, err := c.post(ctx, c.txPath(), c.txAffinity, body, &response); err != nil {
+		// the server rolls the transaction back when one of its queries fails
+		c.txId, c.txAffinity = "", ""
+		c.failed = true
+		return nil, err
+	}
+	return c.newStream(response)
+}
+
+func (c *connection) queryRequest(cmd idb.Command, txConfig idb.TxConfig) (queryRequest, error) {
+	if txConfig.Timeout != idb.DefaultTxConfigTimeout {
+		return queryRequest{}, &db.FeatureNotSupportedError{Server: c.serverName, Feature: "transaction timeout", Reason: "not available over HTTP"}
+	}
+	if len(txConfig.Meta) > 0 {
+		return queryRequest{}, &db.FeatureNotSupportedError{Server: c.serverName, Feature: "transaction metadata", Reason: "not available over HTTP"}
+	}
+	if !txConfig.Notifications.IsDefault() {
+		return queryRequest{}, &db.FeatureNotSupportedError{Server: c.serverName, Feature: "notification filtering", Reason: "not available over HTTP"}
+	}
+	params, err := encodeParams(cmd.Params)
+	if err != nil {
+		return queryRequest{}, err
+	}
+	accessMode := "WRITE"
+	if txConfig.Mode == idb.ReadMode {
+		accessMode = "READ"
+	}
+	return queryRequest{
+		Statement:        cmd.Cypher,
+		Parameters:       params,
+		Bookmarks:        txConfig.Bookmarks,
+		AccessMode:       accessMode,
+		ImpersonatedUser: txConfig.ImpersonatedUser,
+		IncludeCounters:  cmd.Cypher != "",
+	}, nil
+}
+
+func (c *connection) checkTx(tx idb.TxHandle) error {
+	if c.txId == "" || tx != c.txHandle {
+		return fmt.Errorf("invalid transaction handle")
+	}
+	return nil
+}
+
+func (c *connection) databasePath() string {
+	database := c.databaseName
+	if database == idb.DefaultDatabase {
+		database = DefaultDatabase
+	}
+	return "/db/" + url.PathEscape(database) + "/query/v2"
+}
+
+func (c *connection) txPath() string {
+	return c.databasePath() + "/tx/" + url.PathEscape(c.txId)
+}
+
+func (c *connection) retrieveBookmark(response queryResponse) {
+	if len(response.Bookmarks) > 0 {
+		c.bookmark = response.Bookmarks[0]
+	}
+}
+
+func (c *connection) post(ctx context.Context, path, affinity string, body queryRequest, response *queryResponse) (string, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	return c.send(ctx, http.MethodPost, path, affinity, payload, response)
+}
+
+func (c *connection) request(ctx context.Context, method, path, affinity string, payload []byte, response any) error {
+	_, err := c.send(ctx, method, path, affinity, payload, response)
+	return err
+}
+
+// send sends a request to the Query API and decodes its response
+// The cluster affinity of the response is returned, to be sent along the following requests of transactions.
+func (c *connection) send(ctx context.Context, method, path, affinity string, payload []byte, response any) (string, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, c.baseUrl+path, body)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Accept", contentType)
+	if payload != nil {
+		request.Header.Set("Content-Type", contentType)
+	}
+	if c.authorization != "" {
+		request.Header.Set("Authorization", c.authorization)
+	}
+	if c.userAgent != "" {
+		request.Header.Set("User-Agent", c.userAgent)
+	}
+	if affinity != "" {
+		request.Header.Set(clusterAffinityHeader, affinity)
+	}
+	if c.boltLogger != nil {
+		c.boltLogger.LogClientMessage(c.logId, "%s %s %s", method, path, payload)
+	}
+
+	httpResponse, err := c.client.Do(request)
+	c.idleDate = time.Now()
+	if err != nil {
+		c.alive = false
+		return "", err
+	}
+	defer httpResponse.Body.Close()
+	content, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		c.alive = false
+		return "", err
+	}
+	if c.boltLogger != nil {
+		c.boltLogger.LogServerMessage(c.logId, "%s %s", httpResponse.Status, content)
+	}
+
+	var failure queryResponse
+	if len(content) > 0 && json.Unmarshal(content, &failure) == nil && len(failure.Errors) > 0 {
+		return "", &db.Neo4jError{Code: failure.Errors[0].Code, Msg: failure.Errors[0].Message}
+	}
+	if httpResponse.StatusCode >= http.StatusBadRequest {
+		return "", fmt.Errorf("unexpected response from %s: %s", c.serverName, httpResponse.Status)
+	}
+	if response != nil && len(content) > 0 {
+		if err := json.Unmarshal(content, response); err != nil {
+			return "", err
+		}
+	}
+	return httpResponse.Header.Get(clusterAffinityHeader), nil
+}
+
+// stream holds the fully received result of a query
+type stream struct {
+	keys    []string
+	records []*db.Record
+	summary *db.Summary
+}
+
+func (c *connection) newStream(response queryResponse) (*stream, error) {
+	s := &stream{summary: c.summaryOf(response)}
+	if response.Data == nil {
+		return s, nil
+	}
+	s.keys = response.Data.Fields
+	keyIndex := keyindex.New(s.keys)
+	s.records = make([]*db.Record, len(response.Data.Values))
+	for i, row := range response.Data.Values {
+		values := make([]any, len(row))
+		for j, raw := range row {
+			value, err := decodeValue(raw)
+			if err != nil {
+				return nil, err
+			}
+			values[j] = value
+		}
+		s.records[i] = &db.Record{Keys: s.keys, Values: values}
+		keyindex.Attach(s.records[i], keyIndex)
+	}
+	return s, nil
+}
+
+func (c *connection) summaryOf(response queryResponse) *db.Summary {
+	summary := &db.Summary{
+		ServerName: c.serverName,
+		Agent:      c.serverVersion,
+		Counters:   map[string]int{},
+		Database:   c.databaseName,
+		TFirst:     -1,
+		TLast:      -1,
+	}
+	if len(response.Bookmarks) > 0 {
+		summary.Bookmark = response.Bookmarks[0]
+	}
+	for key, value := range response.Counters {
+		switch v := value.(type) {
+		case bool:
+			b := v
+			switch key {
+			case "containsUpdates":
+				summary.ContainsUpdates = &b
+			case "containsSystemUpdates":
+				summary.ContainsSystemUpdates = &b
+			}
+		case float64:
+			summary.Counters[counterKey(key)] = int(v)
+		}
+	}
+	for _, n := range response.Notifications {
+		summary.Notifications = append(summary.Notifications, db.Notification{
+			Code:        n.Code,
+			Title:       n.Title,
+			Description: n.Description,
+			Position:    n.Position,
+			Severity:    n.Severity,
+			Category:    n.Category,
+		})
+	}
+	return summary
+}
+
+// counterKey converts the camel case counter names of the Query API to the Bolt ones, nodesCreated to nodes-created
+func counterKey(name string) string {
+	var key strings.Builder
+	for _, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			key.WriteByte('-')
+			r += 'a' - 'A'
+		}
+		key.WriteRune(r)
+	}
+	return key.String()
+}
+
+func (c *connection) streamOf(handle idb.StreamHandle) (*stream, error) {
+	s, ok := handle.(*stream)
+	if !ok || s == nil {
+		return nil, fmt.Errorf("invalid stream handle")
+	}
+	return s, nil
+}
+
+func (c *connection) Keys(handle idb.StreamHandle) ([]string, error) {
+	s, err := c.streamOf(handle)
+	if err != nil {
+		return nil, err
+	}
+	return s.keys, nil
+}
+
+func (c *connection) Next(_ context.Context, handle idb.StreamHandle) (*db.Record, *db.Summary, error) {
+	s, err := c.streamOf(handle)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(s.records) == 0 {
+		return nil, s.summary, nil
+	}
+	record := s.records[0]
+	s.records = s.records[1:]
+	return record, nil, nil
+}
+
+func (c *connection) Consume(_ context.Context, handle idb.StreamHandle) (*db.Summary, error) {
+	s, err := c.streamOf(handle)
+	if err != nil {
+		return nil, err
+	}
+	s.records = nil
+	return s.summary, nil
+}
+
+// Buffer is a no-op since results are fully received with the response to their query
+func (c *connection) Buffer(_ context.Context, handle idb.StreamHandle) error {
+	_, err := c.streamOf(handle)
+	return err
+}
+
+func (c *connection) Bookmark() string {
+	return c.bookmark
+}
+
+func (c *connection) ServerName() string {
+	return c.serverName
+}
+
+func (c *connection) ServerVersion() string {
+	return c.serverVersion
+}
+
+func (c *connection) IsAlive() bool {
+	return c.alive
+}
+
+func (c *connection) HasFailed() bool {
+	return c.failed
+}
+
+func (c *connection) Birthdate() time.Time {
+	return c.birthDate
+}
+
+func (c *connection) IdleDate() time.Time {
+	return c.idleDate
+}
+
+// Reset rolls back the open transaction, if any
+func (c *connection) Reset(ctx context.Context) {
+	if c.txId != "" {
+		if err := c.TxRollback(ctx, c.txHandle); err != nil {
+			c.log.Warnf(log.Http, c.logId, "could not roll back transaction on reset: %s", err.Error())
+		}
+	}
+	c.failed = false
+	c.bookmark = ""
+	c.databaseName = idb.DefaultDatabase
+}
+
+func (c *connection) ForceReset(ctx context.Context) {
+	c.Reset(ctx)
+}
+
+func (c *connection) Close(ctx context.Context) {
+	if !c.alive {
+		return
+	}
+	c.Reset(ctx)
+	c.alive = false
+	c.client.CloseIdleConnections()
+	c.log.Infof(log.Http, c.logId, "Close")
+}
+
+func (c *connection) GetRoutingTable(context.Context, map[string]string, []string, string, string) (*idb.RoutingTable, error) {
+	return nil, &db.FeatureNotSupportedError{Server: c.serverName, Feature: "routing", Reason: "not available over HTTP"}
+}
+
+func (c *connection) SetBoltLogger(boltLogger log.BoltLogger) {
+	c.boltLogger = boltLogger
+}
+
+// Version returns the zero protocol version, since the connection does not use Bolt
+func (c *connection) Version() db.ProtocolVersion {
+	return db.ProtocolVersion{}
+}
+
+func (c *connection) SelectDatabase(database string) {
+	c.databaseName = database
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpquery
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+)
+
+type receivedRequest struct {
+	method        string
+	path          string
+	authorization string
+	affinity      string
+	body          map[string]any
+}
+
+// queryApiFake serves the given responses by request path and records the received requests
+func queryApiFake(t *testing.T, responses map[string]string) (*httptest.Server, *[]receivedRequest) {
+	received := &[]receivedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := receivedRequest{
+			method:        r.Method,
+			path:          r.URL.Path,
+			authorization: r.Header.Get("Authorization"),
+			affinity:      r.Header.Get(clusterAffinityHeader),
+		}
+		if content, _ := io.ReadAll(r.Body); len(content) > 0 {
+			AssertNoError(t, json.Unmarshal(content, &request.body))
+		}
+		*received = append(*received, request)
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{"neo4j_version": "5.20.0"}`))
+			return
+		}
+		response, found := responses[r.Method+" "+r.URL.Path]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set(clusterAffinityHeader, "server-1")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func connectTo(t *testing.T, server *httptest.Server) idb.Connection {
+	auth := map[string]any{"scheme": "basic", "principal": "neo4j", "credentials": "pass"}
+	conn, err := Connect(context.Background(), "server", server.URL, server.Client(), auth, "agent", idb.NotificationConfig{}, &log.Void{}, nil)
+	AssertNoError(t, err)
+	return conn
+}
+
+func TestConnection(outer *testing.T) {
+	ctx := context.Background()
+	defaultTxConfig := idb.TxConfig{Timeout: idb.DefaultTxConfigTimeout}
+
+	outer.Run("retrieves the server version on connect", func(t *testing.T) {
+		server, _ := queryApiFake(t, nil)
+
+		conn := connectTo(t, server)
+
+		AssertStringEqual(t, conn.ServerVersion(), "Neo4j/5.20.0")
+		AssertTrue(t, conn.IsAlive())
+	})
+
+	outer.Run("runs auto-commit queries", func(t *testing.T) {
+		server, received := queryApiFake(t, map[string]string{
+			"POST /db/neo4j/query/v2": `{
+				"data": {"fields": ["n"], "values": [[{"$type": "Integer", "_value": "1"}], [{"$type": "Integer", "_value": "2"}]]},
+				"counters": {"containsUpdates": true, "nodesCreated": 2},
+				"bookmarks": ["FB:1"]
+			}`,
+		})
+		conn := connectTo(t, server)
+
+		stream, err := conn.Run(ctx, idb.Command{Cypher: "UNWIND [1, 2] AS n CREATE () RETURN n", Params: map[string]any{"x": 1}},
+			idb.TxConfig{Mode: idb.ReadMode, Bookmarks: []string{"FB:0"}, Timeout: idb.DefaultTxConfigTimeout})
+		AssertNoError(t, err)
+
+		keys, err := conn.Keys(stream)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, keys, []string{"n"})
+		record, _, err := conn.Next(ctx, stream)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, record.Values, []any{int64(1)})
+		summary, err := conn.Consume(ctx, stream)
+		AssertNoError(t, err)
+		AssertIntEqual(t, summary.Counters[db.NodesCreated], 2)
+		AssertTrue(t, *summary.ContainsUpdates)
+		AssertStringEqual(t, conn.Bookmark(), "FB:1")
+		request := (*received)[1]
+		AssertStringEqual(t, request.authorization, "Basic bmVvNGo6cGFzcw==")
+		AssertStringEqual(t, request.body["accessMode"].(string), "READ")
+		AssertDeepEquals(t, request.body["bookmarks"], []any{"FB:0"})
+		AssertDeepEquals(t, request.body["parameters"], map[string]any{"x": map[string]any{"$type": "Integer", "_value": "1"}})
+	})
+
+	outer.Run("queries the selected database", func(t *testing.T) {
+		server, received := queryApiFake(t, map[string]string{"POST /db/movies/query/v2": `{}`})
+		conn := connectTo(t, server)
+		conn.(idb.DatabaseSelector).SelectDatabase("movies")
+
+		_, err := conn.Run(ctx, idb.Command{Cypher: "RETURN 1"}, defaultTxConfig)
+
+		AssertNoError(t, err)
+		AssertStringEqual(t, (*received)[1].path, "/db/movies/query/v2")
+	})
+
+	outer.Run("runs explicit transactions with cluster affinity", func(t *testing.T) {
+		server, received := queryApiFake(t, map[string]string{
+			"POST /db/neo4j/query/v2/tx":            `{"transaction": {"id": "tx1"}}`,
+			"POST /db/neo4j/query/v2/tx/tx1":        `{"data": {"fields": [], "values": []}}`,
+			"POST /db/neo4j/query/v2/tx/tx1/commit": `{"bookmarks": ["FB:2"]}`,
+		})
+		conn := connectTo(t, server)
+
+		tx, err := conn.TxBegin(ctx, defaultTxConfig)
+		AssertNoError(t, err)
+		_, err = conn.RunTx(ctx, tx, idb.Command{Cypher: "CREATE ()"})
+		AssertNoError(t, err)
+		AssertNoError(t, conn.TxCommit(ctx, tx))
+
+		AssertStringEqual(t, conn.Bookmark(), "FB:2")
+		AssertStringEqual(t, (*received)[2].affinity, "server-1")
+		AssertStringEqual(t, (*received)[3].affinity, "server-1")
+	})
+
+	outer.Run("rolls back the open transaction on reset", func(t *testing.T) {
+		server, received := queryApiFake(t, map[string]string{
+			"POST /db/neo4j/query/v2/tx":       `{"transaction": {"id": "tx1"}}`,
+			"DELETE /db/neo4j/query/v2/tx/tx1": `{}`,
+		})
+		conn := connectTo(t, server)
+		_, err := conn.TxBegin(ctx, defaultTxConfig)
+		AssertNoError(t, err)
+
+		conn.Reset(ctx)
+
+		AssertStringEqual(t, (*received)[2].method, http.MethodDelete)
+	})
+
+	outer.Run("returns server errors", func(t *testing.T) {
+		server, _ := queryApiFake(t, map[string]string{
+			"POST /db/neo4j/query/v2": `{"errors": [{"code": "Neo.ClientError.Statement.SyntaxError", "message": "Invalid input"}]}`,
+		})
+		conn := connectTo(t, server)
+
+		_, err := conn.Run(ctx, idb.Command{Cypher: "RETRUN 1"}, defaultTxConfig)
+
+		AssertDeepEquals(t, err, &db.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError", Msg: "Invalid input"})
+		AssertTrue(t, conn.IsAlive())
+	})
+
+	outer.Run("does not support transaction timeouts", func(t *testing.T) {
+		server, _ := queryApiFake(t, nil)
+		conn := connectTo(t, server)
+
+		_, err := conn.Run(ctx, idb.Command{Cypher: "RETURN 1"}, idb.TxConfig{Timeout: 0})
+
+		AssertSameType(t, err, &db.FeatureNotSupportedError{})
+	})
+
+	outer.Run("does not support transaction metadata", func(t *testing.T) {
+		server, _ := queryApiFake(t, nil)
+		conn := connectTo(t, server)
+
+		_, err := conn.TxBegin(ctx, idb.TxConfig{Timeout: idb.DefaultTxConfigTimeout, Meta: map[string]any{"app": "x"}})
+
+		AssertSameType(t, err, &db.FeatureNotSupportedError{})
+	})
+
+	outer.Run("does not support routing", func(t *testing.T) {
+		server, _ := queryApiFake(t, nil)
+		conn := connectTo(t, server)
+
+		_, err := conn.GetRoutingTable(ctx, nil, nil, idb.DefaultDatabase, "")
+
+		AssertSameType(t, err, &db.FeatureNotSupportedError{})
+	})
+}
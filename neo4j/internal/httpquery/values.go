@@ -0,0 +1,463 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpquery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+// typedValue is the typed JSON representation of a Cypher value, as exchanged with the Query API
+type typedValue struct {
+	Type  string          `json:"$type"`
+	Value json.RawMessage `json:"_value"`
+}
+
+const (
+	localTimeLayout     = "15:04:05.999999999"
+	timeLayout          = "15:04:05.999999999Z07:00"
+	dateLayout          = "2006-01-02"
+	localDateTimeLayout = "2006-01-02T15:04:05.999999999"
+)
+
+func newTypedValue(typ string, value any) (typedValue, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return typedValue{}, err
+	}
+	return typedValue{Type: typ, Value: raw}, nil
+}
+
+// encodeParams converts query parameters to their typed JSON representation
+func encodeParams(params map[string]any) (map[string]typedValue, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	encoded := make(map[string]typedValue, len(params))
+	for key, value := range params {
+		typed, err := encodeValue(value)
+		if err != nil {
+			return nil, err
+		}
+		encoded[key] = typed
+	}
+	return encoded, nil
+}
+
+func encodeValue(value any) (typedValue, error) {
+	switch v := value.(type) {
+	case nil:
+		return typedValue{Type: "Null", Value: json.RawMessage("null")}, nil
+	case bool:
+		return newTypedValue("Boolean", v)
+	case string:
+		return newTypedValue("String", v)
+	case []byte:
+		return newTypedValue("Base64", base64.StdEncoding.EncodeToString(v))
+	case time.Time:
+		return encodeDateTime(v)
+	case dbtype.Date:
+		return newTypedValue("Date", v.Time().Format(dateLayout))
+	case dbtype.Time:
+		return newTypedValue("Time", v.Time().Format(timeLayout))
+	case dbtype.LocalTime:
+		return newTypedValue("LocalTime", v.Time().Format(localTimeLayout))
+	case dbtype.LocalDateTime:
+		return newTypedValue("LocalDateTime", v.Time().Format(localDateTimeLayout))
+	case dbtype.Duration:
+		return newTypedValue("Duration", v.String())
+	case dbtype.Point2D:
+		return newTypedValue("Point", fmt.Sprintf("SRID=%d;POINT (%s %s)", v.SpatialRefId, formatFloat(v.X), formatFloat(v.Y)))
+	case dbtype.Point3D:
+		return newTypedValue("Point", fmt.Sprintf("SRID=%d;POINT Z (%s %s %s)", v.SpatialRefId, formatFloat(v.X), formatFloat(v.Y), formatFloat(v.Z)))
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return newTypedValue("Boolean", rv.Bool())
+	case reflect.String:
+		return newTypedValue("String", rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return newTypedValue("Integer", strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > 1<<63-1 {
+			return typedValue{}, &db.UnsupportedTypeError{Type: rv.Type()}
+		}
+		return newTypedValue("Integer", strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		return newTypedValue("Float", formatFloat(rv.Float()))
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return encodeValue(nil)
+		}
+		return encodeValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		list := make([]typedValue, rv.Len())
+		for i := range list {
+			typed, err := encodeValue(rv.Index(i).Interface())
+			if err != nil {
+				return typedValue{}, err
+			}
+			list[i] = typed
+		}
+		return newTypedValue("List", list)
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return typedValue{}, &db.UnsupportedTypeError{Type: rv.Type()}
+		}
+		entries := make(map[string]typedValue, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			typed, err := encodeValue(iter.Value().Interface())
+			if err != nil {
+				return typedValue{}, err
+			}
+			entries[iter.Key().String()] = typed
+		}
+		return newTypedValue("Map", entries)
+	}
+	return typedValue{}, &db.UnsupportedTypeError{Type: reflect.TypeOf(value)}
+}
+
+// encodeDateTime encodes date times with a named time zone as ZonedDateTime and the others as OffsetDateTime
+func encodeDateTime(t time.Time) (typedValue, error) {
+	formatted := t.Format(time.RFC3339Nano)
+	zone := t.Location().String()
+	if t.Location() == time.UTC || t.Location() == time.Local || zone == "" || strings.HasPrefix(zone, "Offset") {
+		return newTypedValue("OffsetDateTime", formatted)
+	}
+	return newTypedValue("ZonedDateTime", fmt.Sprintf("%s[%s]", formatted, zone))
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// decodeValue converts the typed JSON representation of a Cypher value to its Go counterpart
+func decodeValue(raw json.RawMessage) (any, error) {
+	var typed typedValue
+	if err := json.Unmarshal(raw, &typed); err != nil {
+		return nil, err
+	}
+	switch typed.Type {
+	case "Null":
+		return nil, nil
+	case "Boolean":
+		var b bool
+		err := json.Unmarshal(typed.Value, &b)
+		return b, err
+	case "String":
+		var s string
+		err := json.Unmarshal(typed.Value, &s)
+		return s, err
+	case "Map":
+		var entries map[string]json.RawMessage
+		if err := json.Unmarshal(typed.Value, &entries); err != nil {
+			return nil, err
+		}
+		return decodeMap(entries)
+	case "List":
+		var items []json.RawMessage
+		if err := json.Unmarshal(typed.Value, &items); err != nil {
+			return nil, err
+		}
+		list := make([]any, len(items))
+		for i, item := range items {
+			value, err := decodeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+	case "Node":
+		return decodeNode(typed.Value)
+	case "Relationship":
+		return decodeRelationship(typed.Value)
+	case "Path":
+		return decodePath(typed.Value)
+	}
+
+	// all remaining types are represented as strings
+	var s string
+	if err := json.Unmarshal(typed.Value, &s); err != nil {
+		return nil, err
+	}
+	switch typed.Type {
+	case "Integer":
+		return strconv.ParseInt(s, 10, 64)
+	case "Float":
+		return strconv.ParseFloat(s, 64)
+	case "Base64":
+		return base64.StdEncoding.DecodeString(s)
+	case "Date":
+		t, err := time.Parse(dateLayout, s)
+		return dbtype.Date(t), err
+	case "Time":
+		t, err := time.Parse(timeLayout, s)
+		return dbtype.Time(t), err
+	case "LocalTime":
+		t, err := time.Parse(localTimeLayout, s)
+		return dbtype.LocalTime(t), err
+	case "LocalDateTime":
+		t, err := time.Parse(localDateTimeLayout, s)
+		return dbtype.LocalDateTime(t), err
+	case "OffsetDateTime":
+		return time.Parse(time.RFC3339Nano, s)
+	case "ZonedDateTime":
+		return parseZonedDateTime(s)
+	case "Duration":
+		return parseDuration(s)
+	case "Point":
+		return parsePoint(s)
+	}
+	return nil, fmt.Errorf("unsupported Query API value type %q", typed.Type)
+}
+
+func decodeMap(entries map[string]json.RawMessage) (map[string]any, error) {
+	decoded := make(map[string]any, len(entries))
+	for key, raw := range entries {
+		value, err := decodeValue(raw)
+		if err != nil {
+			return nil, err
+		}
+		decoded[key] = value
+	}
+	return decoded, nil
+}
+
+type nodeValue struct {
+	ElementId  string                     `json:"_element_id"`
+	Labels     []string                   `json:"_labels"`
+	Properties map[string]json.RawMessage `json:"_properties"`
+}
+
+type relationshipValue struct {
+	ElementId      string                     `json:"_element_id"`
+	StartElementId string                     `json:"_start_node_element_id"`
+	EndElementId   string                     `json:"_end_node_element_id"`
+	Type           string                     `json:"_type"`
+	Properties     map[string]json.RawMessage `json:"_properties"`
+}
+
+func decodeNode(raw json.RawMessage) (dbtype.Node, error) {
+	var node nodeValue
+	if err := json.Unmarshal(raw, &node); err != nil {
+		return dbtype.Node{}, err
+	}
+	props, err := decodeMap(node.Properties)
+	if err != nil {
+		return dbtype.Node{}, err
+	}
+	labels := node.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	return dbtype.Node{
+		Id:        legacyId(node.ElementId),
+		ElementId: node.ElementId,
+		Labels:    labels,
+		Props:     props,
+	}, nil
+}
+
+func decodeRelationship(raw json.RawMessage) (dbtype.Relationship, error) {
+	var rel relationshipValue
+	if err := json.Unmarshal(raw, &rel); err != nil {
+		return dbtype.Relationship{}, err
+	}
+	props, err := decodeMap(rel.Properties)
+	if err != nil {
+		return dbtype.Relationship{}, err
+	}
+	return dbtype.Relationship{
+		Id:             legacyId(rel.ElementId),
+		ElementId:      rel.ElementId,
+		StartId:        legacyId(rel.StartElementId),
+		StartElementId: rel.StartElementId,
+		EndId:          legacyId(rel.EndElementId),
+		EndElementId:   rel.EndElementId,
+		Type:           rel.Type,
+		Props:          props,
+	}, nil
+}
+
+// decodePath decodes paths, sent as the alternating sequence of their nodes and relationships
+func decodePath(raw json.RawMessage) (dbtype.Path, error) {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(raw, &elements); err != nil {
+		return dbtype.Path{}, err
+	}
+	path := dbtype.Path{}
+	for i, element := range elements {
+		value, err := decodeValue(element)
+		if err != nil {
+			return dbtype.Path{}, err
+		}
+		switch entity := value.(type) {
+		case dbtype.Node:
+			path.Nodes = append(path.Nodes, entity)
+		case dbtype.Relationship:
+			path.Relationships = append(path.Relationships, entity)
+		default:
+			return dbtype.Path{}, fmt.Errorf("unexpected path element %d of type %T", i, value)
+		}
+	}
+	return path, nil
+}
+
+// legacyId extracts the legacy numeric ID of entities from their element ID, -1 when not available
+func legacyId(elementId string) int64 {
+	id, err := strconv.ParseInt(elementId[strings.LastIndex(elementId, ":")+1:], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return id
+}
+
+// parseZonedDateTime parses date times like 2015-11-21T21:40:32.142+01:00[Europe/Berlin]
+func parseZonedDateTime(s string) (time.Time, error) {
+	zoneStart := strings.IndexByte(s, '[')
+	if zoneStart < 0 || !strings.HasSuffix(s, "]") {
+		return time.Parse(time.RFC3339Nano, s)
+	}
+	t, err := time.Parse(time.RFC3339Nano, s[:zoneStart])
+	if err != nil {
+		return time.Time{}, err
+	}
+	location, err := time.LoadLocation(s[zoneStart+1 : len(s)-1])
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(location), nil
+}
+
+// parseDuration parses ISO-8601 durations like P1Y2M3DT4H5M6.5S
+func parseDuration(s string) (dbtype.Duration, error) {
+	invalid := fmt.Errorf("invalid duration %q", s)
+	if !strings.HasPrefix(s, "P") {
+		return dbtype.Duration{}, invalid
+	}
+	var duration dbtype.Duration
+	inTime := false
+	rest := s[1:]
+	for len(rest) > 0 {
+		if rest[0] == 'T' {
+			inTime = true
+			rest = rest[1:]
+			continue
+		}
+		end := strings.IndexAny(rest, "YMWDHS")
+		if end <= 0 {
+			return dbtype.Duration{}, invalid
+		}
+		number, unit := rest[:end], rest[end]
+		rest = rest[end+1:]
+		if unit == 'S' && inTime {
+			seconds, nanos, err := parseSeconds(number)
+			if err != nil {
+				return dbtype.Duration{}, invalid
+			}
+			duration.Seconds += seconds
+			duration.Nanos += nanos
+			continue
+		}
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return dbtype.Duration{}, invalid
+		}
+		switch {
+		case unit == 'Y' && !inTime:
+			duration.Months += 12 * n
+		case unit == 'M' && !inTime:
+			duration.Months += n
+		case unit == 'W' && !inTime:
+			duration.Days += 7 * n
+		case unit == 'D' && !inTime:
+			duration.Days += n
+		case unit == 'H' && inTime:
+			duration.Seconds += 3600 * n
+		case unit == 'M' && inTime:
+			duration.Seconds += 60 * n
+		default:
+			return dbtype.Duration{}, invalid
+		}
+	}
+	return duration, nil
+}
+
+// parseSeconds parses decimal seconds like -1.5 without loss of precision
+func parseSeconds(s string) (int64, int, error) {
+	whole, fraction, _ := strings.Cut(s, ".")
+	seconds, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || len(fraction) > 9 {
+		return 0, 0, fmt.Errorf("invalid seconds %q", s)
+	}
+	nanos := 0
+	if fraction != "" {
+		if nanos, err = strconv.Atoi((fraction + "000000000")[:9]); err != nil {
+			return 0, 0, err
+		}
+	}
+	if strings.HasPrefix(s, "-") {
+		nanos = -nanos
+	}
+	return seconds, nanos, nil
+}
+
+// parsePoint parses points in the extended WKT format, like SRID=4326;POINT (1.2 3.4) or SRID=4979;POINT Z (1 2 3)
+func parsePoint(s string) (any, error) {
+	invalid := fmt.Errorf("invalid point %q", s)
+	sridPart, wkt, found := strings.Cut(s, ";")
+	if !found || !strings.HasPrefix(sridPart, "SRID=") {
+		return nil, invalid
+	}
+	srid, err := strconv.ParseUint(strings.TrimPrefix(sridPart, "SRID="), 10, 32)
+	if err != nil {
+		return nil, invalid
+	}
+	open, close := strings.IndexByte(wkt, '('), strings.LastIndexByte(wkt, ')')
+	if open < 0 || close < open {
+		return nil, invalid
+	}
+	coordinates := strings.Fields(wkt[open+1 : close])
+	values := make([]float64, len(coordinates))
+	for i, coordinate := range coordinates {
+		if values[i], err = strconv.ParseFloat(coordinate, 64); err != nil {
+			return nil, invalid
+		}
+	}
+	switch len(values) {
+	case 2:
+		return dbtype.Point2D{X: values[0], Y: values[1], SpatialRefId: uint32(srid)}, nil
+	case 3:
+		return dbtype.Point3D{X: values[0], Y: values[1], Z: values[2], SpatialRefId: uint32(srid)}, nil
+	}
+	return nil, invalid
+}
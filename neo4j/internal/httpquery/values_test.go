@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpquery
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestValues(outer *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	AssertNoError(outer, err)
+
+	outer.Run("round trips values", func(inner *testing.T) {
+		values := map[string]any{
+			"null":           nil,
+			"boolean":        true,
+			"integer":        int64(-42),
+			"float":          1.5,
+			"string":         "hello",
+			"bytes":          []byte{1, 2, 3},
+			"list":           []any{int64(1), "two"},
+			"map":            map[string]any{"key": 3.25},
+			"date":           dbtype.Date(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+			"time":           dbtype.Time(time.Date(0, 1, 1, 12, 30, 15, 500, time.FixedZone("", 3600))),
+			"local time":     dbtype.LocalTime(time.Date(0, 1, 1, 12, 30, 15, 0, time.UTC)),
+			"local datetime": dbtype.LocalDateTime(time.Date(2024, 2, 29, 12, 30, 15, 0, time.UTC)),
+			"offset time":    time.Date(2024, 2, 29, 12, 30, 15, 0, time.FixedZone("", -7200)),
+			"zoned time":     time.Date(2024, 2, 29, 12, 30, 15, 0, berlin),
+			"duration":       dbtype.Duration{Months: 14, Days: 3, Seconds: 3723, Nanos: 500000000},
+			"point 2D":       dbtype.Point2D{X: 1.5, Y: -2, SpatialRefId: 4326},
+			"point 3D":       dbtype.Point3D{X: 1, Y: 2, Z: 3, SpatialRefId: 4979},
+		}
+
+		for name, value := range values {
+			inner.Run(name, func(t *testing.T) {
+				typed, err := encodeValue(value)
+				AssertNoError(t, err)
+				raw, err := json.Marshal(typed)
+				AssertNoError(t, err)
+
+				decoded, err := decodeValue(raw)
+
+				AssertNoError(t, err)
+				if expected, ok := value.(time.Time); ok {
+					AssertTrue(t, expected.Equal(decoded.(time.Time)))
+					AssertStringEqual(t, decoded.(time.Time).Location().String(), expected.Location().String())
+				} else {
+					AssertDeepEquals(t, decoded, value)
+				}
+			})
+		}
+	})
+
+	outer.Run("encodes Go integers as Cypher integers", func(t *testing.T) {
+		typed, err := encodeValue(uint8(7))
+
+		AssertNoError(t, err)
+		AssertStringEqual(t, typed.Type, "Integer")
+		AssertStringEqual(t, string(typed.Value), `"7"`)
+	})
+
+	outer.Run("does not encode unsupported types", func(t *testing.T) {
+		_, err := encodeValue(map[int]string{1: "one"})
+
+		AssertError(t, err)
+	})
+
+	outer.Run("decodes graph entities", func(t *testing.T) {
+		raw := `{"$type": "Path", "_value": [
+			{"$type": "Node", "_value": {"_element_id": "4:db:1", "_labels": ["Person"], "_properties": {"name": {"$type": "String", "_value": "Ada"}}}},
+			{"$type": "Relationship", "_value": {"_element_id": "5:db:7", "_start_node_element_id": "4:db:1", "_end_node_element_id": "4:db:2", "_type": "KNOWS", "_properties": {}}},
+			{"$type": "Node", "_value": {"_element_id": "4:db:2", "_labels": [], "_properties": {}}}
+		]}`
+
+		decoded, err := decodeValue(json.RawMessage(raw))
+
+		AssertNoError(t, err)
+		path := decoded.(dbtype.Path)
+		AssertLen(t, path.Nodes, 2)
+		AssertLen(t, path.Relationships, 1)
+		AssertDeepEquals(t, path.Nodes[0], dbtype.Node{Id: 1, ElementId: "4:db:1", Labels: []string{"Person"}, Props: map[string]any{"name": "Ada"}})
+		AssertDeepEquals(t, path.Relationships[0], dbtype.Relationship{
+			Id: 7, ElementId: "5:db:7", StartId: 1, StartElementId: "4:db:1", EndId: 2, EndElementId: "4:db:2",
+			Type: "KNOWS", Props: map[string]any{},
+		})
+	})
+
+	outer.Run("decodes negative durations", func(t *testing.T) {
+		duration, err := parseDuration("P-1DT-0.25S")
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, duration, dbtype.Duration{Days: -1, Nanos: -250000000})
+	})
+}
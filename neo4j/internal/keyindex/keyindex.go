@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package keyindex builds the index of the keys shared by the records of a result, which is kept out of the db.Record
+// API.
+package keyindex
+
+// Index maps the keys of records to the position of their value
+type Index map[string]int
+
+// threshold is the number of keys from which a map lookup gets faster than a linear scan of the keys
+const threshold = 8
+
+// New returns the index of the given keys, or nil when there are too few keys for an index to pay off.
+// The first of duplicated keys takes precedence, as when keys are scanned.
+func New(keys []string) Index {
+	if len(keys) < threshold {
+		return nil
+	}
+	index := make(Index, len(keys))
+	for i, key := range keys {
+		if _, found := index[key]; !found {
+			index[key] = i
+		}
+	}
+	return index
+}
+
+// Attach sets the index of a *db.Record.
+// It is registered by package db, since db imports this package and cannot be imported here.
+var Attach func(record any, index Index)
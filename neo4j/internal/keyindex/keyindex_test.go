@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keyindex
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNew(outer *testing.T) {
+	outer.Parallel()
+
+	outer.Run("does not index few keys", func(t *testing.T) {
+		if index := New([]string{"a", "b"}); index != nil {
+			t.Errorf("expected no index, got %v", index)
+		}
+	})
+
+	outer.Run("indexes the first of duplicated keys", func(t *testing.T) {
+		keys := []string{"dup"}
+		for i := 0; i < threshold; i++ {
+			keys = append(keys, fmt.Sprintf("k%d", i))
+		}
+		keys = append(keys, "dup")
+
+		index := New(keys)
+
+		if index["dup"] != 0 {
+			t.Errorf("expected first duplicated key to be indexed, got position %d", index["dup"])
+		}
+	})
+}
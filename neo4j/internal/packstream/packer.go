@@ -144,6 +144,23 @@ func (p *Packer) listHeader(ll int, shortOffset, longOffset byte) {
 	p.buf = append(p.buf, hdr...)
 }
 
+// OpenArray packs the header of a list whose size is not known yet and returns its position in the buffer.
+// The size must be set with CloseArray once all the items have been packed.
+func (p *Packer) OpenArray() int {
+	position := len(p.buf)
+	p.buf = append(p.buf, 0xd6, 0, 0, 0, 0)
+	return position
+}
+
+// CloseArray sets the size of the list opened at the given position with OpenArray
+func (p *Packer) CloseArray(position, num int) {
+	if int64(num) >= math.MaxUint32 {
+		p.setErr(&OverflowError{msg: fmt.Sprintf("Trying to pack too large list of size %d ", num)})
+		return
+	}
+	binary.BigEndian.PutUint32(p.buf[position+1:], uint32(num))
+}
+
 func (p *Packer) String(s string) {
 	p.listHeader(len(s), 0x80, 0xd0)
 	p.buf = append(p.buf, []byte(s)...)
@@ -63,6 +63,11 @@ func (u *Unpacker) setErr(err error) {
 	}
 }
 
+// Remaining returns the bytes that are yet to be unpacked, without copying them
+func (u *Unpacker) Remaining() []byte {
+	return u.buf[u.off:]
+}
+
 func (u *Unpacker) Next() {
 	i := u.pop()
 	u.mrk = markers[i]
@@ -40,6 +40,15 @@ func (e *PoolFull) Error() string {
 	return fmt.Sprintf("No idle connections on any of [%s]", e.servers)
 }
 
+type PoolWaitQueueFull struct {
+	servers []string
+	size    int
+}
+
+func (e *PoolWaitQueueFull) Error() string {
+	return fmt.Sprintf("No idle connections on any of [%s] and %d requests are already waiting for one", e.servers, e.size)
+}
+
 type PoolClosed struct {
 }
 
@@ -30,6 +30,7 @@ import (
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/racing"
 	"math"
+	"reflect"
 	"sort"
 	"time"
 
@@ -42,10 +43,14 @@ const DefaultLivenessCheckThreshold = math.MaxInt64
 
 type Connect func(context.Context, string, log.BoltLogger) (db.Connection, error)
 
+// qitem is a borrow request waiting in the queue of the pool, requests are served in FIFO order.
+// Before the request is woken up, either conn is set when a connection is handed over, or reserved is set to the
+// name of the server on which a freed connection slot has been reserved for the request to connect.
 type qitem struct {
-	servers []string
-	wakeup  chan bool
-	conn    db.Connection
+	servers  []string
+	wakeup   chan bool
+	conn     db.Connection
+	reserved string
 }
 
 type Pool struct {
@@ -60,6 +65,18 @@ type Pool struct {
 	closed     bool
 	log        log.Logger
 	logId      string
+	// RotateOnTerminationNotice makes the pool discard returned connections whose server notified it is terminating,
+	// together with the idle connections to the same server that are not younger
+	RotateOnTerminationNotice bool
+	// MaxWaiters bounds the number of borrow requests waiting for a connection, Borrow fails with PoolWaitQueueFull
+	// beyond it. 0 means no bound.
+	MaxWaiters int
+	// GetAuth optionally returns the token connections should be authenticated with, unless the context of the
+	// borrower carries a session token (see db.WithSessionAuth). Borrowed connections authenticated with another
+	// token are re-authenticated, or replaced when the server does not support it.
+	GetAuth func(context.Context) (map[string]any, error)
+	// stopReaper stops the idle connection reaper started by StartIdleReaper, if any
+	stopReaper chan struct{}
 }
 
 type serverPenalty struct {
@@ -100,12 +117,16 @@ func (p *Pool) Close(ctx context.Context) error {
 	if !p.serversMut.TryLock(ctx) {
 		return racing.LockTimeoutError("could not acquire server lock in time when closing pool")
 	}
+	if p.stopReaper != nil {
+		close(p.stopReaper)
+		p.stopReaper = nil
+	}
 	for n, s := range p.servers {
 		s.closeAll(ctx)
 		delete(p.servers, n)
 	}
 	p.serversMut.Unlock()
-	p.log.Infof(log.Pool, p.logId, "Closed")
+	log.ForContext(ctx, p.log).Infof(log.Pool, p.logId, "Closed")
 	return nil
 }
 
@@ -147,6 +168,38 @@ func (p *Pool) getServers(ctx context.Context) (map[string]*server, error) {
 	return servers, nil
 }
 
+// ConnectionState describes a connection held by the pool at the time ConnectionStates is called.
+type ConnectionState struct {
+	Server string
+	// Busy is true when the connection is borrowed, false when it is idle in the pool
+	Busy bool
+	Age  time.Duration
+	// IdleTime is how long the connection has been idle in the pool, 0 for busy connections
+	IdleTime time.Duration
+	// LastError is the last error the connection failed with, if known. Always nil for busy connections.
+	LastError error
+}
+
+// ConnectionStates returns the state of every connection held by the pool, sorted by server name, idle connections
+// first.
+func (p *Pool) ConnectionStates(ctx context.Context) ([]ConnectionState, error) {
+	if !p.serversMut.TryLock(ctx) {
+		return nil, racing.LockTimeoutError("could not acquire server lock in time when getting connection states")
+	}
+	defer p.serversMut.Unlock()
+	names := make([]string, 0, len(p.servers))
+	for name := range p.servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	now := p.now()
+	var states []ConnectionState
+	for _, name := range names {
+		states = append(states, p.servers[name].states(name, now)...)
+	}
+	return states, nil
+}
+
 // CleanUp prunes all old connection on all the servers, this makes sure that servers
 // gets removed from the map at some point in time. If there is a noticed
 // failed connect still active  we should wait a while with removal to get
@@ -155,7 +208,6 @@ func (p *Pool) CleanUp(ctx context.Context) error {
 	if !p.serversMut.TryLock(ctx) {
 		return fmt.Errorf("could not acquire server lock in time when cleaning up pool")
 	}
-	defer p.serversMut.Unlock()
 	now := p.now()
 	for n, s := range p.servers {
 		s.removeIdleOlderThan(ctx, now, p.maxAge)
@@ -163,7 +215,51 @@ func (p *Pool) CleanUp(ctx context.Context) error {
 			delete(p.servers, n)
 		}
 	}
-	return nil
+	p.serversMut.Unlock()
+	return p.wakeUpWaiters(ctx)
+}
+
+// StartIdleReaper starts closing the connections that have been idle for maxIdleTime or longer in the background,
+// checking every half of maxIdleTime, until the pool is closed. At least minSize connections are kept per server.
+func (p *Pool) StartIdleReaper(maxIdleTime time.Duration, minSize int) {
+	stop := make(chan struct{})
+	// Close reads it under the same lock, which cannot time out with a background context
+	p.serversMut.TryLock(context.Background())
+	p.stopReaper = stop
+	p.serversMut.Unlock()
+	go func() {
+		ticker := time.NewTicker(maxIdleTime / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := p.reapIdle(context.Background(), maxIdleTime, minSize); err != nil {
+					p.log.Warnf(log.Pool, p.logId, "Failed to close idle connections: %s", err)
+				}
+			}
+		}
+	}()
+}
+
+// reapIdle closes the connections idle for maxIdleTime or longer on all the servers, down to minSize connections per
+// server, and removes the servers left without connection, unless they recently failed to connect, like CleanUp.
+func (p *Pool) reapIdle(ctx context.Context, maxIdleTime time.Duration, minSize int) error {
+	if !p.serversMut.TryLock(ctx) {
+		return racing.LockTimeoutError("could not acquire server lock in time when closing idle connections")
+	}
+	now := p.now()
+	for n, s := range p.servers {
+		if removed := s.removeIdleLongerThan(ctx, now, maxIdleTime, minSize); removed > 0 {
+			p.log.Debugf(log.Pool, p.logId, "Closed %d idle connection(s) to %s", removed, n)
+		}
+		if s.size() == 0 && !s.hasFailedConnect(now) {
+			delete(p.servers, n)
+		}
+	}
+	p.serversMut.Unlock()
+	return p.wakeUpWaiters(ctx)
 }
 
 func (p *Pool) getPenaltiesForServers(ctx context.Context, serverNames []string) ([]serverPenalty, error) {
@@ -189,35 +285,77 @@ func (p *Pool) getPenaltiesForServers(ctx context.Context, serverNames []string)
 	return penalties, nil
 }
 
+// tryAnyIdle borrows an idle connection to any of the servers, if any. The queue lock must be held.
 func (p *Pool) tryAnyIdle(ctx context.Context, serverNames []string, idlenessThreshold time.Duration) (db.Connection, error) {
 	if !p.serversMut.TryLock(ctx) {
 		return nil, racing.LockTimeoutError("could not acquire server lock in time when getting idle connection")
 	}
+	dropped := false
+	// Runs once the server lock is released
+	defer func() {
+		if dropped {
+			p.handOverFreedSlots(ctx)
+		}
+	}()
 	defer p.serversMut.Unlock()
 	for _, serverName := range serverNames {
 		srv := p.servers[serverName]
 		if srv != nil {
 			// Try to get an existing idle connection
-			conn, _ := srv.getIdle(ctx, idlenessThreshold)
+			conn, found := srv.getIdle(ctx, idlenessThreshold)
 			if conn != nil {
 				return conn, nil
 			}
+			dropped = dropped || found
 		}
 	}
 	return nil, nil
 }
 
 func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, boltLogger log.BoltLogger, idlenessThreshold time.Duration) (db.Connection, error) {
+	for {
+		conn, err := p.borrow(ctx, serverNames, wait, boltLogger, idlenessThreshold)
+		if err != nil || p.reAuth(ctx, conn) {
+			return conn, err
+		}
+		// The connection could not be re-authenticated, replace it
+		if err := p.unreg(ctx, conn.ServerName(), conn, p.now()); err != nil {
+			return nil, err
+		}
+		if err := p.wakeUpWaiters(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *Pool) borrow(ctx context.Context, serverNames []string, wait bool, boltLogger log.BoltLogger, idlenessThreshold time.Duration) (db.Connection, error) {
 	if p.closed {
 		return nil, &PoolClosed{}
 	}
-	p.log.Debugf(log.Pool, p.logId, "Trying to borrow connection from %s", serverNames)
+	log.ForContext(ctx, p.log).Debugf(log.Pool, p.logId, "Trying to borrow connection from %s", serverNames)
+
+	// Connecting ahead of the requests already waiting for the same servers would take the connection slots freed
+	// for them, queue up behind them instead
+	waiting, err := p.hasWaiters(ctx, serverNames)
+	if err != nil {
+		return nil, err
+	}
+	if waiting {
+		if !wait {
+			return nil, &PoolFull{servers: serverNames}
+		}
+		return p.waitForConnection(ctx, serverNames, boltLogger, idlenessThreshold)
+	}
 
 	// Retrieve penalty for each server
 	penalties, err := p.getPenaltiesForServers(ctx, serverNames)
 	if err != nil {
 		return nil, err
 	}
+	// Removing too old connections may have freed connection slots
+	if err := p.wakeUpWaiters(ctx); err != nil {
+		return nil, err
+	}
 	// Sort server penalties by lowest penalty
 	sort.Slice(penalties, func(i, j int) bool {
 		return penalties[i].penalty < penalties[j].penalty
@@ -231,7 +369,7 @@ func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, bolt
 		}
 
 		if bolt.IsTimeoutError(err) {
-			p.log.Warnf(log.Pool, p.logId, "Borrow time-out")
+			log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Borrow time-out")
 			return nil, &PoolTimeout{servers: serverNames, err: err}
 		}
 	}
@@ -243,7 +381,7 @@ func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, bolt
 	// If there are no connections for any of the servers, there is no point in waiting for anything
 	// to be returned.
 	if !anyConnection {
-		p.log.Warnf(log.Pool, p.logId, "No server connection available to any of %v", serverNames)
+		log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "No server connection available to any of %v", serverNames)
 		if err == nil {
 			err = fmt.Errorf("no server connection available to any of %v", serverNames)
 		}
@@ -255,7 +393,12 @@ func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, bolt
 	if !wait {
 		return nil, &PoolFull{servers: serverNames}
 	}
+	return p.waitForConnection(ctx, serverNames, boltLogger, idlenessThreshold)
+}
 
+// waitForConnection queues the borrow request until a connection is handed over or a connection slot is reserved
+// for it, see Return and wakeUpWaiters
+func (p *Pool) waitForConnection(ctx context.Context, serverNames []string, boltLogger log.BoltLogger, idlenessThreshold time.Duration) (db.Connection, error) {
 	// Wait for a matching connection to be returned from another thread.
 	if !p.queueMut.TryLock(ctx) {
 		return nil, racing.LockTimeoutError("could not acquire lock in time when trying to get an idle connection")
@@ -263,7 +406,7 @@ func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, bolt
 	// Ok, now that we own the queue we can add the item there but between getting the lock
 	// and above check for an existing connection another thread might have returned a connection
 	// so check again to avoid potentially starving this thread.
-	conn, err = p.tryAnyIdle(ctx, serverNames, idlenessThreshold)
+	conn, err := p.tryAnyIdle(ctx, serverNames, idlenessThreshold)
 	if err != nil {
 		p.queueMut.Unlock()
 		return nil, err
@@ -274,17 +417,31 @@ func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, bolt
 	}
 	// Add a waiting request to the queue and unlock the queue to let other threads that return
 	// their connections access the queue.
+	if p.MaxWaiters > 0 && p.queue.Len() >= p.MaxWaiters {
+		p.queueMut.Unlock()
+		log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Borrow wait queue full")
+		return nil, &PoolWaitQueueFull{servers: serverNames, size: p.MaxWaiters}
+	}
 	q := &qitem{
 		servers: serverNames,
-		wakeup:  make(chan bool),
+		// buffered so that waking up a request that timed out concurrently does not block
+		wakeup: make(chan bool, 1),
 	}
 	e := p.queue.PushBack(q)
+	// A connection slot may have been freed since the servers were found full, while nobody was waiting for it
+	err = p.handOverFreeSlots(ctx)
 	p.queueMut.Unlock()
+	if err != nil {
+		return nil, err
+	}
 
-	p.log.Warnf(log.Pool, p.logId, "Borrow queued")
+	log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Borrow queued")
 	// Wait for either a wake-up signal that indicates that we got a connection or a timeout.
 	select {
 	case <-q.wakeup:
+		if q.reserved != "" {
+			return p.connectReserved(ctx, q.reserved, boltLogger)
+		}
 		return q.conn, nil
 	case <-ctx.Done():
 		// TODO: provided ctx has reached deadline already - set some hardcoded timeout instead?
@@ -296,11 +453,78 @@ func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, bolt
 		if q.conn != nil {
 			return q.conn, nil
 		}
-		p.log.Warnf(log.Pool, p.logId, "Borrow time-out")
+		if q.reserved != "" {
+			// Pass the reserved connection slot on to the next waiting request
+			p.releaseReserved(q.reserved)
+			if err := p.wakeUpWaiters(context.Background()); err != nil {
+				return nil, err
+			}
+		}
+		log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Borrow time-out")
 		return nil, &PoolTimeout{err: ctx.Err(), servers: serverNames}
 	}
 }
 
+// hasWaiters returns true if requests are waiting for a connection to any of the given servers
+func (p *Pool) hasWaiters(ctx context.Context, serverNames []string) (bool, error) {
+	if !p.queueMut.TryLock(ctx) {
+		return false, racing.LockTimeoutError("could not acquire queue lock in time when checking connection requests")
+	}
+	defer p.queueMut.Unlock()
+	for e := p.queue.Front(); e != nil; e = e.Next() {
+		for _, queuedServer := range e.Value.(*qitem).servers {
+			for _, serverName := range serverNames {
+				if queuedServer == serverName {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// connectReserved connects to the server on which a connection slot has been reserved, and registers the new
+// connection as busy in place of the reservation. The lock is not held while connecting.
+func (p *Pool) connectReserved(ctx context.Context, serverName string, boltLogger log.BoltLogger) (db.Connection, error) {
+	log.ForContext(ctx, p.log).Infof(log.Pool, p.logId, "Connecting to %s", serverName)
+	c, err := p.connect(ctx, serverName, boltLogger)
+	// The reservation must be released whatever the state of the context
+	p.serversMut.TryLock(context.Background())
+	srv := p.servers[serverName]
+	if srv == nil {
+		// The pool has been closed in the meantime
+		p.serversMut.Unlock()
+		if c != nil {
+			go c.Close(ctx)
+		}
+		return nil, &PoolClosed{}
+	}
+	srv.release()
+	if err != nil {
+		srv.notifyFailedConnect(p.now())
+		p.serversMut.Unlock()
+		log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Failed to connect to %s: %s", serverName, err)
+		// The reserved slot is free again
+		if wakeErr := p.wakeUpWaiters(context.Background()); wakeErr != nil {
+			return nil, wakeErr
+		}
+		return nil, err
+	}
+	srv.registerBusy(c)
+	srv.notifySuccessfulConnect()
+	p.serversMut.Unlock()
+	return c, nil
+}
+
+// releaseReserved releases a connection slot reserved on the given server, which is then free again
+func (p *Pool) releaseReserved(serverName string) {
+	p.serversMut.TryLock(context.Background())
+	defer p.serversMut.Unlock()
+	if srv := p.servers[serverName]; srv != nil {
+		srv.release()
+	}
+}
+
 func (p *Pool) tryBorrow(ctx context.Context, serverName string, boltLogger log.BoltLogger, idlenessThreshold time.Duration) (db.Connection, error) {
 	// For now, lock complete servers map to avoid over connecting but with the downside
 	// that long connect times will block connects to other servers as well. To fix this
@@ -308,6 +532,13 @@ func (p *Pool) tryBorrow(ctx context.Context, serverName string, boltLogger log.
 	if !p.serversMut.TryLock(ctx) {
 		return nil, racing.LockTimeoutError("could not acquire lock in time when borrowing a connection")
 	}
+	dropped := false
+	// Runs once the server lock is released
+	defer func() {
+		if dropped {
+			p.wakeUpWaitersForFreedSlots(ctx)
+		}
+	}()
 	defer p.serversMut.Unlock()
 
 	srv := p.servers[serverName]
@@ -315,13 +546,15 @@ func (p *Pool) tryBorrow(ctx context.Context, serverName string, boltLogger log.
 		for {
 			connection, found := srv.getIdle(ctx, idlenessThreshold)
 			if connection == nil && found {
+				dropped = true
 				continue
 			}
 			if connection != nil {
 				connection.SetBoltLogger(boltLogger)
 				return connection, nil
 			}
-			if srv.size() >= p.maxSize {
+			// Idle connections left are authenticated with another token and cannot be re-authenticated
+			if srv.size() >= p.maxSize && !srv.closeIdle(ctx) {
 				return nil, &PoolFull{servers: []string{serverName}}
 			}
 			break
@@ -333,12 +566,12 @@ func (p *Pool) tryBorrow(ctx context.Context, serverName string, boltLogger log.
 	}
 
 	// No idle connection, try to connect
-	p.log.Infof(log.Pool, p.logId, "Connecting to %s", serverName)
+	log.ForContext(ctx, p.log).Infof(log.Pool, p.logId, "Connecting to %s", serverName)
 	c, err := p.connect(ctx, serverName, boltLogger)
 	if err != nil {
 		// Failed to connect, keep track that it was bad for a while
 		srv.notifyFailedConnect(p.now())
-		p.log.Warnf(log.Pool, p.logId, "Failed to connect to %s: %s", serverName, err)
+		log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Failed to connect to %s: %s", serverName, err)
 		return nil, err
 	}
 
@@ -348,6 +581,58 @@ func (p *Pool) tryBorrow(ctx context.Context, serverName string, boltLogger log.
 	return c, nil
 }
 
+// WarmUp connects to each of the given servers until they have at least minSize connections, without exceeding the
+// maximum size of the pool. The new connections are made available as idle connections.
+// Servers that cannot be reached are skipped, in which case the first connection error is returned.
+func (p *Pool) WarmUp(ctx context.Context, serverNames []string, minSize int) error {
+	var firstErr error
+	for _, serverName := range serverNames {
+		// bound the attempts in case new connections are discarded when returned, e.g. dead ones
+		for i := 0; i < minSize && !p.closed; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			c, err := p.tryWarmUp(ctx, serverName, minSize)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				break
+			}
+			if c == nil {
+				break
+			}
+			if err := p.Return(ctx, c); err != nil {
+				return err
+			}
+		}
+	}
+	return firstErr
+}
+
+// tryWarmUp connects to the server when it has fewer than minSize connections, and registers the new connection as
+// busy. nil is returned when the server already has enough connections.
+// The connection slot is reserved under the lock, but the connection is established outside it, see connectReserved.
+func (p *Pool) tryWarmUp(ctx context.Context, serverName string, minSize int) (db.Connection, error) {
+	if !p.serversMut.TryLock(ctx) {
+		return nil, racing.LockTimeoutError("could not acquire lock in time when warming up connections")
+	}
+	srv := p.servers[serverName]
+	if srv == nil {
+		srv = NewServer()
+		p.servers[serverName] = srv
+	}
+	if srv.size() >= minSize || srv.size() >= p.maxSize {
+		p.serversMut.Unlock()
+		return nil, nil
+	}
+	srv.reserve()
+	p.serversMut.Unlock()
+
+	log.ForContext(ctx, p.log).Infof(log.Pool, p.logId, "Warming up connection to %s", serverName)
+	return p.connectReserved(ctx, serverName, nil)
+}
+
 func (p *Pool) unreg(ctx context.Context, serverName string, c db.Connection, now time.Time) error {
 	if !p.serversMut.TryLock(ctx) {
 		return racing.LockTimeoutError("could not acquire server lock in time when unregistering server")
@@ -362,7 +647,7 @@ func (p *Pool) unreg(ctx context.Context, serverName string, c db.Connection, no
 	server := p.servers[serverName]
 	// Check for strange condition of not finding the server.
 	if server == nil {
-		p.log.Warnf(log.Pool, p.logId, "Server %s not found", serverName)
+		log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Server %s not found", serverName)
 		return nil
 	}
 
@@ -373,6 +658,63 @@ func (p *Pool) unreg(ctx context.Context, serverName string, c db.Connection, no
 	return nil
 }
 
+// wakeUpWaiters hands the free connection slots over to the oldest requests waiting for them, see handOverFreeSlots.
+// It must be called whenever connection slots are freed, since waiting requests are only woken up by the pool.
+func (p *Pool) wakeUpWaiters(ctx context.Context) error {
+	if !p.queueMut.TryLock(ctx) {
+		return racing.LockTimeoutError("could not acquire queue lock when waking up connection requests")
+	}
+	defer p.queueMut.Unlock()
+	return p.handOverFreeSlots(ctx)
+}
+
+// wakeUpWaitersForFreedSlots wakes up the waiting requests after idle connections were found dead and closed, which
+// frees their connection slots. The server lock must not be held.
+func (p *Pool) wakeUpWaitersForFreedSlots(ctx context.Context) {
+	if err := p.wakeUpWaiters(ctx); err != nil {
+		log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Failed to wake up connection requests: %s", err)
+	}
+}
+
+// handOverFreedSlots is like wakeUpWaitersForFreedSlots when the queue lock is already held
+func (p *Pool) handOverFreedSlots(ctx context.Context) {
+	if err := p.handOverFreeSlots(ctx); err != nil {
+		log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Failed to wake up connection requests: %s", err)
+	}
+}
+
+// handOverFreeSlots reserves the free connection slots of the servers for the waiting requests in FIFO order, and
+// wakes the requests up so that they connect in turn. The queue lock must be held.
+func (p *Pool) handOverFreeSlots(ctx context.Context) error {
+	if p.queue.Len() == 0 {
+		return nil
+	}
+	if !p.serversMut.TryLock(ctx) {
+		return racing.LockTimeoutError("could not acquire server lock when waking up connection requests")
+	}
+	defer p.serversMut.Unlock()
+	for e := p.queue.Front(); e != nil; {
+		next := e.Next()
+		queuedRequest := e.Value.(*qitem)
+		for _, serverName := range queuedRequest.servers {
+			srv := p.servers[serverName]
+			if srv == nil {
+				srv = NewServer()
+				p.servers[serverName] = srv
+			}
+			if srv.size() < p.maxSize {
+				srv.reserve()
+				queuedRequest.reserved = serverName
+				p.queue.Remove(e)
+				queuedRequest.wakeup <- true
+				break
+			}
+		}
+		e = next
+	}
+	return nil
+}
+
 func (p *Pool) removeIdleOlderThanOnServer(ctx context.Context, serverName string, now time.Time, maxAge time.Duration) error {
 	if !p.serversMut.TryLock(ctx) {
 		return racing.LockTimeoutError("could not acquire server lock in time before removing old idle connections")
@@ -386,16 +728,97 @@ func (p *Pool) removeIdleOlderThanOnServer(ctx context.Context, serverName strin
 	return nil
 }
 
+// Reborrow borrows the specified connection again, provided that it is still idle in the pool and alive.
+// nil is returned otherwise, in which case a connection should be borrowed with Borrow instead.
+func (p *Pool) Reborrow(ctx context.Context, c db.Connection, boltLogger log.BoltLogger, idlenessThreshold time.Duration) db.Connection {
+	if p.closed {
+		return nil
+	}
+	connection := p.tryIdleConnection(ctx, c, idlenessThreshold)
+	if connection == nil {
+		return nil
+	}
+	// Re-authentication involves network round trips and the token provider, it must not block the pool
+	if !p.reAuth(ctx, connection) {
+		if err := p.unreg(ctx, connection.ServerName(), connection, p.now()); err != nil {
+			log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Failed to discard connection to %s: %s", connection.ServerName(), err)
+		} else if err := p.wakeUpWaiters(ctx); err != nil {
+			log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Failed to wake up connection requests: %s", err)
+		}
+		return nil
+	}
+	connection.SetBoltLogger(boltLogger)
+	log.ForContext(ctx, p.log).Debugf(log.Pool, p.logId, "Reborrowed connection to %s", c.ServerName())
+	return connection
+}
+
+// tryIdleConnection borrows the specified connection, provided that it is still idle in the pool and alive
+func (p *Pool) tryIdleConnection(ctx context.Context, c db.Connection, idlenessThreshold time.Duration) db.Connection {
+	if !p.serversMut.TryLock(ctx) {
+		return nil
+	}
+	srv := p.servers[c.ServerName()]
+	if srv == nil {
+		p.serversMut.Unlock()
+		return nil
+	}
+	connection, found := srv.getIdleConnection(ctx, c, idlenessThreshold)
+	p.serversMut.Unlock()
+	if connection == nil && found {
+		p.wakeUpWaitersForFreedSlots(ctx)
+	}
+	return connection
+}
+
+// reAuth makes sure that the borrowed connection is authenticated with the current token.
+// false is returned when the connection failed to re-authenticate, in which case it should be discarded.
+func (p *Pool) reAuth(ctx context.Context, c db.Connection) bool {
+	reAuthenticator, ok := c.(db.ReAuthenticator)
+	if !ok {
+		return true
+	}
+	auth, ok := db.SessionAuthFrom(ctx)
+	if !ok {
+		if p.GetAuth == nil {
+			return true
+		}
+		var err error
+		if auth, err = p.GetAuth(ctx); err != nil {
+			// Keep the current token, the connection itself is fine
+			log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Failed to get authentication token: %s", err)
+			return true
+		}
+	}
+	if reflect.DeepEqual(reAuthenticator.AuthToken(), auth) {
+		return true
+	}
+	if err := reAuthenticator.ReAuth(ctx, auth); err != nil {
+		log.ForContext(ctx, p.log).Infof(log.Pool, p.logId, "Replacing connection to %s: %s", c.ServerName(), err)
+		return false
+	}
+	log.ForContext(ctx, p.log).Debugf(log.Pool, p.logId, "Re-authenticated connection to %s", c.ServerName())
+	return true
+}
+
+func terminationNotified(c db.Connection) bool {
+	notifier, ok := c.(db.TerminationNotifier)
+	return ok && notifier.TerminationNotified()
+}
+
 func (p *Pool) Return(ctx context.Context, c db.Connection) error {
 	if p.closed {
-		p.log.Warnf(log.Pool, p.logId, "Trying to return connection to closed pool")
+		log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Trying to return connection to closed pool")
 		return nil
 	}
 
 	// Get the name of the server that the connection belongs to.
 	serverName := c.ServerName()
 	isAlive := c.IsAlive()
-	p.log.Debugf(log.Pool, p.logId, "Returning connection to %s {alive:%t}", serverName, isAlive)
+	log.ForContext(ctx, p.log).Debugf(log.Pool, p.logId, "Returning connection to %s {alive:%t}", serverName, isAlive)
+	if isAlive && p.RotateOnTerminationNotice && terminationNotified(c) {
+		log.ForContext(ctx, p.log).Infof(log.Pool, p.logId, "Server %s notified termination, rotating connections", serverName)
+		isAlive = false
+	}
 
 	// If the connection is dead, remove all other idle connections on the same server that older
 	// or of the same age as the dead connection, otherwise perform normal cleanup of old connections
@@ -412,6 +835,9 @@ func (p *Pool) Return(ctx context.Context, c db.Connection) error {
 	if err := p.removeIdleOlderThanOnServer(ctx, serverName, now, maxAge); err != nil {
 		return err
 	}
+	if err := p.wakeUpWaiters(ctx); err != nil {
+		return err
+	}
 
 	// Prepare connection for being used by someone else if is alive.
 	// Since reset could find the connection to be in a bad state or non-recoverable state,
@@ -428,11 +854,9 @@ func (p *Pool) Return(ctx context.Context, c db.Connection) error {
 		if err := p.unreg(ctx, serverName, c, now); err != nil {
 			return err
 		}
-		p.log.Infof(log.Pool, p.logId, "Unregistering dead or too old connection to %s", serverName)
-		// Returning here could cause a waiting thread to wait until it times out, to do it
-		// properly we could wake up threads that waits on the server and wake them up if there
-		// are no more connections to wait for.
-		return nil
+		log.ForContext(ctx, p.log).Infof(log.Pool, p.logId, "Unregistering dead or too old connection to %s", serverName)
+		// The connection slot is free again, let the oldest waiting request use it rather than a newer one
+		return p.wakeUpWaiters(ctx)
 	}
 
 	// Check if there is anyone in the queue waiting for a connection to this server.
@@ -463,7 +887,7 @@ func (p *Pool) Return(ctx context.Context, c db.Connection) error {
 	if server != nil { // Strange when server not found
 		server.returnBusy(c)
 	} else {
-		p.log.Warnf(log.Pool, p.logId, "Server %s not found", serverName)
+		log.ForContext(ctx, p.log).Warnf(log.Pool, p.logId, "Server %s not found", serverName)
 	}
 	return nil
 }
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"math/rand"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -255,6 +256,227 @@ func TestPoolBorrowReturn(outer *testing.T) {
 		testutil.AssertNil(t, err)
 		testutil.AssertDeepEquals(t, result, healthyConnection)
 	})
+
+	outer.Run("Reborrows specified idle connection", func(t *testing.T) {
+		conn1 := &testutil.ConnFake{Name: "a server", Alive: true, Idle: time.Now()}
+		conn2 := &testutil.ConnFake{Name: "a server", Alive: true, Idle: time.Now()}
+		pool := New(2, maxAge, failingConnect, logger, "pool id")
+		setIdleConnections(pool, map[string][]db.Connection{"a server": {conn1, conn2}})
+
+		result := pool.Reborrow(ctx, conn2, nil, DefaultLivenessCheckThreshold)
+
+		testutil.AssertTrue(t, result == conn2)
+		testutil.AssertIntEqual(t, pool.servers["a server"].numIdle(), 1)
+		testutil.AssertIntEqual(t, pool.servers["a server"].numBusy(), 1)
+	})
+
+	outer.Run("Re-authenticates reborrowed connection without holding the lock", func(t *testing.T) {
+		conn := &testutil.ConnFake{Name: "a server", Alive: true, Idle: time.Now(), Auth: map[string]any{"credentials": "first"}}
+		pool := New(1, maxAge, failingConnect, logger, "pool id")
+		setIdleConnections(pool, map[string][]db.Connection{"a server": {conn}})
+		pool.GetAuth = func(context.Context) (map[string]any, error) {
+			lockCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+			_, err := pool.getServers(lockCtx)
+			testutil.AssertNoError(t, err)
+			return map[string]any{"credentials": "second"}, nil
+		}
+
+		result := pool.Reborrow(ctx, conn, nil, DefaultLivenessCheckThreshold)
+
+		testutil.AssertTrue(t, result == conn)
+		testutil.AssertDeepEquals(t, conn.Auth, map[string]any{"credentials": "second"})
+	})
+
+	outer.Run("Does not reborrow busy connection", func(t *testing.T) {
+		pool := New(1, maxAge, succeedingConnect, logger, "pool id")
+		conn, err := pool.Borrow(ctx, []string{"a server"}, false, nil, DefaultLivenessCheckThreshold)
+		assertConnection(t, conn, err)
+
+		result := pool.Reborrow(ctx, conn, nil, DefaultLivenessCheckThreshold)
+
+		testutil.AssertNil(t, result)
+	})
+
+	outer.Run("Does not reborrow connection dead after reset", func(t *testing.T) {
+		idlenessThreshold := 1 * time.Hour
+		deadAfterReset := deadConnectionAfterForceReset("a server", time.Now().Add(-2*idlenessThreshold))
+		pool := New(1, maxAge, failingConnect, logger, "pool id")
+		setIdleConnections(pool, map[string][]db.Connection{"a server": {deadAfterReset}})
+
+		result := pool.Reborrow(ctx, deadAfterReset, nil, idlenessThreshold)
+
+		testutil.AssertNil(t, result)
+	})
+
+	outer.Run("Hands the slot of connection dead after reset over to waiting threads", func(t *testing.T) {
+		idlenessThreshold := 1 * time.Hour
+		deadAfterReset := deadConnectionAfterForceReset("a server", time.Now().Add(-2*idlenessThreshold))
+		pool := New(1, maxAge, failingConnect, logger, "pool id")
+		setIdleConnections(pool, map[string][]db.Connection{"a server": {deadAfterReset}})
+		waiter := &qitem{servers: []string{"a server"}, wakeup: make(chan bool, 1)}
+		pool.queue.PushBack(waiter)
+
+		result := pool.Reborrow(ctx, deadAfterReset, nil, idlenessThreshold)
+
+		testutil.AssertNil(t, result)
+		select {
+		case <-waiter.wakeup:
+			testutil.AssertStringEqual(t, waiter.reserved, "a server")
+		default:
+			t.Error("Should have woken up the waiting thread")
+		}
+	})
+
+	waitForQueueSize := func(t *testing.T, p *Pool, expected int) {
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if size, err := p.queueSize(ctx); err != nil {
+				t.Fatalf("should not fail computing queue size, got: %v", err)
+			} else if size == expected {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		t.Fatalf("queue size did not reach %d in time", expected)
+	}
+
+	outer.Run("Serves waiting threads in FIFO order", func(t *testing.T) {
+		p := New(1, maxAge, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+		serverNames := []string{"srv1"}
+		c1, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		assertConnection(t, c1, err)
+		served := make(chan int, 2)
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		for i := 1; i <= 2; i++ {
+			i := i
+			go func() {
+				defer wg.Done()
+				c, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+				assertConnection(t, c, err)
+				served <- i
+				if err := p.Return(ctx, c); err != nil {
+					t.Errorf("Should not fail returning connection to pool, but got: %v", err)
+				}
+			}()
+			waitForQueueSize(t, p, i)
+		}
+
+		if err := p.Return(ctx, c1); err != nil {
+			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
+		}
+
+		wg.Wait()
+		testutil.AssertIntEqual(t, <-served, 1)
+		testutil.AssertIntEqual(t, <-served, 2)
+	})
+
+	outer.Run("Bounds the number of waiting threads", func(t *testing.T) {
+		p := New(1, maxAge, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		p.MaxWaiters = 1
+		defer p.Close(ctx)
+		serverNames := []string{"srv1"}
+		c1, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		assertConnection(t, c1, err)
+		waiterCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go p.Borrow(waiterCtx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		waitForQueueSize(t, p, 1)
+
+		_, err = p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+
+		testutil.AssertDeepEquals(t, err, &PoolWaitQueueFull{servers: serverNames, size: 1})
+	})
+
+	outer.Run("Wakes up waiting thread when a dead connection is returned", func(t *testing.T) {
+		p := New(1, maxAge, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+		serverNames := []string{"srv1"}
+		c1, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		assertConnection(t, c1, err)
+		borrowed := make(chan db.Connection)
+		go func() {
+			c2, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+			assertConnection(t, c2, err)
+			borrowed <- c2
+		}()
+		waitForQueueSize(t, p, 1)
+
+		c1.(*testutil.ConnFake).Alive = false
+		if err := p.Return(ctx, c1); err != nil {
+			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
+		}
+
+		if c2 := <-borrowed; c2 == c1 {
+			t.Errorf("Should have connected again instead of handing the dead connection over")
+		}
+	})
+
+	outer.Run("Hands freed connection slot over to the oldest waiting thread", func(t *testing.T) {
+		p := New(1, maxAge, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+		serverNames := []string{"srv1"}
+		c1, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		assertConnection(t, c1, err)
+		borrowed := make(chan db.Connection)
+		go func() {
+			c2, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+			assertConnection(t, c2, err)
+			borrowed <- c2
+		}()
+		waitForQueueSize(t, p, 1)
+
+		c1.(*testutil.ConnFake).Alive = false
+		if err := p.Return(ctx, c1); err != nil {
+			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
+		}
+		// the freed slot belongs to the waiting thread, even though it has not connected yet
+		c3, err := p.Borrow(ctx, serverNames, false, nil, DefaultLivenessCheckThreshold)
+
+		assertNoConnection(t, c3, err)
+		testutil.AssertDeepEquals(t, err, &PoolFull{servers: serverNames})
+		assertConnection(t, <-borrowed, nil)
+	})
+
+	outer.Run("Queues new borrowers behind waiting threads", func(t *testing.T) {
+		p := New(1, maxAge, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+		serverNames := []string{"srv1"}
+		c1, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		assertConnection(t, c1, err)
+		served := make(chan int, 2)
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		for i := 1; i <= 2; i++ {
+			i := i
+			go func() {
+				defer wg.Done()
+				c, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+				assertConnection(t, c, err)
+				served <- i
+				if err := p.Return(ctx, c); err != nil {
+					t.Errorf("Should not fail returning connection to pool, but got: %v", err)
+				}
+			}()
+			waitForQueueSize(t, p, i)
+		}
+
+		c1.(*testutil.ConnFake).Alive = false
+		if err := p.Return(ctx, c1); err != nil {
+			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
+		}
+
+		wg.Wait()
+		testutil.AssertIntEqual(t, <-served, 1)
+		testutil.AssertIntEqual(t, <-served, 2)
+	})
 }
 
 // Resource usage scenarios
@@ -357,6 +579,154 @@ func TestPoolResourceUsage(ot *testing.T) {
 		assertNumberOfIdle(t, ctx, p, "A", 1)
 	})
 
+	ot.Run("Returning connection notified of termination rotates older idle connections", func(t *testing.T) {
+		p := New(3, 0, succeedingConnect, logger, "pool id")
+		p.RotateOnTerminationNotice = true
+		c1, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c2, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		now := time.Now()
+		c1.(*testutil.ConnFake).Birth = now.Add(-1 * time.Second)
+		c2.(*testutil.ConnFake).Birth = now
+		if err := p.Return(ctx, c1); err != nil {
+			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
+		}
+		assertNumberOfIdle(t, ctx, p, "A", 1)
+
+		c2.(*testutil.ConnFake).TerminationNotice = true
+		if err := p.Return(ctx, c2); err != nil {
+			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
+		}
+
+		servers, err := p.getServers(ctx)
+		if err != nil {
+			t.Errorf("Should not fail retrieving server but got: %v", err)
+		}
+		if len(servers) > 0 && servers["A"].size() > 0 {
+			t.Errorf("Should have either removed the server or kept it but emptied it")
+		}
+	})
+
+	ot.Run("Returning connection notified of termination keeps it without rotation", func(t *testing.T) {
+		p := New(1, 0, succeedingConnect, logger, "pool id")
+		c, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c.(*testutil.ConnFake).TerminationNotice = true
+
+		if err := p.Return(ctx, c); err != nil {
+			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
+		}
+
+		assertNumberOfIdle(t, ctx, p, "A", 1)
+	})
+
+	ot.Run("Borrowing idle connection re-authenticates it with the current token", func(t *testing.T) {
+		p := New(1, 0, succeedingConnect, logger, "pool id")
+		token := map[string]any{"scheme": "bearer", "credentials": "first"}
+		p.GetAuth = func(context.Context) (map[string]any, error) {
+			return token, nil
+		}
+		c1, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		if err := p.Return(ctx, c1); err != nil {
+			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
+		}
+		token = map[string]any{"scheme": "bearer", "credentials": "second"}
+
+		c2, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+
+		if err != nil {
+			t.Fatalf("Should not fail borrowing connection, but got: %v", err)
+		}
+		if c2 != c1 {
+			t.Errorf("Should have reused the idle connection")
+		}
+		if !reflect.DeepEqual(c2.(*testutil.ConnFake).Auth, token) {
+			t.Errorf("Should have re-authenticated the connection with the current token")
+		}
+	})
+
+	ot.Run("Borrowing idle connection replaces it when re-authentication is not supported", func(t *testing.T) {
+		p := New(1, 0, succeedingConnect, logger, "pool id")
+		token := map[string]any{"scheme": "bearer", "credentials": "first"}
+		p.GetAuth = func(context.Context) (map[string]any, error) {
+			return token, nil
+		}
+		c1, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c1.(*testutil.ConnFake).ReAuthErr = db.ErrReAuthNotSupported
+		if err := p.Return(ctx, c1); err != nil {
+			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
+		}
+		token = map[string]any{"scheme": "bearer", "credentials": "second"}
+
+		c2, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+
+		if err != nil {
+			t.Fatalf("Should not fail borrowing connection, but got: %v", err)
+		}
+		if c2 == c1 {
+			t.Errorf("Should have replaced the connection which could not be re-authenticated")
+		}
+	})
+
+	ot.Run("Borrowing with session auth prefers idle connections authenticated with it", func(t *testing.T) {
+		p := New(2, 0, succeedingConnect, logger, "pool id")
+		driverToken := map[string]any{"scheme": "basic", "principal": "driver"}
+		sessionToken := map[string]any{"scheme": "basic", "principal": "tenant"}
+		p.GetAuth = func(context.Context) (map[string]any, error) {
+			return driverToken, nil
+		}
+		sessionCtx := db.WithSessionAuth(ctx, sessionToken)
+		c1, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c2, _ := p.Borrow(sessionCtx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c2.(*testutil.ConnFake).ReAuthErr = db.ErrReAuthNotSupported
+		_ = p.Return(ctx, c1)
+		_ = p.Return(ctx, c2)
+
+		c3, err := p.Borrow(sessionCtx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+
+		if err != nil {
+			t.Fatalf("Should not fail borrowing connection, but got: %v", err)
+		}
+		if c3 != c2 {
+			t.Errorf("Should have borrowed the connection authenticated with the session token")
+		}
+		assertNumberOfIdle(t, ctx, p, "A", 1)
+	})
+
+	ot.Run("Borrowing with session auth skips idle connections that cannot be re-authenticated", func(t *testing.T) {
+		p := New(2, 0, succeedingConnect, logger, "pool id")
+		sessionCtx := db.WithSessionAuth(ctx, map[string]any{"scheme": "basic", "principal": "tenant"})
+		c1, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c1.(*testutil.ConnFake).ReAuthErr = db.ErrReAuthNotSupported
+		_ = p.Return(ctx, c1)
+
+		c2, err := p.Borrow(sessionCtx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+
+		if err != nil {
+			t.Fatalf("Should not fail borrowing connection, but got: %v", err)
+		}
+		if c2 == c1 {
+			t.Errorf("Should have opened a new connection")
+		}
+		assertNumberOfIdle(t, ctx, p, "A", 1)
+	})
+
+	ot.Run("Borrowing with session auth replaces idle connection that cannot be re-authenticated when full", func(t *testing.T) {
+		p := New(1, 0, succeedingConnect, logger, "pool id")
+		sessionCtx := db.WithSessionAuth(ctx, map[string]any{"scheme": "basic", "principal": "tenant"})
+		c1, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c1.(*testutil.ConnFake).ReAuthErr = db.ErrReAuthNotSupported
+		_ = p.Return(ctx, c1)
+
+		c2, err := p.Borrow(sessionCtx, []string{"A"}, false, nil, DefaultLivenessCheckThreshold)
+
+		if err != nil {
+			t.Fatalf("Should not fail borrowing connection, but got: %v", err)
+		}
+		if c2 == c1 {
+			t.Errorf("Should have replaced the idle connection")
+		}
+		assertNumberOfIdle(t, ctx, p, "A", 0)
+	})
+
 	ot.Run("Do not borrow too old connections", func(t *testing.T) {
 		p := New(1, maxAge, succeedingConnect, logger, "pool id")
 		nowMut := sync.Mutex{}
@@ -404,6 +774,219 @@ func TestPoolResourceUsage(ot *testing.T) {
 	})
 }
 
+func TestPoolWarmUp(outer *testing.T) {
+	maxAge := 1 * time.Second
+	birthdate := time.Now()
+
+	succeedingConnect := func(_ context.Context, s string, _ log.BoltLogger) (db.Connection, error) {
+		return &testutil.ConnFake{Name: s, Alive: true, Birth: birthdate}, nil
+	}
+
+	outer.Run("Establishes idle connections up to the minimum size", func(t *testing.T) {
+		p := New(10, maxAge, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+		conn, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		assertConnection(t, conn, err)
+
+		if err := p.WarmUp(ctx, []string{"A", "B"}, 3); err != nil {
+			t.Errorf("Should not fail warming up the pool, but got: %v", err)
+		}
+
+		servers, _ := p.getServers(ctx)
+		if servers["A"].numBusy() != 1 || servers["A"].numIdle() != 2 {
+			t.Errorf("Should have completed server A with 2 idle connections")
+		}
+		if servers["B"].numIdle() != 3 {
+			t.Errorf("Should have established 3 idle connections to server B")
+		}
+	})
+
+	outer.Run("Does not exceed the maximum size", func(t *testing.T) {
+		p := New(2, maxAge, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+
+		if err := p.WarmUp(ctx, []string{"A"}, 3); err != nil {
+			t.Errorf("Should not fail warming up the pool, but got: %v", err)
+		}
+
+		servers, _ := p.getServers(ctx)
+		if servers["A"].numIdle() != 2 {
+			t.Errorf("Should have established 2 idle connections to server A")
+		}
+	})
+
+	outer.Run("Skips unreachable servers", func(t *testing.T) {
+		failingError := errors.New("whatever")
+		p := New(10, maxAge, func(_ context.Context, s string, _ log.BoltLogger) (db.Connection, error) {
+			if s == "A" {
+				return nil, failingError
+			}
+			return &testutil.ConnFake{Name: s, Alive: true, Birth: birthdate}, nil
+		}, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+
+		if err := p.WarmUp(ctx, []string{"A", "B"}, 2); err != failingError {
+			t.Errorf("Should have returned the connection error, but got: %v", err)
+		}
+
+		servers, _ := p.getServers(ctx)
+		if servers["B"].numIdle() != 2 {
+			t.Errorf("Should have established 2 idle connections to server B")
+		}
+	})
+
+	outer.Run("Connects without holding the lock", func(t *testing.T) {
+		var p *Pool
+		p = New(10, maxAge, func(ctx context.Context, s string, l log.BoltLogger) (db.Connection, error) {
+			lockCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+			if _, err := p.getServers(lockCtx); err != nil {
+				return nil, err
+			}
+			return succeedingConnect(ctx, s, l)
+		}, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+
+		if err := p.WarmUp(ctx, []string{"A"}, 2); err != nil {
+			t.Errorf("Should not fail warming up the pool, but got: %v", err)
+		}
+	})
+
+	outer.Run("Stops when the context is cancelled", func(t *testing.T) {
+		p := New(10, maxAge, func(context.Context, string, log.BoltLogger) (db.Connection, error) {
+			return nil, errors.New("should not connect")
+		}, logger, "pool id")
+		defer p.Close(ctx)
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		if err := p.WarmUp(cancelledCtx, []string{"A"}, 2); err != context.Canceled {
+			t.Errorf("Should have returned the cancellation error, but got: %v", err)
+		}
+	})
+}
+
+func TestPoolIdleReaper(outer *testing.T) {
+	now := time.Now()
+	maxIdleTime := 1 * time.Minute
+	noConnect := func(context.Context, string, log.BoltLogger) (db.Connection, error) {
+		return nil, errors.New("should not connect")
+	}
+
+	outer.Run("Closes connections idle for too long", func(t *testing.T) {
+		p := New(10, time.Hour, noConnect, logger, "pool id")
+		p.now = func() time.Time { return now }
+		defer p.Close(ctx)
+		recent := &testutil.ConnFake{Name: "A", Alive: true, Idle: now.Add(-maxIdleTime / 2)}
+		setIdleConnections(p, map[string][]db.Connection{
+			"A": {&testutil.ConnFake{Name: "A", Alive: true, Idle: now.Add(-maxIdleTime)}, recent},
+			"B": {&testutil.ConnFake{Name: "B", Alive: true, Idle: now.Add(-2 * maxIdleTime)}},
+		})
+
+		if err := p.reapIdle(ctx, maxIdleTime, 0); err != nil {
+			t.Errorf("Should not fail closing idle connections, but got: %v", err)
+		}
+
+		servers, _ := p.getServers(ctx)
+		if len(servers) != 1 || servers["A"].numIdle() != 1 || servers["A"].idle.Front().Value != recent {
+			t.Errorf("Should only have kept the recently idle connection to server A")
+		}
+	})
+
+	outer.Run("Does not close busy connections", func(t *testing.T) {
+		p := New(10, time.Hour, noConnect, logger, "pool id")
+		p.now = func() time.Time { return now }
+		defer p.Close(ctx)
+		srv := NewServer()
+		srv.registerBusy(&testutil.ConnFake{Name: "A", Alive: true, Idle: now.Add(-2 * maxIdleTime)})
+		p.servers["A"] = srv
+
+		if err := p.reapIdle(ctx, maxIdleTime, 0); err != nil {
+			t.Errorf("Should not fail closing idle connections, but got: %v", err)
+		}
+
+		servers, _ := p.getServers(ctx)
+		if servers["A"].numBusy() != 1 {
+			t.Errorf("Should have kept the busy connection")
+		}
+	})
+
+	outer.Run("Keeps the minimum number of connections per server", func(t *testing.T) {
+		p := New(10, time.Hour, noConnect, logger, "pool id")
+		p.now = func() time.Time { return now }
+		defer p.Close(ctx)
+		setIdleConnections(p, map[string][]db.Connection{
+			"A": {
+				&testutil.ConnFake{Name: "A", Alive: true, Idle: now.Add(-maxIdleTime)},
+				&testutil.ConnFake{Name: "A", Alive: true, Idle: now.Add(-maxIdleTime)},
+				&testutil.ConnFake{Name: "A", Alive: true, Idle: now.Add(-maxIdleTime)},
+			},
+			"B": {&testutil.ConnFake{Name: "B", Alive: true, Idle: now.Add(-maxIdleTime)}},
+		})
+		p.servers["B"].registerBusy(&testutil.ConnFake{Name: "B", Alive: true})
+
+		if err := p.reapIdle(ctx, maxIdleTime, 2); err != nil {
+			t.Errorf("Should not fail closing idle connections, but got: %v", err)
+		}
+
+		servers, _ := p.getServers(ctx)
+		if servers["A"].numIdle() != 2 {
+			t.Errorf("Should have kept 2 idle connections to server A")
+		}
+		if servers["B"].numIdle() != 1 {
+			t.Errorf("Should have kept the idle connection to server B")
+		}
+	})
+
+	outer.Run("Closes connections in the background until the pool is closed", func(t *testing.T) {
+		p := New(10, time.Hour, noConnect, logger, "pool id")
+		setIdleConnections(p, map[string][]db.Connection{
+			"A": {&testutil.ConnFake{Name: "A", Alive: true, Idle: now.Add(-maxIdleTime)}},
+		})
+
+		p.StartIdleReaper(10*time.Millisecond, 0)
+
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			if servers, _ := p.getServers(ctx); len(servers) == 0 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("Should have closed the idle connection in the background")
+			}
+			time.Sleep(time.Millisecond)
+		}
+		if err := p.Close(ctx); err != nil {
+			t.Errorf("Should not fail closing the pool, but got: %v", err)
+		}
+	})
+}
+
+func TestPoolConnectionStates(t *testing.T) {
+	now := time.Now()
+	lastErr := errors.New("connection reset by peer")
+	p := New(0, time.Hour, nil, logger, "pool id")
+	p.now = func() time.Time { return now }
+	setIdleConnections(p, map[string][]db.Connection{
+		"srv2": {&testutil.ConnFake{Birth: now.Add(-time.Minute), Idle: now.Add(-time.Second)}},
+		"srv1": {&testutil.ConnFake{Birth: now.Add(-time.Hour), Idle: now.Add(-time.Minute), LastErr: lastErr}},
+	})
+	p.servers["srv1"].registerBusy(&testutil.ConnFake{Birth: now.Add(-2 * time.Second), Idle: now.Add(-time.Second), LastErr: lastErr})
+
+	states, err := p.ConnectionStates(ctx)
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertDeepEquals(t, states, []ConnectionState{
+		{Server: "srv1", Age: time.Hour, IdleTime: time.Minute, LastError: lastErr},
+		{Server: "srv1", Busy: true, Age: 2 * time.Second},
+		{Server: "srv2", Age: time.Minute, IdleTime: time.Second},
+	})
+}
+
 func TestPoolCleanup(ot *testing.T) {
 	birthdate := time.Now()
 	maxLife := 1 * time.Second
@@ -23,6 +23,7 @@ import (
 	"container/list"
 	"context"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"reflect"
 	"sync/atomic"
 	"time"
 )
@@ -35,6 +36,8 @@ type server struct {
 	busy            list.List
 	failedConnectAt time.Time
 	roundRobin      uint32
+	// reserved is the number of connection slots reserved for connections being established
+	reserved int
 }
 
 func NewServer() *server {
@@ -48,26 +51,77 @@ var sharedRoundRobin uint32
 
 const rememberFailedConnectDuration = 3 * time.Minute
 
-// Returns an idle connection if any
+// Returns an idle connection if any. An idle connection found dead is closed, in which case nil and true are returned.
 func (s *server) getIdle(ctx context.Context, idlenessThreshold time.Duration) (db.Connection, bool) {
-	availableConnection := s.idle.Front()
-	found := availableConnection != nil
-	if found {
-		idleConnection := s.idle.Remove(availableConnection)
-		connection := idleConnection.(db.Connection)
-		if time.Since(connection.IdleDate()) > idlenessThreshold {
-			connection.ForceReset(ctx)
-			if !connection.IsAlive() {
-				return nil, found
-			}
+	availableConnection := s.idleFor(ctx)
+	if availableConnection == nil {
+		return nil, false
+	}
+	return s.borrowIdle(ctx, availableConnection, idlenessThreshold), true
+}
+
+// Returns the specified connection if it is idle, like getIdle
+func (s *server) getIdleConnection(ctx context.Context, conn db.Connection, idlenessThreshold time.Duration) (db.Connection, bool) {
+	for e := s.idle.Front(); e != nil; e = e.Next() {
+		if e.Value.(db.Connection) == conn {
+			return s.borrowIdle(ctx, e, idlenessThreshold), true
+		}
+	}
+	return nil, false
+}
+
+// borrowIdle makes the idle connection busy, after resetting it when it has been idle for longer than
+// idlenessThreshold. Connections found dead are closed and nil is returned.
+func (s *server) borrowIdle(ctx context.Context, e *list.Element, idlenessThreshold time.Duration) db.Connection {
+	connection := s.idle.Remove(e).(db.Connection)
+	if time.Since(connection.IdleDate()) > idlenessThreshold {
+		connection.ForceReset(ctx)
+		if !connection.IsAlive() {
+			go connection.Close(ctx)
+			return nil
+		}
+	}
+	s.busy.PushFront(connection)
+	// Update round-robin counter every time we give away a connection and keep track
+	// of our own round-robin index
+	s.roundRobin = atomic.AddUint32(&sharedRoundRobin, 1)
+	return connection
+}
+
+// idleFor prefers the idle connections already authenticated with the session token carried by ctx, if any, to
+// avoid re-authenticating connections. Connections authenticated with another token are skipped when the server does
+// not support re-authentication, nil is returned when there is no other idle connection.
+func (s *server) idleFor(ctx context.Context) *list.Element {
+	auth, ok := db.SessionAuthFrom(ctx)
+	if !ok {
+		return s.idle.Front()
+	}
+	var reAuthenticable *list.Element
+	for e := s.idle.Front(); e != nil; e = e.Next() {
+		reAuthenticator, ok := e.Value.(db.ReAuthenticator)
+		if !ok {
+			return e
+		}
+		if reflect.DeepEqual(reAuthenticator.AuthToken(), auth) {
+			return e
+		}
+		if reAuthenticable == nil && reAuthenticator.SupportsReAuth() {
+			reAuthenticable = e
 		}
-		s.busy.PushFront(idleConnection)
-		// Update round-robin counter every time we give away a connection and keep track
-		// of our own round-robin index
-		s.roundRobin = atomic.AddUint32(&sharedRoundRobin, 1)
-		return connection, found
 	}
-	return nil, found
+	return reAuthenticable
+}
+
+// closeIdle closes the least recently used idle connection to make room for a new one, and returns whether there
+// was any
+func (s *server) closeIdle(ctx context.Context) bool {
+	e := s.idle.Back()
+	if e == nil {
+		return false
+	}
+	s.idle.Remove(e)
+	go e.Value.(db.Connection).Close(ctx)
+	return true
 }
 
 func (s *server) notifyFailedConnect(now time.Time) {
@@ -151,7 +205,17 @@ func (s *server) unregisterBusy(c db.Connection) {
 }
 
 func (s *server) size() int {
-	return s.busy.Len() + s.idle.Len()
+	return s.busy.Len() + s.idle.Len() + s.reserved
+}
+
+// reserve keeps a connection slot for a connection about to be established outside the pool lock
+func (s *server) reserve() {
+	s.reserved++
+}
+
+// release frees a connection slot kept by reserve, once the connection is established or failed to be
+func (s *server) release() {
+	s.reserved--
 }
 
 func (s *server) removeIdleOlderThan(ctx context.Context, now time.Time, maxAge time.Duration) {
@@ -170,6 +234,55 @@ func (s *server) removeIdleOlderThan(ctx context.Context, now time.Time, maxAge
 	}
 }
 
+// removeIdleLongerThan closes the connections that have been idle for maxIdleTime or longer, as long as the server
+// keeps more than minSize connections, and returns how many
+func (s *server) removeIdleLongerThan(ctx context.Context, now time.Time, maxIdleTime time.Duration, minSize int) int {
+	removed := 0
+	e := s.idle.Front()
+	for e != nil && s.size() > minSize {
+		n := e.Next()
+		c := e.Value.(db.Connection)
+
+		if now.Sub(c.IdleDate()) >= maxIdleTime {
+			s.idle.Remove(e)
+			removed++
+			go c.Close(ctx)
+		}
+
+		e = n
+	}
+	return removed
+}
+
+// states returns the state of the idle connections followed by the busy connections of the server
+func (s *server) states(name string, now time.Time) []ConnectionState {
+	states := make([]ConnectionState, 0, s.size())
+	for e := s.idle.Front(); e != nil; e = e.Next() {
+		states = append(states, connectionState(name, e.Value.(db.Connection), false, now))
+	}
+	for e := s.busy.Front(); e != nil; e = e.Next() {
+		states = append(states, connectionState(name, e.Value.(db.Connection), true, now))
+	}
+	return states
+}
+
+func connectionState(name string, c db.Connection, busy bool, now time.Time) ConnectionState {
+	state := ConnectionState{
+		Server: name,
+		Busy:   busy,
+		Age:    now.Sub(c.Birthdate()),
+	}
+	if busy {
+		// The connection is used by another goroutine, its idle date and last error cannot be read safely
+		return state
+	}
+	state.IdleTime = now.Sub(c.IdleDate())
+	if reporter, ok := c.(db.LastErrorReporter); ok {
+		state.LastError = reporter.LastError()
+	}
+	return state
+}
+
 func (s *server) closeAll(ctx context.Context) {
 	closeAndEmptyConnections(ctx, s.idle)
 	// Closing the busy connections could mean here that we do close from another thread.
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"sync"
+	"time"
+)
+
+// minRetriesPerWindow is the number of retries always allowed per window, so that drivers with little traffic can
+// still retry
+const minRetriesPerWindow = 10
+
+// Budget limits the share of attempts that may be retries within a time window.
+// It is shared by all the sessions of a driver, so that a cluster-wide outage does not trigger a retry storm.
+// A nil *Budget allows every retry.
+type Budget struct {
+	ratio       float64
+	window      time.Duration
+	mut         sync.Mutex
+	windowStart time.Time
+	requests    int
+	retries     int
+}
+
+// NewBudget creates a budget allowing, within every window, retries up to the given ratio of the first attempts
+// (with a minimum of minRetriesPerWindow retries)
+func NewBudget(ratio float64, window time.Duration) *Budget {
+	return &Budget{ratio: ratio, window: window}
+}
+
+func (b *Budget) onRequest(now func() time.Time) {
+	if b == nil {
+		return
+	}
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	b.roll(now())
+	b.requests++
+}
+
+// tryRetry records a retry and returns true if the budget allows it, returns false otherwise
+func (b *Budget) tryRetry(now func() time.Time) bool {
+	if b == nil {
+		return true
+	}
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	b.roll(now())
+	allowed := int(b.ratio * float64(b.requests))
+	if allowed < minRetriesPerWindow {
+		allowed = minRetriesPerWindow
+	}
+	if b.retries >= allowed {
+		return false
+	}
+	b.retries++
+	return true
+}
+
+func (b *Budget) roll(now time.Time) {
+	if now.Sub(b.windowStart) < b.window {
+		return
+	}
+	b.windowStart = now
+	b.requests = 0
+	b.retries = 0
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+)
+
+func TestBudget(outer *testing.T) {
+	start := time.Now()
+	now := start
+	clock := func() time.Time { return now }
+
+	outer.Run("nil budget allows every retry", func(t *testing.T) {
+		var budget *Budget
+
+		budget.onRequest(clock)
+
+		AssertTrue(t, budget.tryRetry(clock))
+	})
+
+	outer.Run("allows minimum retries per window", func(t *testing.T) {
+		budget := NewBudget(0.1, time.Second)
+
+		for i := 0; i < minRetriesPerWindow; i++ {
+			AssertTrue(t, budget.tryRetry(clock))
+		}
+		AssertFalse(t, budget.tryRetry(clock))
+	})
+
+	outer.Run("allows retries up to ratio of requests", func(t *testing.T) {
+		budget := NewBudget(0.5, time.Second)
+		for i := 0; i < 30; i++ {
+			budget.onRequest(clock)
+		}
+
+		for i := 0; i < 15; i++ {
+			AssertTrue(t, budget.tryRetry(clock))
+		}
+		AssertFalse(t, budget.tryRetry(clock))
+	})
+
+	outer.Run("resets every window", func(t *testing.T) {
+		now = start
+		budget := NewBudget(0.1, time.Second)
+		for i := 0; i < minRetriesPerWindow; i++ {
+			budget.tryRetry(clock)
+		}
+		AssertFalse(t, budget.tryRetry(clock))
+
+		now = start.Add(time.Second)
+
+		AssertTrue(t, budget.tryRetry(clock))
+	})
+}
+
+func TestStateWithBudget(t *testing.T) {
+	now := time.Now()
+	budget := NewBudget(0.1, time.Minute)
+	for i := 0; i < minRetriesPerWindow; i++ {
+		budget.tryRetry(func() time.Time { return now })
+	}
+	state := State{
+		MaxTransactionRetryTime: time.Minute,
+		Log:                     &log.Void{},
+		Clock:                   clock.NewFake(now),
+		Throttle:                Throttler(time.Millisecond),
+		Budget:                  budget,
+	}
+	transientErr := errors.New("transient")
+
+	AssertTrue(t, state.Continue())
+	state.LastErr = transientErr
+	state.LastErrWasRetryable = true
+
+	AssertFalse(t, state.Continue())
+	AssertDeepEquals(t, state.Causes, []string{"Retry budget exhausted"})
+	AssertDeepEquals(t, state.Errs, []error{transientErr})
+}
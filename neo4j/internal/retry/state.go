@@ -27,6 +27,7 @@ import (
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 )
@@ -43,6 +44,19 @@ func (e *CommitFailedDeadError) Error() string {
 	return fmt.Sprintf("Connection lost during commit: %s", e.inner)
 }
 
+// RetryableError marks an error as retryable regardless of its own classification
+type RetryableError struct {
+	Inner error
+}
+
+func (e *RetryableError) Error() string {
+	return e.Inner.Error()
+}
+
+func (e *RetryableError) Unwrap() error {
+	return e.Inner
+}
+
 type State struct {
 	LastErrWasRetryable     bool
 	LastErr                 error
@@ -53,18 +67,25 @@ type State struct {
 	Log                     log.Logger
 	LogName                 string
 	LogId                   string
-	Now                     func() time.Time
-	Sleep                   func(time.Duration)
+	Clock                   clock.Clock
 	Throttle                Throttler
 	MaxDeadConnections      int
 	Router                  Router
 	DatabaseName            string
+	Budget                  *Budget
+	// RetryDelay is the total time slept before retrying so far
+	RetryDelay time.Duration
 
 	start            time.Time
 	cause            string
 	deadErrors       int
 	skipSleep        bool
 	OnDeadConnection func(server string) error
+	// RefreshExpiredToken optionally tells whether err is caused by an expired authentication token that could be
+	// refreshed, in which case the transaction is retried with the fresh token
+	RefreshExpiredToken func(ctx context.Context, conn idb.Connection, err error) bool
+	// IsRetryable optionally overrides the classification of the errors occurring on live connections
+	IsRetryable func(err error) bool
 }
 
 func (s *State) OnFailure(ctx context.Context, conn idb.Connection, err error, isCommitting bool) {
@@ -74,9 +95,9 @@ func (s *State) OnFailure(ctx context.Context, conn idb.Connection, err error, i
 
 	// Check timeout
 	if s.start.IsZero() {
-		s.start = s.Now()
+		s.start = s.Clock.Now()
 	}
-	if s.Now().Sub(s.start) > s.MaxTransactionRetryTime {
+	if s.Clock.Now().Sub(s.start) > s.MaxTransactionRetryTime {
 		s.stop = true
 		s.cause = "Timeout"
 		return
@@ -97,6 +118,13 @@ func (s *State) OnFailure(ctx context.Context, conn idb.Connection, err error, i
 		return
 	}
 
+	if s.RefreshExpiredToken != nil && s.RefreshExpiredToken(ctx, conn, err) {
+		s.LastErrWasRetryable = true
+		s.cause = "Token expired"
+		s.skipSleep = true
+		return
+	}
+
 	// Failed to connect
 	if conn == nil {
 		s.LastErrWasRetryable = true
@@ -124,7 +152,15 @@ func (s *State) OnFailure(ctx context.Context, conn idb.Connection, err error, i
 	}
 
 	s.LastErrWasRetryable = IsRetryable(err)
-	if dbErr, isDbErr := err.(*db.Neo4jError); isDbErr {
+	if s.IsRetryable != nil {
+		s.LastErrWasRetryable = s.IsRetryable(err)
+	}
+	if !s.LastErrWasRetryable {
+		s.stop = true
+		return
+	}
+	var dbErr *db.Neo4jError
+	if errors.As(err, &dbErr) {
 		if dbErr.IsRetriableCluster() {
 			// Force routing tables to be updated before trying again
 			if err := s.Router.Invalidate(ctx, s.DatabaseName); err != nil {
@@ -139,13 +175,13 @@ func (s *State) OnFailure(ctx context.Context, conn idb.Connection, err error, i
 			return
 		}
 	}
-
-	s.stop = true
+	s.cause = "Retryable error"
 }
 
 func (s *State) Continue() bool {
 	// No error happened yet
 	if !s.stop && s.LastErr == nil {
+		s.Budget.onRequest(s.Clock.Now)
 		return true
 	}
 
@@ -157,6 +193,11 @@ func (s *State) Continue() bool {
 
 	// Retry after optional sleep
 	if !s.stop {
+		if !s.Budget.tryRetry(s.Clock.Now) {
+			s.Causes = append(s.Causes, "Retry budget exhausted")
+			s.Log.Warnf(s.LogName, s.LogId, "Not retrying transaction, retry budget exhausted: %s", s.LastErr)
+			return false
+		}
 		if s.skipSleep {
 			s.Log.Debugf(s.LogName, s.LogId, "Retrying transaction (%s): %s", s.cause, s.LastErr)
 		} else {
@@ -164,7 +205,8 @@ func (s *State) Continue() bool {
 			sleepTime := s.Throttle.delay()
 			s.Log.Debugf(s.LogName, s.LogId,
 				"Retrying transaction (%s): %s [after %s]", s.cause, s.LastErr, sleepTime)
-			s.Sleep(sleepTime)
+			s.Clock.Sleep(sleepTime)
+			s.RetryDelay += sleepTime
 		}
 		return true
 	}
@@ -173,6 +215,10 @@ func (s *State) Continue() bool {
 }
 
 func IsRetryable(err error) bool {
+	var retryableErr *RetryableError
+	if errors.As(err, &retryableErr) {
+		return true
+	}
 	var dbError *db.Neo4jError
 	if !errors.As(err, &dbError) {
 		return false
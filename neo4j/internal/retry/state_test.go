@@ -22,12 +22,14 @@ package retry
 import (
 	"context"
 	"errors"
+	"fmt"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"io"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
@@ -122,6 +124,10 @@ func TestState(outer *testing.T) {
 			{conn: &testutil.ConnFake{Alive: true}, err: errors.New("client error"), expectContinued: false,
 				expectLastErrWasRetryable: false},
 		},
+		"User defined retryable error": {
+			{conn: &testutil.ConnFake{Alive: true}, err: &RetryableError{Inner: errors.New("client error")},
+				expectContinued: true, expectLastErrWasRetryable: true},
+		},
 		"Fail during commit": {
 			{conn: &testutil.ConnFake{Alive: false}, err: io.EOF, isCommitting: true, expectContinued: false,
 				expectLastErrWasRetryable: false, expectLastErrType: &CommitFailedDeadError{}},
@@ -151,13 +157,12 @@ func TestState(outer *testing.T) {
 	ctx := context.Background()
 	for i, testCase := range testCases {
 		outer.Run(i, func(t *testing.T) {
-			now := baseTime
+			fakeClock := clock.NewFake(baseTime)
 			state := State{
-				Now:                     func() time.Time { return now },
+				Clock:                   fakeClock,
 				Log:                     &log.Void{},
 				LogName:                 "TEST",
 				LogId:                   "State",
-				Sleep:                   func(time.Duration) {},
 				MaxTransactionRetryTime: maxRetryTime,
 				MaxDeadConnections:      maxDead,
 				DatabaseName:            dbName,
@@ -165,7 +170,7 @@ func TestState(outer *testing.T) {
 			for _, invocation := range testCase {
 				// Update now if a value has been provided
 				if !invocation.now.IsZero() {
-					now = invocation.now
+					fakeClock.Set(invocation.now)
 				}
 				router := &testutil.RouterFake{}
 				state.Router = router
@@ -202,3 +207,98 @@ func TestState(outer *testing.T) {
 		})
 	}
 }
+
+func TestStateWithRetryableOverride(outer *testing.T) {
+	ctx := context.Background()
+	businessErr := errors.New("conflict")
+	newState := func() *State {
+		return &State{
+			MaxTransactionRetryTime: time.Minute,
+			Log:                     &log.Void{},
+			Clock:                   clock.NewFake(time.Now()),
+			Throttle:                Throttler(time.Millisecond),
+			IsRetryable: func(err error) bool {
+				return err == businessErr
+			},
+		}
+	}
+
+	outer.Run("Retries errors classified as retryable", func(t *testing.T) {
+		state := newState()
+
+		state.OnFailure(ctx, &testutil.ConnFake{Alive: true}, businessErr, false)
+
+		testutil.AssertTrue(t, state.Continue())
+		testutil.AssertTrue(t, state.LastErrWasRetryable)
+		testutil.AssertDeepEquals(t, state.Causes, []string{"Retryable error"})
+	})
+
+	outer.Run("Stops on errors classified as non-retryable", func(t *testing.T) {
+		state := newState()
+
+		state.OnFailure(ctx, &testutil.ConnFake{Alive: true}, &db.Neo4jError{Code: "Neo.TransientError.Some.Some"}, false)
+
+		testutil.AssertFalse(t, state.Continue())
+		testutil.AssertFalse(t, state.LastErrWasRetryable)
+	})
+
+	outer.Run("Invalidates the routing table on wrapped cluster errors", func(t *testing.T) {
+		state := newState()
+		state.IsRetryable = nil
+		state.DatabaseName = "neo4j"
+		router := &testutil.RouterFake{}
+		state.Router = router
+		clusterErr := &db.Neo4jError{Code: "Neo.ClientError.Cluster.NotALeader"}
+
+		state.OnFailure(ctx, &testutil.ConnFake{Alive: true}, fmt.Errorf("write failed: %w", clusterErr), false)
+
+		testutil.AssertTrue(t, state.Continue())
+		testutil.AssertTrue(t, router.Invalidated)
+		testutil.AssertStringEqual(t, router.InvalidatedDb, "neo4j")
+		testutil.AssertDeepEquals(t, state.Causes, []string{"Cluster error"})
+	})
+
+	outer.Run("Still retries on dead connections", func(t *testing.T) {
+		state := newState()
+		state.MaxDeadConnections = 1
+		state.OnDeadConnection = func(string) error { return nil }
+
+		state.OnFailure(ctx, &testutil.ConnFake{Alive: false}, io.EOF, false)
+
+		testutil.AssertTrue(t, state.Continue())
+	})
+}
+
+func TestStateWithExpiredToken(outer *testing.T) {
+	ctx := context.Background()
+	tokenExpiredErr := &db.Neo4jError{Code: "Neo.ClientError.Security.TokenExpired"}
+	newState := func(refreshed bool) *State {
+		return &State{
+			MaxTransactionRetryTime: time.Minute,
+			Log:                     &log.Void{},
+			Clock:                   clock.NewFake(time.Now()),
+			Throttle:                Throttler(time.Millisecond),
+			RefreshExpiredToken: func(_ context.Context, _ idb.Connection, err error) bool {
+				return refreshed && err == tokenExpiredErr
+			},
+		}
+	}
+
+	outer.Run("Retries once the token is refreshed", func(t *testing.T) {
+		state := newState(true)
+
+		state.OnFailure(ctx, &testutil.ConnFake{Alive: true}, tokenExpiredErr, false)
+
+		testutil.AssertTrue(t, state.Continue())
+		testutil.AssertTrue(t, state.LastErrWasRetryable)
+		testutil.AssertDeepEquals(t, state.Causes, []string{"Token expired"})
+	})
+
+	outer.Run("Stops when the token cannot be refreshed", func(t *testing.T) {
+		state := newState(false)
+
+		state.OnFailure(ctx, &testutil.ConnFake{Alive: true}, tokenExpiredErr, false)
+
+		testutil.AssertFalse(t, state.Continue())
+	})
+}
@@ -20,11 +20,16 @@
 package router
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 )
 
+// ErrNoReaders is wrapped by the error returned by Router.Readers when the routing table of the database does not
+// contain any reader
+var ErrNoReaders = errors.New("no readers")
+
 type ReadRoutingTableError struct {
 	err    error
 	server string
@@ -37,6 +42,10 @@ func (e *ReadRoutingTableError) Error() string {
 	return "Unable to retrieve routing table, no router provided"
 }
 
+func (e *ReadRoutingTableError) Unwrap() error {
+	return e.err
+}
+
 func wrapError(server string, err error) error {
 	// Preserve error originating from the database, wrap other errors
 	_, isNeo4jErr := err.(*db.Neo4jError)
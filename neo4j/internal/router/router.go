@@ -24,6 +24,7 @@ import (
 	"errors"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/racing"
+	"sort"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
@@ -33,8 +34,9 @@ const missingWriterRetries = 100
 const missingReaderRetries = 100
 
 type databaseRouter struct {
-	dueUnix int64
-	table   *db.RoutingTable
+	dueUnix   int64
+	fetchedAt time.Time
+	table     *db.RoutingTable
 }
 
 // Router is thread safe
@@ -49,6 +51,11 @@ type Router struct {
 	getRouters    func() []string
 	log           log.Logger
 	logId         string
+	// PreferReadReplicas makes Readers return the read replicas of the routing table when there are any.
+	// Read replicas are the readers that are not routers as well.
+	PreferReadReplicas bool
+	// defaultDatabase is the name of the default database resolved by Prefetch, empty if it has not been prefetched
+	defaultDatabase string
 }
 
 type Pool interface {
@@ -144,6 +151,38 @@ func (r *Router) getOrReadTable(ctx context.Context, bookmarksFn func(context.Co
 	return table, nil
 }
 
+// Prefetch reads the routing table of the given database, unless the cached one remains valid for at least the given
+// duration. This allows refreshing routing tables in the background, before they are needed.
+// The table of the default database is stored under its resolved name, which GetNameOfDefaultDatabase then returns
+// without a round trip as long as the table is valid.
+func (r *Router) Prefetch(ctx context.Context, database string, validFor time.Duration) error {
+	now := r.now()
+
+	if !r.dbRoutersMut.TryLock(ctx) {
+		return racing.LockTimeoutError("could not acquire router lock in time when prefetching routing table")
+	}
+	defer r.dbRoutersMut.Unlock()
+
+	name := database
+	if database == db.DefaultDatabase {
+		name = r.defaultDatabase
+	}
+	dbRouter := r.dbRouters[name]
+	if dbRouter != nil && now.Add(validFor).Unix() < dbRouter.dueUnix {
+		return nil
+	}
+	table, err := r.readTable(ctx, dbRouter, nil, database, "", nil)
+	if err != nil {
+		return err
+	}
+	if database == db.DefaultDatabase {
+		name = table.DatabaseName
+		r.defaultDatabase = name
+	}
+	r.storeRoutingTable(name, table, now)
+	return nil
+}
+
 func (r *Router) Readers(ctx context.Context, bookmarks func(context.Context) ([]string, error), database string, boltLogger log.BoltLogger) ([]string, error) {
 	table, err := r.getOrReadTable(ctx, bookmarks, database, boltLogger)
 	if err != nil {
@@ -168,12 +207,33 @@ func (r *Router) Readers(ctx context.Context, bookmarks func(context.Context) ([
 		}
 	}
 	if len(table.Readers) == 0 {
-		return nil, wrapError(r.rootRouter, errors.New("no readers"))
+		return nil, wrapError(r.rootRouter, ErrNoReaders)
 	}
 
+	if r.PreferReadReplicas {
+		if replicas := readReplicas(table); len(replicas) > 0 {
+			return replicas, nil
+		}
+	}
 	return table.Readers, nil
 }
 
+// readReplicas returns the readers of the routing table that are not also routers.
+// Core members of a cluster are always routers, read replicas never are.
+func readReplicas(table *db.RoutingTable) []string {
+	routers := make(map[string]struct{}, len(table.Routers))
+	for _, router := range table.Routers {
+		routers[router] = struct{}{}
+	}
+	var replicas []string
+	for _, reader := range table.Readers {
+		if _, isRouter := routers[reader]; !isRouter {
+			replicas = append(replicas, reader)
+		}
+	}
+	return replicas
+}
+
 func (r *Router) Writers(ctx context.Context, bookmarks func(context.Context) ([]string, error), database string, boltLogger log.BoltLogger) ([]string, error) {
 	table, err := r.getOrReadTable(ctx, bookmarks, database, boltLogger)
 	if err != nil {
@@ -205,6 +265,11 @@ func (r *Router) Writers(ctx context.Context, bookmarks func(context.Context) ([
 }
 
 func (r *Router) GetNameOfDefaultDatabase(ctx context.Context, bookmarks []string, user string, boltLogger log.BoltLogger) (string, error) {
+	if user == "" {
+		if name, err := r.prefetchedDefaultDatabase(ctx); err != nil || name != "" {
+			return name, err
+		}
+	}
 	table, err := r.readTable(ctx, nil, bookmarks, db.DefaultDatabase, user, boltLogger)
 	if err != nil {
 		return "", err
@@ -219,6 +284,23 @@ func (r *Router) GetNameOfDefaultDatabase(ctx context.Context, bookmarks []strin
 	return table.DatabaseName, err
 }
 
+// prefetchedDefaultDatabase returns the name of the default database resolved by Prefetch, provided that its routing
+// table is still valid, or an empty string
+func (r *Router) prefetchedDefaultDatabase(ctx context.Context) (string, error) {
+	if !r.dbRoutersMut.TryLock(ctx) {
+		return "", racing.LockTimeoutError("could not acquire router lock in time when resolving home database")
+	}
+	defer r.dbRoutersMut.Unlock()
+	if r.defaultDatabase == "" {
+		return "", nil
+	}
+	dbRouter := r.dbRouters[r.defaultDatabase]
+	if dbRouter == nil || r.now().Unix() >= dbRouter.dueUnix {
+		return "", nil
+	}
+	return r.defaultDatabase, nil
+}
+
 func (r *Router) Context() map[string]string {
 	return r.routerContext
 }
@@ -294,10 +376,32 @@ func (r *Router) CleanUp(ctx context.Context) error {
 	return nil
 }
 
+// TableStates returns the state of each cached routing table, sorted by database name.
+func (r *Router) TableStates(ctx context.Context) ([]db.RoutingTableState, error) {
+	if !r.dbRoutersMut.TryLock(ctx) {
+		return nil, racing.LockTimeoutError("could not acquire router lock in time when getting routing table states")
+	}
+	defer r.dbRoutersMut.Unlock()
+
+	states := make([]db.RoutingTableState, 0, len(r.dbRouters))
+	for database, dbRouter := range r.dbRouters {
+		states = append(states, db.RoutingTableState{
+			DatabaseName: database,
+			FetchedAt:    dbRouter.fetchedAt,
+			ExpiresAt:    dbRouter.fetchedAt.Add(time.Duration(dbRouter.table.TimeToLive) * time.Second),
+		})
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].DatabaseName < states[j].DatabaseName
+	})
+	return states, nil
+}
+
 func (r *Router) storeRoutingTable(database string, table *db.RoutingTable, now time.Time) {
 	r.dbRouters[database] = &databaseRouter{
-		table:   table,
-		dueUnix: now.Add(time.Duration(table.TimeToLive) * time.Second).Unix(),
+		table:     table,
+		fetchedAt: now,
+		dueUnix:   now.Add(time.Duration(table.TimeToLive) * time.Second).Unix(),
 	}
 	r.log.Debugf(log.Router, r.logId, "New routing table for '%s', TTL %d", database, table.TimeToLive)
 }
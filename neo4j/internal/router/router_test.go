@@ -356,6 +356,166 @@ func TestReadersRetriesWhenNoReaders(t *testing.T) {
 	}
 }
 
+func TestReadersFailsWhenNoReaders(t *testing.T) {
+	table := &db.RoutingTable{TimeToLive: 1, Routers: []string{"rt1"}, Writers: []string{"wd1"}}
+	pool := &poolFake{
+		borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+			return &testutil.ConnFake{Table: table}, nil
+		},
+	}
+	router := New("router", func() []string { return []string{} }, nil, pool, logger, "routerid")
+	router.sleep = func(time.Duration) {}
+
+	_, err := router.Readers(context.Background(), nilBookmarks, "dbname", nil)
+	if !errors.Is(err, ErrNoReaders) {
+		t.Errorf("Expected no readers error, got: %v", err)
+	}
+}
+
+func TestReadersPreferReadReplicas(outer *testing.T) {
+	newRouter := func(table *db.RoutingTable) *Router {
+		pool := &poolFake{
+			borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+				return &testutil.ConnFake{Table: table}, nil
+			},
+		}
+		router := New("router", func() []string { return []string{} }, nil, pool, logger, "routerid")
+		router.PreferReadReplicas = true
+		return router
+	}
+
+	outer.Run("returns read replicas only", func(t *testing.T) {
+		table := &db.RoutingTable{
+			TimeToLive: 1,
+			Routers:    []string{"core1", "core2", "core3"},
+			Writers:    []string{"core1"},
+			Readers:    []string{"core2", "replica1", "core3", "replica2"},
+		}
+		router := newRouter(table)
+
+		readers, err := router.Readers(context.Background(), nilBookmarks, "dbname", nil)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertDeepEquals(t, readers, []string{"replica1", "replica2"})
+	})
+
+	outer.Run("falls back to core readers without read replicas", func(t *testing.T) {
+		table := &db.RoutingTable{
+			TimeToLive: 1,
+			Routers:    []string{"core1", "core2", "core3"},
+			Writers:    []string{"core1"},
+			Readers:    []string{"core2", "core3"},
+		}
+		router := newRouter(table)
+
+		readers, err := router.Readers(context.Background(), nilBookmarks, "dbname", nil)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertDeepEquals(t, readers, []string{"core2", "core3"})
+	})
+}
+
+func TestTableStates(t *testing.T) {
+	tables := map[string]*db.RoutingTable{
+		"db1": {TimeToLive: 10, Routers: []string{"rt"}, Readers: []string{"rd"}, Writers: []string{"wr"}},
+		"db2": {TimeToLive: 20, Routers: []string{"rt"}, Readers: []string{"rd"}, Writers: []string{"wr"}},
+	}
+	pool := &poolFake{
+		borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+			return &testutil.ConnFake{Table: &db.RoutingTable{}}, nil
+		},
+	}
+	router := New("router", func() []string { return []string{} }, nil, pool, logger, "routerid")
+	now := time.Now()
+	router.storeRoutingTable("db2", tables["db2"], now.Add(time.Second))
+	router.storeRoutingTable("db1", tables["db1"], now)
+
+	states, err := router.TableStates(context.Background())
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertDeepEquals(t, states, []db.RoutingTableState{
+		{DatabaseName: "db1", FetchedAt: now, ExpiresAt: now.Add(10 * time.Second)},
+		{DatabaseName: "db2", FetchedAt: now.Add(time.Second), ExpiresAt: now.Add(21 * time.Second)},
+	})
+}
+
+func TestPrefetch(t *testing.T) {
+	numfetch := 0
+	table := &db.RoutingTable{TimeToLive: 30, Readers: []string{"router1"}}
+	pool := &poolFake{
+		borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+			numfetch++
+			return &testutil.ConnFake{Table: table}, nil
+		},
+	}
+	now := time.Now()
+	router := New("router", func() []string { return []string{} }, nil, pool, logger, "routerid")
+	router.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// First prefetch should read the table
+	testutil.AssertNoError(t, router.Prefetch(ctx, "db1", 10*time.Second))
+	assertNum(t, numfetch, 1, "Should have fetched initial")
+
+	// Readers should then use the prefetched table
+	if _, err := router.Readers(ctx, nilBookmarks, "db1", nil); err != nil {
+		testutil.AssertNoError(t, err)
+	}
+	assertNum(t, numfetch, 1, "Should not have fetched")
+
+	// Table remaining valid long enough should not be read again
+	now = now.Add(15 * time.Second)
+	testutil.AssertNoError(t, router.Prefetch(ctx, "db1", 10*time.Second))
+	assertNum(t, numfetch, 1, "Should not have fetched")
+
+	// Table expiring within the given duration should be read again, before its expiry
+	now = now.Add(10 * time.Second)
+	testutil.AssertNoError(t, router.Prefetch(ctx, "db1", 10*time.Second))
+	assertNum(t, numfetch, 2, "Should have fetched")
+}
+
+func TestPrefetchDefaultDatabase(t *testing.T) {
+	numfetch := 0
+	table := &db.RoutingTable{TimeToLive: 30, DatabaseName: "home", Readers: []string{"router1"}}
+	pool := &poolFake{
+		borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+			numfetch++
+			return &testutil.ConnFake{Table: table}, nil
+		},
+	}
+	now := time.Now()
+	router := New("router", func() []string { return []string{} }, nil, pool, logger, "routerid")
+	router.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	testutil.AssertNoError(t, router.Prefetch(ctx, db.DefaultDatabase, 10*time.Second))
+	assertNum(t, numfetch, 1, "Should have fetched initial")
+
+	// Sessions to the default database should then resolve it and use its table without any round trip
+	name, err := router.GetNameOfDefaultDatabase(ctx, nil, "", nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringEqual(t, name, "home")
+	if _, err := router.Readers(ctx, nilBookmarks, name, nil); err != nil {
+		testutil.AssertNoError(t, err)
+	}
+	assertNum(t, numfetch, 1, "Should not have fetched")
+
+	// Table remaining valid long enough should not be read again
+	testutil.AssertNoError(t, router.Prefetch(ctx, db.DefaultDatabase, 10*time.Second))
+	assertNum(t, numfetch, 1, "Should not have fetched")
+
+	// Impersonated users may have another home database
+	_, err = router.GetNameOfDefaultDatabase(ctx, nil, "someone", nil)
+	testutil.AssertNoError(t, err)
+	assertNum(t, numfetch, 2, "Should have fetched")
+
+	// The home database is resolved again once its table expired
+	now = now.Add(31 * time.Second)
+	_, err = router.GetNameOfDefaultDatabase(ctx, nil, "", nil)
+	testutil.AssertNoError(t, err)
+	assertNum(t, numfetch, 3, "Should have fetched")
+}
+
 // TODO: Tests here
 
 func TestCleanUp(t *testing.T) {
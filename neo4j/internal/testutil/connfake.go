@@ -40,6 +40,12 @@ type RecordedTx struct {
 	Bookmarks []string
 	Timeout   time.Duration
 	Meta      map[string]any
+	// ImpersonatedUser is the user impersonated by the transaction
+	ImpersonatedUser string
+	// FetchSize is the fetch size of the auto-commit query, only recorded by Run
+	FetchSize int
+	// Notifications are the notification settings of the transaction
+	Notifications idb.NotificationConfig
 }
 
 type ConnFake struct {
@@ -65,15 +71,22 @@ type ConnFake struct {
 	ConsumeErr         error
 	ConsumeHook        func()
 	RecordedTxs        []RecordedTx // Appended to by Run/TxBegin
+	KeysRet            []string
+	KeysErr            error
 	BufferErr          error
 	BufferHook         func()
 	DatabaseName       string
 	Idle               time.Time
 	ServerVersionValue string
 	ForceResetHook     func()
+	TerminationNotice  bool
+	Auth               map[string]any
+	ReAuthErr          error
+	Received           int64
+	LastErr            error
 }
 
-func (c *ConnFake) Connect(context.Context, int, map[string]any, string, map[string]string) error {
+func (c *ConnFake) Connect(context.Context, int, map[string]any, string, map[string]string, idb.NotificationConfig) error {
 	return nil
 }
 
@@ -85,6 +98,34 @@ func (c *ConnFake) IsAlive() bool {
 	return c.Alive
 }
 
+func (c *ConnFake) TerminationNotified() bool {
+	return c.TerminationNotice
+}
+
+func (c *ConnFake) AuthToken() map[string]any {
+	return c.Auth
+}
+
+func (c *ConnFake) ReAuth(_ context.Context, auth map[string]any) error {
+	if c.ReAuthErr != nil {
+		return c.ReAuthErr
+	}
+	c.Auth = auth
+	return nil
+}
+
+func (c *ConnFake) SupportsReAuth() bool {
+	return c.ReAuthErr != idb.ErrReAuthNotSupported
+}
+
+func (c *ConnFake) BytesReceived() int64 {
+	return c.Received
+}
+
+func (c *ConnFake) LastError() error {
+	return c.LastErr
+}
+
 func (c *ConnFake) HasFailed() bool {
 	return false
 }
@@ -130,14 +171,15 @@ func (c *ConnFake) Consume(context.Context, idb.StreamHandle) (*db.Summary, erro
 }
 
 func (c *ConnFake) GetRoutingTable(_ context.Context, _ map[string]string, _ []string, database, _ string) (*idb.RoutingTable, error) {
-	if c.Table != nil {
+	// the server resolves the name of the default database
+	if c.Table != nil && database != idb.DefaultDatabase {
 		c.Table.DatabaseName = database
 	}
 	return c.Table, c.Err
 }
 
 func (c *ConnFake) TxBegin(_ context.Context, txConfig idb.TxConfig) (idb.TxHandle, error) {
-	c.RecordedTxs = append(c.RecordedTxs, RecordedTx{Origin: "TxBegin", Mode: txConfig.Mode, Bookmarks: txConfig.Bookmarks, Timeout: txConfig.Timeout, Meta: txConfig.Meta})
+	c.RecordedTxs = append(c.RecordedTxs, RecordedTx{Origin: "TxBegin", Mode: txConfig.Mode, Bookmarks: txConfig.Bookmarks, Timeout: txConfig.Timeout, Meta: txConfig.Meta, ImpersonatedUser: txConfig.ImpersonatedUser, Notifications: txConfig.Notifications})
 	return c.TxBeginHandle, c.TxBeginErr
 }
 
@@ -152,9 +194,9 @@ func (c *ConnFake) TxCommit(context.Context, idb.TxHandle) error {
 	return c.TxCommitErr
 }
 
-func (c *ConnFake) Run(_ context.Context, _ idb.Command, txConfig idb.TxConfig) (idb.StreamHandle, error) {
+func (c *ConnFake) Run(_ context.Context, cmd idb.Command, txConfig idb.TxConfig) (idb.StreamHandle, error) {
 
-	c.RecordedTxs = append(c.RecordedTxs, RecordedTx{Origin: "Run", Mode: txConfig.Mode, Bookmarks: txConfig.Bookmarks, Timeout: txConfig.Timeout, Meta: txConfig.Meta})
+	c.RecordedTxs = append(c.RecordedTxs, RecordedTx{Origin: "Run", Mode: txConfig.Mode, Bookmarks: txConfig.Bookmarks, Timeout: txConfig.Timeout, Meta: txConfig.Meta, ImpersonatedUser: txConfig.ImpersonatedUser, FetchSize: cmd.FetchSize, Notifications: txConfig.Notifications})
 	return c.RunStream, c.RunErr
 }
 
@@ -163,7 +205,7 @@ func (c *ConnFake) RunTx(context.Context, idb.TxHandle, idb.Command) (idb.Stream
 }
 
 func (c *ConnFake) Keys(idb.StreamHandle) ([]string, error) {
-	return nil, nil
+	return c.KeysRet, c.KeysErr
 }
 
 func (c *ConnFake) Next(context.Context, idb.StreamHandle) (*db.Record, *db.Summary, error) {
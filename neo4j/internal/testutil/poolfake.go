@@ -32,9 +32,16 @@ type PoolFake struct {
 	ReturnHook  func()
 	CleanUpHook func()
 	BorrowHook  func() (db.Connection, error)
+	ReborrowRet db.Connection
+	// LivenessCheckThreshold is the threshold of the last Borrow call
+	LivenessCheckThreshold time.Duration
+	// BorrowCtx is the context of the last Borrow call
+	BorrowCtx context.Context
 }
 
-func (p *PoolFake) Borrow(context.Context, []string, bool, log.BoltLogger, time.Duration) (db.Connection, error) {
+func (p *PoolFake) Borrow(ctx context.Context, _ []string, _ bool, _ log.BoltLogger, livenessCheckThreshold time.Duration) (db.Connection, error) {
+	p.LivenessCheckThreshold = livenessCheckThreshold
+	p.BorrowCtx = ctx
 	if p.BorrowHook != nil && (p.BorrowConn != nil || p.BorrowErr != nil) {
 		panic("either use the hook or the desired return values, but not both")
 	}
@@ -44,6 +51,10 @@ func (p *PoolFake) Borrow(context.Context, []string, bool, log.BoltLogger, time.
 	return p.BorrowConn, p.BorrowErr
 }
 
+func (p *PoolFake) Reborrow(context.Context, db.Connection, log.BoltLogger, time.Duration) db.Connection {
+	return p.ReborrowRet
+}
+
 func (p *PoolFake) Return(context.Context, db.Connection) error {
 	if p.ReturnHook != nil {
 		p.ReturnHook()
@@ -21,6 +21,7 @@ package testutil
 
 import (
 	"context"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 )
 
@@ -35,6 +36,7 @@ type RouterFake struct {
 	CleanUpHook            func()
 	GetNameOfDefaultDbHook func(user string) (string, error)
 	InvalidatedServer      string
+	TableStatesRet         []db.RoutingTableState
 }
 
 func (r *RouterFake) InvalidateReader(ctx context.Context, database string, server string) error {
@@ -82,3 +84,7 @@ func (r *RouterFake) CleanUp(ctx context.Context) error {
 	}
 	return nil
 }
+
+func (r *RouterFake) TableStates(context.Context) ([]db.RoutingTableState, error) {
+	return r.TableStatesRet, r.Err
+}
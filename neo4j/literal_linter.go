@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/collection"
+	"sync"
+	"unicode"
+)
+
+// InlinedLiteralsNotice describes a query containing literal values that are likely to have been inlined in the
+// query text instead of being passed as parameters, see Config.OnInlinedLiterals
+type InlinedLiteralsNotice struct {
+	// Query is the query text, as passed to the driver
+	Query string
+	// QueryFingerprint identifies the query regardless of its literal values, see QueryFingerprint
+	QueryFingerprint string
+	// QueryName is the name of the prepared query with the same fingerprint, empty if there is none, see
+	// DriverWithContext.Prepare
+	QueryName string
+	// Literals are the string and number literals found in the query, in order of appearance
+	Literals []QueryLiteral
+}
+
+// QueryLiteral is a string or number literal found in the text of a query
+type QueryLiteral struct {
+	// Text is the literal as written in the query, including the quotes of string literals
+	Text string
+	// Offset is the position of the first character of the literal in the query, starting at 0
+	Offset int
+}
+
+// literalLinter reports the literals of every distinct query fingerprint once, for the whole lifetime of the driver.
+// Drivers only create a linter when Config.OnInlinedLiterals is set, sessions and transactions of other drivers hold
+// a nil linter, whose lint returns without scanning queries.
+type literalLinter struct {
+	onInlinedLiterals func(InlinedLiteralsNotice)
+	mut               sync.Mutex
+	linted            collection.Set[string]
+}
+
+func newLiteralLinter(onInlinedLiterals func(InlinedLiteralsNotice)) *literalLinter {
+	return &literalLinter{
+		onInlinedLiterals: onInlinedLiterals,
+		linted:            collection.NewSet[string](nil),
+	}
+}
+
+// lint calls the callback with the literals of the given query, unless the query has no literals or a query with the
+// same fingerprint has already been linted
+func (l *literalLinter) lint(cypher string) {
+	if l == nil {
+		return
+	}
+	fingerprint := QueryFingerprint(cypher)
+	if !l.claim(fingerprint) {
+		return
+	}
+	literals := findLiterals(cypher)
+	if len(literals) == 0 {
+		return
+	}
+	l.onInlinedLiterals(InlinedLiteralsNotice{
+		Query:            cypher,
+		QueryFingerprint: fingerprint,
+		Literals:         literals,
+	})
+}
+
+// claim returns true if the fingerprint has not been linted yet, and marks it as linted
+func (l *literalLinter) claim(fingerprint string) bool {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	if _, found := l.linted[fingerprint]; found {
+		return false
+	}
+	l.linted.Add(fingerprint)
+	return true
+}
+
+// findLiterals returns the string and number literals of the given query, following the same lexical rules as
+// NormalizeQuery: literals in comments, escaped identifiers, parameter names and identifiers are ignored
+func findLiterals(cypher string) []QueryLiteral {
+	var literals []QueryLiteral
+	runes := []rune(cypher)
+	for i := 0; i < len(runes); i++ {
+		current := runes[i]
+		switch {
+		case current == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			i = skipUntil(runes, i+2, "\n")
+		case current == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i = skipUntil(runes, i+2, "*/")
+		case current == '\'' || current == '"':
+			end := skipStringLiteral(runes, i)
+			literals = append(literals, QueryLiteral{Text: string(runes[i : end+1]), Offset: i})
+			i = end
+		case current == '`':
+			i = skipUntil(runes, i+1, "`")
+		case unicode.IsDigit(current):
+			end := skipNumberLiteral(runes, i)
+			literals = append(literals, QueryLiteral{Text: string(runes[i : end+1]), Offset: i})
+			i = end
+		case isIdentifierRune(current) || current == '$':
+			for i+1 < len(runes) && isIdentifierRune(runes[i+1]) {
+				i++
+			}
+		}
+	}
+	return literals
+}
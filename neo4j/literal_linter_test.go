@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"testing"
+)
+
+func TestLiteralLinter(outer *testing.T) {
+	outer.Parallel()
+
+	outer.Run("reports literals of each fingerprint once", func(t *testing.T) {
+		var notices []InlinedLiteralsNotice
+		linter := newLiteralLinter(func(notice InlinedLiteralsNotice) {
+			notices = append(notices, notice)
+		})
+
+		linter.lint("MATCH (n {name: 'Alice'}) WHERE n.age > 42 RETURN n")
+		linter.lint("MATCH (n {name: 'Bob'}) WHERE n.age > 21 RETURN n")
+		linter.lint("MATCH (n {name: $name}) RETURN n")
+
+		AssertIntEqual(t, len(notices), 1)
+		AssertStringEqual(t, notices[0].Query, "MATCH (n {name: 'Alice'}) WHERE n.age > 42 RETURN n")
+		AssertStringEqual(t, notices[0].QueryFingerprint,
+			QueryFingerprint("MATCH (n {name: 'Alice'}) WHERE n.age > 42 RETURN n"))
+		AssertDeepEquals(t, notices[0].Literals, []QueryLiteral{
+			{Text: "'Alice'", Offset: 16},
+			{Text: "42", Offset: 40},
+		})
+	})
+
+	outer.Run("does nothing when nil", func(t *testing.T) {
+		var linter *literalLinter
+
+		linter.lint("RETURN 42")
+	})
+}
+
+func TestFindLiterals(outer *testing.T) {
+	outer.Parallel()
+
+	type testCase struct {
+		description string
+		cypher      string
+		expected    []QueryLiteral
+	}
+
+	testCases := []testCase{
+		{
+			description: "string literals with either quote and escapes",
+			cypher:      `RETURN "a\"b", 'c'`,
+			expected:    []QueryLiteral{{Text: `"a\"b"`, Offset: 7}, {Text: "'c'", Offset: 15}},
+		},
+		{
+			description: "number literals",
+			cypher:      "RETURN 1.5e-3, 0x1F",
+			expected:    []QueryLiteral{{Text: "1.5e-3", Offset: 7}, {Text: "0x1F", Offset: 15}},
+		},
+		{
+			description: "offsets in characters",
+			cypher:      "RETURN 'é', 1",
+			expected:    []QueryLiteral{{Text: "'é'", Offset: 7}, {Text: "1", Offset: 12}},
+		},
+		{
+			description: "ignores comments, escaped identifiers, parameters and identifiers",
+			cypher:      "// 'a'\nMATCH (`n 1`:Label2 {id: $id1}) /* 2 */ RETURN n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		outer.Run(testCase.description, func(t *testing.T) {
+			AssertDeepEquals(t, findLiterals(testCase.cypher), testCase.expected)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type fieldsKey struct{}
+
+type contextFields struct {
+	fields map[string]string
+	// prefix is the rendered form of fields, escaped to be used as part of a format string
+	prefix string
+}
+
+// WithFields returns a copy of the parent context carrying the given key/value pairs, on top of the ones the parent
+// context may already carry.
+// The driver includes these pairs in its log lines and Bolt logger calls for all the operations using the resulting
+// context, which enables per-request log correlation:
+//
+//	ctx = log.WithFields(ctx, map[string]string{"request_id": requestId})
+//	result, err := session.Run(ctx, "RETURN 42", nil)
+//	// log lines related to this Run call are prefixed with [request_id=...]
+func WithFields(parent context.Context, fields map[string]string) context.Context {
+	merged := make(map[string]string, len(fields))
+	for key, value := range FieldsFrom(parent) {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	pairs := make([]string, 0, len(merged))
+	for key, value := range merged {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	prefix := strings.ReplaceAll(fmt.Sprintf("[%s] ", strings.Join(pairs, " ")), "%", "%%")
+	return context.WithValue(parent, fieldsKey{}, &contextFields{fields: merged, prefix: prefix})
+}
+
+// FieldsFrom returns the key/value pairs attached to the context with WithFields, or nil if there is none
+func FieldsFrom(ctx context.Context) map[string]string {
+	if fields := fieldsOf(ctx); fields != nil {
+		return fields.fields
+	}
+	return nil
+}
+
+func fieldsOf(ctx context.Context) *contextFields {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).(*contextFields)
+	return fields
+}
+
+// ForContext returns a logger prefixing all messages with the key/value pairs attached to the context with
+// WithFields.
+// The given logger is returned as is if the context does not carry any pair.
+func ForContext(ctx context.Context, logger Logger) Logger {
+	fields := fieldsOf(ctx)
+	if fields == nil || logger == nil {
+		return logger
+	}
+	return &fieldsLogger{delegate: logger, prefix: fields.prefix}
+}
+
+// BoltForContext returns a Bolt logger prefixing all messages with the key/value pairs attached to the context with
+// WithFields.
+// The given Bolt logger is returned as is if it is nil or if the context does not carry any pair.
+func BoltForContext(ctx context.Context, logger BoltLogger) BoltLogger {
+	fields := fieldsOf(ctx)
+	if fields == nil || logger == nil {
+		return logger
+	}
+	return &fieldsBoltLogger{delegate: logger, prefix: fields.prefix}
+}
+
+type fieldsLogger struct {
+	delegate Logger
+	prefix   string
+}
+
+func (l *fieldsLogger) Error(name string, id string, err error) {
+	l.delegate.Error(name, id, fmt.Errorf(l.prefix+"%w", err))
+}
+
+func (l *fieldsLogger) Warnf(name string, id string, msg string, args ...any) {
+	l.delegate.Warnf(name, id, l.prefix+msg, args...)
+}
+
+func (l *fieldsLogger) Infof(name string, id string, msg string, args ...any) {
+	l.delegate.Infof(name, id, l.prefix+msg, args...)
+}
+
+func (l *fieldsLogger) Debugf(name string, id string, msg string, args ...any) {
+	l.delegate.Debugf(name, id, l.prefix+msg, args...)
+}
+
+type fieldsBoltLogger struct {
+	delegate BoltLogger
+	prefix   string
+}
+
+func (l *fieldsBoltLogger) LogClientMessage(context string, msg string, args ...any) {
+	l.delegate.LogClientMessage(context, l.prefix+msg, args...)
+}
+
+func (l *fieldsBoltLogger) LogServerMessage(context string, msg string, args ...any) {
+	l.delegate.LogServerMessage(context, l.prefix+msg, args...)
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JSONBoltLogger writes one JSON object per line for each Bolt message, instead of the console format, so that the
+// wire traffic can be analysed by tools or asserted on in tests.
+// Each object has the following attributes:
+//   - time: when the message was logged, in RFC 3339 format with nanoseconds
+//   - id: the ID of the connection, omitted for handshakes
+//   - direction: "client" for messages sent by the driver, "server" for messages received from the server
+//   - type: the message type, e.g. "RUN" or "SUCCESS"
+//   - fields: the message fields, sanitized like in the console format (e.g. credentials are redacted)
+//
+// It can be shared by concurrent connections.
+type JSONBoltLogger struct {
+	writer io.Writer
+	mut    sync.Mutex
+}
+
+// NewJSONBoltLogger creates a JSONBoltLogger writing to the given writer
+func NewJSONBoltLogger(writer io.Writer) *JSONBoltLogger {
+	return &JSONBoltLogger{writer: writer}
+}
+
+type jsonBoltMessage struct {
+	Time      string `json:"time"`
+	Id        string `json:"id,omitempty"`
+	Direction string `json:"direction"`
+	Type      string `json:"type"`
+	Fields    []any  `json:"fields"`
+}
+
+func (jbl *JSONBoltLogger) LogClientMessage(id, msg string, args ...any) {
+	jbl.logBoltMessage("client", id, msg, args)
+}
+
+func (jbl *JSONBoltLogger) LogServerMessage(id, msg string, args ...any) {
+	jbl.logBoltMessage("server", id, msg, args)
+}
+
+func (jbl *JSONBoltLogger) logBoltMessage(direction, id string, msg string, args []any) {
+	message := jsonBoltMessage{
+		Time:      time.Now().Format(time.RFC3339Nano),
+		Id:        id,
+		Direction: direction,
+		Type:      messageType(msg),
+		Fields:    make([]any, len(args)),
+	}
+	for i, arg := range args {
+		if bytes, ok := arg.([]byte); ok {
+			arg = fmt.Sprintf("%#X", bytes)
+		}
+		message.Fields[i] = arg
+	}
+	line, err := json.Marshal(message)
+	if err != nil {
+		// fall back to the textual representation of the fields
+		for i, arg := range args {
+			message.Fields[i] = fmt.Sprint(arg)
+		}
+		line, _ = json.Marshal(message)
+	}
+	jbl.mut.Lock()
+	defer jbl.mut.Unlock()
+	_, _ = jbl.writer.Write(append(line, '\n'))
+}
+
+// messageType extracts the message type from the leading words of the format of a Bolt message, e.g. "PULL" from
+// "PULL %s" or "HANDSHAKE" from "<HANDSHAKE> %#010X"
+func messageType(msg string) string {
+	words := strings.Fields(msg)
+	end := 0
+	for end < len(words) && !strings.ContainsAny(words[end], "%<") {
+		end++
+	}
+	if end == 0 && len(words) > 0 {
+		return strings.Trim(words[0], "<>")
+	}
+	return strings.Join(words[:end], " ")
+}
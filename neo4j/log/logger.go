@@ -54,6 +54,7 @@ const (
 	Bolt3   = "bolt3"
 	Bolt4   = "bolt4"
 	Bolt5   = "bolt5"
+	Http    = "http"
 	Driver  = "driver"
 	Pool    = "pool"
 	Router  = "router"
@@ -0,0 +1,464 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package migrate applies versioned Cypher migrations to a Neo4j database, and tracks the applied versions in the
+// graph itself.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/errorutil"
+)
+
+// DefaultLabel is the label of the nodes tracking the applied migrations
+const DefaultLabel = "__Neo4jMigration"
+
+var fileNamePattern = regexp.MustCompile(`^V(\d+)__(.+)\.cypher$`)
+
+// Migration is a versioned list of Cypher statements
+type Migration struct {
+	// Version orders migrations, it must be greater than 0 and unique
+	Version int
+	// Description explains the purpose of the migration
+	Description string
+	// Statements are run in order, in a single transaction
+	Statements []string
+}
+
+// Checksum identifies the statements of the migration, so that changes to already applied migrations are detected
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(strings.Join(m.Statements, ";\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// AppliedMigration describes a migration tracked in the database
+type AppliedMigration struct {
+	Version     int
+	Description string
+	Checksum    string
+	AppliedAt   time.Time
+}
+
+// Config configures migration runs
+type Config struct {
+	// SessionConfig configures the sessions running the migrations, for instance their target database.
+	// The access mode is ignored since migrations are always run with SessionWithContext.ExecuteWrite.
+	SessionConfig neo4j.SessionConfig
+	// Label is the label of the nodes tracking the applied migrations.
+	// Runs are serialized by a lock node labeled with Label followed by "Lock", e.g. __Neo4jMigrationLock.
+	//
+	// default: DefaultLabel
+	Label string
+	// DryRun makes Migrate only validate the migrations and report the pending ones, without applying them.
+	//
+	// default: false
+	DryRun bool
+}
+
+// Report describes the outcome of a migration run
+type Report struct {
+	// Pending are the migrations that were not applied yet when the run started
+	Pending []Migration
+	// Applied are the migrations applied by the run, always empty in dry-run mode
+	Applied []Migration
+	// Version is the version of the database once the run is over, 0 when no migration is applied
+	Version int
+}
+
+// ChecksumMismatchError is returned when an applied migration has been changed since it was applied
+type ChecksumMismatchError struct {
+	Version  int
+	Applied  string
+	Expected string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("migration %d has changed since it was applied: checksum %s does not match applied checksum %s",
+		e.Version, e.Expected, e.Applied)
+}
+
+// LockedError is returned when the migration lock is held by another run.
+// If no other run is in progress, the lock has been left by a run that could not release it, for instance because
+// its process crashed, and its node must be deleted before migrating again.
+type LockedError struct {
+	// Label is the label of the lock node
+	Label string
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("migrations are locked by another run, if none is in progress delete the node labeled %s", e.Label)
+}
+
+// Load reads the migrations of the files of dir named like V<version>__<description>.cypher, for instance
+// V2__add_person_constraint.cypher. Underscores of the description are replaced by spaces and other files are ignored.
+//
+// Statements are separated by semicolons, except the ones within string literals, quoted identifiers and comments.
+// Comments preceding a statement are dropped. Migrations are returned sorted by version.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	var migrations []Migration
+	for _, entry := range entries {
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil {
+			continue
+		}
+		version, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version of migration file %s: %w", entry.Name(), err)
+		}
+		script, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{
+			Version:     version,
+			Description: strings.ReplaceAll(matches[2], "_", " "),
+			Statements:  splitStatements(string(script)),
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+	for len(script) > 0 {
+		length := tokenLength(script)
+		token := script[:length]
+		script = script[length:]
+		switch {
+		case token == ";":
+			flush()
+		case isComment(token) && strings.TrimSpace(current.String()) == "":
+			// comments preceding a statement are dropped
+		default:
+			current.WriteString(token)
+		}
+	}
+	flush()
+	return statements
+}
+
+// tokenLength returns the length of the string literal, quoted identifier or comment starting the script, or 1 if
+// it starts with none of them. Unterminated tokens span the rest of the script.
+func tokenLength(script string) int {
+	switch {
+	case strings.HasPrefix(script, "//"):
+		if end := strings.IndexByte(script, '\n'); end >= 0 {
+			return end
+		}
+		return len(script)
+	case strings.HasPrefix(script, "/*"):
+		if end := strings.Index(script[2:], "*/"); end >= 0 {
+			return end + 4
+		}
+		return len(script)
+	case script[0] == '\'' || script[0] == '"':
+		for i := 1; i < len(script); i++ {
+			if script[i] == '\\' {
+				i++
+			} else if script[i] == script[0] {
+				return i + 1
+			}
+		}
+		return len(script)
+	case script[0] == '`':
+		// backticks are escaped by doubling them
+		for i := 1; i < len(script); i++ {
+			if script[i] == '`' {
+				if i+1 < len(script) && script[i+1] == '`' {
+					i++
+					continue
+				}
+				return i + 1
+			}
+		}
+		return len(script)
+	default:
+		return 1
+	}
+}
+
+func isComment(token string) bool {
+	return strings.HasPrefix(token, "//") || strings.HasPrefix(token, "/*")
+}
+
+// Migrate applies the given migrations that are not applied yet, in version order.
+//
+// Applied migrations are first checked against the given ones: Migrate fails with a ChecksumMismatchError if an
+// applied migration has changed, and fails as well if an applied migration is missing or if a pending migration is
+// older than the latest applied one.
+//
+// Each migration runs in its own transaction function, and is then tracked by a node in a separate transaction, since
+// Neo4j does not allow schema changes and data writes in the same transaction. As a consequence, a migration that
+// creates indexes or constraints cannot write data, and migrations should be idempotent (e.g. with IF NOT EXISTS)
+// so that they can be rerun if the driver fails before tracking them.
+//
+// Migrate stops at the first failing migration, the returned Report then lists the migrations applied so far.
+//
+// Unless in dry-run mode, concurrent runs against the same database are serialized by a lock node, backed by a
+// uniqueness constraint that Migrate creates if needed: Migrate fails with a LockedError while another run holds the
+// lock, and releases the lock once it is done.
+func Migrate(ctx context.Context, driver neo4j.DriverWithContext, migrations []Migration, config Config) (report Report, err error) {
+	if err = normalizeConfig(&config); err != nil {
+		return Report{}, err
+	}
+	if err = validateMigrations(migrations); err != nil {
+		return Report{}, err
+	}
+	session := driver.NewSession(ctx, config.SessionConfig)
+	defer session.Close(ctx)
+
+	if !config.DryRun {
+		if err = lock(ctx, session, config.Label); err != nil {
+			return Report{}, err
+		}
+		defer func() {
+			err = errorutil.CombineErrors(err, unlock(ctx, session, config.Label))
+		}()
+	}
+
+	applied, err := appliedMigrations(ctx, session, config.Label)
+	if err != nil {
+		return Report{}, err
+	}
+	if len(applied) > 0 {
+		report.Version = applied[len(applied)-1].Version
+	}
+	report.Pending, err = pendingMigrations(migrations, applied)
+	if err != nil {
+		return report, err
+	}
+	if config.DryRun {
+		return report, nil
+	}
+	for _, migration := range report.Pending {
+		if err = apply(ctx, session, config.Label, migration); err != nil {
+			return report, fmt.Errorf("could not apply migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+		report.Applied = append(report.Applied, migration)
+		report.Version = migration.Version
+	}
+	return report, nil
+}
+
+// Applied returns the migrations applied to the database, sorted by version
+func Applied(ctx context.Context, driver neo4j.DriverWithContext, config Config) ([]AppliedMigration, error) {
+	if err := normalizeConfig(&config); err != nil {
+		return nil, err
+	}
+	session := driver.NewSession(ctx, config.SessionConfig)
+	defer session.Close(ctx)
+	return appliedMigrations(ctx, session, config.Label)
+}
+
+func normalizeConfig(config *Config) error {
+	if config.Label == "" {
+		config.Label = DefaultLabel
+	}
+	if strings.Contains(config.Label, "`") {
+		return &neo4j.UsageError{Message: fmt.Sprintf("Migration label cannot contain backticks, got %s", config.Label)}
+	}
+	return nil
+}
+
+func validateMigrations(migrations []Migration) error {
+	versions := make(map[int]bool, len(migrations))
+	for _, migration := range migrations {
+		if migration.Version <= 0 {
+			return &neo4j.UsageError{Message: fmt.Sprintf("Migration version must be greater than 0, got %d", migration.Version)}
+		}
+		if versions[migration.Version] {
+			return &neo4j.UsageError{Message: fmt.Sprintf("Migration version %d is defined more than once", migration.Version)}
+		}
+		if len(migration.Statements) == 0 {
+			return &neo4j.UsageError{Message: fmt.Sprintf("Migration %d has no statements", migration.Version)}
+		}
+		versions[migration.Version] = true
+	}
+	return nil
+}
+
+func pendingMigrations(migrations []Migration, applied []AppliedMigration) ([]Migration, error) {
+	byVersion := make(map[int]Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+	latest := 0
+	for _, appliedMigration := range applied {
+		migration, found := byVersion[appliedMigration.Version]
+		if !found {
+			return nil, fmt.Errorf("applied migration %d (%s) is missing", appliedMigration.Version, appliedMigration.Description)
+		}
+		if checksum := migration.Checksum(); checksum != appliedMigration.Checksum {
+			return nil, &ChecksumMismatchError{Version: migration.Version, Applied: appliedMigration.Checksum, Expected: checksum}
+		}
+		delete(byVersion, appliedMigration.Version)
+		latest = appliedMigration.Version
+	}
+	pending := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Version < latest {
+			return nil, fmt.Errorf("migration %d is older than the latest applied migration %d", migration.Version, latest)
+		}
+		pending = append(pending, migration)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Version < pending[j].Version
+	})
+	return pending, nil
+}
+
+// lock creates the lock node, after creating the uniqueness constraint that prevents concurrent runs from creating
+// their own
+func lock(ctx context.Context, session neo4j.SessionWithContext, label string) error {
+	lockLabel := label + "Lock"
+	constraint := fmt.Sprintf("CREATE CONSTRAINT IF NOT EXISTS FOR (l:`%s`) REQUIRE l.id IS UNIQUE", lockLabel)
+	if err := write(ctx, session, constraint, nil); err != nil {
+		return fmt.Errorf("could not create the migration lock constraint: %w", err)
+	}
+	query := fmt.Sprintf("CREATE (:`%s` {id: 'lock', lockedAt: datetime()})", lockLabel)
+	if err := write(ctx, session, query, nil); err != nil {
+		var neo4jErr *neo4j.Neo4jError
+		if errors.As(err, &neo4jErr) && neo4jErr.Code == "Neo.ClientError.Schema.ConstraintValidationFailed" {
+			return &LockedError{Label: lockLabel}
+		}
+		return fmt.Errorf("could not lock migrations: %w", err)
+	}
+	return nil
+}
+
+func unlock(ctx context.Context, session neo4j.SessionWithContext, label string) error {
+	query := fmt.Sprintf("MATCH (l:`%sLock` {id: 'lock'}) DELETE l", label)
+	if err := write(ctx, session, query, nil); err != nil {
+		return fmt.Errorf("could not unlock migrations: %w", err)
+	}
+	return nil
+}
+
+// write runs the query in its own write transaction and discards its result
+func write(ctx context.Context, session neo4j.SessionWithContext, query string, params map[string]any) error {
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(ctx)
+	})
+	return err
+}
+
+func appliedMigrations(ctx context.Context, session neo4j.SessionWithContext, label string) ([]AppliedMigration, error) {
+	query := fmt.Sprintf("MATCH (m:`%s`) "+
+		"RETURN m.version AS version, m.description AS description, m.checksum AS checksum, m.appliedAt AS appliedAt "+
+		"ORDER BY m.version", label)
+	records, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+		return result.Collect(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read applied migrations: %w", err)
+	}
+	applied := make([]AppliedMigration, 0, len(records.([]*neo4j.Record)))
+	for _, record := range records.([]*neo4j.Record) {
+		migration, err := toAppliedMigration(record)
+		if err != nil {
+			return nil, fmt.Errorf("could not read applied migrations: %w", err)
+		}
+		applied = append(applied, migration)
+	}
+	return applied, nil
+}
+
+func toAppliedMigration(record *neo4j.Record) (AppliedMigration, error) {
+	if err := neo4j.DecodeRawRecord(record); err != nil {
+		return AppliedMigration{}, err
+	}
+	version, _, err := neo4j.GetRecordValue[int64](record, "version")
+	if err != nil {
+		return AppliedMigration{}, err
+	}
+	description, _, err := neo4j.GetRecordValue[string](record, "description")
+	if err != nil {
+		return AppliedMigration{}, err
+	}
+	checksum, _, err := neo4j.GetRecordValue[string](record, "checksum")
+	if err != nil {
+		return AppliedMigration{}, err
+	}
+	value, _ := record.Get("appliedAt")
+	appliedAt, ok := value.(time.Time)
+	if !ok {
+		return AppliedMigration{}, fmt.Errorf("expected appliedAt to be a date time, got %T", value)
+	}
+	return AppliedMigration{Version: int(version), Description: description, Checksum: checksum, AppliedAt: appliedAt}, nil
+}
+
+func apply(ctx context.Context, session neo4j.SessionWithContext, label string, migration Migration) error {
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		for _, statement := range migration.Statements {
+			result, err := tx.Run(ctx, statement, nil)
+			if err != nil {
+				return nil, err
+			}
+			if _, err = result.Consume(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf("CREATE (:`%s` {version: $version, description: $description, checksum: $checksum, "+
+		"appliedAt: datetime()})", label)
+	return write(ctx, session, query, map[string]any{
+		"version":     migration.Version,
+		"description": migration.Description,
+		"checksum":    migration.Checksum(),
+	})
+}
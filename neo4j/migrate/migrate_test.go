@@ -0,0 +1,366 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestLoad(outer *testing.T) {
+	outer.Parallel()
+
+	outer.Run("loads migration files sorted by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/V10__add_movies.cypher": {Data: []byte("CREATE (:Movie {title: 'The Matrix'})")},
+			"migrations/V2__add_person_constraint.cypher": {Data: []byte(
+				"// people are unique\n" +
+					"CREATE CONSTRAINT person_name IF NOT EXISTS\n" +
+					"FOR (p:Person) REQUIRE p.name IS UNIQUE;\n" +
+					"\n" +
+					"CREATE INDEX person_age IF NOT EXISTS FOR (p:Person) ON (p.age);\n")},
+			"migrations/README.md": {Data: []byte("not a migration")},
+		}
+
+		migrations, err := Load(fsys, "migrations")
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, migrations, []Migration{
+			{Version: 2, Description: "add person constraint", Statements: []string{
+				"CREATE CONSTRAINT person_name IF NOT EXISTS\nFOR (p:Person) REQUIRE p.name IS UNIQUE",
+				"CREATE INDEX person_age IF NOT EXISTS FOR (p:Person) ON (p.age)",
+			}},
+			{Version: 10, Description: "add movies", Statements: []string{"CREATE (:Movie {title: 'The Matrix'})"}},
+		})
+	})
+
+	outer.Run("splits statements on semicolons outside of literals and comments", func(t *testing.T) {
+		fsys := fstest.MapFS{"V1__tricky.cypher": {Data: []byte(
+			"CREATE (:Quote {text: 'a;b', other: \"c\\\";d\"}); /* one; two */ MATCH (`a;``b`) RETURN 1;\n" +
+				"// trailing; comment\n" +
+				"RETURN 2 // not; split\n")}}
+
+		migrations, err := Load(fsys, ".")
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, migrations[0].Statements, []string{
+			"CREATE (:Quote {text: 'a;b', other: \"c\\\";d\"})",
+			"MATCH (`a;``b`) RETURN 1",
+			"RETURN 2 // not; split",
+		})
+	})
+
+	outer.Run("fails when the directory does not exist", func(t *testing.T) {
+		_, err := Load(fstest.MapFS{}, "migrations")
+
+		AssertNotNil(t, err)
+	})
+}
+
+func TestMigrate(outer *testing.T) {
+	outer.Parallel()
+
+	ctx := context.Background()
+	migrations := []Migration{
+		{Version: 1, Description: "add constraint", Statements: []string{"CREATE CONSTRAINT c1"}},
+		{Version: 2, Description: "add people", Statements: []string{"CREATE (:Person)", "CREATE (:Person)"}},
+	}
+
+	outer.Run("applies pending migrations in order", func(t *testing.T) {
+		driver := &fakeDriver{}
+
+		report, err := Migrate(ctx, driver, []Migration{migrations[1], migrations[0]}, Config{})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, report, Report{Pending: migrations, Applied: migrations, Version: 2})
+		AssertDeepEquals(t, driver.statements, []string{"CREATE CONSTRAINT c1", "CREATE (:Person)", "CREATE (:Person)"})
+		AssertLen(t, driver.nodes, 2)
+		AssertDeepEquals(t, driver.nodes[1]["checksum"], migrations[1].Checksum())
+	})
+
+	outer.Run("skips applied migrations", func(t *testing.T) {
+		driver := &fakeDriver{}
+		_, err := Migrate(ctx, driver, migrations[:1], Config{})
+		AssertNoError(t, err)
+		driver.statements = nil
+
+		report, err := Migrate(ctx, driver, migrations, Config{})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, report, Report{Pending: migrations[1:], Applied: migrations[1:], Version: 2})
+		AssertDeepEquals(t, driver.statements, []string{"CREATE (:Person)", "CREATE (:Person)"})
+	})
+
+	outer.Run("only reports pending migrations in dry-run mode", func(t *testing.T) {
+		driver := &fakeDriver{}
+
+		report, err := Migrate(ctx, driver, migrations, Config{DryRun: true})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, report, Report{Pending: migrations})
+		AssertLen(t, driver.statements, 0)
+		AssertLen(t, driver.nodes, 0)
+	})
+
+	outer.Run("fails when an applied migration changed", func(t *testing.T) {
+		driver := &fakeDriver{}
+		_, err := Migrate(ctx, driver, migrations[:1], Config{})
+		AssertNoError(t, err)
+		changed := []Migration{{Version: 1, Description: "add constraint", Statements: []string{"CREATE CONSTRAINT c2"}}}
+
+		_, err = Migrate(ctx, driver, changed, Config{})
+
+		var mismatch *ChecksumMismatchError
+		AssertTrue(t, errors.As(err, &mismatch))
+		AssertIntEqual(t, mismatch.Version, 1)
+		AssertStringEqual(t, mismatch.Applied, migrations[0].Checksum())
+		AssertStringEqual(t, mismatch.Expected, changed[0].Checksum())
+	})
+
+	outer.Run("fails when an applied migration is missing", func(t *testing.T) {
+		driver := &fakeDriver{}
+		_, err := Migrate(ctx, driver, migrations, Config{})
+		AssertNoError(t, err)
+
+		_, err = Migrate(ctx, driver, migrations[1:], Config{})
+
+		AssertStringContain(t, err.Error(), "applied migration 1 (add constraint) is missing")
+	})
+
+	outer.Run("fails when a pending migration is older than the latest applied one", func(t *testing.T) {
+		driver := &fakeDriver{}
+		_, err := Migrate(ctx, driver, migrations[1:], Config{})
+		AssertNoError(t, err)
+
+		_, err = Migrate(ctx, driver, migrations, Config{})
+
+		AssertStringContain(t, err.Error(), "migration 1 is older than the latest applied migration 2")
+	})
+
+	outer.Run("stops at the first failing migration", func(t *testing.T) {
+		failure := errors.New("oopsie")
+		driver := &fakeDriver{failOn: "CREATE (:Person)", err: failure}
+
+		report, err := Migrate(ctx, driver, migrations, Config{})
+
+		AssertTrue(t, errors.Is(err, failure))
+		AssertDeepEquals(t, report, Report{Pending: migrations, Applied: migrations[:1], Version: 1})
+		AssertLen(t, driver.nodes, 1)
+	})
+
+	outer.Run("tracks migrations with the configured label", func(t *testing.T) {
+		driver := &fakeDriver{}
+
+		_, err := Migrate(ctx, driver, migrations[:1], Config{Label: "SchemaVersion"})
+
+		AssertNoError(t, err)
+		AssertTrue(t, strings.HasPrefix(driver.queries[2], "MATCH (m:`SchemaVersion`)"))
+		AssertTrue(t, strings.HasPrefix(driver.queries[4], "CREATE (:`SchemaVersion`"))
+	})
+
+	outer.Run("locks migrations while applying them", func(t *testing.T) {
+		driver := &fakeDriver{}
+
+		_, err := Migrate(ctx, driver, migrations, Config{})
+
+		AssertNoError(t, err)
+		AssertStringEqual(t, driver.queries[0],
+			"CREATE CONSTRAINT IF NOT EXISTS FOR (l:`__Neo4jMigrationLock`) REQUIRE l.id IS UNIQUE")
+		AssertTrue(t, strings.HasPrefix(driver.queries[1], "CREATE (:`__Neo4jMigrationLock`"))
+		AssertTrue(t, strings.HasPrefix(driver.queries[len(driver.queries)-1], "MATCH (l:`__Neo4jMigrationLock`"))
+		AssertFalse(t, driver.locked)
+	})
+
+	outer.Run("fails while another run holds the lock", func(t *testing.T) {
+		driver := &fakeDriver{locked: true}
+
+		_, err := Migrate(ctx, driver, migrations, Config{})
+
+		AssertDeepEquals(t, err, &LockedError{Label: "__Neo4jMigrationLock"})
+		AssertLen(t, driver.statements, 0)
+		AssertTrue(t, driver.locked)
+	})
+
+	outer.Run("releases the lock when a migration fails", func(t *testing.T) {
+		failure := errors.New("oopsie")
+		driver := &fakeDriver{failOn: "CREATE CONSTRAINT c1", err: failure}
+
+		_, err := Migrate(ctx, driver, migrations, Config{})
+
+		AssertTrue(t, errors.Is(err, failure))
+		AssertFalse(t, driver.locked)
+	})
+
+	outer.Run("does not lock in dry-run mode", func(t *testing.T) {
+		driver := &fakeDriver{locked: true}
+
+		_, err := Migrate(ctx, driver, migrations, Config{DryRun: true})
+
+		AssertNoError(t, err)
+		AssertLen(t, driver.queries, 1)
+	})
+
+	outer.Run("configures sessions", func(t *testing.T) {
+		driver := &fakeDriver{}
+		sessionConfig := neo4j.SessionConfig{DatabaseName: "movies"}
+
+		_, err := Migrate(ctx, driver, migrations, Config{SessionConfig: sessionConfig})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, driver.sessionConfigs, []neo4j.SessionConfig{sessionConfig})
+	})
+
+	outer.Run("rejects invalid migrations", func(inner *testing.T) {
+		testCases := map[string][]Migration{
+			"zero version":       {{Version: 0, Statements: []string{"RETURN 1"}}},
+			"duplicated version": {{Version: 1, Statements: []string{"RETURN 1"}}, {Version: 1, Statements: []string{"RETURN 2"}}},
+			"no statements":      {{Version: 1}},
+		}
+		for description, invalidMigrations := range testCases {
+			inner.Run(description, func(t *testing.T) {
+				_, err := Migrate(ctx, &fakeDriver{}, invalidMigrations, Config{})
+
+				AssertTrue(t, neo4j.IsUsageError(err))
+			})
+		}
+	})
+
+	outer.Run("rejects labels with backticks", func(t *testing.T) {
+		_, err := Migrate(ctx, &fakeDriver{}, migrations, Config{Label: "Oops`"})
+
+		AssertTrue(t, neo4j.IsUsageError(err))
+	})
+}
+
+func TestApplied(t *testing.T) {
+	ctx := context.Background()
+	driver := &fakeDriver{}
+	migration := Migration{Version: 1, Description: "add constraint", Statements: []string{"CREATE CONSTRAINT c1"}}
+	_, err := Migrate(ctx, driver, []Migration{migration}, Config{})
+	AssertNoError(t, err)
+
+	applied, err := Applied(ctx, driver, Config{})
+
+	AssertNoError(t, err)
+	AssertDeepEquals(t, applied, []AppliedMigration{{
+		Version:     1,
+		Description: "add constraint",
+		Checksum:    migration.Checksum(),
+		AppliedAt:   driver.nodes[0]["appliedAt"].(time.Time),
+	}})
+}
+
+// fakeDriver keeps track of the migration nodes, and of the migration statements that are run
+type fakeDriver struct {
+	neo4j.DriverWithContext
+	failOn         string
+	err            error
+	sessionConfigs []neo4j.SessionConfig
+	queries        []string
+	statements     []string
+	nodes          []map[string]any
+	locked         bool
+}
+
+func (d *fakeDriver) NewSession(_ context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	d.sessionConfigs = append(d.sessionConfigs, config)
+	return &fakeSession{driver: d}
+}
+
+type fakeSession struct {
+	neo4j.SessionWithContext
+	driver *fakeDriver
+}
+
+func (s *fakeSession) ExecuteRead(_ context.Context, work neo4j.ManagedTransactionWork, _ ...func(*neo4j.TransactionConfig)) (any, error) {
+	return work(&fakeTransaction{driver: s.driver})
+}
+
+func (s *fakeSession) ExecuteWrite(_ context.Context, work neo4j.ManagedTransactionWork, _ ...func(*neo4j.TransactionConfig)) (any, error) {
+	return work(&fakeTransaction{driver: s.driver})
+}
+
+func (s *fakeSession) Close(context.Context) error {
+	return nil
+}
+
+type fakeTransaction struct {
+	neo4j.ManagedTransaction
+	driver *fakeDriver
+}
+
+func (tx *fakeTransaction) Run(_ context.Context, query string, params map[string]any) (neo4j.ResultWithContext, error) {
+	driver := tx.driver
+	driver.queries = append(driver.queries, query)
+	switch {
+	case strings.Contains(query, "Lock`"):
+		if strings.HasPrefix(query, "CREATE (:") {
+			if driver.locked {
+				return nil, &neo4j.Neo4jError{Code: "Neo.ClientError.Schema.ConstraintValidationFailed"}
+			}
+			driver.locked = true
+		}
+		if strings.HasPrefix(query, "MATCH (l:") {
+			driver.locked = false
+		}
+		return &fakeResult{}, nil
+	case strings.HasPrefix(query, "MATCH (m:"):
+		keys := []string{"version", "description", "checksum", "appliedAt"}
+		records := make([]*neo4j.Record, len(driver.nodes))
+		for i, node := range driver.nodes {
+			records[i] = &neo4j.Record{Keys: keys, Values: []any{
+				int64(node["version"].(int)), node["description"], node["checksum"], node["appliedAt"],
+			}}
+		}
+		return &fakeResult{records: records}, nil
+	case strings.HasPrefix(query, "CREATE (:`"):
+		node := map[string]any{"appliedAt": time.Now()}
+		for key, value := range params {
+			node[key] = value
+		}
+		driver.nodes = append(driver.nodes, node)
+		return &fakeResult{}, nil
+	case query == driver.failOn:
+		return nil, driver.err
+	default:
+		driver.statements = append(driver.statements, query)
+		return &fakeResult{}, nil
+	}
+}
+
+type fakeResult struct {
+	neo4j.ResultWithContext
+	records []*neo4j.Record
+}
+
+func (r *fakeResult) Collect(context.Context) ([]*neo4j.Record, error) {
+	return r.records, nil
+}
+
+func (r *fakeResult) Consume(context.Context) (neo4j.ResultSummary, error) {
+	return nil, nil
+}
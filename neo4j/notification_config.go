@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+
+// NotificationMinSeverity defines the minimum severity level of the notifications the server sends
+type NotificationMinSeverity string
+
+const (
+	// NotificationsServerDefault lets the server decide the minimum severity level of its notifications
+	NotificationsServerDefault NotificationMinSeverity = ""
+	// NotificationsWarning makes the server only send WARNING notifications
+	NotificationsWarning NotificationMinSeverity = "WARNING"
+	// NotificationsInformation makes the server send INFORMATION and WARNING notifications
+	NotificationsInformation NotificationMinSeverity = "INFORMATION"
+	// NotificationsOff makes the server send no notifications at all
+	NotificationsOff NotificationMinSeverity = "OFF"
+)
+
+// NotificationCategory defines a category of notifications, as returned by Notification.RawCategory
+type NotificationCategory string
+
+const (
+	NotificationCategoryHint         NotificationCategory = "HINT"
+	NotificationCategoryUnrecognized NotificationCategory = "UNRECOGNIZED"
+	NotificationCategoryUnsupported  NotificationCategory = "UNSUPPORTED"
+	NotificationCategoryPerformance  NotificationCategory = "PERFORMANCE"
+	NotificationCategoryDeprecation  NotificationCategory = "DEPRECATION"
+	NotificationCategoryGeneric      NotificationCategory = "GENERIC"
+)
+
+func (s NotificationMinSeverity) isValid() bool {
+	switch s {
+	case NotificationsServerDefault, NotificationsWarning, NotificationsInformation, NotificationsOff:
+		return true
+	}
+	return false
+}
+
+// notificationConfig converts the notification settings of the driver or of a session to their Bolt representation,
+// keeping a nil disabled categories slice apart from an empty one
+func notificationConfig(minSeverity NotificationMinSeverity, disabledCategories []NotificationCategory) idb.NotificationConfig {
+	config := idb.NotificationConfig{MinSeverity: string(minSeverity)}
+	if disabledCategories != nil {
+		config.DisabledCategories = make([]string, len(disabledCategories))
+		for i, category := range disabledCategories {
+			config.DisabledCategories[i] = string(category)
+		}
+	}
+	return config
+}
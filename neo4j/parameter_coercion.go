@@ -0,0 +1,238 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(-?\d+)Y)?(?:(-?\d+)M)?(?:(-?\d+)W)?(?:(-?\d+)D)?` +
+	`(?:T(?:(-?\d+)H)?(?:(-?\d+)M)?(?:(-?\d+)(?:[.,](\d{1,9}))?S)?)?$`)
+
+const wktNumber = `([-+]?(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][-+]?\d+)?)`
+
+var wktPointPattern = regexp.MustCompile(`^(?i:SRID=(\d+);)?\s*(?i:POINT)\s*(?i:(Z)\s*)?\(\s*` +
+	wktNumber + `\s+` + wktNumber + `(?:\s+` + wktNumber + `)?\s*\)$`)
+
+// Spatial reference ids of the Cartesian coordinate reference systems, used when WKT points do not specify any
+const (
+	cartesian2DSrid = 7203
+	cartesian3DSrid = 9157
+)
+
+// coerceParameters returns a copy of params where the strings that are well-formed ISO-8601 durations or WKT points
+// are replaced by Duration and Point2D/Point3D values, see Config.CoerceParameterStrings.
+// Strings nested in lists and maps are coerced as well, params is returned as is when nothing is coerced.
+// A UsageError is returned for durations that overflow.
+func coerceParameters(params map[string]any) (map[string]any, error) {
+	coerced, changed, err := coerceParameter(params)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return params, nil
+	}
+	return coerced.(map[string]any), nil
+}
+
+func coerceParameter(value any) (any, bool, error) {
+	switch v := value.(type) {
+	case string:
+		if duration, ok, err := parseIsoDuration(v); ok || err != nil {
+			return duration, ok, err
+		}
+		if point, ok := parseWktPoint(v); ok {
+			return point, true, nil
+		}
+		return v, false, nil
+	case []any:
+		var coerced []any
+		for i, item := range v {
+			c, changed, err := coerceParameter(item)
+			if err != nil {
+				return nil, false, err
+			}
+			if changed {
+				if coerced == nil {
+					coerced = append([]any(nil), v...)
+				}
+				coerced[i] = c
+			}
+		}
+		if coerced == nil {
+			return v, false, nil
+		}
+		return coerced, true, nil
+	case map[string]any:
+		var coerced map[string]any
+		for key, item := range v {
+			c, changed, err := coerceParameter(item)
+			if err != nil {
+				return nil, false, err
+			}
+			if changed {
+				if coerced == nil {
+					coerced = make(map[string]any, len(v))
+					for k, i := range v {
+						coerced[k] = i
+					}
+				}
+				coerced[key] = c
+			}
+		}
+		if coerced == nil {
+			return v, false, nil
+		}
+		return coerced, true, nil
+	default:
+		return v, false, nil
+	}
+}
+
+// parseIsoDuration parses ISO-8601 durations such as P1Y2M3DT4H5M6.5S, as produced by Duration.String.
+// Only the seconds may have a fraction, of up to 9 digits.
+// It reports whether s is a duration, and returns a UsageError for durations that do not fit the 64-bit components
+// of Duration.
+func parseIsoDuration(s string) (dbtype.Duration, bool, error) {
+	matches := isoDurationPattern.FindStringSubmatch(s)
+	if matches == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return dbtype.Duration{}, false, nil
+	}
+	overflowErr := &UsageError{Message: fmt.Sprintf("duration %s overflows 64-bit integers", s)}
+	components := make([]int64, 7)
+	for i := range components {
+		if matches[i+1] == "" {
+			continue
+		}
+		component, err := strconv.ParseInt(matches[i+1], 10, 64)
+		if err != nil {
+			// the pattern only matches integers, so the component is out of range
+			return dbtype.Duration{}, false, overflowErr
+		}
+		components[i] = component
+	}
+	years, months, weeks, days, hours, minutes, seconds :=
+		components[0], components[1], components[2], components[3], components[4], components[5], components[6]
+	nanos := 0
+	if fraction := matches[8]; fraction != "" {
+		nanos, _ = strconv.Atoi(fraction + strings.Repeat("0", 9-len(fraction)))
+		if strings.HasPrefix(matches[7], "-") {
+			// -1.5S is -2 seconds and 500000000 nanoseconds
+			var ok bool
+			if seconds, ok = addExact(seconds, -1); !ok {
+				return dbtype.Duration{}, false, overflowErr
+			}
+			nanos = int(time.Second) - nanos
+		}
+	}
+	totalMonths, monthsOk := combine(years, 12, months, 1)
+	totalDays, daysOk := combine(weeks, 7, days, 1)
+	totalSeconds, secondsOk := combine(hours, 3600, minutes, 60, seconds, 1)
+	if !monthsOk || !daysOk || !secondsOk {
+		return dbtype.Duration{}, false, overflowErr
+	}
+	return dbtype.Duration{Months: totalMonths, Days: totalDays, Seconds: totalSeconds, Nanos: nanos}, true, nil
+}
+
+// combine returns the sum of the products of the given pairs of value and factor, and whether it fits in an int64
+func combine(valuesAndFactors ...int64) (int64, bool) {
+	var total int64
+	for i := 0; i < len(valuesAndFactors); i += 2 {
+		product, ok := multiplyExact(valuesAndFactors[i], valuesAndFactors[i+1])
+		if !ok {
+			return 0, false
+		}
+		if total, ok = addExact(total, product); !ok {
+			return 0, false
+		}
+	}
+	return total, true
+}
+
+// multiplyExact returns a*b and whether it fits in an int64
+func multiplyExact(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return product, true
+}
+
+// addExact returns a+b and whether it fits in an int64
+func addExact(a, b int64) (int64, bool) {
+	total := a + b
+	if (total > a) != (b > 0) {
+		return 0, false
+	}
+	return total, true
+}
+
+// parseWktPoint parses WKT points, optionally prefixed with a spatial reference id as in EWKT, such as POINT(1 2),
+// POINT Z(1 2 3) and SRID=4326;POINT(12.99 55.61).
+// Points without spatial reference id are Cartesian points.
+func parseWktPoint(s string) (any, bool) {
+	matches := wktPointPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return nil, false
+	}
+	is3D := matches[5] != ""
+	if matches[2] != "" && !is3D {
+		return nil, false
+	}
+	coordinates := make([]float64, 3)
+	for i := range coordinates {
+		if matches[i+3] == "" {
+			continue
+		}
+		coordinate, err := strconv.ParseFloat(matches[i+3], 64)
+		if err != nil {
+			return nil, false
+		}
+		coordinates[i] = coordinate
+	}
+	var srid uint32
+	if matches[1] != "" {
+		parsed, err := strconv.ParseUint(matches[1], 10, 32)
+		if err != nil {
+			return nil, false
+		}
+		srid = uint32(parsed)
+	}
+	if is3D {
+		if srid == 0 {
+			srid = cartesian3DSrid
+		}
+		return dbtype.Point3D{X: coordinates[0], Y: coordinates[1], Z: coordinates[2], SpatialRefId: srid}, true
+	}
+	if srid == 0 {
+		srid = cartesian2DSrid
+	}
+	return dbtype.Point2D{X: coordinates[0], Y: coordinates[1], SpatialRefId: srid}, true
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+)
+
+func TestParameterCoercion(outer *testing.T) {
+	outer.Run("Durations", func(inner *testing.T) {
+		testCases := map[string]dbtype.Duration{
+			"P1Y2M3DT4H5M6S":    {Months: 14, Days: 3, Seconds: 14706},
+			"P2W":               {Days: 14},
+			"PT0.5S":            {Nanos: 500000000},
+			"PT1,000000001S":    {Seconds: 1, Nanos: 1},
+			"PT-1.5S":           {Seconds: -2, Nanos: 500000000},
+			"P-1M-2DT-3S":       {Months: -1, Days: -2, Seconds: -3},
+			"P14M3DT14706.000S": {Months: 14, Days: 3, Seconds: 14706},
+		}
+		for input, expected := range testCases {
+			inner.Run(input, func(t *testing.T) {
+				duration, ok, err := parseIsoDuration(input)
+
+				AssertNoError(t, err)
+				AssertTrue(t, ok)
+				AssertDeepEquals(t, duration, expected)
+			})
+		}
+
+		inner.Run("round-trips with Duration.String", func(t *testing.T) {
+			expected := dbtype.Duration{Months: 5, Days: -3, Seconds: -10, Nanos: 250}
+
+			duration, ok, err := parseIsoDuration(expected.String())
+
+			AssertNoError(t, err)
+			AssertTrue(t, ok)
+			AssertDeepEquals(t, duration, expected)
+		})
+
+		for _, input := range []string{"", "P", "PT", "P1DT", "P1S", "1D", "P1.5D", "PT1.0000000001S", "P1D "} {
+			inner.Run("rejects "+input, func(t *testing.T) {
+				_, ok, err := parseIsoDuration(input)
+
+				AssertNoError(t, err)
+				AssertFalse(t, ok)
+			})
+		}
+
+		for _, input := range []string{"P9223372036854775808D", "P768614336404564651Y", "P1317624576693539402W",
+			"PT2562047788015216H", "P-1Y-9223372036854775797M", "PT-9223372036854775808.5S"} {
+			inner.Run("fails on overflowing "+input, func(t *testing.T) {
+				_, _, err := parseIsoDuration(input)
+
+				AssertSameType(t, err, &UsageError{})
+			})
+		}
+
+		inner.Run("accepts the largest durations", func(t *testing.T) {
+			duration, ok, err := parseIsoDuration("P9223372036854775807M-9223372036854775808DT9223372036854775807S")
+
+			AssertNoError(t, err)
+			AssertTrue(t, ok)
+			AssertDeepEquals(t, duration, dbtype.Duration{
+				Months: math.MaxInt64, Days: math.MinInt64, Seconds: math.MaxInt64})
+		})
+	})
+
+	outer.Run("Points", func(inner *testing.T) {
+		testCases := map[string]any{
+			"POINT(1 2)":                   dbtype.Point2D{X: 1, Y: 2, SpatialRefId: 7203},
+			"point ( -1.5  2e3 )":          dbtype.Point2D{X: -1.5, Y: 2000, SpatialRefId: 7203},
+			"POINT Z(1 2 3)":               dbtype.Point3D{X: 1, Y: 2, Z: 3, SpatialRefId: 9157},
+			"POINT(1 2 3)":                 dbtype.Point3D{X: 1, Y: 2, Z: 3, SpatialRefId: 9157},
+			"SRID=4326;POINT(12.99 55.61)": dbtype.Point2D{X: 12.99, Y: 55.61, SpatialRefId: 4326},
+			"srid=4979;POINT Z(1 2 3)":     dbtype.Point3D{X: 1, Y: 2, Z: 3, SpatialRefId: 4979},
+			" SRID=7203; POINT(.5 -.5)":    dbtype.Point2D{X: 0.5, Y: -0.5, SpatialRefId: 7203},
+		}
+		for input, expected := range testCases {
+			inner.Run(input, func(t *testing.T) {
+				point, ok := parseWktPoint(input)
+
+				AssertTrue(t, ok)
+				AssertDeepEquals(t, point, expected)
+			})
+		}
+
+		for _, input := range []string{"POINT()", "POINT(1)", "POINT Z(1 2)", "POINT(1 2 3 4)", "LINESTRING(1 2, 3 4)",
+			"SRID=;POINT(1 2)", "POINT(a b)", "Point of view"} {
+			inner.Run("rejects "+input, func(t *testing.T) {
+				_, ok := parseWktPoint(input)
+
+				AssertFalse(t, ok)
+			})
+		}
+	})
+
+	outer.Run("Coerces nested strings without altering the parameters", func(t *testing.T) {
+		params := map[string]any{
+			"name":     "Peter",
+			"timeout":  "PT30S",
+			"location": "POINT(1 2)",
+			"nested":   map[string]any{"list": []any{"P1D", 42, "text"}},
+		}
+
+		coerced, err := coerceParameters(params)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, coerced, map[string]any{
+			"name":     "Peter",
+			"timeout":  dbtype.Duration{Seconds: 30},
+			"location": dbtype.Point2D{X: 1, Y: 2, SpatialRefId: 7203},
+			"nested":   map[string]any{"list": []any{dbtype.Duration{Days: 1}, 42, "text"}},
+		})
+		AssertDeepEquals(t, params["timeout"], "PT30S")
+		AssertDeepEquals(t, params["nested"], map[string]any{"list": []any{"P1D", 42, "text"}})
+	})
+
+	outer.Run("Fails queries with overflowing durations before contacting the server", func(t *testing.T) {
+		pool := &PoolFake{}
+		conf := Config{CoerceParameterStrings: true}
+		sess := newSessionWithContext(&conf, SessionConfig{}, &RouterFake{}, pool, &log.Void{})
+
+		_, err := sess.Run(context.Background(), "RETURN $d", map[string]any{"d": []any{"P9223372036854775808D"}})
+
+		AssertSameType(t, err, &UsageError{})
+		AssertTrue(t, pool.BorrowCtx == nil)
+	})
+
+	outer.Run("Returns the parameters as is when there is nothing to coerce", func(t *testing.T) {
+		params := map[string]any{"name": "Peter", "tags": []any{"a", "b"}}
+
+		coerced, err := coerceParameters(params)
+
+		AssertNoError(t, err)
+		coerced["extra"] = true
+		AssertTrue(t, params["extra"] == true)
+	})
+}
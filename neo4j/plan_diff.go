@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+// PlanDifferenceKind identifies the kind of difference found between two plan trees, see ComparePlans and
+// CompareProfiledPlans
+type PlanDifferenceKind int
+
+const (
+	// OperatorChanged is reported when the operator of a plan node differs between both trees
+	OperatorChanged PlanDifferenceKind = iota
+	// PlanNodeAdded is reported when a plan node (and its subtree) only exists in the new tree
+	PlanNodeAdded
+	// PlanNodeRemoved is reported when a plan node (and its subtree) only exists in the old tree
+	PlanNodeRemoved
+	// DbHitsChanged is reported when the database hits of a profiled plan node differ between both trees
+	DbHitsChanged
+	// RecordsChanged is reported when the records produced by a profiled plan node differ between both trees
+	RecordsChanged
+)
+
+func (k PlanDifferenceKind) String() string {
+	switch k {
+	case OperatorChanged:
+		return "operator changed"
+	case PlanNodeAdded:
+		return "node added"
+	case PlanNodeRemoved:
+		return "node removed"
+	case DbHitsChanged:
+		return "db hits changed"
+	case RecordsChanged:
+		return "records changed"
+	default:
+		return "unknown"
+	}
+}
+
+// PlanDifference describes a single difference between two plan trees
+type PlanDifference struct {
+	Kind PlanDifferenceKind
+	// Path locates the plan node as the child indices to follow from the root, the root path being empty
+	Path []int
+	// OldOperator is the operator of the node in the old tree, empty for PlanNodeAdded differences
+	OldOperator string
+	// NewOperator is the operator of the node in the new tree, empty for PlanNodeRemoved differences
+	NewOperator string
+	// OldValue and NewValue are the compared database hits or records, only set for DbHitsChanged and
+	// RecordsChanged differences
+	OldValue int64
+	NewValue int64
+}
+
+// Delta returns the difference between the new and old values of DbHitsChanged and RecordsChanged differences
+func (d PlanDifference) Delta() int64 {
+	return d.NewValue - d.OldValue
+}
+
+// PlanReport is the result of the comparison of two plan trees
+type PlanReport struct {
+	// Differences lists the differences in depth-first order
+	Differences []PlanDifference
+	// OldTotalDbHits and NewTotalDbHits sum the database hits of all the nodes of each profiled plan tree
+	OldTotalDbHits int64
+	NewTotalDbHits int64
+}
+
+// HasStructuralChanges returns true if operators changed or plan nodes were added or removed, regardless of the
+// database hits and records.
+func (r PlanReport) HasStructuralChanges() bool {
+	for _, difference := range r.Differences {
+		switch difference.Kind {
+		case OperatorChanged, PlanNodeAdded, PlanNodeRemoved:
+			return true
+		}
+	}
+	return false
+}
+
+// TotalDbHitsDelta returns the difference between the total database hits of the new and old profiled plan trees
+func (r PlanReport) TotalDbHitsDelta() int64 {
+	return r.NewTotalDbHits - r.OldTotalDbHits
+}
+
+// ComparePlans compares the old (before) and new (after) plan trees, for instance the plans of the same query before
+// and after a server upgrade, and reports operator changes as well as added or removed plan nodes.
+// Children are matched by position: when the operator of a node changes, its children are still compared to each
+// other.
+// A nil plan is considered as an empty tree.
+func ComparePlans(before, after Plan) PlanReport {
+	var report PlanReport
+	comparePlanNodes(&report, nil, planToNode(before), planToNode(after))
+	return report
+}
+
+// CompareProfiledPlans compares two profiled plan trees like ComparePlans, and additionally reports the database
+// hits and records changes of the matched nodes as well as the total database hits of each tree.
+func CompareProfiledPlans(before, after ProfiledPlan) PlanReport {
+	beforeNode, afterNode := profiledPlanToNode(before), profiledPlanToNode(after)
+	report := PlanReport{OldTotalDbHits: beforeNode.totalDbHits(), NewTotalDbHits: afterNode.totalDbHits()}
+	comparePlanNodes(&report, nil, beforeNode, afterNode)
+	return report
+}
+
+// planNode unifies Plan and ProfiledPlan trees
+type planNode struct {
+	operator string
+	dbHits   int64
+	records  int64
+	profiled bool
+	children []*planNode
+}
+
+func planToNode(plan Plan) *planNode {
+	if plan == nil {
+		return nil
+	}
+	children := plan.Children()
+	node := &planNode{operator: plan.Operator(), children: make([]*planNode, len(children))}
+	for i, child := range children {
+		node.children[i] = planToNode(child)
+	}
+	return node
+}
+
+func profiledPlanToNode(plan ProfiledPlan) *planNode {
+	if plan == nil {
+		return nil
+	}
+	children := plan.Children()
+	node := &planNode{
+		operator: plan.Operator(),
+		dbHits:   plan.DbHits(),
+		records:  plan.Records(),
+		profiled: true,
+		children: make([]*planNode, len(children)),
+	}
+	for i, child := range children {
+		node.children[i] = profiledPlanToNode(child)
+	}
+	return node
+}
+
+func (n *planNode) totalDbHits() int64 {
+	if n == nil {
+		return 0
+	}
+	total := n.dbHits
+	for _, child := range n.children {
+		total += child.totalDbHits()
+	}
+	return total
+}
+
+func comparePlanNodes(report *PlanReport, path []int, before, after *planNode) {
+	switch {
+	case before == nil && after == nil:
+		return
+	case before == nil:
+		report.add(PlanDifference{Kind: PlanNodeAdded, Path: path, NewOperator: after.operator})
+		return
+	case after == nil:
+		report.add(PlanDifference{Kind: PlanNodeRemoved, Path: path, OldOperator: before.operator})
+		return
+	}
+	if before.operator != after.operator {
+		report.add(PlanDifference{Kind: OperatorChanged, Path: path, OldOperator: before.operator, NewOperator: after.operator})
+	}
+	if before.profiled && after.profiled {
+		if before.dbHits != after.dbHits {
+			report.add(PlanDifference{Kind: DbHitsChanged, Path: path, OldOperator: before.operator,
+				NewOperator: after.operator, OldValue: before.dbHits, NewValue: after.dbHits})
+		}
+		if before.records != after.records {
+			report.add(PlanDifference{Kind: RecordsChanged, Path: path, OldOperator: before.operator,
+				NewOperator: after.operator, OldValue: before.records, NewValue: after.records})
+		}
+	}
+	childCount := len(before.children)
+	if len(after.children) > childCount {
+		childCount = len(after.children)
+	}
+	for i := 0; i < childCount; i++ {
+		var beforeChild, afterChild *planNode
+		if i < len(before.children) {
+			beforeChild = before.children[i]
+		}
+		if i < len(after.children) {
+			afterChild = after.children[i]
+		}
+		comparePlanNodes(report, append(path[:len(path):len(path)], i), beforeChild, afterChild)
+	}
+}
+
+func (r *PlanReport) add(difference PlanDifference) {
+	if difference.Path == nil {
+		difference.Path = []int{}
+	}
+	r.Differences = append(r.Differences, difference)
+}
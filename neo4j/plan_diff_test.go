@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"testing"
+)
+
+func TestComparePlans(outer *testing.T) {
+	outer.Parallel()
+
+	outer.Run("reports no differences for identical plans", func(t *testing.T) {
+		before := &plan{plan: &db.Plan{Operator: "ProduceResults", Children: []db.Plan{{Operator: "NodeIndexSeek"}}}}
+		after := &plan{plan: &db.Plan{Operator: "ProduceResults", Children: []db.Plan{{Operator: "NodeIndexSeek"}}}}
+
+		report := ComparePlans(before, after)
+
+		AssertLen(t, report.Differences, 0)
+		AssertFalse(t, report.HasStructuralChanges())
+	})
+
+	outer.Run("reports operator changes", func(t *testing.T) {
+		before := &plan{plan: &db.Plan{Operator: "ProduceResults", Children: []db.Plan{
+			{Operator: "Filter"},
+			{Operator: "NodeIndexSeek"},
+		}}}
+		after := &plan{plan: &db.Plan{Operator: "ProduceResults", Children: []db.Plan{
+			{Operator: "Filter"},
+			{Operator: "AllNodesScan"},
+		}}}
+
+		report := ComparePlans(before, after)
+
+		AssertDeepEquals(t, report.Differences, []PlanDifference{{
+			Kind:        OperatorChanged,
+			Path:        []int{1},
+			OldOperator: "NodeIndexSeek",
+			NewOperator: "AllNodesScan",
+		}})
+		AssertTrue(t, report.HasStructuralChanges())
+	})
+
+	outer.Run("reports added and removed nodes", func(t *testing.T) {
+		before := &plan{plan: &db.Plan{Operator: "ProduceResults", Children: []db.Plan{
+			{Operator: "Filter", Children: []db.Plan{{Operator: "NodeByLabelScan"}}},
+		}}}
+		after := &plan{plan: &db.Plan{Operator: "ProduceResults", Children: []db.Plan{
+			{Operator: "Filter"},
+			{Operator: "CartesianProduct"},
+		}}}
+
+		report := ComparePlans(before, after)
+
+		AssertDeepEquals(t, report.Differences, []PlanDifference{
+			{Kind: PlanNodeRemoved, Path: []int{0, 0}, OldOperator: "NodeByLabelScan"},
+			{Kind: PlanNodeAdded, Path: []int{1}, NewOperator: "CartesianProduct"},
+		})
+	})
+
+	outer.Run("reports nil plans as empty trees", func(t *testing.T) {
+		report := ComparePlans(nil, &plan{plan: &db.Plan{Operator: "ProduceResults"}})
+
+		AssertDeepEquals(t, report.Differences, []PlanDifference{
+			{Kind: PlanNodeAdded, Path: []int{}, NewOperator: "ProduceResults"},
+		})
+	})
+}
+
+func TestCompareProfiledPlans(outer *testing.T) {
+	outer.Parallel()
+
+	outer.Run("reports db hits and records changes", func(t *testing.T) {
+		before := &profile{profile: &db.ProfiledPlan{Operator: "ProduceResults", DbHits: 0, Records: 10,
+			Children: []db.ProfiledPlan{{Operator: "NodeIndexSeek", DbHits: 11, Records: 10}}}}
+		after := &profile{profile: &db.ProfiledPlan{Operator: "ProduceResults", DbHits: 0, Records: 10,
+			Children: []db.ProfiledPlan{{Operator: "NodeIndexSeek", DbHits: 42, Records: 12}}}}
+
+		report := CompareProfiledPlans(before, after)
+
+		AssertDeepEquals(t, report.Differences, []PlanDifference{
+			{Kind: DbHitsChanged, Path: []int{0}, OldOperator: "NodeIndexSeek", NewOperator: "NodeIndexSeek",
+				OldValue: 11, NewValue: 42},
+			{Kind: RecordsChanged, Path: []int{0}, OldOperator: "NodeIndexSeek", NewOperator: "NodeIndexSeek",
+				OldValue: 10, NewValue: 12},
+		})
+		AssertFalse(t, report.HasStructuralChanges())
+		AssertDeepEquals(t, report.Differences[0].Delta(), int64(31))
+		AssertDeepEquals(t, report.TotalDbHitsDelta(), int64(31))
+	})
+
+	outer.Run("sums db hits of whole trees", func(t *testing.T) {
+		before := &profile{profile: &db.ProfiledPlan{Operator: "ProduceResults", DbHits: 1,
+			Children: []db.ProfiledPlan{{Operator: "Filter", DbHits: 2}, {Operator: "AllNodesScan", DbHits: 3}}}}
+
+		report := CompareProfiledPlans(before, nil)
+
+		AssertDeepEquals(t, report.OldTotalDbHits, int64(6))
+		AssertDeepEquals(t, report.NewTotalDbHits, int64(0))
+	})
+}
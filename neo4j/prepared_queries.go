@@ -0,0 +1,260 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ParameterType is the expected type of a query parameter, see ParameterSchema
+type ParameterType int
+
+const (
+	// AnyParameter accepts values of any type
+	AnyParameter ParameterType = iota
+	// StringParameter accepts strings
+	StringParameter
+	// IntegerParameter accepts signed and unsigned integers
+	IntegerParameter
+	// FloatParameter accepts floats
+	FloatParameter
+	// BooleanParameter accepts booleans
+	BooleanParameter
+	// ListParameter accepts slices and arrays, except byte slices
+	ListParameter
+	// MapParameter accepts maps with string keys
+	MapParameter
+)
+
+func (t ParameterType) String() string {
+	switch t {
+	case AnyParameter:
+		return "any"
+	case StringParameter:
+		return "string"
+	case IntegerParameter:
+		return "integer"
+	case FloatParameter:
+		return "float"
+	case BooleanParameter:
+		return "boolean"
+	case ListParameter:
+		return "list"
+	case MapParameter:
+		return "map"
+	default:
+		return "unknown"
+	}
+}
+
+// Parameter describes a parameter of a prepared query
+type Parameter struct {
+	Type ParameterType
+	// Optional parameters can be omitted or set to nil, required ones cannot
+	Optional bool
+}
+
+// ParameterSchema describes the parameters accepted by a prepared query, by name.
+// Parameters that are not part of the schema are rejected.
+type ParameterSchema map[string]Parameter
+
+// PreparedQuery is a named query registered with DriverWithContext.Prepare, whose parameters are validated against
+// its schema whenever it runs.
+//
+// Its name is reported along with its fingerprint (see QueryFingerprint) in DeprecationNotice and QueryPlanNotice,
+// and is available to Config.StatementAnnotator via PreparedQueryName while the query runs.
+type PreparedQuery struct {
+	name   string
+	cypher string
+	schema ParameterSchema
+}
+
+func (q *PreparedQuery) Name() string {
+	return q.name
+}
+
+func (q *PreparedQuery) Cypher() string {
+	return q.cypher
+}
+
+// Validate checks the given parameters against the schema of the query, and returns a UsageError describing all
+// the invalid parameters, if any
+func (q *PreparedQuery) Validate(parameters map[string]any) error {
+	var problems []string
+	for key, value := range parameters {
+		parameter, found := q.schema[key]
+		if !found {
+			problems = append(problems, fmt.Sprintf("unknown parameter %s", key))
+			continue
+		}
+		if value != nil && !parameter.Type.accepts(value) {
+			problems = append(problems, fmt.Sprintf("parameter %s must be of type %s, got %T", key, parameter.Type, value))
+		}
+	}
+	for key, parameter := range q.schema {
+		if !parameter.Optional && parameters[key] == nil {
+			problems = append(problems, fmt.Sprintf("missing required parameter %s", key))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return &UsageError{Message: fmt.Sprintf("Invalid parameters for query %s: %s", q.name, strings.Join(problems, ", "))}
+}
+
+// Run validates the given parameters and runs the query in the given transaction
+func (q *PreparedQuery) Run(ctx context.Context, tx ManagedTransaction, parameters map[string]any) (ResultWithContext, error) {
+	if err := q.Validate(parameters); err != nil {
+		return nil, err
+	}
+	return tx.Run(withPreparedQueryName(ctx, q.name), q.cypher, parameters)
+}
+
+// ExecutePreparedQuery validates the given parameters and runs the prepared query with ExecuteQuery
+func ExecutePreparedQuery[T any](
+	ctx context.Context,
+	driver DriverWithContext,
+	query *PreparedQuery,
+	parameters map[string]any,
+	newResultTransformer func() ResultTransformer[T],
+	settings ...ExecuteQueryConfigurationOption) (T, error) {
+
+	if err := query.Validate(parameters); err != nil {
+		return *new(T), err
+	}
+	return ExecuteQuery[T](withPreparedQueryName(ctx, query.name), driver, query.cypher, parameters, newResultTransformer, settings...)
+}
+
+type preparedQueryNameKey struct{}
+
+func withPreparedQueryName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, preparedQueryNameKey{}, name)
+}
+
+// PreparedQueryName returns the name of the prepared query run with the given context, or an empty string if the
+// context does not belong to a prepared query.
+// This lets Config.StatementAnnotator annotate prepared queries with their name, for instance for auditing purposes.
+func PreparedQueryName(ctx context.Context) string {
+	name, _ := ctx.Value(preparedQueryNameKey{}).(string)
+	return name
+}
+
+func (t ParameterType) accepts(value any) bool {
+	kind := reflect.TypeOf(value).Kind()
+	switch t {
+	case AnyParameter:
+		return true
+	case StringParameter:
+		return kind == reflect.String
+	case IntegerParameter:
+		return kind >= reflect.Int && kind <= reflect.Uint64
+	case FloatParameter:
+		return kind == reflect.Float32 || kind == reflect.Float64
+	case BooleanParameter:
+		return kind == reflect.Bool
+	case ListParameter:
+		_, isBytes := value.([]byte)
+		return (kind == reflect.Slice || kind == reflect.Array) && !isBytes
+	case MapParameter:
+		return kind == reflect.Map && reflect.TypeOf(value).Key().Kind() == reflect.String
+	default:
+		return false
+	}
+}
+
+// preparedQueries is the registry of the prepared queries of a driver
+type preparedQueries struct {
+	mut           sync.RWMutex
+	byName        map[string]*PreparedQuery
+	byFingerprint map[string]string
+}
+
+func newPreparedQueries() *preparedQueries {
+	return &preparedQueries{
+		byName:        make(map[string]*PreparedQuery),
+		byFingerprint: make(map[string]string),
+	}
+}
+
+func (p *preparedQueries) prepare(name, cypher string, schema ParameterSchema) (*PreparedQuery, error) {
+	if name == "" {
+		return nil, &UsageError{Message: "Prepared query name cannot be empty"}
+	}
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	if existing, found := p.byName[name]; found {
+		if existing.cypher != cypher || !reflect.DeepEqual(existing.schema, schema) {
+			return nil, &UsageError{Message: fmt.Sprintf("Query %s is already prepared with another query or schema", name)}
+		}
+		return existing, nil
+	}
+	query := &PreparedQuery{name: name, cypher: cypher, schema: schema}
+	p.byName[name] = query
+	fingerprint := QueryFingerprint(cypher)
+	if _, found := p.byFingerprint[fingerprint]; !found {
+		p.byFingerprint[fingerprint] = name
+	}
+	return query, nil
+}
+
+func (p *preparedQueries) get(name string) (*PreparedQuery, bool) {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+	query, found := p.byName[name]
+	return query, found
+}
+
+// nameOf returns the name of the first prepared query with the given fingerprint, if any
+func (p *preparedQueries) nameOf(fingerprint string) string {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+	return p.byFingerprint[fingerprint]
+}
+
+// nameDeprecationNotices decorates the given callback so that notices of prepared queries carry their name
+func (p *preparedQueries) nameDeprecationNotices(onDeprecationNotice func(DeprecationNotice)) func(DeprecationNotice) {
+	return func(notice DeprecationNotice) {
+		notice.QueryName = p.nameOf(notice.QueryFingerprint)
+		onDeprecationNotice(notice)
+	}
+}
+
+// nameQueryPlans decorates the given callback so that plans of prepared queries carry their name
+func (p *preparedQueries) nameQueryPlans(onQueryPlan func(QueryPlanNotice)) func(QueryPlanNotice) {
+	return func(notice QueryPlanNotice) {
+		notice.QueryName = p.nameOf(notice.QueryFingerprint)
+		onQueryPlan(notice)
+	}
+}
+
+// nameInlinedLiterals decorates the given callback so that literal notices of prepared queries carry their name
+func (p *preparedQueries) nameInlinedLiterals(
+	onInlinedLiterals func(InlinedLiteralsNotice)) func(InlinedLiteralsNotice) {
+	return func(notice InlinedLiteralsNotice) {
+		notice.QueryName = p.nameOf(notice.QueryFingerprint)
+		onInlinedLiterals(notice)
+	}
+}
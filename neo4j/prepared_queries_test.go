@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestPreparedQueries(outer *testing.T) {
+	outer.Parallel()
+
+	schema := ParameterSchema{
+		"id":     {Type: IntegerParameter},
+		"name":   {Type: StringParameter, Optional: true},
+		"tags":   {Type: ListParameter, Optional: true},
+		"extras": {Type: MapParameter, Optional: true},
+		"score":  {Type: FloatParameter, Optional: true},
+		"active": {Type: BooleanParameter, Optional: true},
+		"raw":    {Type: AnyParameter, Optional: true},
+	}
+	query := &PreparedQuery{name: "getUser", cypher: "MATCH (u:User {id: $id}) RETURN u", schema: schema}
+
+	outer.Run("Validate", func(inner *testing.T) {
+		inner.Run("accepts valid parameters", func(t *testing.T) {
+			err := query.Validate(map[string]any{
+				"id":     uint8(1),
+				"name":   "jane",
+				"tags":   []string{"admin"},
+				"extras": map[string]int{"age": 42},
+				"score":  1.5,
+				"active": true,
+				"raw":    []byte{1},
+			})
+
+			AssertNoError(t, err)
+		})
+
+		inner.Run("accepts missing and nil optional parameters", func(t *testing.T) {
+			err := query.Validate(map[string]any{"id": 1, "name": nil})
+
+			AssertNoError(t, err)
+		})
+
+		inner.Run("reports all invalid parameters", func(t *testing.T) {
+			err := query.Validate(map[string]any{"name": 42, "tags": []byte{1}, "extras": map[int]string{}, "nope": 1})
+
+			AssertTrue(t, IsUsageError(err))
+			AssertStringEqual(t, err.Error(), "Invalid parameters for query getUser: "+
+				"missing required parameter id, "+
+				"parameter extras must be of type map, got map[int]string, "+
+				"parameter name must be of type string, got int, "+
+				"parameter tags must be of type list, got []uint8, "+
+				"unknown parameter nope")
+		})
+
+		inner.Run("rejects nil required parameters", func(t *testing.T) {
+			err := query.Validate(map[string]any{"id": nil})
+
+			AssertStringContain(t, err.Error(), "missing required parameter id")
+		})
+	})
+
+	outer.Run("Run", func(inner *testing.T) {
+		inner.Run("runs the query with its name in the context", func(t *testing.T) {
+			tx := &recordingTransaction{}
+
+			_, err := query.Run(context.Background(), tx, map[string]any{"id": 1})
+
+			AssertNoError(t, err)
+			AssertStringEqual(t, tx.cypher, "MATCH (u:User {id: $id}) RETURN u")
+			AssertStringEqual(t, tx.queryName, "getUser")
+		})
+
+		inner.Run("does not run queries with invalid parameters", func(t *testing.T) {
+			tx := &recordingTransaction{}
+
+			_, err := query.Run(context.Background(), tx, nil)
+
+			AssertTrue(t, IsUsageError(err))
+			AssertStringEqual(t, tx.cypher, "")
+		})
+	})
+
+	outer.Run("PreparedQueryName", func(inner *testing.T) {
+		inner.Run("is empty for queries that are not prepared", func(t *testing.T) {
+			AssertStringEqual(t, PreparedQueryName(context.Background()), "")
+		})
+	})
+
+	outer.Run("registry", func(inner *testing.T) {
+		inner.Run("registers queries by name", func(t *testing.T) {
+			registry := newPreparedQueries()
+
+			prepared, err := registry.prepare("getUser", query.cypher, schema)
+
+			AssertNoError(t, err)
+			AssertStringEqual(t, prepared.Name(), "getUser")
+			AssertStringEqual(t, prepared.Cypher(), query.cypher)
+			found, ok := registry.get("getUser")
+			AssertTrue(t, ok)
+			AssertTrue(t, found == prepared)
+		})
+
+		inner.Run("returns the registered query when preparing it again", func(t *testing.T) {
+			registry := newPreparedQueries()
+			first, _ := registry.prepare("getUser", query.cypher, schema)
+
+			second, err := registry.prepare("getUser", query.cypher, schema)
+
+			AssertNoError(t, err)
+			AssertTrue(t, first == second)
+		})
+
+		inner.Run("rejects conflicting queries", func(t *testing.T) {
+			registry := newPreparedQueries()
+			_, _ = registry.prepare("getUser", query.cypher, schema)
+
+			_, err := registry.prepare("getUser", "MATCH (u:User) RETURN u", schema)
+
+			AssertTrue(t, IsUsageError(err))
+		})
+
+		inner.Run("rejects empty names", func(t *testing.T) {
+			_, err := newPreparedQueries().prepare("", query.cypher, schema)
+
+			AssertTrue(t, IsUsageError(err))
+		})
+
+		inner.Run("names notices of queries sharing the fingerprint", func(t *testing.T) {
+			registry := newPreparedQueries()
+			_, _ = registry.prepare("getUser", "MATCH (u:User {id: $id, kind: 'human'}) RETURN u", nil)
+			var deprecations []DeprecationNotice
+			var plans []QueryPlanNotice
+			onDeprecationNotice := registry.nameDeprecationNotices(func(notice DeprecationNotice) {
+				deprecations = append(deprecations, notice)
+			})
+			onQueryPlan := registry.nameQueryPlans(func(notice QueryPlanNotice) {
+				plans = append(plans, notice)
+			})
+
+			onDeprecationNotice(DeprecationNotice{QueryFingerprint: QueryFingerprint("MATCH (u:User {id: $id, kind: 'robot'}) RETURN u")})
+			onDeprecationNotice(DeprecationNotice{QueryFingerprint: QueryFingerprint("RETURN 1")})
+			onQueryPlan(QueryPlanNotice{QueryFingerprint: QueryFingerprint("MATCH (u:User {id: $id, kind: 'human'}) RETURN u")})
+
+			AssertLen(t, deprecations, 2)
+			AssertStringEqual(t, deprecations[0].QueryName, "getUser")
+			AssertStringEqual(t, deprecations[1].QueryName, "")
+			AssertLen(t, plans, 1)
+			AssertStringEqual(t, plans[0].QueryName, "getUser")
+		})
+	})
+
+	outer.Run("driver", func(inner *testing.T) {
+		inner.Run("prepares queries", func(t *testing.T) {
+			driver, err := NewDriverWithContext("bolt://localhost:7687", NoAuth())
+			AssertNoError(t, err)
+
+			prepared, err := driver.Prepare("getUser", query.cypher, schema)
+
+			AssertNoError(t, err)
+			found, ok := driver.PreparedQuery("getUser")
+			AssertTrue(t, ok)
+			AssertTrue(t, found == prepared)
+			_, ok = driver.PreparedQuery("getGroup")
+			AssertFalse(t, ok)
+		})
+	})
+}
+
+type recordingTransaction struct {
+	ManagedTransaction
+	cypher    string
+	queryName string
+}
+
+func (tx *recordingTransaction) Run(ctx context.Context, cypher string, _ map[string]any) (ResultWithContext, error) {
+	tx.cypher = cypher
+	tx.queryName = PreparedQueryName(ctx)
+	return nil, nil
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"container/list"
+	"fmt"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// QueryCache holds the results of the read queries run by ExecuteQuery with ExecuteQueryWithCache.
+// Entries are evicted once they are older than Config.QueryCacheTTL, when the cache grows past
+// Config.QueryCacheMaxEntries (least recently used entries first) or when they are explicitly invalidated.
+//
+// DriverWithContext.QueryCache returns nil when Config.QueryCacheMaxEntries is 0. Invalidate and InvalidateAll can
+// still be called on the nil cache, they have nothing to invalidate, and Len returns 0.
+//
+// This API is currently experimental and may change or be removed at any time.
+type QueryCache struct {
+	maxEntries int
+	ttl        time.Duration
+	clock      clock.Clock
+	mut        sync.Mutex
+	entries    map[string]*list.Element
+	// most recently used entries are at the front
+	usage *list.List
+}
+
+type queryCacheEntry struct {
+	key         string
+	fingerprint string
+	value       any
+	expiresAt   time.Time
+}
+
+func newQueryCache(maxEntries int, ttl time.Duration, clock clock.Clock) *QueryCache {
+	return &QueryCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		clock:      clock,
+		entries:    make(map[string]*list.Element),
+		usage:      list.New(),
+	}
+}
+
+// Invalidate removes the cached results of all queries sharing the fingerprint of the given query (see
+// QueryFingerprint), regardless of their literal values, parameters, target database or impersonated user.
+func (c *QueryCache) Invalidate(query string) {
+	if c == nil {
+		return
+	}
+	fingerprint := QueryFingerprint(query)
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	for element := c.usage.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*queryCacheEntry).fingerprint == fingerprint {
+			c.remove(element)
+		}
+		element = next
+	}
+}
+
+// InvalidateAll removes all the cached results.
+func (c *QueryCache) InvalidateAll() {
+	if c == nil {
+		return
+	}
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.usage.Init()
+}
+
+// Len returns the number of cached results, including the expired ones that have not been evicted yet.
+func (c *QueryCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.usage.Len()
+}
+
+func (c *QueryCache) get(key string) (any, bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	element, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+	entry := element.Value.(*queryCacheEntry)
+	if !c.clock.Now().Before(entry.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+	c.usage.MoveToFront(element)
+	return entry.value, true
+}
+
+func (c *QueryCache) put(key, query string, value any) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if element, found := c.entries[key]; found {
+		c.remove(element)
+	}
+	c.entries[key] = c.usage.PushFront(&queryCacheEntry{
+		key:         key,
+		fingerprint: QueryFingerprint(query),
+		value:       value,
+		expiresAt:   c.clock.Now().Add(c.ttl),
+	})
+	for c.usage.Len() > c.maxEntries {
+		c.remove(c.usage.Back())
+	}
+}
+
+func (c *QueryCache) remove(element *list.Element) {
+	c.usage.Remove(element)
+	delete(c.entries, element.Value.(*queryCacheEntry).key)
+}
+
+// queryCacheKey identifies a cached query result.
+// The result type is part of the key, since the same query may be transformed differently by different calls.
+func queryCacheKey[T any](configuration *ExecuteQueryConfiguration, query string, parameters map[string]any) string {
+	database := NormalizeDatabaseName(configuration.Database)
+	key := &strings.Builder{}
+	fmt.Fprintf(key, "%T\x00%s\x00%s\x00%s\x00", new(T), database, configuration.ImpersonatedUser, query)
+	writeCacheKeyValue(key, parameters)
+	return key.String()
+}
+
+// writeCacheKeyValue writes a representation of the value that preserves its type, so that values printing the same,
+// like "1" and 1 or 1 and 1.0, do not share a key
+func writeCacheKeyValue(key *strings.Builder, value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		key.WriteString("{")
+		for _, name := range names {
+			fmt.Fprintf(key, "%q:", name)
+			writeCacheKeyValue(key, value[name])
+			key.WriteString(",")
+		}
+		key.WriteString("}")
+	case []any:
+		key.WriteString("[")
+		for _, item := range value {
+			writeCacheKeyValue(key, item)
+			key.WriteString(",")
+		}
+		key.WriteString("]")
+	default:
+		fmt.Fprintf(key, "%T(%#v)", value, value)
+	}
+}
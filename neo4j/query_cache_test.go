@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"testing"
+	"time"
+)
+
+func TestQueryCache(outer *testing.T) {
+	outer.Parallel()
+
+	outer.Run("returns cached values", func(t *testing.T) {
+		cache := newQueryCache(10, time.Minute, clock.NewFake(time.Now()))
+
+		cache.put("key", "RETURN 42", 42)
+		value, found := cache.get("key")
+
+		AssertTrue(t, found)
+		AssertDeepEquals(t, value, 42)
+	})
+
+	outer.Run("expires values after TTL", func(t *testing.T) {
+		fakeClock := clock.NewFake(time.Now())
+		cache := newQueryCache(10, time.Minute, fakeClock)
+
+		cache.put("key", "RETURN 42", 42)
+		fakeClock.Advance(time.Minute)
+		_, found := cache.get("key")
+
+		AssertFalse(t, found)
+		AssertIntEqual(t, cache.Len(), 0)
+	})
+
+	outer.Run("evicts least recently used values", func(t *testing.T) {
+		cache := newQueryCache(2, time.Minute, clock.NewFake(time.Now()))
+
+		cache.put("key1", "RETURN 1", 1)
+		cache.put("key2", "RETURN 2", 2)
+		_, _ = cache.get("key1")
+		cache.put("key3", "RETURN 3", 3)
+
+		_, found := cache.get("key2")
+		AssertFalse(t, found)
+		_, found = cache.get("key1")
+		AssertTrue(t, found)
+		_, found = cache.get("key3")
+		AssertTrue(t, found)
+	})
+
+	outer.Run("invalidates values by query fingerprint", func(t *testing.T) {
+		cache := newQueryCache(10, time.Minute, clock.NewFake(time.Now()))
+
+		cache.put("key1", "MATCH (n) WHERE n.id = 1 RETURN n", 1)
+		cache.put("key2", "MATCH (n) WHERE n.id = 2 RETURN n", 2)
+		cache.put("key3", "RETURN 3", 3)
+		cache.Invalidate("MATCH (n)  WHERE n.id = 42 RETURN n")
+
+		AssertIntEqual(t, cache.Len(), 1)
+		_, found := cache.get("key3")
+		AssertTrue(t, found)
+	})
+
+	outer.Run("invalidates all values", func(t *testing.T) {
+		cache := newQueryCache(10, time.Minute, clock.NewFake(time.Now()))
+
+		cache.put("key1", "RETURN 1", 1)
+		cache.put("key2", "RETURN 2", 2)
+		cache.InvalidateAll()
+
+		AssertIntEqual(t, cache.Len(), 0)
+	})
+
+	outer.Run("keys parameters by type", func(t *testing.T) {
+		config := &ExecuteQueryConfiguration{}
+		key := func(parameters map[string]any) string {
+			return queryCacheKey[*EagerResult](config, "RETURN $x", parameters)
+		}
+
+		AssertFalse(t, key(map[string]any{"x": "1"}) == key(map[string]any{"x": 1}))
+		AssertFalse(t, key(map[string]any{"x": 1}) == key(map[string]any{"x": 1.0}))
+		AssertFalse(t, key(map[string]any{"x": []any{"1"}}) == key(map[string]any{"x": []any{1}}))
+		AssertFalse(t, key(map[string]any{"x": map[string]any{"y": "1"}}) == key(map[string]any{"x": map[string]any{"y": 1}}))
+		AssertStringEqual(t, key(map[string]any{"x": 1, "y": []any{"a"}}), key(map[string]any{"y": []any{"a"}, "x": 1}))
+	})
+
+	outer.Run("is a no-op when disabled", func(t *testing.T) {
+		var cache *QueryCache
+
+		cache.Invalidate("RETURN 42")
+		cache.InvalidateAll()
+
+		AssertIntEqual(t, cache.Len(), 0)
+	})
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// NormalizeQuery normalizes the given Cypher query so that queries that only differ in their literal values,
+// comments or whitespace are normalized the same way:
+//   - string and number literals are replaced with ?
+//   - comments are removed
+//   - consecutive whitespace characters are collapsed into a single space
+//
+// Parameters, identifiers (quoted or not) and keywords are left untouched.
+// NormalizeQuery is meant for grouping queries together, e.g. in logs or metrics, and does not validate the query.
+func NormalizeQuery(cypher string) string {
+	var builder strings.Builder
+	runes := []rune(cypher)
+	pendingSpace := false
+	write := func(s string) {
+		if pendingSpace && builder.Len() > 0 {
+			builder.WriteRune(' ')
+		}
+		pendingSpace = false
+		builder.WriteString(s)
+	}
+	for i := 0; i < len(runes); i++ {
+		current := runes[i]
+		switch {
+		case unicode.IsSpace(current):
+			pendingSpace = true
+		case current == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			i = skipUntil(runes, i+2, "\n")
+			pendingSpace = true
+		case current == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i = skipUntil(runes, i+2, "*/")
+			pendingSpace = true
+		case current == '\'' || current == '"':
+			i = skipStringLiteral(runes, i)
+			write("?")
+		case current == '`':
+			end := skipUntil(runes, i+1, "`")
+			write(string(runes[i : end+1]))
+			i = end
+		case unicode.IsDigit(current):
+			i = skipNumberLiteral(runes, i)
+			write("?")
+		case isIdentifierRune(current) || current == '$':
+			end := i + 1
+			for end < len(runes) && isIdentifierRune(runes[end]) {
+				end++
+			}
+			write(string(runes[i:end]))
+			i = end - 1
+		default:
+			write(string(current))
+		}
+	}
+	return builder.String()
+}
+
+// QueryFingerprint returns a stable hash of the normalized form of the given Cypher query (see NormalizeQuery).
+// Unlike raw query strings, fingerprints are low-cardinality and can be safely used as metrics labels or to group
+// slow queries together.
+func QueryFingerprint(cypher string) string {
+	hash := fnv.New64a()
+	// writing to a hash never fails
+	_, _ = hash.Write([]byte(NormalizeQuery(cypher)))
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+// skipUntil returns the index of the last rune of the given terminator, found from the start index onwards, or the
+// index of the last rune if the terminator cannot be found
+func skipUntil(runes []rune, start int, terminator string) int {
+	terminatorRunes := []rune(terminator)
+	for i := start; i+len(terminatorRunes) <= len(runes); i++ {
+		if string(runes[i:i+len(terminatorRunes)]) == terminator {
+			return i + len(terminatorRunes) - 1
+		}
+	}
+	return len(runes) - 1
+}
+
+// skipStringLiteral returns the index of the closing quote of the string literal starting at the given index
+func skipStringLiteral(runes []rune, start int) int {
+	quote := runes[start]
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		}
+	}
+	return len(runes) - 1
+}
+
+// skipNumberLiteral returns the index of the last rune of the number literal starting at the given index
+// This covers integers, floats, exponents as well as hexadecimal and octal literals.
+func skipNumberLiteral(runes []rune, start int) int {
+	i := start
+	for i+1 < len(runes) {
+		next := runes[i+1]
+		if unicode.IsDigit(next) || unicode.IsLetter(next) || next == '_' {
+			i++
+			continue
+		}
+		if next == '.' && i+2 < len(runes) && unicode.IsDigit(runes[i+2]) {
+			i++
+			continue
+		}
+		if (next == '-' || next == '+') && (runes[i] == 'e' || runes[i] == 'E') {
+			i++
+			continue
+		}
+		break
+	}
+	return i
+}
+
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
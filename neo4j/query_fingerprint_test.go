@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j_test
+
+import (
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestNormalizeQuery(outer *testing.T) {
+	testCases := []struct {
+		description string
+		query       string
+		expected    string
+	}{
+		{"replaces string literals", `MATCH (p:Person {name: 'Jane \'JJ\' Doe'}) WHERE p.alias = "J" RETURN p`,
+			`MATCH (p:Person {name: ?}) WHERE p.alias = ? RETURN p`},
+		{"replaces number literals", "UNWIND [1, -2.5, 3e-4, 0x1F] AS n RETURN n LIMIT 10",
+			"UNWIND [?, -?, ?, ?] AS n RETURN n LIMIT ?"},
+		{"keeps identifiers and parameters", "MATCH (n1:`Label 2`) SET n1.prop3 = $param4 RETURN n1",
+			"MATCH (n1:`Label 2`) SET n1.prop3 = $param4 RETURN n1"},
+		{"collapses whitespace", "  MATCH (n)\n\t\tRETURN   n  ", "MATCH (n) RETURN n"},
+		{"removes comments", "/* service='billing' */ MATCH (n) // all nodes\nRETURN n /* done */",
+			"MATCH (n) RETURN n"},
+		{"tolerates unterminated literals", "RETURN 'oops", "RETURN ?"},
+	}
+
+	for _, testCase := range testCases {
+		outer.Run(testCase.description, func(t *testing.T) {
+			AssertStringEqual(t, neo4j.NormalizeQuery(testCase.query), testCase.expected)
+		})
+	}
+}
+
+func TestQueryFingerprint(outer *testing.T) {
+	outer.Run("is stable across literal values", func(t *testing.T) {
+		fingerprint1 := neo4j.QueryFingerprint("MATCH (p:Person {name: 'Jane'}) RETURN p LIMIT 1")
+		fingerprint2 := neo4j.QueryFingerprint("MATCH (p:Person  {name: 'John'})\nRETURN p LIMIT 25")
+
+		AssertStringEqual(t, fingerprint1, fingerprint2)
+		AssertIntEqual(t, len(fingerprint1), 16)
+	})
+
+	outer.Run("differs across query shapes", func(t *testing.T) {
+		fingerprint1 := neo4j.QueryFingerprint("MATCH (p:Person) RETURN p")
+		fingerprint2 := neo4j.QueryFingerprint("MATCH (p:Movie) RETURN p")
+
+		AssertFalse(t, fingerprint1 == fingerprint2)
+	})
+}
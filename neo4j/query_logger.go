@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"time"
+)
+
+// RedactedParameterValue replaces the values of the parameters reported to Config.QueryLogger that are not selected
+// by Config.QueryLogParameters
+const RedactedParameterValue = "<redacted>"
+
+// QueryLogEntry describes a query executed by the driver, see Config.QueryLogger
+type QueryLogEntry struct {
+	// Query is the query text, as passed to the driver
+	Query string
+	// Params are the parameters of the query, the values of the parameters not selected by Config.QueryLogParameters
+	// being replaced by RedactedParameterValue
+	Params map[string]any
+	// Server is the address of the server the query ran on
+	Server string
+	// Retries is the number of times the transaction function running the query was retried before, 0 for the
+	// first attempt and for queries run outside transaction functions
+	Retries int
+	// Duration is the time spent executing the query, from sending it to the reception of its summary or failure
+	Duration time.Duration
+	// Durations break Duration down, see ClientDurations
+	Durations ClientDurations
+	// Err is the error the query failed with, nil if it succeeded
+	Err error
+}
+
+// LogAllParameters selects all the parameters to be reported to Config.QueryLogger, see Config.QueryLogParameters
+func LogAllParameters(string) bool {
+	return true
+}
+
+// LogParameters returns a selection of the given parameters only, to be reported to Config.QueryLogger, see
+// Config.QueryLogParameters
+func LogParameters(names ...string) func(string) bool {
+	selected := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		selected[name] = struct{}{}
+	}
+	return func(name string) bool {
+		_, found := selected[name]
+		return found
+	}
+}
+
+// RedactParameters returns a selection of all the parameters but the given ones, to be reported to
+// Config.QueryLogger, see Config.QueryLogParameters
+func RedactParameters(names ...string) func(string) bool {
+	redacted := LogParameters(names...)
+	return func(name string) bool {
+		return !redacted(name)
+	}
+}
+
+// queryLogger reports executed queries to Config.QueryLogger.
+// newQueryLogger returns nil when Config.QueryLogger is not set, start then returns a nil queryLog whose done reports
+// nothing, so that queries are run the same way whether they are logged or not.
+type queryLogger struct {
+	onQuery        func(QueryLogEntry)
+	keepsParameter func(string) bool
+}
+
+func newQueryLogger(config *Config) *queryLogger {
+	if config.QueryLogger == nil {
+		return nil
+	}
+	return &queryLogger{onQuery: config.QueryLogger, keepsParameter: config.QueryLogParameters}
+}
+
+// start returns the log of a query about to be reported, nil when queries are not logged
+func (l *queryLogger) start(ctx context.Context, server, cypher string, params map[string]any) *queryLog {
+	if l == nil {
+		return nil
+	}
+	entry := QueryLogEntry{Query: cypher, Params: l.redact(params), Server: server}
+	if attempt, ok := TransactionAttemptFrom(ctx); ok && attempt.Number > 1 {
+		entry.Retries = attempt.Number - 1
+	}
+	return &queryLog{onQuery: l.onQuery, entry: entry}
+}
+
+func (l *queryLogger) redact(params map[string]any) map[string]any {
+	if params == nil {
+		return nil
+	}
+	redacted := make(map[string]any, len(params))
+	for name, value := range params {
+		if l.keepsParameter != nil && l.keepsParameter(name) {
+			redacted[name] = value
+		} else {
+			redacted[name] = RedactedParameterValue
+		}
+	}
+	return redacted
+}
+
+// queryLog reports a single query, once
+type queryLog struct {
+	onQuery func(QueryLogEntry)
+	entry   QueryLogEntry
+	logged  bool
+}
+
+// done reports the query with the given durations and error, unless it has already been reported
+func (q *queryLog) done(durations ClientDurations, err error) {
+	if q == nil || q.logged {
+		return
+	}
+	q.logged = true
+	q.entry.Durations = durations
+	q.entry.Duration = durations.Request + durations.FirstRecord + durations.Streaming
+	q.entry.Err = err
+	q.onQuery(q.entry)
+}
+
+// unloggedQueries keeps track of the results of a transaction, so that the queries whose results are discarded when
+// the transaction is committed or rolled back are still reported, without their summary being retrieved.
+// Tracking only happens when the results are logged.
+type unloggedQueries struct {
+	results []*resultWithContext
+}
+
+func (u *unloggedQueries) track(result *resultWithContext) {
+	if result.queryLog != nil {
+		u.results = append(u.results, result)
+	}
+}
+
+// flush reports the queries not reported yet, as failed with the given error if not nil
+func (u *unloggedQueries) flush(err error) {
+	for _, result := range u.results {
+		result.logCompletion(err)
+	}
+	u.results = nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"testing"
+	"time"
+)
+
+func TestQueryLogger(outer *testing.T) {
+	outer.Parallel()
+
+	ctx := context.Background()
+	params := map[string]any{"name": "Alice", "password": "secret"}
+	newLogger := func(keepsParameter func(string) bool, entries *[]QueryLogEntry) *queryLogger {
+		return newQueryLogger(&Config{
+			QueryLogger:        func(entry QueryLogEntry) { *entries = append(*entries, entry) },
+			QueryLogParameters: keepsParameter,
+		})
+	}
+
+	outer.Run("reports queries once", func(t *testing.T) {
+		var entries []QueryLogEntry
+		queryErr := errors.New("boom")
+		log := newLogger(LogAllParameters, &entries).start(ctx, "server:7687", "RETURN $name", params)
+
+		log.done(ClientDurations{Request: time.Second, FirstRecord: 2 * time.Second, Streaming: 3 * time.Second}, queryErr)
+		log.done(ClientDurations{}, nil)
+
+		AssertDeepEquals(t, entries, []QueryLogEntry{{
+			Query:     "RETURN $name",
+			Params:    params,
+			Server:    "server:7687",
+			Duration:  6 * time.Second,
+			Durations: ClientDurations{Request: time.Second, FirstRecord: 2 * time.Second, Streaming: 3 * time.Second},
+			Err:       queryErr,
+		}})
+	})
+
+	outer.Run("reports retries of transaction functions", func(t *testing.T) {
+		var entries []QueryLogEntry
+		attemptCtx := withTransactionAttempt(ctx, TransactionAttempt{Number: 3})
+
+		newLogger(nil, &entries).start(attemptCtx, "server:7687", "RETURN 1", nil).done(ClientDurations{}, nil)
+
+		AssertIntEqual(t, entries[0].Retries, 2)
+	})
+
+	outer.Run("redacts parameters", func(t *testing.T) {
+		type testCase struct {
+			description    string
+			keepsParameter func(string) bool
+			expected       map[string]any
+		}
+		testCases := []testCase{
+			{"all by default", nil,
+				map[string]any{"name": RedactedParameterValue, "password": RedactedParameterValue}},
+			{"none", LogAllParameters, params},
+			{"all but the selected ones", LogParameters("name"),
+				map[string]any{"name": "Alice", "password": RedactedParameterValue}},
+			{"the selected ones", RedactParameters("password"),
+				map[string]any{"name": "Alice", "password": RedactedParameterValue}},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.description, func(t *testing.T) {
+				var entries []QueryLogEntry
+
+				newLogger(testCase.keepsParameter, &entries).start(ctx, "server:7687", "RETURN 1", params).
+					done(ClientDurations{}, nil)
+
+				AssertDeepEquals(t, entries[0].Params, testCase.expected)
+			})
+		}
+	})
+
+	outer.Run("does nothing when nil", func(t *testing.T) {
+		var logger *queryLogger
+
+		logger.start(ctx, "server:7687", "RETURN 1", nil).done(ClientDurations{}, nil)
+	})
+}
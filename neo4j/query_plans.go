@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/collection"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"strings"
+	"sync"
+)
+
+// QueryPlanNotice describes the plan of a query explained by the driver, see Config.OnQueryPlan
+type QueryPlanNotice struct {
+	// Query is the query text, as passed to the driver
+	Query string
+	// QueryFingerprint identifies the query regardless of its literal values, see QueryFingerprint
+	QueryFingerprint string
+	// QueryName is the name of the prepared query with the same fingerprint, empty if there is none, see
+	// DriverWithContext.Prepare
+	QueryName string
+	// Plan is the plan the server would use to execute the query, nil if the server did not return any
+	Plan Plan
+	// Err is the error of the EXPLAIN query, nil if it succeeded.
+	// The query itself runs regardless of this error.
+	Err error
+}
+
+// queryExplainer runs EXPLAIN once per distinct query fingerprint, for the whole lifetime of the driver.
+// Drivers only create an explainer when Config.OnQueryPlan is set. With a nil explainer, explain runs nothing and
+// deferExplain defers nothing, which leaves explainDeferred nothing to explain either.
+type queryExplainer struct {
+	onQueryPlan func(QueryPlanNotice)
+	mut         sync.Mutex
+	explained   collection.Set[string]
+}
+
+func newQueryExplainer(onQueryPlan func(QueryPlanNotice)) *queryExplainer {
+	return &queryExplainer{
+		onQueryPlan: onQueryPlan,
+		explained:   collection.NewSet[string](nil),
+	}
+}
+
+// explain runs EXPLAIN for the given query with the provided run function, unless the query cannot be explained or a
+// query with the same fingerprint has already been explained, and calls the callback with the resulting plan.
+// The run function is expected to run the given EXPLAIN query on the provided connection, outside any transaction.
+// A failed EXPLAIN is reported to the callback, and leaves the connection in a failed state: explain then returns
+// false so that the caller can reset the connection before running the query.
+func (e *queryExplainer) explain(ctx context.Context, conn idb.Connection, cypher string, params map[string]any,
+	run func(explainCypher string) (idb.StreamHandle, error)) bool {
+
+	if e == nil || !explainable(cypher, params) {
+		return true
+	}
+	fingerprint := QueryFingerprint(cypher)
+	if !e.claim(fingerprint) {
+		return true
+	}
+	notice := QueryPlanNotice{Query: cypher, QueryFingerprint: fingerprint}
+	stream, err := run("EXPLAIN " + cypher)
+	var summary *db.Summary
+	if err == nil {
+		summary, err = conn.Consume(ctx, stream)
+	}
+	if err != nil {
+		e.release(fingerprint)
+		notice.Err = wrapError(err)
+		e.onQueryPlan(notice)
+		return false
+	}
+	if summary.Plan != nil {
+		notice.Plan = &plan{plan: summary.Plan}
+	}
+	e.onQueryPlan(notice)
+	return true
+}
+
+// deferredExplain is a query run within a transaction, explained once the transaction has been committed
+type deferredExplain struct {
+	cypher string
+	params map[string]any
+}
+
+// deferExplain returns the given deferred queries, along with the given query if it needs to be explained.
+// Queries run within transactions are only explained once their transaction has been committed, since a failed
+// EXPLAIN would abort the transaction.
+func (e *queryExplainer) deferExplain(deferred []deferredExplain, cypher string, params map[string]any) []deferredExplain {
+	if e == nil || !explainable(cypher, params) || e.isExplained(QueryFingerprint(cypher)) {
+		return deferred
+	}
+	return append(deferred, deferredExplain{cypher: cypher, params: params})
+}
+
+// explainDeferred explains the given deferred queries on the connection of their committed transaction, until one
+// of them fails and leaves the connection in a failed state
+func (e *queryExplainer) explainDeferred(ctx context.Context, conn idb.Connection, deferred []deferredExplain,
+	txConfig idb.TxConfig, fetchSize int) {
+
+	for _, query := range deferred {
+		params := query.params
+		explained := e.explain(ctx, conn, query.cypher, params, func(explainCypher string) (idb.StreamHandle, error) {
+			return conn.Run(ctx, idb.Command{Cypher: explainCypher, Params: params, FetchSize: fetchSize}, txConfig)
+		})
+		if !explained {
+			return
+		}
+	}
+}
+
+// unexplainableKeywords are the leading keywords of the queries that EXPLAIN does not support, either because they
+// already carry a query option or because they are administration commands
+var unexplainableKeywords = collection.NewSet([]string{
+	"EXPLAIN", "PROFILE", "CYPHER", "SHOW", "GRANT", "DENY", "REVOKE", "ALTER", "RENAME", "START", "STOP",
+	"ENABLE", "DEALLOCATE", "REALLOCATE", "TERMINATE", "DRYRUN",
+})
+
+// unexplainableObjects are the schema and administration objects that CREATE and DROP commands manage, which EXPLAIN
+// does not support either
+var unexplainableObjects = collection.NewSet([]string{
+	"INDEX", "CONSTRAINT", "DATABASE", "ALIAS", "USER", "ROLE", "SERVER",
+})
+
+// explainable returns false for the queries that EXPLAIN does not support, and for the queries with
+// *dbtype.StreamedList parameters, which can only be sent once
+func explainable(cypher string, params map[string]any) bool {
+	for _, param := range params {
+		if _, streamed := param.(*dbtype.StreamedList); streamed {
+			return false
+		}
+	}
+	// normalizing drops the leading comments
+	words := strings.Fields(NormalizeQuery(cypher))
+	if len(words) == 0 {
+		return false
+	}
+	keyword := strings.ToUpper(words[0])
+	if _, found := unexplainableKeywords[keyword]; found {
+		return false
+	}
+	if keyword != "CREATE" && keyword != "DROP" {
+		return true
+	}
+	// CREATE and DROP commands name the managed object before any pattern, e.g. CREATE OR REPLACE DATABASE or
+	// CREATE TEXT INDEX, while CREATE clauses go straight to their pattern
+	for _, word := range words[1:] {
+		if strings.IndexFunc(word, func(r rune) bool { return !isIdentifierRune(r) }) != -1 {
+			return true
+		}
+		if _, found := unexplainableObjects[strings.ToUpper(word)]; found {
+			return false
+		}
+	}
+	return true
+}
+
+// claim returns true if the fingerprint has not been explained yet, and marks it as explained
+func (e *queryExplainer) claim(fingerprint string) bool {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if _, found := e.explained[fingerprint]; found {
+		return false
+	}
+	e.explained.Add(fingerprint)
+	return true
+}
+
+// isExplained returns true if the fingerprint has already been explained
+func (e *queryExplainer) isExplained(fingerprint string) bool {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	_, found := e.explained[fingerprint]
+	return found
+}
+
+// release allows the fingerprint to be explained again, after a failed attempt
+func (e *queryExplainer) release(fingerprint string) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	e.explained.Remove(fingerprint)
+}
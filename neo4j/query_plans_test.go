@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"testing"
+)
+
+func TestQueryExplainer(outer *testing.T) {
+	outer.Parallel()
+
+	ctx := context.Background()
+
+	outer.Run("explains each fingerprint once", func(t *testing.T) {
+		var notices []QueryPlanNotice
+		explainer := newQueryExplainer(func(notice QueryPlanNotice) {
+			notices = append(notices, notice)
+		})
+		conn := &ConnFake{ConsumeSum: &db.Summary{Plan: &db.Plan{Operator: "AllNodesScan"}}}
+		var explained []string
+		run := func(explainCypher string) (idb.StreamHandle, error) {
+			explained = append(explained, explainCypher)
+			return nil, nil
+		}
+
+		AssertTrue(t, explainer.explain(ctx, conn, "MATCH (n) WHERE n.id = 1 RETURN n", nil, run))
+		AssertTrue(t, explainer.explain(ctx, conn, "MATCH (n) WHERE n.id = 2 RETURN n", nil, run))
+		AssertTrue(t, explainer.explain(ctx, conn, "RETURN 42", nil, run))
+
+		AssertDeepEquals(t, explained, []string{"EXPLAIN MATCH (n) WHERE n.id = 1 RETURN n", "EXPLAIN RETURN 42"})
+		AssertLen(t, notices, 2)
+		AssertStringEqual(t, notices[0].Query, "MATCH (n) WHERE n.id = 1 RETURN n")
+		AssertStringEqual(t, notices[0].QueryFingerprint, QueryFingerprint("MATCH (n) WHERE n.id = 1 RETURN n"))
+		AssertStringEqual(t, notices[0].Plan.Operator(), "AllNodesScan")
+	})
+
+	outer.Run("explains fingerprint again after failure", func(t *testing.T) {
+		var notices []QueryPlanNotice
+		explainer := newQueryExplainer(func(notice QueryPlanNotice) {
+			notices = append(notices, notice)
+		})
+		explainErr := errors.New("oopsie")
+		conn := &ConnFake{ConsumeSum: &db.Summary{}}
+		failingRun := func(string) (idb.StreamHandle, error) {
+			return nil, explainErr
+		}
+		run := func(string) (idb.StreamHandle, error) {
+			return nil, nil
+		}
+
+		AssertFalse(t, explainer.explain(ctx, conn, "RETURN 42", nil, failingRun))
+		AssertTrue(t, explainer.explain(ctx, conn, "RETURN 42", nil, run))
+
+		AssertLen(t, notices, 2)
+		AssertDeepEquals(t, notices[0].Err, explainErr)
+		AssertNil(t, notices[0].Plan)
+		AssertNoError(t, notices[1].Err)
+	})
+
+	outer.Run("does not explain unsupported queries", func(t *testing.T) {
+		explainer := newQueryExplainer(func(QueryPlanNotice) {
+			t.Errorf("should not explain unsupported queries")
+		})
+		run := func(string) (idb.StreamHandle, error) {
+			t.Errorf("should not run EXPLAIN for unsupported queries")
+			return nil, nil
+		}
+
+		for _, cypher := range []string{
+			"EXPLAIN MATCH (n) RETURN n",
+			"profile MATCH (n) RETURN n",
+			"// comment\nCYPHER runtime=slotted MATCH (n) RETURN n",
+			"SHOW DATABASES",
+			"CREATE INDEX person_name FOR (n:Person) ON (n.name)",
+			"CREATE TEXT INDEX IF NOT EXISTS FOR (n:Person) ON (n.name)",
+			"CREATE OR REPLACE DATABASE movies",
+			"DROP CONSTRAINT person_id",
+			"GRANT ROLE reader TO alice",
+			"  ",
+		} {
+			AssertTrue(t, explainer.explain(ctx, &ConnFake{}, cypher, nil, run))
+		}
+		streamed := map[string]any{"rows": &dbtype.StreamedList{}}
+		AssertTrue(t, explainer.explain(ctx, &ConnFake{}, "UNWIND $rows AS row CREATE (:Row)", streamed, run))
+	})
+
+	outer.Run("explains CREATE clauses", func(t *testing.T) {
+		calls := 0
+		explainer := newQueryExplainer(func(QueryPlanNotice) {
+			calls++
+		})
+		run := func(string) (idb.StreamHandle, error) {
+			return nil, nil
+		}
+
+		AssertTrue(t, explainer.explain(ctx, &ConnFake{ConsumeSum: &db.Summary{}}, "CREATE (n:Index {user: $user})",
+			map[string]any{"user": "alice"}, run))
+
+		AssertIntEqual(t, calls, 1)
+	})
+
+	outer.Run("defers queries until they are explained", func(t *testing.T) {
+		explainer := newQueryExplainer(func(QueryPlanNotice) {})
+		run := func(string) (idb.StreamHandle, error) {
+			return nil, nil
+		}
+		explainer.explain(ctx, &ConnFake{ConsumeSum: &db.Summary{}}, "RETURN 1", nil, run)
+
+		deferred := explainer.deferExplain(nil, "RETURN 1", nil)
+		deferred = explainer.deferExplain(deferred, "SHOW USERS", nil)
+		deferred = explainer.deferExplain(deferred, "MATCH (n) RETURN n", nil)
+
+		AssertLen(t, deferred, 1)
+		AssertStringEqual(t, deferred[0].cypher, "MATCH (n) RETURN n")
+	})
+
+	outer.Run("is a no-op when disabled", func(t *testing.T) {
+		var explainer *queryExplainer
+		run := func(string) (idb.StreamHandle, error) {
+			t.Errorf("should not run EXPLAIN when disabled")
+			return nil, nil
+		}
+
+		AssertTrue(t, explainer.explain(ctx, &ConnFake{}, "RETURN 42", nil, run))
+	})
+}
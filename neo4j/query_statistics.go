@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"errors"
+	"sync"
+)
+
+// QueryStatistics aggregates statistics about the queries executed by a driver.
+// See Config.CollectQueryStatistics and DriverWithContext.QueryStatistics.
+type QueryStatistics struct {
+	// Queries is the total number of queries run, including the queries of every transaction function attempt.
+	Queries int64
+	// Failures is the total number of queries that failed, either when run or when fetching their results.
+	Failures int64
+	// FailuresByCode counts the failures reported by the server, per Neo4j error code.
+	FailuresByCode map[string]int64
+	// BytesReceived is the total number of bytes received from the servers, including protocol overhead.
+	BytesReceived int64
+	// RecordsDecoded is the total number of records fetched from query results.
+	RecordsDecoded int64
+}
+
+// SessionStats counts the work done by a session so far, see SessionWithContext.Stats.
+type SessionStats struct {
+	// Queries is the number of queries run by the session, including the queries of every transaction function attempt.
+	Queries int64
+	// Failures is the number of queries of the session that failed, either when run or when fetching their results.
+	Failures int64
+	// RecordsConsumed is the number of records fetched from the results of the session.
+	RecordsConsumed int64
+	// BytesReceived is the number of bytes of the Bolt messages received by the connections of the session while
+	// they were borrowed by the session. Unlike QueryStatistics.BytesReceived, it excludes TLS and chunking overhead.
+	// Connections to the HTTP Query API are not accounted for.
+	BytesReceived int64
+	// Retries is the number of times the transaction functions of the session were retried.
+	Retries int64
+}
+
+// queryStatisticsCollector is safe for concurrent use.
+// All its methods are no-ops on a nil collector, i.e. when statistics are not collected.
+type queryStatisticsCollector struct {
+	mut   sync.Mutex
+	stats QueryStatistics
+	// parent is also notified of the queries, failures and records, see newChild
+	parent *queryStatisticsCollector
+}
+
+func newQueryStatisticsCollector() *queryStatisticsCollector {
+	return &queryStatisticsCollector{stats: QueryStatistics{FailuresByCode: map[string]int64{}}}
+}
+
+// newChild returns a collector counting a subset of the queries of this collector, e.g. the queries of a session.
+// The child forwards queries, failures and records to this collector, which may be nil.
+func (c *queryStatisticsCollector) newChild() *queryStatisticsCollector {
+	child := newQueryStatisticsCollector()
+	child.parent = c
+	return child
+}
+
+func (c *queryStatisticsCollector) onQuery() {
+	if c == nil {
+		return
+	}
+	c.mut.Lock()
+	c.stats.Queries++
+	c.mut.Unlock()
+	c.parent.onQuery()
+}
+
+func (c *queryStatisticsCollector) onFailure(err error) {
+	if c == nil || err == nil {
+		return
+	}
+	c.mut.Lock()
+	c.stats.Failures++
+	var neo4jErr *Neo4jError
+	if errors.As(err, &neo4jErr) {
+		c.stats.FailuresByCode[neo4jErr.Code]++
+	}
+	c.mut.Unlock()
+	c.parent.onFailure(err)
+}
+
+func (c *queryStatisticsCollector) onBytesReceived(n int) {
+	if c == nil {
+		return
+	}
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.stats.BytesReceived += int64(n)
+}
+
+func (c *queryStatisticsCollector) onRecord(record *Record) {
+	if c == nil || record == nil {
+		return
+	}
+	c.mut.Lock()
+	c.stats.RecordsDecoded++
+	c.mut.Unlock()
+	c.parent.onRecord(record)
+}
+
+// snapshot returns a copy of the statistics collected so far
+func (c *queryStatisticsCollector) snapshot() QueryStatistics {
+	if c == nil {
+		return QueryStatistics{}
+	}
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	stats := c.stats
+	stats.FailuresByCode = make(map[string]int64, len(c.stats.FailuresByCode))
+	for code, count := range c.stats.FailuresByCode {
+		stats.FailuresByCode[code] = count
+	}
+	return stats
+}
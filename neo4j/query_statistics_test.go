@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+)
+
+func TestQueryStatistics(outer *testing.T) {
+	ctx := context.Background()
+
+	outer.Run("nil collector collects nothing", func(t *testing.T) {
+		var collector *queryStatisticsCollector
+
+		collector.onQuery()
+		collector.onFailure(errors.New("oopsie"))
+		collector.onBytesReceived(42)
+		collector.onRecord(&Record{})
+
+		AssertDeepEquals(t, collector.snapshot(), QueryStatistics{})
+	})
+
+	outer.Run("aggregates failures by code", func(t *testing.T) {
+		collector := newQueryStatisticsCollector()
+
+		collector.onFailure(&db.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError"})
+		collector.onFailure(&db.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError"})
+		collector.onFailure(errors.New("not a server failure"))
+
+		stats := collector.snapshot()
+		AssertDeepEquals(t, stats.Failures, int64(3))
+		AssertDeepEquals(t, stats.FailuresByCode, map[string]int64{"Neo.ClientError.Statement.SyntaxError": 2})
+	})
+
+	outer.Run("snapshots are copies", func(t *testing.T) {
+		collector := newQueryStatisticsCollector()
+		collector.onFailure(&db.Neo4jError{Code: "Neo.TransientError.General.DatabaseUnavailable"})
+
+		stats := collector.snapshot()
+		stats.FailuresByCode["Neo.TransientError.General.DatabaseUnavailable"] = 42
+
+		AssertDeepEquals(t, collector.snapshot().FailuresByCode, map[string]int64{"Neo.TransientError.General.DatabaseUnavailable": 1})
+	})
+
+	outer.Run("children forward to their parent", func(t *testing.T) {
+		parent := newQueryStatisticsCollector()
+		child := parent.newChild()
+		sibling := parent.newChild()
+
+		child.onQuery()
+		child.onFailure(&db.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError"})
+		sibling.onQuery()
+		sibling.onRecord(&Record{})
+
+		AssertDeepEquals(t, child.snapshot(), QueryStatistics{Queries: 1, Failures: 1,
+			FailuresByCode: map[string]int64{"Neo.ClientError.Statement.SyntaxError": 1}})
+		AssertDeepEquals(t, parent.snapshot(), QueryStatistics{Queries: 2, Failures: 1, RecordsDecoded: 1,
+			FailuresByCode: map[string]int64{"Neo.ClientError.Statement.SyntaxError": 1}})
+	})
+
+	outer.Run("children of a nil collector collect", func(t *testing.T) {
+		var parent *queryStatisticsCollector
+		child := parent.newChild()
+
+		child.onQuery()
+
+		AssertDeepEquals(t, child.snapshot().Queries, int64(1))
+	})
+
+	outer.Run("collects auto-commit queries and their records", func(t *testing.T) {
+		conn := &ConnFake{Alive: true, Nexts: []Next{
+			{Record: &db.Record{Keys: []string{"n"}, Values: []any{1}}},
+			{Record: &db.Record{Keys: []string{"n"}, Values: []any{2}}},
+			{Summary: &db.Summary{}},
+		}}
+		session := newSessionWithContext(&Config{}, SessionConfig{}, &RouterFake{}, &PoolFake{BorrowConn: conn}, &log.Void{})
+		session.statistics = newQueryStatisticsCollector()
+
+		result, err := session.Run(ctx, "UNWIND [1, 2] AS n RETURN n", nil)
+		AssertNoError(t, err)
+		_, err = result.Collect(ctx)
+		AssertNoError(t, err)
+
+		stats := session.statistics.snapshot()
+		AssertDeepEquals(t, stats.Queries, int64(1))
+		AssertDeepEquals(t, stats.RecordsDecoded, int64(2))
+		AssertDeepEquals(t, stats.Failures, int64(0))
+	})
+
+	outer.Run("collects failed transaction queries", func(t *testing.T) {
+		syntaxErr := &db.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError"}
+		conn := &ConnFake{Alive: true, RunTxErr: syntaxErr, TxBeginHandle: idb.TxHandle(1)}
+		session := newSessionWithContext(&Config{}, SessionConfig{}, &RouterFake{}, &PoolFake{BorrowConn: conn}, &log.Void{})
+		session.statistics = newQueryStatisticsCollector()
+
+		tx, err := session.BeginTransaction(ctx)
+		AssertNoError(t, err)
+		_, err = tx.Run(ctx, "RETRUN 42", nil)
+		AssertError(t, err)
+
+		stats := session.statistics.snapshot()
+		AssertDeepEquals(t, stats.Queries, int64(1))
+		AssertDeepEquals(t, stats.FailuresByCode, map[string]int64{"Neo.ClientError.Statement.SyntaxError": 1})
+	})
+}
@@ -19,10 +19,14 @@
 
 package neo4j
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/bolt"
+)
 
 type RecordValue interface {
-	bool | int64 | float64 | string |
+	bool | int | int64 | float64 | string |
 		Point2D | Point3D |
 		Date | LocalTime | LocalDateTime | Time | Duration | /* OffsetTime == Time == dbtype.Time */
 		[]byte | []any | map[string]any |
@@ -34,6 +38,8 @@ type RecordValue interface {
 // If the key specified for the value does not exist, an error is returned
 // If the value is not defined for the provided existing key, the returned boolean is true
 // If the value type does not match the type specification, an error is returned
+// Integers and floats are only converted to another numeric type (int, int64 or float64) according to the optional
+// NumberCoercion policy, StrictNumbers being the default
 //
 // Take this simple graph made of three nodes: `(:Person {name: "Arya"})`, `(:Person {name: ""})`, `(:Person)`
 // and the query: `MATCH (p:Person) RETURN p.name AS name`.
@@ -49,7 +55,10 @@ type RecordValue interface {
 //		_, _, err := neo4j.GetRecordValue[string](record, "invalid-key")
 //		// this results in an error, since "invalid-key" is not part of the query result keys
 //	}
-func GetRecordValue[T RecordValue](record *Record, key string) (T, bool, error) {
+func GetRecordValue[T RecordValue](record *Record, key string, coercion ...NumberCoercion) (T, bool, error) {
+	if err := checkDecodedRecord(record); err != nil {
+		return *new(T), false, err
+	}
 	rawValue, found := record.Get(key)
 	if !found {
 		return *new(T), false, fmt.Errorf("record value %s not found", key)
@@ -57,10 +66,31 @@ func GetRecordValue[T RecordValue](record *Record, key string) (T, bool, error)
 	if rawValue == nil {
 		return *new(T), true, nil
 	}
-	value, ok := rawValue.(T)
-	if !ok {
-		zeroValue := *new(T)
-		return zeroValue, false, fmt.Errorf("expected value to have type %T but found type %T", zeroValue, rawValue)
+	value, err := coerceValue[T](rawValue, coercion)
+	if err != nil {
+		return *new(T), false, err
 	}
 	return value, false, nil
 }
+
+// DecodeRawRecord decodes the values of a record obtained raw (see Config.RawRecords), so that they can be read with
+// Record.Get and the record helpers like GetRecordValue. Records that are not raw are left untouched.
+func DecodeRawRecord(record *Record) error {
+	if record == nil || record.Values != nil || record.Raw == nil {
+		return nil
+	}
+	values, err := bolt.DecodeRawRecord(record.Raw)
+	if err != nil {
+		return err
+	}
+	record.Values = values
+	return nil
+}
+
+// checkDecodedRecord returns a UsageError for raw records, whose values cannot be read until they are decoded
+func checkDecodedRecord(record *Record) error {
+	if record.Values == nil && record.Raw != nil {
+		return &UsageError{Message: "cannot read the values of a raw record, decode it with DecodeRawRecord first"}
+	}
+	return nil
+}
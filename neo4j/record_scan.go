@@ -0,0 +1,297 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ScanRecord maps the values of the record to the fields of the struct pointed to by dst, by record key.
+// A field is mapped to the key named by its `neo4j:"key"` tag or, without a tag, to the key matching its name
+// case-insensitively. Fields tagged with `neo4j:"-"` and unexported fields are ignored, and the fields of embedded
+// structs are mapped as if they were fields of the outer struct.
+// When the record has a single value, which is a Node, a Relationship or a map matching none of the fields, the
+// struct fields are mapped to the properties or entries of that value instead, as in `MATCH (p:Person) RETURN p`.
+//
+// Values are mapped recursively: Node and Relationship properties, as well as map entries, to nested structs and
+// maps, lists to slices and values to pointers. Fields without a corresponding key and fields matching null values
+// are left untouched.
+// Integers are mapped to all integer fields and floats to all float fields, as long as the value fits; converting
+// between integers and floats follows the optional NumberCoercion policy, StrictNumbers being the default.
+// An error naming the field is returned for values of a type that does not match the field type.
+//
+//	type Person struct {
+//		Name    string   `neo4j:"name"`
+//		Age     int      `neo4j:"age"`
+//		Friends []string `neo4j:"friends"`
+//	}
+//	var person Person
+//	err := neo4j.ScanRecord(record, &person)
+func ScanRecord[T any](record *Record, dst *T, coercion ...NumberCoercion) error {
+	if record == nil {
+		return &UsageError{Message: "cannot scan a nil record"}
+	}
+	if dst == nil {
+		return &UsageError{Message: "cannot scan a record into a nil destination"}
+	}
+	if err := checkDecodedRecord(record); err != nil {
+		return err
+	}
+	target := reflect.ValueOf(dst).Elem()
+	if target.Kind() != reflect.Struct {
+		return &UsageError{Message: fmt.Sprintf("cannot scan a record into %s, expected a struct", target.Type())}
+	}
+	scanner := recordScanner{policy: StrictNumbers}
+	if len(coercion) > 0 {
+		scanner.policy = coercion[0]
+	}
+	values := make(map[string]any, len(record.Keys))
+	for i, key := range record.Keys {
+		values[key] = record.Values[i]
+	}
+	if len(record.Values) == 1 && !structFieldsOf(target.Type()).matchAny(record.Keys) {
+		if properties, ok := propertiesOf(record.Values[0]); ok {
+			values = properties
+		}
+	}
+	return scanner.scanStruct(values, target, target.Type().Name())
+}
+
+// ScanMapper returns a mapper scanning records to instances of T with ScanRecord, to be used with
+// CollectTWithContext and SingleTWithContext.
+//
+//	people, err := neo4j.CollectTWithContext(ctx, result, neo4j.ScanMapper[Person]())
+func ScanMapper[T any](coercion ...NumberCoercion) func(*Record) (T, error) {
+	return func(record *Record) (T, error) {
+		var value T
+		err := ScanRecord(record, &value, coercion...)
+		return value, err
+	}
+}
+
+type recordScanner struct {
+	policy NumberCoercion
+}
+
+func (s recordScanner) scanStruct(values map[string]any, target reflect.Value, path string) error {
+	for _, field := range structFieldsOf(target.Type()) {
+		value, found := field.lookup(values)
+		if !found || value == nil {
+			continue
+		}
+		if err := s.scan(value, target.FieldByIndex(field.index), path+"."+field.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s recordScanner) scan(value any, target reflect.Value, path string) error {
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	source := reflect.ValueOf(value)
+	if source.Type().AssignableTo(target.Type()) {
+		target.Set(source)
+		return nil
+	}
+	switch target.Kind() {
+	case reflect.Ptr:
+		element := reflect.New(target.Type().Elem())
+		if err := s.scan(value, element.Elem(), path); err != nil {
+			return err
+		}
+		target.Set(element)
+		return nil
+	case reflect.Struct:
+		if properties, ok := propertiesOf(value); ok {
+			return s.scanStruct(properties, target, path)
+		}
+		// temporal values like Date to time.Time
+		if source.Kind() == reflect.Struct && source.Type().ConvertibleTo(target.Type()) {
+			target.Set(source.Convert(target.Type()))
+			return nil
+		}
+	case reflect.Slice:
+		if items, ok := value.([]any); ok {
+			slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+			for i, item := range items {
+				if err := s.scan(item, slice.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+			target.Set(slice)
+			return nil
+		}
+	case reflect.Map:
+		if entries, ok := value.(map[string]any); ok && target.Type().Key().Kind() == reflect.String {
+			m := reflect.MakeMapWithSize(target.Type(), len(entries))
+			for key, entry := range entries {
+				element := reflect.New(target.Type().Elem()).Elem()
+				if err := s.scan(entry, element, fmt.Sprintf("%s[%q]", path, key)); err != nil {
+					return err
+				}
+				m.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), element)
+			}
+			target.Set(m)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return s.scanInt(value, target, path)
+	case reflect.Float32, reflect.Float64:
+		return s.scanFloat(value, target, path)
+	case reflect.Bool, reflect.String:
+		// named types like `type Status string`
+		if source.Kind() == target.Kind() {
+			target.Set(source.Convert(target.Type()))
+			return nil
+		}
+	}
+	return s.mismatch(value, target, path)
+}
+
+func (s recordScanner) scanInt(value any, target reflect.Value, path string) error {
+	policy := s.policy
+	if _, isInt := value.(int64); isInt {
+		policy = LossyNumbers
+	}
+	converted, err := coerceToInt64(value, policy)
+	if err == errNumberCoercion || (err == nil && policy == StrictNumbers) {
+		return s.mismatch(value, target, path)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot scan %s: %w", path, err)
+	}
+	if target.OverflowInt(converted) {
+		return fmt.Errorf("cannot scan %s: value %d overflows %s", path, converted, target.Type())
+	}
+	target.SetInt(converted)
+	return nil
+}
+
+func (s recordScanner) scanFloat(value any, target reflect.Value, path string) error {
+	policy := s.policy
+	if _, isFloat := value.(float64); isFloat {
+		policy = LossyNumbers
+	}
+	converted, err := coerceToFloat64(value, policy)
+	if err == errNumberCoercion || (err == nil && policy == StrictNumbers) {
+		return s.mismatch(value, target, path)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot scan %s: %w", path, err)
+	}
+	if target.OverflowFloat(converted) {
+		return fmt.Errorf("cannot scan %s: value %v overflows %s", path, converted, target.Type())
+	}
+	target.SetFloat(converted)
+	return nil
+}
+
+func (s recordScanner) mismatch(value any, target reflect.Value, path string) error {
+	return fmt.Errorf("cannot scan value of type %T into %s of type %s", value, path, target.Type())
+}
+
+// propertiesOf returns the properties of nodes and relationships, as well as the entries of maps
+func propertiesOf(value any) (map[string]any, bool) {
+	switch v := value.(type) {
+	case Node:
+		return v.Props, true
+	case Relationship:
+		return v.Props, true
+	case map[string]any:
+		return v, true
+	}
+	return nil, false
+}
+
+type scannedField struct {
+	name  string
+	key   string
+	index []int
+	// tagged fields only match their key exactly, other fields match their name case-insensitively
+	tagged bool
+}
+
+func (f scannedField) lookup(values map[string]any) (any, bool) {
+	if value, found := values[f.key]; found || f.tagged {
+		return value, found
+	}
+	for key, value := range values {
+		if strings.EqualFold(key, f.key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+type scannedFields []scannedField
+
+func (fields scannedFields) matchAny(keys []string) bool {
+	for _, field := range fields {
+		for _, key := range keys {
+			if key == field.key || (!field.tagged && strings.EqualFold(key, field.key)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scannedFieldsCache caches the scanned fields by struct type
+var scannedFieldsCache sync.Map
+
+func structFieldsOf(structType reflect.Type) scannedFields {
+	if fields, found := scannedFieldsCache.Load(structType); found {
+		return fields.(scannedFields)
+	}
+	fields := collectStructFields(structType, nil)
+	scannedFieldsCache.Store(structType, fields)
+	return fields
+}
+
+func collectStructFields(structType reflect.Type, index []int) scannedFields {
+	var fields scannedFields
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, tagged := field.Tag.Lookup("neo4j")
+		if tag == "-" {
+			continue
+		}
+		fieldIndex := append(append([]int{}, index...), i)
+		if field.Anonymous && !tagged && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, collectStructFields(field.Type, fieldIndex)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		key := field.Name
+		if tagged && tag != "" {
+			key = tag
+		}
+		fields = append(fields, scannedField{name: field.Name, key: key, index: fieldIndex, tagged: tagged && tag != ""})
+	}
+	return fields
+}
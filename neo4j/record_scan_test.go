@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+type scannedAddress struct {
+	City string `neo4j:"city"`
+}
+
+type scannedAudit struct {
+	CreatedAt time.Time `neo4j:"createdAt"`
+}
+
+type scannedPerson struct {
+	scannedAudit
+	Name      string           `neo4j:"name"`
+	Age       int              `neo4j:"age"`
+	Score     float32          `neo4j:"score"`
+	Nickname  *string          `neo4j:"nickname"`
+	Tags      []string         `neo4j:"tags"`
+	Addresses []scannedAddress `neo4j:"addresses"`
+	Ratings   map[string]int   `neo4j:"ratings"`
+	Node      neo4j.Node       `neo4j:"node"`
+	Ignored   string           `neo4j:"-"`
+	Country   string
+}
+
+func TestScanRecord(outer *testing.T) {
+	outer.Parallel()
+
+	createdAt := time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)
+
+	outer.Run("scans record values by key", func(t *testing.T) {
+		node := neo4j.Node{ElementId: "4:db:1", Labels: []string{"Person"}}
+		rec := &neo4j.Record{
+			Keys: []string{"name", "age", "score", "nickname", "tags", "addresses", "ratings", "node", "Ignored", "country", "createdAt"},
+			Values: []any{"Ada", int64(36), 1.5, "ada", []any{"math", "code"},
+				[]any{map[string]any{"city": "London"}, neo4j.Node{Props: map[string]any{"city": "Paris"}}},
+				map[string]any{"poem": int64(5)}, node, "x", "UK", createdAt},
+		}
+		var person scannedPerson
+
+		err := neo4j.ScanRecord(rec, &person)
+
+		AssertNoError(t, err)
+		nickname := "ada"
+		AssertDeepEquals(t, person, scannedPerson{
+			scannedAudit: scannedAudit{CreatedAt: createdAt},
+			Name:         "Ada",
+			Age:          36,
+			Score:        1.5,
+			Nickname:     &nickname,
+			Tags:         []string{"math", "code"},
+			Addresses:    []scannedAddress{{City: "London"}, {City: "Paris"}},
+			Ratings:      map[string]int{"poem": 5},
+			Node:         node,
+			Country:      "UK",
+		})
+	})
+
+	outer.Run("scans the properties of a single entity", func(t *testing.T) {
+		rec := &neo4j.Record{
+			Keys:   []string{"p"},
+			Values: []any{neo4j.Node{Props: map[string]any{"name": "Ada", "age": int64(36)}}},
+		}
+		var person scannedPerson
+
+		err := neo4j.ScanRecord(rec, &person)
+
+		AssertNoError(t, err)
+		AssertStringEqual(t, person.Name, "Ada")
+		AssertIntEqual(t, person.Age, 36)
+	})
+
+	outer.Run("leaves fields of missing and null values untouched", func(t *testing.T) {
+		rec := &neo4j.Record{Keys: []string{"name", "age"}, Values: []any{"Ada", nil}}
+		person := scannedPerson{Age: 42, Country: "UK"}
+
+		err := neo4j.ScanRecord(rec, &person)
+
+		AssertNoError(t, err)
+		AssertIntEqual(t, person.Age, 42)
+		AssertStringEqual(t, person.Country, "UK")
+	})
+
+	outer.Run("converts temporal values", func(t *testing.T) {
+		rec := &neo4j.Record{Keys: []string{"createdAt"}, Values: []any{neo4j.DateOf(createdAt)}}
+		var audit scannedAudit
+
+		err := neo4j.ScanRecord(rec, &audit)
+
+		AssertNoError(t, err)
+		AssertTrue(t, audit.CreatedAt.Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)))
+	})
+
+	outer.Run("fails on mismatching types", func(t *testing.T) {
+		rec := &neo4j.Record{Keys: []string{"age"}, Values: []any{"old"}}
+		var person scannedPerson
+
+		err := neo4j.ScanRecord(rec, &person)
+
+		AssertErrorMessageContains(t, err, "cannot scan value of type string into scannedPerson.Age of type int")
+	})
+
+	outer.Run("names the failing list element", func(t *testing.T) {
+		rec := &neo4j.Record{Keys: []string{"tags"}, Values: []any{[]any{int64(1)}}}
+		var person scannedPerson
+
+		err := neo4j.ScanRecord(rec, &person)
+
+		AssertErrorMessageContains(t, err, "scannedPerson.Tags[0]")
+	})
+
+	outer.Run("converts between integers and floats according to the coercion policy", func(t *testing.T) {
+		rec := &neo4j.Record{Keys: []string{"age", "score"}, Values: []any{2.0, int64(3)}}
+		var person scannedPerson
+
+		strictErr := neo4j.ScanRecord(rec, &person)
+		losslessErr := neo4j.ScanRecord(rec, &person, neo4j.LosslessNumbers)
+
+		AssertError(t, strictErr)
+		AssertNoError(t, losslessErr)
+		AssertIntEqual(t, person.Age, 2)
+		AssertTrue(t, person.Score == 3)
+	})
+
+	outer.Run("rejects non struct destinations", func(t *testing.T) {
+		var name string
+
+		err := neo4j.ScanRecord(&neo4j.Record{}, &name)
+
+		AssertTrue(t, neo4j.IsUsageError(err))
+	})
+
+	outer.Run("rejects nil destinations", func(t *testing.T) {
+		var person *scannedPerson
+
+		err := neo4j.ScanRecord(&neo4j.Record{}, person)
+
+		AssertTrue(t, neo4j.IsUsageError(err))
+	})
+
+	outer.Run("maps records with ScanMapper", func(t *testing.T) {
+		rec := &neo4j.Record{Keys: []string{"city"}, Values: []any{"Paris"}}
+
+		address, err := neo4j.ScanMapper[scannedAddress]()(rec)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, address, scannedAddress{City: "Paris"})
+	})
+}
@@ -55,6 +55,21 @@ func TestGetRecordValue(outer *testing.T) {
 		}
 	})
 
+	outer.Run("requires raw records to be decoded", func(t *testing.T) {
+		raw := &neo4j.Record{Keys: []string{"n", "s"}, Raw: []byte{0x92, 0x01, 0x81, 'a'}}
+
+		_, _, err := neo4j.GetRecordValue[int64](raw, "n")
+		AssertSameType(t, err, &neo4j.UsageError{})
+
+		AssertNoError(t, neo4j.DecodeRawRecord(raw))
+		n, _, err := neo4j.GetRecordValue[int64](raw, "n")
+		AssertNoError(t, err)
+		AssertIntEqual(t, int(n), 1)
+		s, _, err := neo4j.GetRecordValue[string](raw, "s")
+		AssertNoError(t, err)
+		AssertStringEqual(t, s, "a")
+	})
+
 	outer.Run("supports only valid record values", func(inner *testing.T) {
 		now := time.Now()
 
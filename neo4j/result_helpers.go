@@ -61,6 +61,112 @@ func CollectTWithContext[T any](ctx context.Context, result ResultWithContext, m
 	return mapAll(records, mapper)
 }
 
+// Column extracts the value named by the specified key from each remaining record into a slice of T, in record order.
+// Records are processed as they are streamed, without collecting them first.
+// Values are converted like GetRecordValue does, according to the optional NumberCoercion policy, and nil values
+// are extracted as the zero value of T.
+// An error is returned if the key is not part of the result keys, or if a value does not match T, in which case the
+// result is left open on the offending record.
+// It accepts a context.Context, which may be canceled or carry a deadline, to control the overall record fetching
+// execution time.
+//
+// For instance:
+//
+//	result, err := session.Run(ctx, "MATCH (p:Person) RETURN p.name AS name", nil)
+//	// [...]
+//	names, err := neo4j.Column[string](ctx, result, "name")
+func Column[T RecordValue](ctx context.Context, result ResultWithContext, key string, coercion ...NumberCoercion) ([]T, error) {
+	columns, err := extractColumns[T](ctx, result, []string{key}, coercion)
+	if err != nil {
+		return nil, err
+	}
+	return columns[key], nil
+}
+
+// Columns extracts the values of all the keys of the result from each remaining record, into one slice of T per key,
+// in record order. The values of a column can be of different types as long as they all convert to T.
+// It otherwise behaves like Column.
+//
+// For instance:
+//
+//	result, err := session.Run(ctx, "MATCH (m:Measure) RETURN m.min AS min, m.max AS max", nil)
+//	// [...]
+//	columns, err := neo4j.Columns[float64](ctx, result, neo4j.LosslessNumbers)
+//	// columns["min"] and columns["max"] are []float64
+func Columns[T RecordValue](ctx context.Context, result ResultWithContext, coercion ...NumberCoercion) (map[string][]T, error) {
+	return extractColumns[T](ctx, result, nil, coercion)
+}
+
+// extractColumns extracts the values of the given keys, or of all the result keys when nil
+func extractColumns[T RecordValue](ctx context.Context, result ResultWithContext, keys []string, coercion []NumberCoercion) (map[string][]T, error) {
+	resultKeys, err := result.Keys()
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys = resultKeys
+	}
+	columns := make(map[string][]T, len(keys))
+	for _, key := range keys {
+		if !containsKey(resultKeys, key) {
+			return nil, unknownKeyError(key, resultKeys)
+		}
+		columns[key] = []T{}
+	}
+	for i := 0; result.Next(ctx); i++ {
+		record := result.Record()
+		for _, key := range keys {
+			value, _, err := GetRecordValue[T](record, key, coercion...)
+			if err != nil {
+				return nil, fmt.Errorf("record %d: %w", i, err)
+			}
+			columns[key] = append(columns[key], value)
+		}
+	}
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// Nodes extracts the nodes named by the specified key from each remaining record into a slice, in record order.
+// It is a shorthand for Column[Node].
+//
+// For instance:
+//
+//	result, err := session.Run(ctx, "MATCH (p:Person) RETURN p", nil)
+//	// [...]
+//	people, err := neo4j.Nodes(ctx, result, "p")
+func Nodes(ctx context.Context, result ResultWithContext, key string) ([]Node, error) {
+	return Column[Node](ctx, result, key)
+}
+
+// Relationships extracts the relationships named by the specified key from each remaining record into a slice, in
+// record order.
+// It is a shorthand for Column[Relationship].
+//
+// For instance:
+//
+//	result, err := session.Run(ctx, "MATCH (:Person)-[k:KNOWS]->(:Person) RETURN k", nil)
+//	// [...]
+//	acquaintances, err := neo4j.Relationships(ctx, result, "k")
+func Relationships(ctx context.Context, result ResultWithContext, key string) ([]Relationship, error) {
+	return Column[Relationship](ctx, result, key)
+}
+
+func unknownKeyError(key string, resultKeys []string) *UsageError {
+	return &UsageError{Message: fmt.Sprintf("Result has no key %q, available keys: %v", key, resultKeys)}
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 // CollectT maps the records to a slice of T with the provided mapper function.
 // It relies on Result.Collect and propagate its error, if any.
 //
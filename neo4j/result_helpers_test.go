@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestColumns(outer *testing.T) {
+	ctx := context.Background()
+	keys := []string{"name", "age"}
+	newResult := func(nexts ...Next) *resultWithContext {
+		conn := &ConnFake{KeysRet: keys, Nexts: append(nexts, Next{Summary: &db.Summary{}})}
+		return newResultWithContext(conn, idb.StreamHandle(0), "", nil, nil)
+	}
+	record := func(name any, age any) Next {
+		return Next{Record: &db.Record{Keys: keys, Values: []any{name, age}}}
+	}
+
+	outer.Run("Column", func(inner *testing.T) {
+		inner.Run("extracts the values of the key", func(t *testing.T) {
+			result := newResult(record("Arya", int64(11)), record(nil, int64(17)), record("Sansa", int64(13)))
+
+			names, err := Column[string](ctx, result, "name")
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, names, []string{"Arya", "", "Sansa"})
+			AssertFalse(t, result.IsOpen())
+		})
+
+		inner.Run("converts numbers", func(t *testing.T) {
+			result := newResult(record("Arya", int64(11)))
+
+			ages, err := Column[float64](ctx, result, "age", LosslessNumbers)
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, ages, []float64{11})
+		})
+
+		inner.Run("extracts no values from empty results", func(t *testing.T) {
+			names, err := Column[string](ctx, newResult(), "name")
+
+			AssertNoError(t, err)
+			AssertLen(t, names, 0)
+		})
+
+		inner.Run("fails on unknown keys before streaming", func(t *testing.T) {
+			result := newResult(record("Arya", int64(11)))
+
+			_, err := Column[string](ctx, result, "surname")
+
+			AssertSameType(t, err, &UsageError{})
+			AssertErrorMessageContains(t, err, `"surname"`)
+			AssertTrue(t, result.Peek(ctx))
+		})
+
+		inner.Run("fails on values of another type", func(t *testing.T) {
+			result := newResult(record("Arya", int64(11)), record(int64(42), int64(17)))
+
+			_, err := Column[string](ctx, result, "name")
+
+			AssertErrorMessageContains(t, err, "record 1")
+			AssertTrue(t, result.IsOpen())
+		})
+
+		inner.Run("returns stream errors", func(t *testing.T) {
+			streamErr := errors.New("oopsie")
+			conn := &ConnFake{KeysRet: keys, Nexts: []Next{record("Arya", int64(11)), {Err: streamErr}}}
+			result := newResultWithContext(conn, idb.StreamHandle(0), "", nil, nil)
+
+			_, err := Column[string](ctx, result, "name")
+
+			AssertDeepEquals(t, err, streamErr)
+		})
+	})
+
+	outer.Run("Columns extracts the values of all keys", func(t *testing.T) {
+		result := newResult(record("Arya", int64(11)), record("Sansa", 13.0))
+
+		columns, err := Columns[string](ctx, newResult(record("Arya", "11")))
+		AssertNoError(t, err)
+		AssertDeepEquals(t, columns, map[string][]string{"name": {"Arya"}, "age": {"11"}})
+
+		numbers, err := Columns[int64](ctx, result, LosslessNumbers)
+		AssertErrorMessageContains(t, err, "record 0")
+		AssertLen(t, numbers, 0)
+	})
+}
+
+func TestEntities(outer *testing.T) {
+	ctx := context.Background()
+	keys := []string{"p", "k"}
+	newResult := func(nexts ...Next) *resultWithContext {
+		conn := &ConnFake{KeysRet: keys, Nexts: append(nexts, Next{Summary: &db.Summary{}})}
+		return newResultWithContext(conn, idb.StreamHandle(0), "", nil, nil)
+	}
+	record := func(p any, k any) Next {
+		return Next{Record: &db.Record{Keys: keys, Values: []any{p, k}}}
+	}
+	arya := Node{ElementId: "1", Labels: []string{"Person"}, Props: map[string]any{"name": "Arya"}}
+	sansa := Node{ElementId: "2", Labels: []string{"Person"}, Props: map[string]any{"name": "Sansa"}}
+	knows := Relationship{ElementId: "3", StartElementId: "1", EndElementId: "2", Type: "KNOWS"}
+
+	outer.Run("Nodes extracts the nodes of the key", func(t *testing.T) {
+		result := newResult(record(arya, knows), record(sansa, knows))
+
+		nodes, err := Nodes(ctx, result, "p")
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, nodes, []Node{arya, sansa})
+		AssertFalse(t, result.IsOpen())
+	})
+
+	outer.Run("Relationships extracts the relationships of the key", func(t *testing.T) {
+		relationships, err := Relationships(ctx, newResult(record(arya, knows)), "k")
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, relationships, []Relationship{knows})
+	})
+
+	outer.Run("extracts no entities from empty results", func(t *testing.T) {
+		nodes, err := Nodes(ctx, newResult(), "p")
+
+		AssertNoError(t, err)
+		AssertLen(t, nodes, 0)
+	})
+
+	outer.Run("fails on unknown keys before streaming", func(t *testing.T) {
+		result := newResult(record(arya, knows))
+
+		_, err := Nodes(ctx, result, "n")
+
+		AssertSameType(t, err, &UsageError{})
+		AssertErrorMessageContains(t, err, `"n"`)
+		AssertTrue(t, result.Peek(ctx))
+	})
+
+	outer.Run("fails on values of another entity type", func(t *testing.T) {
+		result := newResult(record(arya, knows), record(arya, knows))
+
+		_, err := Relationships(ctx, result, "p")
+
+		AssertErrorMessageContains(t, err, "record 0: expected value to have type dbtype.Relationship but found type dbtype.Node")
+		AssertTrue(t, result.IsOpen())
+	})
+
+	outer.Run("extracts nil values as zero entities", func(t *testing.T) {
+		nodes, err := Nodes(ctx, newResult(record(arya, knows), record(nil, knows)), "p")
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, nodes, []Node{arya, {}})
+	})
+}
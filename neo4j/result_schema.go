@@ -0,0 +1,182 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"time"
+)
+
+// FieldType is the type of value held by a result field
+type FieldType int
+
+const (
+	// FieldTypeUnknown is used when the type of the field cannot be determined, typically because its value is nil
+	FieldTypeUnknown FieldType = iota
+	FieldTypeBoolean
+	FieldTypeInteger
+	FieldTypeFloat
+	FieldTypeString
+	FieldTypeBytes
+	FieldTypeList
+	FieldTypeMap
+	FieldTypeNode
+	FieldTypeRelationship
+	FieldTypePath
+	FieldTypePoint2D
+	FieldTypePoint3D
+	FieldTypeDate
+	FieldTypeTime
+	FieldTypeLocalTime
+	FieldTypeDateTime
+	FieldTypeLocalDateTime
+	FieldTypeDuration
+)
+
+func (t FieldType) String() string {
+	switch t {
+	case FieldTypeBoolean:
+		return "BOOLEAN"
+	case FieldTypeInteger:
+		return "INTEGER"
+	case FieldTypeFloat:
+		return "FLOAT"
+	case FieldTypeString:
+		return "STRING"
+	case FieldTypeBytes:
+		return "BYTE ARRAY"
+	case FieldTypeList:
+		return "LIST"
+	case FieldTypeMap:
+		return "MAP"
+	case FieldTypeNode:
+		return "NODE"
+	case FieldTypeRelationship:
+		return "RELATIONSHIP"
+	case FieldTypePath:
+		return "PATH"
+	case FieldTypePoint2D, FieldTypePoint3D:
+		return "POINT"
+	case FieldTypeDate:
+		return "DATE"
+	case FieldTypeTime:
+		return "ZONED TIME"
+	case FieldTypeLocalTime:
+		return "LOCAL TIME"
+	case FieldTypeDateTime:
+		return "ZONED DATETIME"
+	case FieldTypeLocalDateTime:
+		return "LOCAL DATETIME"
+	case FieldTypeDuration:
+		return "DURATION"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// FieldDescriptor describes a field of a result
+type FieldDescriptor struct {
+	// Name is the key of the field, as returned by ResultWithContext.Keys
+	Name string
+	// Type is the type of the field, derived from the field value of a sample record
+	Type FieldType
+}
+
+// DescribeResultFields returns the descriptors of the fields of the given result, in the order of the result keys.
+// Field types are derived from the values of the next record, which is peeked at and therefore not consumed.
+// This allows generic exporters (e.g. to CSV or Arrow) to build their schema before consuming the result.
+// If the result has no more records, or if a value of the next record is nil, the corresponding field types are
+// FieldTypeUnknown.
+func DescribeResultFields(ctx context.Context, result ResultWithContext) ([]FieldDescriptor, error) {
+	keys, err := result.Keys()
+	if err != nil {
+		return nil, err
+	}
+	var record *Record
+	if !result.PeekRecord(ctx, &record) {
+		if err := result.Err(); err != nil {
+			return nil, err
+		}
+	}
+	descriptors := make([]FieldDescriptor, len(keys))
+	for i, key := range keys {
+		descriptors[i] = FieldDescriptor{Name: key, Type: FieldTypeUnknown}
+		if record != nil && i < len(record.Values) {
+			descriptors[i].Type = fieldTypeOf(record.Values[i])
+		}
+	}
+	return descriptors, nil
+}
+
+// DescribeRecordFields returns the descriptors of the fields of the given record, derived from its values.
+// This is useful with eagerly fetched results, such as EagerResult.Records.
+func DescribeRecordFields(record *Record) []FieldDescriptor {
+	descriptors := make([]FieldDescriptor, len(record.Keys))
+	for i, key := range record.Keys {
+		descriptors[i] = FieldDescriptor{Name: key, Type: FieldTypeUnknown}
+		if i < len(record.Values) {
+			descriptors[i].Type = fieldTypeOf(record.Values[i])
+		}
+	}
+	return descriptors
+}
+
+func fieldTypeOf(value any) FieldType {
+	switch value.(type) {
+	case bool:
+		return FieldTypeBoolean
+	case int64:
+		return FieldTypeInteger
+	case float64:
+		return FieldTypeFloat
+	case string:
+		return FieldTypeString
+	case []byte:
+		return FieldTypeBytes
+	case []any:
+		return FieldTypeList
+	case map[string]any:
+		return FieldTypeMap
+	case Node:
+		return FieldTypeNode
+	case Relationship:
+		return FieldTypeRelationship
+	case Path:
+		return FieldTypePath
+	case Point2D:
+		return FieldTypePoint2D
+	case Point3D:
+		return FieldTypePoint3D
+	case Date:
+		return FieldTypeDate
+	case Time:
+		return FieldTypeTime
+	case LocalTime:
+		return FieldTypeLocalTime
+	case time.Time:
+		return FieldTypeDateTime
+	case LocalDateTime:
+		return FieldTypeLocalDateTime
+	case Duration:
+		return FieldTypeDuration
+	default:
+		return FieldTypeUnknown
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestDescribeResultFields(outer *testing.T) {
+	ctx := context.Background()
+	keys := []string{"name", "age", "born", "friends", "nickname"}
+
+	outer.Run("derives types from next record without consuming it", func(t *testing.T) {
+		record := &db.Record{Keys: keys, Values: []any{"Arya", int64(11), time.Now(), []any{"Jon"}, nil}}
+		conn := &ConnFake{KeysRet: keys, Nexts: []Next{{Record: record}, {Summary: &db.Summary{}}}}
+		result := newResultWithContext(conn, idb.StreamHandle(0), "", nil, nil)
+
+		descriptors, err := DescribeResultFields(ctx, result)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, descriptors, []FieldDescriptor{
+			{Name: "name", Type: FieldTypeString},
+			{Name: "age", Type: FieldTypeInteger},
+			{Name: "born", Type: FieldTypeDateTime},
+			{Name: "friends", Type: FieldTypeList},
+			{Name: "nickname", Type: FieldTypeUnknown},
+		})
+		AssertTrue(t, result.Next(ctx))
+		AssertDeepEquals(t, result.Record(), record)
+	})
+
+	outer.Run("describes fields of empty results as unknown", func(t *testing.T) {
+		conn := &ConnFake{KeysRet: []string{"n"}, Nexts: []Next{{Summary: &db.Summary{}}}}
+		result := newResultWithContext(conn, idb.StreamHandle(0), "", nil, nil)
+
+		descriptors, err := DescribeResultFields(ctx, result)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, descriptors, []FieldDescriptor{{Name: "n", Type: FieldTypeUnknown}})
+	})
+
+	outer.Run("fails when the next record cannot be fetched", func(t *testing.T) {
+		fetchErr := errors.New("oopsie")
+		conn := &ConnFake{KeysRet: []string{"n"}, Nexts: []Next{{Err: fetchErr}}}
+		result := newResultWithContext(conn, idb.StreamHandle(0), "", nil, nil)
+
+		_, err := DescribeResultFields(ctx, result)
+
+		AssertDeepEquals(t, err, fetchErr)
+	})
+}
+
+func TestDescribeRecordFields(t *testing.T) {
+	record := &Record{
+		Keys:   []string{"n", "location", "since"},
+		Values: []any{Node{}, Point2D{}, Duration{}},
+	}
+
+	descriptors := DescribeRecordFields(record)
+
+	AssertDeepEquals(t, descriptors, []FieldDescriptor{
+		{Name: "n", Type: FieldTypeNode},
+		{Name: "location", Type: FieldTypePoint2D},
+		{Name: "since", Type: FieldTypeDuration},
+	})
+}
@@ -21,8 +21,11 @@ package neo4j
 
 import (
 	"context"
+	"fmt"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"runtime/debug"
+	"time"
 )
 
 type ResultWithContext interface {
@@ -45,6 +48,21 @@ type ResultWithContext interface {
 	Record() *Record
 	// Collect fetches all remaining records and returns them.
 	Collect(ctx context.Context) ([]*Record, error)
+	// NextPage fetches up to n of the remaining records and returns them, advancing the record stream past them.
+	// An empty page is returned once all records have been fetched.
+	// Pages are aligned with the batches of records pulled from the server when n is equal to the fetch size of the
+	// session (see SessionConfig.FetchSize).
+	// n must be greater than 0.
+	// When fetching fails, the records fetched before the failure are returned along with the error.
+	NextPage(ctx context.Context, n int) ([]*Record, error)
+	// CollectWithLimit fetches all remaining records and returns them, like Collect, unless there are more than
+	// maxRecords of them or their approximate size exceeds maxBytes.
+	// In that case, the records collected so far are returned together with a *CollectLimitError and the result is
+	// left open: the record exceeding the limit is the next one returned by Next.
+	// The size of a record is estimated from its values (e.g. the length of strings and byte arrays, 8 bytes per
+	// number), it is not the size of the record on the wire nor in memory.
+	// A limit less than or equal to 0 is not enforced.
+	CollectWithLimit(ctx context.Context, maxRecords int, maxBytes int64) ([]*Record, error)
 	// Single returns the only remaining record from the stream.
 	// If none or more than one record is left, an error is returned.
 	// The result is fully consumed after this call and its summary is immediately available when calling Consume.
@@ -60,6 +78,9 @@ type ResultWithContext interface {
 
 const consumedResultError = "result cursor is not available anymore"
 
+// maxPagePreallocation bounds the capacity NextPage allocates upfront
+const maxPagePreallocation = 1000
+
 type resultWithContext struct {
 	conn                 idb.Connection
 	streamHandle         idb.StreamHandle
@@ -72,15 +93,26 @@ type resultWithContext struct {
 	peekedSummary        *db.Summary
 	peeked               bool
 	afterConsumptionHook func()
+	outOfScope           bool
+	statistics           *queryStatisticsCollector
+	onDeprecationNotice  func(DeprecationNotice)
+	queryLog             *queryLog
+	summaryNotified      bool
+	durations            ClientDurations
+	acknowledgedAt       time.Time
+	firstRecordAt        time.Time
+	// receivedRecords is true once a record has been received, see resultSummary.GqlStatusObjects
+	receivedRecords bool
 }
 
-func newResultWithContext(connection idb.Connection, stream idb.StreamHandle, cypher string, params map[string]any, afterConsumptionHook func()) ResultWithContext {
+func newResultWithContext(connection idb.Connection, stream idb.StreamHandle, cypher string, params map[string]any, afterConsumptionHook func()) *resultWithContext {
 	return &resultWithContext{
 		conn:                 connection,
 		streamHandle:         stream,
 		cypher:               cypher,
 		params:               params,
 		afterConsumptionHook: afterConsumptionHook,
+		acknowledgedAt:       time.Now(),
 	}
 }
 
@@ -97,6 +129,9 @@ func (r *resultWithContext) NextRecord(ctx context.Context, out **Record) bool {
 }
 
 func (r *resultWithContext) Next(ctx context.Context) bool {
+	if r.accessedOutOfScope() {
+		return false
+	}
 	r.checkOpen()
 	if r.err != nil {
 		return false
@@ -117,6 +152,9 @@ func (r *resultWithContext) PeekRecord(ctx context.Context, out **Record) bool {
 }
 
 func (r *resultWithContext) Peek(ctx context.Context) bool {
+	if r.accessedOutOfScope() {
+		return false
+	}
 	r.checkOpen()
 	if r.err != nil {
 		return false
@@ -137,6 +175,9 @@ func (r *resultWithContext) Record() *Record {
 }
 
 func (r *resultWithContext) Collect(ctx context.Context) ([]*Record, error) {
+	if r.accessedOutOfScope() {
+		return nil, r.err
+	}
 	recs := make([]*Record, 0, 1024)
 	for r.summary == nil && r.err == nil {
 		r.advance(ctx)
@@ -151,7 +192,66 @@ func (r *resultWithContext) Collect(ctx context.Context) ([]*Record, error) {
 	return recs, nil
 }
 
+func (r *resultWithContext) CollectWithLimit(ctx context.Context, maxRecords int, maxBytes int64) ([]*Record, error) {
+	if r.accessedOutOfScope() {
+		return nil, r.err
+	}
+	var recs []*Record
+	var size int64
+	for r.summary == nil && r.err == nil {
+		// peek first so that the record exceeding the limit stays available to the caller
+		r.peek(ctx)
+		if r.peekedRecord == nil {
+			r.advance(ctx)
+			break
+		}
+		recordSize := approximateRecordSize(r.peekedRecord)
+		if (maxRecords > 0 && len(recs) >= maxRecords) || (maxBytes > 0 && size+recordSize > maxBytes) {
+			return recs, &CollectLimitError{MaxRecords: maxRecords, MaxBytes: maxBytes, Records: len(recs), Bytes: size}
+		}
+		r.advance(ctx)
+		recs = append(recs, r.record)
+		size += recordSize
+	}
+	if r.err != nil {
+		return nil, wrapError(r.err)
+	}
+	r.callAfterConsumptionHook()
+	return recs, nil
+}
+
+func (r *resultWithContext) NextPage(ctx context.Context, n int) ([]*Record, error) {
+	if r.accessedOutOfScope() {
+		return nil, r.err
+	}
+	if n <= 0 {
+		return nil, &UsageError{Message: fmt.Sprintf("Page size must be greater than 0, got %d", n)}
+	}
+	// large page sizes are bounds rather than expectations, do not allocate for records that may never come
+	capacity := n
+	if capacity > maxPagePreallocation {
+		capacity = maxPagePreallocation
+	}
+	page := make([]*Record, 0, capacity)
+	for len(page) < n && r.summary == nil && r.err == nil {
+		r.advance(ctx)
+		if r.record != nil {
+			page = append(page, r.record)
+		}
+	}
+	if r.err != nil {
+		return page, wrapError(r.err)
+	}
+	if r.summary != nil {
+		r.callAfterConsumptionHook()
+	}
+	return page, nil
+}
+
 func (r *resultWithContext) Single(ctx context.Context) (*Record, error) {
+	if r.accessedOutOfScope() {
+		return nil, r.err
+	}
 	// Try retrieving the single record
 	r.advance(ctx)
 	if r.err != nil {
@@ -171,6 +271,8 @@ func (r *resultWithContext) Single(ctx context.Context) (*Record, error) {
 		// There were more records, consume the stream since the user didn't
 		// expect more records and should therefore not use them.
 		r.summary, _ = r.conn.Consume(ctx, r.streamHandle)
+		r.trackDurations(nil, r.summary)
+		r.notifySummary(r.summary)
 		r.err = &UsageError{Message: "Result contains more than one record"}
 		r.record = nil
 		return nil, r.err
@@ -188,6 +290,9 @@ func (r *resultWithContext) Single(ctx context.Context) (*Record, error) {
 }
 
 func (r *resultWithContext) Consume(ctx context.Context) (ResultSummary, error) {
+	if r.accessedOutOfScope() {
+		return nil, r.err
+	}
 	// Already failed, reuse the internal error, might have been
 	// set by Single to indicate some kind of usage error that "destroyed"
 	// the result.
@@ -197,6 +302,10 @@ func (r *resultWithContext) Consume(ctx context.Context) (ResultSummary, error)
 
 	r.record = nil
 	r.summary, r.err = r.conn.Consume(ctx, r.streamHandle)
+	r.statistics.onFailure(r.err)
+	r.logFailure()
+	r.trackDurations(nil, r.summary)
+	r.notifySummary(r.summary)
 	if r.err != nil {
 		return nil, wrapError(r.err)
 	}
@@ -213,16 +322,22 @@ func (r *resultWithContext) legacy() Result {
 }
 
 func (r *resultWithContext) buffer(ctx context.Context) {
-	if r.err = r.conn.Buffer(ctx, r.streamHandle); r.err == nil {
+	r.err = r.conn.Buffer(ctx, r.streamHandle)
+	r.statistics.onFailure(r.err)
+	r.logFailure()
+	if r.err == nil {
+		r.logCompletion(nil)
 		r.callAfterConsumptionHook()
 	}
 }
 
 func (r *resultWithContext) toResultSummary() ResultSummary {
 	return &resultSummary{
-		sum:    r.summary,
-		cypher: r.cypher,
-		params: r.params,
+		sum:             r.summary,
+		cypher:          r.cypher,
+		params:          r.params,
+		durations:       r.durations,
+		receivedRecords: r.receivedRecords,
 	}
 }
 
@@ -233,12 +348,24 @@ func (r *resultWithContext) advance(ctx context.Context) {
 		r.peeked = false
 	} else {
 		r.record, r.summary, r.err = r.conn.Next(ctx, r.streamHandle)
+		r.receivedRecords = r.receivedRecords || r.record != nil
+		r.statistics.onRecord(r.record)
+		r.statistics.onFailure(r.err)
+		r.logFailure()
+		r.trackDurations(r.record, r.summary)
+		r.notifySummary(r.summary)
 	}
 }
 
 func (r *resultWithContext) peek(ctx context.Context) {
 	if !r.peeked {
 		r.peekedRecord, r.peekedSummary, r.err = r.conn.Next(ctx, r.streamHandle)
+		r.receivedRecords = r.receivedRecords || r.peekedRecord != nil
+		r.statistics.onRecord(r.peekedRecord)
+		r.statistics.onFailure(r.err)
+		r.logFailure()
+		r.trackDurations(r.peekedRecord, r.peekedSummary)
+		r.notifySummary(r.peekedSummary)
 		r.peeked = true
 	}
 }
@@ -250,10 +377,72 @@ func (r *resultWithContext) checkOpen() {
 	}
 }
 
+// accessedOutOfScope sets the result error and returns true if the result is used after its scope is over
+func (r *resultWithContext) accessedOutOfScope() bool {
+	if !r.outOfScope {
+		return false
+	}
+	r.err = &ResultOutOfScopeError{}
+	return true
+}
+
 func (r *resultWithContext) isOpen() bool {
 	return r.summary == nil
 }
 
+// inFlight reports whether records may still be pending on the connection for this result
+func (r *resultWithContext) inFlight() bool {
+	return r.summary == nil && r.err == nil && !r.outOfScope
+}
+
+// trackDurations measures the time until the first record and the time until the summary, as they are received
+func (r *resultWithContext) trackDurations(record *Record, summary *db.Summary) {
+	if (record == nil && summary == nil) || r.summaryNotified {
+		return
+	}
+	now := time.Now()
+	if r.firstRecordAt.IsZero() {
+		r.firstRecordAt = now
+		r.durations.FirstRecord = now.Sub(r.acknowledgedAt)
+	}
+	if summary != nil {
+		r.durations.Streaming = now.Sub(r.firstRecordAt)
+	}
+}
+
+// notifySummary reports the deprecation notifications of the summary and logs the query, the first time the summary
+// is retrieved
+func (r *resultWithContext) notifySummary(summary *db.Summary) {
+	if summary == nil || r.summaryNotified {
+		return
+	}
+	r.summaryNotified = true
+	notifyDeprecations(r.onDeprecationNotice, r.cypher, summary)
+	r.queryLog.done(r.durations, nil)
+}
+
+// logFailure logs the query as failed, if the result failed
+func (r *resultWithContext) logFailure() {
+	if r.err != nil {
+		r.queryLog.done(r.durations, wrapError(r.err))
+	}
+}
+
+// logCompletion logs the query once its records are buffered or discarded on the connection, the time spent
+// streaming them being measured until now since their summary has not been retrieved
+func (r *resultWithContext) logCompletion(err error) {
+	if r.queryLog == nil || r.summaryNotified {
+		return
+	}
+	durations := r.durations
+	streamingStart := r.firstRecordAt
+	if streamingStart.IsZero() {
+		streamingStart = r.acknowledgedAt
+	}
+	durations.Streaming = time.Since(streamingStart)
+	r.queryLog.done(durations, err)
+}
+
 func (r *resultWithContext) callAfterConsumptionHook() {
 	if r.afterConsumptionHook == nil {
 		return
@@ -261,3 +450,170 @@ func (r *resultWithContext) callAfterConsumptionHook() {
 	r.afterConsumptionHook()
 	r.afterConsumptionHook = nil
 }
+
+// resultScope keeps track of the results created within a transaction or a session, so that they can be marked as
+// out of scope once the latter is over.
+// Tracking only happens when Config.ResultScopeBehavior is ResultScopeStrict.
+type resultScope struct {
+	enabled bool
+	results []*resultWithContext
+}
+
+func newResultScope(behavior ResultScopeBehavior) resultScope {
+	return resultScope{enabled: behavior == ResultScopeStrict}
+}
+
+func (s *resultScope) track(result *resultWithContext) {
+	if s.enabled {
+		s.results = append(s.results, result)
+	}
+}
+
+func (s *resultScope) close() {
+	for _, result := range s.results {
+		result.outOfScope = true
+	}
+	s.results = nil
+}
+
+// inFlightResults keeps track of the results open within a session or a transaction, so that queries started beyond
+// Config.MaxInFlightResults fail instead of silently buffering the open results.
+// Tracking only happens when the limit is greater than 0.
+type inFlightResults struct {
+	limit   int
+	results []inFlightResult
+}
+
+type inFlightResult struct {
+	result *resultWithContext
+	stack  []byte
+}
+
+func newInFlightResults(limit int) inFlightResults {
+	return inFlightResults{limit: limit}
+}
+
+func (i *inFlightResults) track(result *resultWithContext) {
+	if i.limit > 0 {
+		i.results = append(i.results, inFlightResult{result: result, stack: debug.Stack()})
+	}
+}
+
+// check returns an InFlightResultsLimitError if the given number of results cannot be opened
+func (i *inFlightResults) check(results int) error {
+	if i.limit <= 0 {
+		return nil
+	}
+	open := i.results[:0]
+	for _, inFlight := range i.results {
+		if inFlight.result.inFlight() {
+			open = append(open, inFlight)
+		}
+	}
+	i.results = open
+	if len(open)+results <= i.limit {
+		return nil
+	}
+	if len(open) == 0 {
+		return &InFlightResultsLimitError{Limit: i.limit}
+	}
+	return &InFlightResultsLimitError{Limit: i.limit, OpenResultStack: string(open[0].stack)}
+}
+
+// unconsumedResults keeps track of the results of an explicit transaction, so that the ones not fully consumed are
+// handled according to Config.UnconsumedResultsOnCommit before committing.
+// Tracking only happens when the policy is not UnconsumedResultsDiscard, since the connection discards them anyway.
+type unconsumedResults struct {
+	policy  UnconsumedResultsPolicy
+	results []*resultWithContext
+}
+
+func newUnconsumedResults(policy UnconsumedResultsPolicy) unconsumedResults {
+	return unconsumedResults{policy: policy}
+}
+
+func (u *unconsumedResults) track(result *resultWithContext) {
+	if u.policy != UnconsumedResultsDiscard {
+		u.results = append(u.results, result)
+	}
+}
+
+// beforeCommit buffers the results that are not fully consumed, or returns an UnconsumedResultsError if there are
+// any, depending on the policy
+func (u *unconsumedResults) beforeCommit(ctx context.Context) error {
+	unconsumed := 0
+	for _, result := range u.results {
+		if !result.inFlight() {
+			continue
+		}
+		if u.policy == UnconsumedResultsBuffer {
+			result.buffer(ctx)
+		} else {
+			unconsumed++
+		}
+	}
+	if unconsumed > 0 {
+		return &UnconsumedResultsError{Count: unconsumed}
+	}
+	return nil
+}
+
+func approximateRecordSize(record *Record) int64 {
+	var size int64
+	for _, value := range record.Values {
+		size += approximateValueSize(value)
+	}
+	return size
+}
+
+func approximateValueSize(value any) int64 {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case string:
+		return int64(len(v))
+	case []byte:
+		return int64(len(v))
+	case []any:
+		var size int64
+		for _, item := range v {
+			size += approximateValueSize(item)
+		}
+		return size
+	case map[string]any:
+		return approximateMapSize(v)
+	case Node:
+		return 8 + int64(len(v.ElementId)) + approximateValueSize(v.Labels) + approximateMapSize(v.Props)
+	case Relationship:
+		return 24 + int64(len(v.ElementId)+len(v.StartElementId)+len(v.EndElementId)+len(v.Type)) +
+			approximateMapSize(v.Props)
+	case Path:
+		var size int64
+		for _, node := range v.Nodes {
+			size += approximateValueSize(node)
+		}
+		for _, relationship := range v.Relationships {
+			size += approximateValueSize(relationship)
+		}
+		return size
+	case []string:
+		var size int64
+		for _, item := range v {
+			size += int64(len(item))
+		}
+		return size
+	default:
+		// numbers, temporal and spatial values
+		return 8
+	}
+}
+
+func approximateMapSize(m map[string]any) int64 {
+	var size int64
+	for key, value := range m {
+		size += int64(len(key)) + approximateValueSize(value)
+	}
+	return size
+}
@@ -24,7 +24,9 @@ import (
 	"errors"
 	"fmt"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
@@ -388,6 +390,168 @@ func TestResult(outer *testing.T) {
 		AssertNotNil(t, res.Err())
 	})
 
+	// NextPage
+	outer.Run("NextPage returns pages of n records", func(t *testing.T) {
+		conn := &ConnFake{
+			Nexts: []Next{{Record: recs[0]}, {Record: recs[1]}, {Record: recs[2]}, {Summary: sums[0]}},
+		}
+		res := newResultWithContext(conn, streamHandle, cypher, params, nil)
+		page, err := res.NextPage(ctx, 2)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, page, []*Record{recs[0], recs[1]})
+		AssertTrue(t, res.IsOpen())
+		page, err = res.NextPage(ctx, 2)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, page, []*Record{recs[2]})
+		AssertFalse(t, res.IsOpen())
+		page, err = res.NextPage(ctx, 2)
+		AssertNoError(t, err)
+		AssertLen(t, page, 0)
+	})
+
+	outer.Run("NextPage after Peek", func(t *testing.T) {
+		conn := &ConnFake{
+			Nexts: []Next{{Record: recs[0]}, {Record: recs[1]}, {Summary: sums[0]}},
+		}
+		res := newResultWithContext(conn, streamHandle, cypher, params, nil)
+		AssertTrue(t, res.Peek(ctx))
+		page, err := res.NextPage(ctx, 1)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, page, []*Record{recs[0]})
+	})
+
+	outer.Run("NextPage stream error", func(t *testing.T) {
+		conn := &ConnFake{
+			Nexts: []Next{{Record: recs[0]}, {Err: errs[0]}},
+		}
+		res := newResultWithContext(conn, streamHandle, cypher, params, nil)
+		page, err := res.NextPage(ctx, 2)
+		AssertError(t, err)
+		AssertDeepEquals(t, page, []*Record{recs[0]})
+		AssertNotNil(t, res.Err())
+	})
+
+	outer.Run("NextPage with invalid size", func(t *testing.T) {
+		res := newResultWithContext(&ConnFake{}, streamHandle, cypher, params, nil)
+		_, err := res.NextPage(ctx, 0)
+		AssertSameType(t, err, &UsageError{})
+		_, err = res.NextPage(ctx, -1)
+		AssertSameType(t, err, &UsageError{})
+	})
+
+	outer.Run("NextPage with huge size", func(t *testing.T) {
+		conn := &ConnFake{
+			Nexts: []Next{{Record: recs[0]}, {Summary: sums[0]}},
+		}
+		res := newResultWithContext(conn, streamHandle, cypher, params, nil)
+		page, err := res.NextPage(ctx, math.MaxInt)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, page, []*Record{recs[0]})
+		AssertTrue(t, cap(page) <= maxPagePreallocation)
+	})
+
+	// CollectWithLimit
+	outer.Run("CollectWithLimit", func(inner *testing.T) {
+		inner.Run("collects all records within limits", func(t *testing.T) {
+			conn := &ConnFake{
+				Nexts: []Next{{Record: recs[0]}, {Record: recs[1]}, {Summary: sums[0]}},
+			}
+			res := newResultWithContext(conn, streamHandle, cypher, params, nil)
+			coll, err := res.CollectWithLimit(ctx, 2, 16)
+			AssertNoError(t, err)
+			AssertDeepEquals(t, coll, []*Record{recs[0], recs[1]})
+			AssertFalse(t, res.IsOpen())
+		})
+
+		inner.Run("stops at the record limit", func(t *testing.T) {
+			conn := &ConnFake{
+				Nexts: []Next{{Record: recs[0]}, {Record: recs[1]}, {Record: recs[2]}, {Summary: sums[0]}},
+			}
+			res := newResultWithContext(conn, streamHandle, cypher, params, nil)
+			coll, err := res.CollectWithLimit(ctx, 2, 0)
+			AssertDeepEquals(t, coll, []*Record{recs[0], recs[1]})
+			AssertTrue(t, IsCollectLimitError(err))
+			AssertDeepEquals(t, err, &CollectLimitError{MaxRecords: 2, Records: 2, Bytes: 16})
+			AssertTrue(t, res.IsOpen())
+			AssertTrue(t, res.Next(ctx))
+			AssertDeepEquals(t, res.Record(), recs[2])
+		})
+
+		inner.Run("stops at the byte limit", func(t *testing.T) {
+			large := &db.Record{Keys: []string{"n"}, Values: []any{map[string]any{"name": "a rather long string"}}}
+			conn := &ConnFake{
+				Nexts: []Next{{Record: recs[0]}, {Record: large}, {Summary: sums[0]}},
+			}
+			res := newResultWithContext(conn, streamHandle, cypher, params, nil)
+			coll, err := res.CollectWithLimit(ctx, 0, 16)
+			AssertDeepEquals(t, coll, []*Record{recs[0]})
+			AssertDeepEquals(t, err, &CollectLimitError{MaxBytes: 16, Records: 1, Bytes: 8})
+			AssertErrorMessageContains(t, err, "exceed 16 byte(s)")
+			coll, err = res.Collect(ctx)
+			AssertNoError(t, err)
+			AssertDeepEquals(t, coll, []*Record{large})
+		})
+
+		inner.Run("returns stream errors", func(t *testing.T) {
+			conn := &ConnFake{
+				Nexts: []Next{{Record: recs[0]}, {Err: errs[0]}},
+			}
+			res := newResultWithContext(conn, streamHandle, cypher, params, nil)
+			coll, err := res.CollectWithLimit(ctx, 10, 0)
+			AssertError(t, err)
+			AssertFalse(t, IsCollectLimitError(err))
+			AssertLen(t, coll, 0)
+		})
+	})
+
+	outer.Run("Client durations", func(inner *testing.T) {
+		inner.Run("measures time to first record and streaming time", func(t *testing.T) {
+			conn := &ConnFake{
+				Nexts:      []Next{{Record: recs[0]}, {Record: recs[1]}},
+				ConsumeSum: sums[0],
+			}
+			res := newResultWithContext(conn, streamHandle, cypher, params, nil)
+			time.Sleep(5 * time.Millisecond)
+			AssertTrue(t, res.Next(ctx))
+			time.Sleep(5 * time.Millisecond)
+
+			summary, err := res.Consume(ctx)
+
+			AssertNoError(t, err)
+			durations := summary.ClientDurations()
+			AssertTrue(t, durations.FirstRecord >= 5*time.Millisecond)
+			AssertTrue(t, durations.Streaming >= 5*time.Millisecond)
+		})
+
+		inner.Run("measures time to summary without records", func(t *testing.T) {
+			conn := &ConnFake{ConsumeSum: sums[0]}
+			res := newResultWithContext(conn, streamHandle, cypher, params, nil)
+			time.Sleep(5 * time.Millisecond)
+
+			summary, err := res.Consume(ctx)
+
+			AssertNoError(t, err)
+			durations := summary.ClientDurations()
+			AssertTrue(t, durations.FirstRecord >= 5*time.Millisecond)
+			AssertDeepEquals(t, durations.Streaming, time.Duration(0))
+		})
+
+		inner.Run("are not updated after the summary is received", func(t *testing.T) {
+			conn := &ConnFake{Nexts: []Next{{Summary: sums[0]}}}
+			res := newResultWithContext(conn, streamHandle, cypher, params, nil)
+			AssertFalse(t, res.Next(ctx))
+			summary, err := res.Consume(ctx)
+			AssertNoError(t, err)
+			durations := summary.ClientDurations()
+			time.Sleep(5 * time.Millisecond)
+
+			summary, err = res.Consume(ctx)
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, summary.ClientDurations(), durations)
+		})
+	})
+
 	outer.Run("IsOpen", func(t *testing.T) {
 		openResult := &resultWithContext{summary: nil}
 		closedResult := &resultWithContext{summary: &db.Summary{}}
@@ -545,4 +709,62 @@ func TestResult(outer *testing.T) {
 			})
 		}
 	})
+
+	outer.Run("Out of scope", func(inner *testing.T) {
+		testCases := []struct {
+			description string
+			callback    func(ResultWithContext) error
+		}{
+			{"Next", func(r ResultWithContext) error {
+				r.Next(ctx)
+				return r.Err()
+			}},
+			{"Peek", func(r ResultWithContext) error {
+				r.Peek(ctx)
+				return r.Err()
+			}},
+			{"Collect", func(r ResultWithContext) error {
+				_, err := r.Collect(ctx)
+				return err
+			}},
+			{"NextPage", func(r ResultWithContext) error {
+				_, err := r.NextPage(ctx, 1)
+				return err
+			}},
+			{"Single", func(r ResultWithContext) error {
+				_, err := r.Single(ctx)
+				return err
+			}},
+			{"Consume", func(r ResultWithContext) error {
+				_, err := r.Consume(ctx)
+				return err
+			}},
+		}
+
+		for _, testCase := range testCases {
+			inner.Run(fmt.Sprintf("%s fails in strict mode", testCase.description), func(t *testing.T) {
+				conn := &ConnFake{Nexts: []Next{{Record: record1}, {Summary: sums[0]}}}
+				scope := newResultScope(ResultScopeStrict)
+				result := newResultWithContext(conn, streamHandle, cypher, params, nil)
+				scope.track(result)
+				scope.close()
+
+				err := testCase.callback(result)
+
+				AssertTrue(t, IsResultOutOfScopeError(err))
+			})
+
+			inner.Run(fmt.Sprintf("%s is not affected in lenient mode", testCase.description), func(t *testing.T) {
+				conn := &ConnFake{Nexts: []Next{{Record: record1}, {Summary: sums[0]}}}
+				scope := newResultScope(ResultScopeLenient)
+				result := newResultWithContext(conn, streamHandle, cypher, params, nil)
+				scope.track(result)
+				scope.close()
+
+				err := testCase.callback(result)
+
+				AssertFalse(t, IsResultOutOfScopeError(err))
+			})
+		}
+	})
 }
@@ -56,6 +56,25 @@ func (st StatementType) String() string {
 	}
 }
 
+// ExpectStatementType returns a StatementTypeError if the statement type of the provided summary is not one of the
+// expected statement types.
+// This is useful to make sure a query only reads data, or only alters the schema, before committing its transaction:
+//
+//	summary, err := result.Consume(ctx)
+//	// [...] handle err
+//	if err := neo4j.ExpectStatementType(summary, neo4j.StatementTypeReadOnly); err != nil {
+//		return tx.Rollback(ctx)
+//	}
+func ExpectStatementType(summary ResultSummary, expected ...StatementType) error {
+	actual := summary.StatementType()
+	for _, statementType := range expected {
+		if statementType == actual {
+			return nil
+		}
+	}
+	return &StatementTypeError{Expected: expected, Actual: actual}
+}
+
 type ResultSummary interface {
 	// Server returns basic information about the server where the statement is carried out.
 	Server() ServerInfo
@@ -72,6 +91,15 @@ type ResultSummary interface {
 	// Notifications returns a slice of notifications produced while executing the statement.
 	// The list will be empty if no notifications produced while executing the statement.
 	Notifications() []Notification
+	// GqlStatusObjects returns a slice of the GQL statuses produced while executing the statement, notifications
+	// included.
+	// Servers only send GQL statuses from Bolt 5.5 on, while this driver negotiates Bolt 5.2 at most: the statuses are
+	// therefore derived client-side. The status about the outcome of the statement comes first, 00000 (successful
+	// completion) when records were received by the driver and 02000 (no data) otherwise, followed by a status for
+	// each notification.
+	// Records discarded without being received, e.g. with ResultWithContext.Consume, are not taken into account by the
+	// derived outcome status.
+	GqlStatusObjects() []GqlStatusObject
 	// ResultAvailableAfter returns the time it took for the server to make the result available for consumption.
 	// Since 5.0, this returns a negative duration if the server has not sent the corresponding statistic.
 	ResultAvailableAfter() time.Duration
@@ -82,6 +110,28 @@ type ResultSummary interface {
 	// Returns nil for Neo4j versions prior to v4.
 	// Returns the default "neo4j" database for Community Edition servers.
 	Database() DatabaseInfo
+	// ClientDurations returns the durations measured by the driver while running the query.
+	// Together with ResultAvailableAfter and ResultConsumedAfter, they decompose the end-to-end latency of the query.
+	ClientDurations() ClientDurations
+}
+
+// ClientDurations holds the durations measured by the driver while running a query.
+// Durations that could not be measured are zero.
+type ClientDurations struct {
+	// ConnectionAcquisition is the time spent acquiring the connection the query ran on.
+	// Queries run within a transaction report the acquisition of the connection of their transaction.
+	ConnectionAcquisition time.Duration
+	// Request is the time spent sending the query until the server acknowledged it.
+	// It is not measured for the queries of SessionWithContext.RunBatch, which are all sent at once.
+	Request time.Duration
+	// FirstRecord is the time between the acknowledgement of the query and the reception of its first record (or of
+	// its summary, if the query returns no record).
+	// Records are fetched as the result is consumed: this includes the time the application takes to start consuming
+	// the result.
+	FirstRecord time.Duration
+	// Streaming is the time between the reception of the first record and the reception of the summary.
+	// Like FirstRecord, this depends on the pace at which the application consumes the result.
+	Streaming time.Duration
 }
 
 // Counters contains statistics about the changes made to the database made as part
@@ -124,21 +174,53 @@ type Query interface {
 	// Text returns the statement's text.
 	Text() string
 	// Parameters returns the statement's parameters.
+	// The parameters are the ones originally provided to the driver, they are neither copied nor re-decoded: values
+	// keep their original Go type (see also GetQueryParameter).
 	Parameters() map[string]any
 }
 
+// GetQueryParameter returns the value of the given query parameter, as originally provided to the driver.
+// The type parameter T must match the original type of the value. Unlike record values, T is not restricted to the
+// types supported by the Bolt protocol, since parameter values are not re-decoded.
+// If the parameter does not exist, an error is returned.
+// If the parameter type does not match T, an error is returned.
+//
+// This allows replay tooling and audit logs to access parameters with their exact types, for instance:
+//
+//	summary, err := result.Consume(ctx)
+//	// [...] handle error
+//	userId, err := neo4j.GetQueryParameter[int64](summary.Query(), "userId")
+func GetQueryParameter[T any](query Query, key string) (T, error) {
+	rawValue, found := query.Parameters()[key]
+	if !found {
+		return *new(T), fmt.Errorf("query parameter %s not found", key)
+	}
+	value, ok := rawValue.(T)
+	if !ok {
+		zeroValue := *new(T)
+		return zeroValue, fmt.Errorf("expected value to have type %T but found type %T", zeroValue, rawValue)
+	}
+	return value, nil
+}
+
 // ServerInfo contains basic information of the server.
 type ServerInfo interface {
 	// Address returns the address of the server.
 	Address() string
 	Agent() string
 	ProtocolVersion() db.ProtocolVersion
+	// ClockSkew returns the estimated offset of the server clock relative to the local clock: it is positive when the
+	// server clock is ahead.
+	// It is only estimated by DriverWithContext.GetServerInfo with Config.EstimateServerClockSkew enabled, and is 0
+	// otherwise.
+	ClockSkew() time.Duration
 }
 
 type simpleServerInfo struct {
 	address         string
 	agent           string
 	protocolVersion db.ProtocolVersion
+	clockSkew       time.Duration
 }
 
 func (s simpleServerInfo) Address() string {
@@ -153,6 +235,10 @@ func (s simpleServerInfo) ProtocolVersion() db.ProtocolVersion {
 	return s.protocolVersion
 }
 
+func (s simpleServerInfo) ClockSkew() time.Duration {
+	return s.clockSkew
+}
+
 // DatabaseInfo contains basic information of the database the query result has been obtained from.
 type DatabaseInfo interface {
 	Name() string
@@ -215,9 +301,41 @@ type Notification interface {
 	Description() string
 	// Position returns the position in the statement where this notification points to.
 	// Not all notifications have a unique position to point to and in that case the position would be set to nil.
+	//
+	// Deprecated: use TypedPosition instead, which returns a plain struct.
 	Position() InputPosition
+	// TypedPosition returns the position in the statement where this notification points to.
+	// Not all notifications have a unique position to point to and in that case the position is nil, see
+	// NotificationPosition.Get.
+	TypedPosition() *NotificationPosition
 	// Severity returns the severity level of this notification.
 	Severity() string
+	// RawSeverityLevel returns the severity level of this notification, as sent by the server (like WARNING).
+	RawSeverityLevel() string
+	// RawCategory returns the category of this notification, as sent by the server (like DEPRECATION).
+	// The category is only sent by Neo4j 5.7+, an empty string is returned otherwise.
+	RawCategory() string
+}
+
+// GqlStatusObject represents a GQL-compliant status generated when executing a statement.
+// Unlike notifications, statuses also report the outcome of the statement.
+type GqlStatusObject interface {
+	// GqlStatus returns the GQLSTATUS code of this status, like 01N42.
+	GqlStatus() string
+	// StatusDescription returns the description of this status.
+	StatusDescription() string
+	// Position returns the position in the statement where this status points to, or nil if there is none.
+	Position() *NotificationPosition
+	// RawSeverity returns the severity level of this status, as sent by the server (like WARNING).
+	// An empty string is returned if the status has no severity, e.g. when it is not a notification.
+	RawSeverity() string
+	// RawClassification returns the classification of this status, as sent by the server (like DEPRECATION).
+	// An empty string is returned if the status has no classification.
+	RawClassification() string
+	// DiagnosticRecord returns the diagnostic record of this status, as sent by the server.
+	DiagnosticRecord() map[string]any
+	// IsNotification returns true if this status is also returned by ResultSummary.Notifications.
+	IsNotification() bool
 }
 
 // InputPosition contains information about a specific position in a statement
@@ -230,10 +348,44 @@ type InputPosition interface {
 	Column() int
 }
 
+// NotificationPosition contains information about a specific position in a statement
+type NotificationPosition struct {
+	// Offset is the character offset referred to by this position; offset numbers start at 0.
+	Offset int
+	// Line is the line number referred to by this position; line numbers start at 1.
+	Line int
+	// Column is the column number referred to by this position; column numbers start at 1.
+	Column int
+}
+
+// Get returns a copy of the position and true, or the zero value and false when the position is nil, i.e. when the
+// notification does not point to a position:
+//
+//	if position, ok := notification.TypedPosition().Get(); ok {
+//		fmt.Printf("line %d, column %d\n", position.Line, position.Column)
+//	}
+func (p *NotificationPosition) Get() (NotificationPosition, bool) {
+	if p == nil {
+		return NotificationPosition{}, false
+	}
+	return *p, true
+}
+
+// String returns a human-readable representation of the position, nil positions included
+func (p *NotificationPosition) String() string {
+	if p == nil {
+		return "no position"
+	}
+	return fmt.Sprintf("line %d, column %d (offset %d)", p.Line, p.Column, p.Offset)
+}
+
 type resultSummary struct {
-	sum    *db.Summary
-	cypher string
-	params map[string]any
+	sum       *db.Summary
+	cypher    string
+	params    map[string]any
+	durations ClientDurations
+	// receivedRecords tells whether records were received, to derive the outcome status of older servers
+	receivedRecords bool
 }
 
 func (s *resultSummary) Agent() string {
@@ -247,6 +399,10 @@ func (s *resultSummary) ProtocolVersion() db.ProtocolVersion {
 	}
 }
 
+func (s *resultSummary) ClockSkew() time.Duration {
+	return 0
+}
+
 func (s *resultSummary) Server() ServerInfo {
 	return s
 }
@@ -357,6 +513,10 @@ func (s *resultSummary) ResultConsumedAfter() time.Duration {
 	return time.Duration(s.sum.TLast) * time.Millisecond
 }
 
+func (s *resultSummary) ClientDurations() ClientDurations {
+	return s.durations
+}
+
 func (s *resultSummary) Plan() Plan {
 	if s.sum.Plan == nil {
 		return nil
@@ -399,7 +559,8 @@ func (p *plan) Identifiers() []string {
 func (p *plan) Children() []Plan {
 	children := make([]Plan, len(p.plan.Children))
 	for i, c := range p.plan.Children {
-		children[i] = &plan{plan: &c}
+		child := c
+		children[i] = &plan{plan: &child}
 	}
 	return children
 }
@@ -495,6 +656,22 @@ func (n *notification) Severity() string {
 	return n.notification.Severity
 }
 
+func (n *notification) RawSeverityLevel() string {
+	return n.notification.Severity
+}
+
+func (n *notification) RawCategory() string {
+	return n.notification.Category
+}
+
+func (n *notification) TypedPosition() *NotificationPosition {
+	position := n.notification.Position
+	if position == nil {
+		return nil
+	}
+	return &NotificationPosition{Offset: position.Offset, Line: position.Line, Column: position.Column}
+}
+
 func (n *notification) Position() InputPosition {
 	if n.notification.Position == nil {
 		return nil
@@ -511,3 +688,110 @@ func (n *notification) Column() int {
 func (n *notification) Line() int {
 	return n.notification.Position.Line
 }
+
+func (s *resultSummary) GqlStatusObjects() []GqlStatusObject {
+	if s.sum.GqlStatusObjects == nil {
+		return append([]GqlStatusObject{outcomeGqlStatusObject(s.receivedRecords)},
+			gqlStatusObjectsFromNotifications(s.sum.Notifications)...)
+	}
+	statuses := make([]GqlStatusObject, len(s.sum.GqlStatusObjects))
+	for i := range s.sum.GqlStatusObjects {
+		statuses[i] = &gqlStatusObject{status: &s.sum.GqlStatusObjects[i]}
+	}
+	return statuses
+}
+
+// outcomeGqlStatusObject derives the status about the outcome of the statement for servers not sending it
+func outcomeGqlStatusObject(receivedRecords bool) GqlStatusObject {
+	gqlStatus, description := "02000", "note: no data"
+	if receivedRecords {
+		gqlStatus, description = "00000", "note: successful completion"
+	}
+	return &gqlStatusObject{status: &db.GqlStatusObject{
+		GqlStatus:         gqlStatus,
+		StatusDescription: description,
+		DiagnosticRecord: map[string]any{
+			"OPERATION":      "",
+			"OPERATION_CODE": "0",
+			"CURRENT_SCHEMA": "/",
+		},
+	}}
+}
+
+// gqlStatusObjectsFromNotifications derives GQL statuses from the notifications of servers not sending them, the same
+// way newer servers do
+func gqlStatusObjectsFromNotifications(notifications []db.Notification) []GqlStatusObject {
+	if notifications == nil {
+		return nil
+	}
+	statuses := make([]GqlStatusObject, len(notifications))
+	for i, notification := range notifications {
+		gqlStatus := "03N42"
+		if notification.Severity == "WARNING" {
+			gqlStatus = "01N42"
+		}
+		diagnosticRecord := map[string]any{
+			"OPERATION":       "",
+			"OPERATION_CODE":  "0",
+			"CURRENT_SCHEMA":  "/",
+			"_severity":       notification.Severity,
+			"_classification": notification.Category,
+		}
+		if position := notification.Position; position != nil {
+			diagnosticRecord["_position"] = map[string]any{
+				"offset": int64(position.Offset),
+				"line":   int64(position.Line),
+				"column": int64(position.Column),
+			}
+		}
+		statuses[i] = &gqlStatusObject{status: &db.GqlStatusObject{
+			GqlStatus:         gqlStatus,
+			StatusDescription: notification.Description,
+			Neo4jCode:         notification.Code,
+			Title:             notification.Title,
+			DiagnosticRecord:  diagnosticRecord,
+		}}
+	}
+	return statuses
+}
+
+type gqlStatusObject struct {
+	status *db.GqlStatusObject
+}
+
+func (s *gqlStatusObject) GqlStatus() string {
+	return s.status.GqlStatus
+}
+
+func (s *gqlStatusObject) StatusDescription() string {
+	return s.status.StatusDescription
+}
+
+func (s *gqlStatusObject) Position() *NotificationPosition {
+	positionx, ok := s.status.DiagnosticRecord["_position"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	offset, _ := positionx["offset"].(int64)
+	line, _ := positionx["line"].(int64)
+	column, _ := positionx["column"].(int64)
+	return &NotificationPosition{Offset: int(offset), Line: int(line), Column: int(column)}
+}
+
+func (s *gqlStatusObject) RawSeverity() string {
+	severity, _ := s.status.DiagnosticRecord["_severity"].(string)
+	return severity
+}
+
+func (s *gqlStatusObject) RawClassification() string {
+	classification, _ := s.status.DiagnosticRecord["_classification"].(string)
+	return classification
+}
+
+func (s *gqlStatusObject) DiagnosticRecord() map[string]any {
+	return s.status.DiagnosticRecord
+}
+
+func (s *gqlStatusObject) IsNotification() bool {
+	return s.status.Neo4jCode != ""
+}
@@ -21,8 +21,10 @@ package neo4j
 
 import (
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestProfiledPlan(st *testing.T) {
@@ -81,6 +83,107 @@ func TestNotifications(st *testing.T) {
 			t.Errorf("Expected %v to equal %v", received, expected)
 		}
 	})
+
+	st.Run("Typed positions are returned correctly", func(t *testing.T) {
+		notifications := summary.Notifications()
+
+		position, ok := notifications[0].TypedPosition().Get()
+		if !ok || position != (NotificationPosition{Offset: 1, Line: 2, Column: 3}) {
+			t.Errorf("Expected position of first notification, got %v", position)
+		}
+		if position, ok := notifications[1].TypedPosition().Get(); ok {
+			t.Errorf("Expected no position for second notification, got %v", position)
+		}
+		if notifications[1].TypedPosition().String() != "no position" {
+			t.Errorf("Expected nil position to be printable")
+		}
+	})
+
+	st.Run("Raw severity and category are returned correctly", func(t *testing.T) {
+		categorized := resultSummary{sum: &db.Summary{Notifications: []db.Notification{
+			{Code: "code3", Severity: "WARNING", Category: "DEPRECATION"},
+		}}}
+
+		notification := categorized.Notifications()[0]
+
+		if notification.RawSeverityLevel() != "WARNING" || notification.RawCategory() != "DEPRECATION" {
+			t.Errorf("Expected raw severity and category, got %q and %q",
+				notification.RawSeverityLevel(), notification.RawCategory())
+		}
+	})
+}
+
+func TestGqlStatusObjects(st *testing.T) {
+
+	st.Run("Statuses sent by the server are returned", func(t *testing.T) {
+		summary := resultSummary{sum: &db.Summary{GqlStatusObjects: []db.GqlStatusObject{
+			{GqlStatus: "00000", StatusDescription: "note: successful completion"},
+			{GqlStatus: "01N42", StatusDescription: "warn: feature deprecated", Neo4jCode: "code1",
+				DiagnosticRecord: map[string]any{
+					"_severity":       "WARNING",
+					"_classification": "DEPRECATION",
+					"_position":       map[string]any{"offset": int64(1), "line": int64(2), "column": int64(3)},
+				}},
+		}}}
+
+		statuses := summary.GqlStatusObjects()
+
+		AssertLen(t, statuses, 2)
+		AssertStringEqual(t, statuses[0].GqlStatus(), "00000")
+		AssertStringEqual(t, statuses[0].StatusDescription(), "note: successful completion")
+		AssertFalse(t, statuses[0].IsNotification())
+		AssertNil(t, statuses[0].Position())
+		AssertStringEqual(t, statuses[0].RawSeverity(), "")
+		AssertStringEqual(t, statuses[1].GqlStatus(), "01N42")
+		AssertTrue(t, statuses[1].IsNotification())
+		AssertStringEqual(t, statuses[1].RawSeverity(), "WARNING")
+		AssertStringEqual(t, statuses[1].RawClassification(), "DEPRECATION")
+		AssertDeepEquals(t, *statuses[1].Position(), NotificationPosition{Offset: 1, Line: 2, Column: 3})
+	})
+
+	st.Run("Statuses are derived from the notifications of older servers", func(t *testing.T) {
+		summary := resultSummary{sum: &db.Summary{Notifications: []db.Notification{
+			{Code: "code1", Description: "desc1", Severity: "WARNING", Category: "DEPRECATION",
+				Position: &db.InputPosition{Offset: 1, Line: 2, Column: 3}},
+			{Code: "code2", Description: "desc2", Severity: "INFORMATION"},
+		}}}
+
+		statuses := summary.GqlStatusObjects()
+
+		AssertLen(t, statuses, 3)
+		AssertStringEqual(t, statuses[1].GqlStatus(), "01N42")
+		AssertStringEqual(t, statuses[1].StatusDescription(), "desc1")
+		AssertTrue(t, statuses[1].IsNotification())
+		AssertStringEqual(t, statuses[1].RawSeverity(), "WARNING")
+		AssertStringEqual(t, statuses[1].RawClassification(), "DEPRECATION")
+		AssertDeepEquals(t, *statuses[1].Position(), NotificationPosition{Offset: 1, Line: 2, Column: 3})
+		AssertStringEqual(t, statuses[2].GqlStatus(), "03N42")
+		AssertNil(t, statuses[2].Position())
+	})
+
+	st.Run("Outcome status is derived for older servers", func(outer *testing.T) {
+		outer.Run("as successful completion when records were received", func(t *testing.T) {
+			summary := resultSummary{sum: &db.Summary{}, receivedRecords: true}
+
+			statuses := summary.GqlStatusObjects()
+
+			AssertLen(t, statuses, 1)
+			AssertStringEqual(t, statuses[0].GqlStatus(), "00000")
+			AssertStringEqual(t, statuses[0].StatusDescription(), "note: successful completion")
+			AssertFalse(t, statuses[0].IsNotification())
+		})
+
+		outer.Run("as no data otherwise", func(t *testing.T) {
+			summary := resultSummary{sum: &db.Summary{}}
+
+			statuses := summary.GqlStatusObjects()
+
+			AssertLen(t, statuses, 1)
+			AssertStringEqual(t, statuses[0].GqlStatus(), "02000")
+			AssertStringEqual(t, statuses[0].StatusDescription(), "note: no data")
+			AssertFalse(t, statuses[0].IsNotification())
+		})
+	})
 }
 
 func TestCounters(st *testing.T) {
@@ -108,3 +211,67 @@ func TestCounters(st *testing.T) {
 		}
 	})
 }
+
+func TestExpectStatementType(st *testing.T) {
+	summary := &resultSummary{sum: &db.Summary{StmntType: db.StatementTypeSchemaWrite}}
+
+	st.Run("Accepts any of the expected statement types", func(t *testing.T) {
+		err := ExpectStatementType(summary, StatementTypeWriteOnly, StatementTypeSchemaWrite)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	st.Run("Rejects unexpected statement type", func(t *testing.T) {
+		err := ExpectStatementType(summary, StatementTypeReadOnly, StatementTypeReadWrite)
+
+		expected := &StatementTypeError{
+			Expected: []StatementType{StatementTypeReadOnly, StatementTypeReadWrite},
+			Actual:   StatementTypeSchemaWrite,
+		}
+		if !reflect.DeepEqual(err, expected) {
+			t.Errorf("Expected %v, got %v", expected, err)
+		}
+		if !IsStatementTypeError(err) {
+			t.Errorf("Expected StatementTypeError, got %T", err)
+		}
+		if err.Error() != `StatementTypeError: expected statement type to be one of [r, rw] but was "s"` {
+			t.Errorf("Unexpected error message %q", err.Error())
+		}
+	})
+}
+
+func TestGetQueryParameter(st *testing.T) {
+	type userId struct{ value int }
+	params := map[string]any{"id": userId{value: 42}, "since": time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), "limit": 10}
+	summary := &resultSummary{sum: &db.Summary{}, cypher: "MATCH (u:User {id: $id}) RETURN u", params: params}
+
+	st.Run("Returns parameters with their original type", func(t *testing.T) {
+		id, err := GetQueryParameter[userId](summary.Query(), "id")
+		if err != nil || id != (userId{value: 42}) {
+			t.Errorf("Expected original id, got %v (error: %v)", id, err)
+		}
+		limit, err := GetQueryParameter[int](summary.Query(), "limit")
+		if err != nil || limit != 10 {
+			t.Errorf("Expected original int limit, got %v (error: %v)", limit, err)
+		}
+		since, err := GetQueryParameter[time.Time](summary.Query(), "since")
+		if err != nil || !since.Equal(params["since"].(time.Time)) {
+			t.Errorf("Expected original time, got %v (error: %v)", since, err)
+		}
+	})
+
+	st.Run("Fails with unknown parameter", func(t *testing.T) {
+		_, err := GetQueryParameter[int](summary.Query(), "offset")
+		if err == nil || err.Error() != "query parameter offset not found" {
+			t.Errorf("Unexpected error %v", err)
+		}
+	})
+
+	st.Run("Fails with mismatched type", func(t *testing.T) {
+		_, err := GetQueryParameter[int64](summary.Query(), "limit")
+		if err == nil || err.Error() != "expected value to have type int64 but found type int" {
+			t.Errorf("Unexpected error %v", err)
+		}
+	})
+}
@@ -0,0 +1,267 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package schema lists the indexes and constraints of a database as typed values, whatever the server version.
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// EntityType is the kind of graph entity an index or a constraint applies to
+type EntityType string
+
+const (
+	Node         EntityType = "NODE"
+	Relationship EntityType = "RELATIONSHIP"
+)
+
+// IndexState is the state of an index
+type IndexState string
+
+const (
+	// IndexOnline is the state of an index that can be used
+	IndexOnline IndexState = "ONLINE"
+	// IndexPopulating is the state of an index being built, see Index.PopulationPercent for its progress
+	IndexPopulating IndexState = "POPULATING"
+	// IndexFailed is the state of an index that could not be built
+	IndexFailed IndexState = "FAILED"
+)
+
+// IndexType is the kind of index, for instance RANGE, TEXT, POINT, FULLTEXT, LOOKUP, VECTOR, or BTREE on servers
+// older than 5.0
+type IndexType string
+
+// Index describes an index, as returned by SHOW INDEXES
+type Index struct {
+	ID                int64
+	Name              string
+	State             IndexState
+	PopulationPercent float64
+	Type              IndexType
+	EntityType        EntityType
+	// LabelsOrTypes are the node labels or relationship types the index applies to, empty for LOOKUP indexes
+	LabelsOrTypes []string
+	// Properties are the indexed properties, empty for LOOKUP indexes
+	Properties []string
+	// OwningConstraint is the name of the constraint backed by the index, empty if there is none or if the server is
+	// older than 5.0
+	OwningConstraint string
+}
+
+// ConstraintType is the kind of constraint
+type ConstraintType string
+
+const (
+	NodeUniqueness           ConstraintType = "NODE_PROPERTY_UNIQUENESS"
+	RelationshipUniqueness   ConstraintType = "RELATIONSHIP_PROPERTY_UNIQUENESS"
+	NodeExistence            ConstraintType = "NODE_PROPERTY_EXISTENCE"
+	RelationshipExistence    ConstraintType = "RELATIONSHIP_PROPERTY_EXISTENCE"
+	NodeKey                  ConstraintType = "NODE_KEY"
+	RelationshipKey          ConstraintType = "RELATIONSHIP_KEY"
+	NodePropertyType         ConstraintType = "NODE_PROPERTY_TYPE"
+	RelationshipPropertyType ConstraintType = "RELATIONSHIP_PROPERTY_TYPE"
+)
+
+// legacyConstraintTypes maps the constraint types reported by older servers to their current name
+var legacyConstraintTypes = map[string]ConstraintType{
+	"UNIQUENESS":              NodeUniqueness,
+	"RELATIONSHIP_UNIQUENESS": RelationshipUniqueness,
+}
+
+// Constraint describes a constraint, as returned by SHOW CONSTRAINTS
+type Constraint struct {
+	ID            int64
+	Name          string
+	Type          ConstraintType
+	EntityType    EntityType
+	LabelsOrTypes []string
+	Properties    []string
+	// OwnedIndex is the name of the index backing the constraint, empty if there is none or if the server is older
+	// than 5.0
+	OwnedIndex string
+}
+
+// Indexes returns the indexes of the database targeted by the given settings, see neo4j.ExecuteQuery.
+// The query is routed to readers unless the settings specify otherwise.
+func Indexes(ctx context.Context, driver neo4j.DriverWithContext, settings ...neo4j.ExecuteQueryConfigurationOption) ([]Index, error) {
+	records, err := show(ctx, driver, "SHOW INDEXES YIELD *", settings)
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([]Index, len(records))
+	for i, record := range records {
+		if indexes[i], err = toIndex(record); err != nil {
+			return nil, err
+		}
+	}
+	return indexes, nil
+}
+
+// Constraints returns the constraints of the database targeted by the given settings, see neo4j.ExecuteQuery.
+// The query is routed to readers unless the settings specify otherwise.
+// Constraint types reported by older servers are converted to their current name, e.g. UNIQUENESS to
+// NODE_PROPERTY_UNIQUENESS.
+func Constraints(ctx context.Context, driver neo4j.DriverWithContext, settings ...neo4j.ExecuteQueryConfigurationOption) ([]Constraint, error) {
+	records, err := show(ctx, driver, "SHOW CONSTRAINTS YIELD *", settings)
+	if err != nil {
+		return nil, err
+	}
+	constraints := make([]Constraint, len(records))
+	for i, record := range records {
+		if constraints[i], err = toConstraint(record); err != nil {
+			return nil, err
+		}
+	}
+	return constraints, nil
+}
+
+func show(ctx context.Context, driver neo4j.DriverWithContext, query string, settings []neo4j.ExecuteQueryConfigurationOption) ([]*neo4j.Record, error) {
+	settings = append([]neo4j.ExecuteQueryConfigurationOption{neo4j.ExecuteQueryWithReadersRouting()}, settings...)
+	result, err := neo4j.ExecuteQuery(ctx, driver, query, nil, neo4j.EagerResultTransformer, settings...)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range result.Records {
+		if err := neo4j.DecodeRawRecord(record); err != nil {
+			return nil, err
+		}
+	}
+	return result.Records, nil
+}
+
+func toIndex(record *neo4j.Record) (Index, error) {
+	var index Index
+	var err error
+	fields := &fieldReader{record: record}
+	index.ID = fields.int("id")
+	index.Name = fields.string("name")
+	index.State = IndexState(fields.string("state"))
+	index.PopulationPercent = fields.float("populationPercent")
+	index.Type = IndexType(fields.string("type"))
+	index.EntityType = EntityType(fields.string("entityType"))
+	index.LabelsOrTypes = fields.strings("labelsOrTypes")
+	index.Properties = fields.strings("properties")
+	index.OwningConstraint = fields.string("owningConstraint")
+	if fields.err != nil {
+		err = fmt.Errorf("could not read index %q: %w", index.Name, fields.err)
+	}
+	return index, err
+}
+
+func toConstraint(record *neo4j.Record) (Constraint, error) {
+	var constraint Constraint
+	var err error
+	fields := &fieldReader{record: record}
+	constraint.ID = fields.int("id")
+	constraint.Name = fields.string("name")
+	constraint.Type = ConstraintType(fields.string("type"))
+	if current, found := legacyConstraintTypes[string(constraint.Type)]; found {
+		constraint.Type = current
+	}
+	constraint.EntityType = EntityType(fields.string("entityType"))
+	constraint.LabelsOrTypes = fields.strings("labelsOrTypes")
+	constraint.Properties = fields.strings("properties")
+	constraint.OwnedIndex = fields.string("ownedIndex")
+	if fields.err != nil {
+		err = fmt.Errorf("could not read constraint %q: %w", constraint.Name, fields.err)
+	}
+	return constraint, err
+}
+
+// fieldReader reads the columns of a record, leaving the zero value for the ones that are missing or null since the
+// columns vary between server versions.
+// The first type mismatch is kept in err.
+type fieldReader struct {
+	record *neo4j.Record
+	err    error
+}
+
+func (f *fieldReader) value(key string) any {
+	value, _ := f.record.Get(key)
+	return value
+}
+
+func (f *fieldReader) fail(key string, expected string, value any) {
+	if f.err == nil {
+		f.err = fmt.Errorf("expected %s to be %s, got %T", key, expected, value)
+	}
+}
+
+func (f *fieldReader) string(key string) string {
+	switch value := f.value(key).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		f.fail(key, "a string", value)
+		return ""
+	}
+}
+
+func (f *fieldReader) int(key string) int64 {
+	switch value := f.value(key).(type) {
+	case nil:
+		return 0
+	case int64:
+		return value
+	default:
+		f.fail(key, "an integer", value)
+		return 0
+	}
+}
+
+func (f *fieldReader) float(key string) float64 {
+	switch value := f.value(key).(type) {
+	case nil:
+		return 0
+	case float64:
+		return value
+	case int64:
+		return float64(value)
+	default:
+		f.fail(key, "a float", value)
+		return 0
+	}
+}
+
+func (f *fieldReader) strings(key string) []string {
+	switch value := f.value(key).(type) {
+	case nil:
+		return nil
+	case []any:
+		result := make([]string, len(value))
+		for i, element := range value {
+			str, ok := element.(string)
+			if !ok {
+				f.fail(key, "a list of strings", element)
+				return nil
+			}
+			result[i] = str
+		}
+		return result
+	default:
+		f.fail(key, "a list of strings", value)
+		return nil
+	}
+}
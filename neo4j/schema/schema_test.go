@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestToIndex(outer *testing.T) {
+	outer.Parallel()
+
+	outer.Run("reads 5.x indexes", func(t *testing.T) {
+		record := recordOf(map[string]any{
+			"id": int64(3), "name": "person_name", "state": "ONLINE", "populationPercent": 100.0,
+			"type": "RANGE", "entityType": "NODE", "labelsOrTypes": []any{"Person"}, "properties": []any{"name"},
+			"indexProvider": "range-1.0", "owningConstraint": "person_name_unique", "lastRead": nil, "readCount": int64(0),
+		})
+
+		index, err := toIndex(record)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, index, Index{
+			ID: 3, Name: "person_name", State: IndexOnline, PopulationPercent: 100, Type: "RANGE", EntityType: Node,
+			LabelsOrTypes: []string{"Person"}, Properties: []string{"name"}, OwningConstraint: "person_name_unique",
+		})
+	})
+
+	outer.Run("reads 4.x indexes", func(t *testing.T) {
+		record := recordOf(map[string]any{
+			"id": int64(1), "name": "knows_since", "state": "POPULATING", "populationPercent": 42.5,
+			"uniqueness": "NONUNIQUE", "type": "BTREE", "entityType": "RELATIONSHIP", "labelsOrTypes": []any{"KNOWS"},
+			"properties": []any{"since"}, "indexProvider": "native-btree-1.0",
+		})
+
+		index, err := toIndex(record)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, index, Index{
+			ID: 1, Name: "knows_since", State: IndexPopulating, PopulationPercent: 42.5, Type: "BTREE",
+			EntityType: Relationship, LabelsOrTypes: []string{"KNOWS"}, Properties: []string{"since"},
+		})
+	})
+
+	outer.Run("reads lookup indexes", func(t *testing.T) {
+		record := recordOf(map[string]any{
+			"id": int64(1), "name": "index_343aff4e", "state": "ONLINE", "populationPercent": 100.0,
+			"type": "LOOKUP", "entityType": "NODE", "labelsOrTypes": nil, "properties": nil,
+		})
+
+		index, err := toIndex(record)
+
+		AssertNoError(t, err)
+		AssertLen(t, index.LabelsOrTypes, 0)
+		AssertLen(t, index.Properties, 0)
+	})
+
+	outer.Run("fails on unexpected column types", func(t *testing.T) {
+		_, err := toIndex(recordOf(map[string]any{"name": "broken", "properties": []any{int64(1)}}))
+
+		AssertStringContain(t, err.Error(), `could not read index "broken": expected properties to be a list of strings`)
+	})
+}
+
+func TestToConstraint(outer *testing.T) {
+	outer.Parallel()
+
+	outer.Run("reads 5.x constraints", func(t *testing.T) {
+		record := recordOf(map[string]any{
+			"id": int64(4), "name": "person_name_unique", "type": "NODE_PROPERTY_UNIQUENESS", "entityType": "NODE",
+			"labelsOrTypes": []any{"Person"}, "properties": []any{"name"}, "ownedIndex": "person_name",
+			"propertyType": nil,
+		})
+
+		constraint, err := toConstraint(record)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, constraint, Constraint{
+			ID: 4, Name: "person_name_unique", Type: NodeUniqueness, EntityType: Node,
+			LabelsOrTypes: []string{"Person"}, Properties: []string{"name"}, OwnedIndex: "person_name",
+		})
+	})
+
+	outer.Run("converts legacy constraint types", func(inner *testing.T) {
+		testCases := map[string]ConstraintType{
+			"UNIQUENESS":              NodeUniqueness,
+			"RELATIONSHIP_UNIQUENESS": RelationshipUniqueness,
+			"NODE_KEY":                NodeKey,
+		}
+		for legacyType, expected := range testCases {
+			inner.Run(legacyType, func(t *testing.T) {
+				record := recordOf(map[string]any{"type": legacyType, "ownedIndexId": int64(2)})
+
+				constraint, err := toConstraint(record)
+
+				AssertNoError(t, err)
+				AssertDeepEquals(t, constraint.Type, expected)
+			})
+		}
+	})
+
+	outer.Run("fails on unexpected column types", func(t *testing.T) {
+		_, err := toConstraint(recordOf(map[string]any{"name": "broken", "id": "1"}))
+
+		AssertStringContain(t, err.Error(), `could not read constraint "broken": expected id to be an integer`)
+	})
+}
+
+func recordOf(values map[string]any) *neo4j.Record {
+	record := &neo4j.Record{}
+	for key, value := range values {
+		record.Keys = append(record.Keys, key)
+		record.Values = append(record.Values, value)
+	}
+	return record
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+)
+
+// serverClockQuery reads the server time, in milliseconds since the Unix epoch
+const serverClockQuery = "RETURN timestamp() AS now"
+
+// estimateServerClockSkew estimates the offset of the server clock relative to the local one by reading the server
+// time and comparing it with the local time half-way through the round trip
+func estimateServerClockSkew(ctx context.Context, conn idb.Connection, now func() time.Time) (time.Duration, error) {
+	sent := now()
+	stream, err := conn.Run(ctx, idb.Command{Cypher: serverClockQuery}, idb.TxConfig{Mode: idb.ReadMode})
+	if err != nil {
+		return 0, err
+	}
+	record, _, err := conn.Next(ctx, stream)
+	if err != nil {
+		return 0, err
+	}
+	received := now()
+	if _, err := conn.Consume(ctx, stream); err != nil {
+		return 0, err
+	}
+	// the record is raw when Config.RawRecords is set
+	if err := DecodeRawRecord(record); err != nil {
+		return 0, err
+	}
+	if record == nil || len(record.Values) != 1 {
+		return 0, fmt.Errorf("expected a single server time value")
+	}
+	serverMillis, ok := record.Values[0].(int64)
+	if !ok {
+		return 0, fmt.Errorf("expected server time to be an integer but found %T", record.Values[0])
+	}
+	local := sent.Add(received.Sub(sent) / 2)
+	return time.UnixMilli(serverMillis).Sub(local), nil
+}
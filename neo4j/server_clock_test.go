@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestEstimateServerClockSkew(outer *testing.T) {
+	ctx := context.Background()
+	local := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	steppingClock := func(step time.Duration) func() time.Time {
+		current := local.Add(-step)
+		return func() time.Time {
+			current = current.Add(step)
+			return current
+		}
+	}
+
+	outer.Run("compares the server time with the middle of the round trip", func(t *testing.T) {
+		serverTime := local.Add(2 * time.Second).Add(50 * time.Millisecond)
+		conn := &ConnFake{Nexts: []Next{{Record: &db.Record{Values: []any{serverTime.UnixMilli()}}}}}
+
+		skew, err := estimateServerClockSkew(ctx, conn, steppingClock(100*time.Millisecond))
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, skew, 2*time.Second)
+		AssertLen(t, conn.RecordedTxs, 1)
+		AssertIntEqual(t, int(conn.RecordedTxs[0].Mode), int(idb.ReadMode))
+	})
+
+	outer.Run("is negative when the server clock is behind", func(t *testing.T) {
+		serverTime := local.Add(-time.Minute)
+		conn := &ConnFake{Nexts: []Next{{Record: &db.Record{Values: []any{serverTime.UnixMilli()}}}}}
+
+		skew, err := estimateServerClockSkew(ctx, conn, steppingClock(0))
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, skew, -time.Minute)
+	})
+
+	outer.Run("decodes raw records", func(t *testing.T) {
+		serverTime := local.Add(time.Second)
+		millis := serverTime.UnixMilli()
+		raw := []byte{0x91, 0xCB}
+		for shift := 56; shift >= 0; shift -= 8 {
+			raw = append(raw, byte(millis>>shift))
+		}
+		conn := &ConnFake{Nexts: []Next{{Record: &db.Record{Keys: []string{"now"}, Raw: raw}}}}
+
+		skew, err := estimateServerClockSkew(ctx, conn, steppingClock(0))
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, skew, time.Second)
+	})
+
+	outer.Run("fails when the query fails", func(t *testing.T) {
+		runErr := errors.New("no time for you")
+		conn := &ConnFake{RunErr: runErr}
+
+		_, err := estimateServerClockSkew(ctx, conn, steppingClock(0))
+
+		AssertDeepEquals(t, err, runErr)
+	})
+
+	outer.Run("fails on unexpected server time", func(t *testing.T) {
+		conn := &ConnFake{Nexts: []Next{{Record: &db.Record{Values: []any{"noon"}}}}}
+
+		_, err := estimateServerClockSkew(ctx, conn, steppingClock(0))
+
+		AssertErrorMessageContains(t, err, "expected server time to be an integer but found string")
+	})
+}
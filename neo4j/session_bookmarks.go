@@ -66,6 +66,10 @@ func (sb *sessionBookmarks) replaceSessionBookmarks(newBookmark string) {
 	sb.bookmarks = []string{newBookmark}
 }
 
+func (sb *sessionBookmarks) setBookmarks(bookmarks Bookmarks) {
+	sb.bookmarks = cleanupBookmarks(bookmarks)
+}
+
 func (sb *sessionBookmarks) getBookmarks(ctx context.Context) (Bookmarks, error) {
 	if sb.bookmarkManager == nil {
 		return nil, nil
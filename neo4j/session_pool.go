@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/errorutil"
+	"sync"
+)
+
+// SessionPoolConfig holds the settings of a SessionPool
+// This API is experimental and may be changed or removed without prior notice
+type SessionPoolConfig struct {
+	// Presets are the session configurations, identified by name, that sessions can be acquired with.
+	// A typical setup defines one preset per database and access mode used by the application.
+	Presets map[string]SessionConfig
+	// MaxSessionsPerTenant limits the number of sessions that can be acquired and not yet released on behalf of the
+	// same tenant. Acquiring more sessions blocks until sessions of that tenant are released.
+	// 0 means no limit.
+	// default: 0
+	MaxSessionsPerTenant int
+}
+
+// SessionPool hands out pre-configured sessions to request-scoped work, such as the handling of an HTTP request, and
+// collects them once the work is done:
+//
+//	sessionPool := neo4j.NewSessionPool(driver, neo4j.SessionPoolConfig{
+//		Presets: map[string]neo4j.SessionConfig{
+//			"catalog": {DatabaseName: "catalog", AccessMode: neo4j.AccessModeRead},
+//		},
+//		MaxSessionsPerTenant: 10,
+//	})
+//	// [...] for each request
+//	session, err := sessionPool.Acquire(ctx, tenantId, "catalog")
+//	if err != nil {
+//		return err
+//	}
+//	defer sessionPool.Release(ctx, session)
+//
+// Sessions are not reused across requests: released sessions are closed, and their connections returned to the
+// driver connection pool. SessionPool is safe for concurrent use.
+// This API is experimental and may be changed or removed without prior notice
+type SessionPool struct {
+	driver   DriverWithContext
+	config   SessionPoolConfig
+	mutex    sync.Mutex
+	tenants  map[string]chan struct{}
+	acquired map[SessionWithContext]string
+	closed   bool
+}
+
+// NewSessionPool creates a SessionPool creating its sessions with the given driver
+// This API is experimental and may be changed or removed without prior notice
+func NewSessionPool(driver DriverWithContext, config SessionPoolConfig) *SessionPool {
+	return &SessionPool{
+		driver:   driver,
+		config:   config,
+		tenants:  make(map[string]chan struct{}),
+		acquired: make(map[SessionWithContext]string),
+	}
+}
+
+// Acquire creates a session configured with the given preset on behalf of the given tenant.
+// When the tenant already holds SessionPoolConfig.MaxSessionsPerTenant sessions, Acquire waits until one of them is
+// released or the context is done, in which case an error wrapping the context error is returned.
+// A UsageError is returned if the preset is unknown or the pool is closed.
+// Acquired sessions must be released with Release.
+func (p *SessionPool) Acquire(ctx context.Context, tenant string, preset string) (SessionWithContext, error) {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil, &UsageError{Message: "Trying to acquire a session from a closed session pool"}
+	}
+	config, found := p.config.Presets[preset]
+	if !found {
+		p.mutex.Unlock()
+		return nil, &UsageError{Message: fmt.Sprintf("Unknown session preset %q", preset)}
+	}
+	slots := p.tenantSlots(tenant)
+	p.mutex.Unlock()
+
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("could not acquire session for tenant %q, limit of %d sessions reached: %w",
+				tenant, p.config.MaxSessionsPerTenant, ctx.Err())
+		}
+	}
+	session := p.driver.NewSession(ctx, config)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		freeSlot(slots)
+		return nil, errorutil.CombineErrors(
+			&UsageError{Message: "Session pool closed while acquiring a session"}, session.Close(ctx))
+	}
+	p.acquired[session] = tenant
+	return session, nil
+}
+
+// Release closes the given session, previously returned by Acquire, and makes room for another session of the same
+// tenant.
+// A UsageError is returned if the session has not been acquired from this pool or has already been released.
+func (p *SessionPool) Release(ctx context.Context, session SessionWithContext) error {
+	p.mutex.Lock()
+	tenant, found := p.acquired[session]
+	if !found {
+		p.mutex.Unlock()
+		return &UsageError{Message: "Trying to release a session not acquired from this session pool"}
+	}
+	delete(p.acquired, session)
+	freeSlot(p.tenants[tenant])
+	p.mutex.Unlock()
+	return session.Close(ctx)
+}
+
+// InUse returns the number of sessions acquired on behalf of the given tenant and not released yet.
+func (p *SessionPool) InUse(tenant string) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	count := 0
+	for _, owner := range p.acquired {
+		if owner == tenant {
+			count++
+		}
+	}
+	return count
+}
+
+// Close closes all the sessions that have not been released yet.
+// Sessions cannot be acquired from the pool anymore after this call, and releasing the sessions closed by this call
+// results in a UsageError.
+func (p *SessionPool) Close(ctx context.Context) error {
+	p.mutex.Lock()
+	p.closed = true
+	sessions := make([]SessionWithContext, 0, len(p.acquired))
+	for session := range p.acquired {
+		sessions = append(sessions, session)
+	}
+	p.acquired = make(map[SessionWithContext]string)
+	p.mutex.Unlock()
+
+	errs := make([]error, len(sessions))
+	for i, session := range sessions {
+		errs[i] = session.Close(ctx)
+	}
+	return errorutil.CombineAllErrors(errs...)
+}
+
+// tenantSlots returns the semaphore limiting the sessions of the given tenant, nil when sessions are not limited
+// must be called with the mutex held
+func (p *SessionPool) tenantSlots(tenant string) chan struct{} {
+	if p.config.MaxSessionsPerTenant <= 0 {
+		return nil
+	}
+	slots, found := p.tenants[tenant]
+	if !found {
+		slots = make(chan struct{}, p.config.MaxSessionsPerTenant)
+		p.tenants[tenant] = slots
+	}
+	return slots
+}
+
+func freeSlot(slots chan struct{}) {
+	if slots != nil {
+		<-slots
+	}
+}
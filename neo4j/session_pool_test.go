@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestSessionPool(outer *testing.T) {
+	outer.Parallel()
+
+	ctx := context.Background()
+	presets := map[string]SessionConfig{
+		"reads":  {DatabaseName: "catalog", AccessMode: AccessModeRead},
+		"writes": {DatabaseName: "catalog", AccessMode: AccessModeWrite},
+	}
+
+	newPool := func(maxSessionsPerTenant int) (*SessionPool, *[]SessionConfig) {
+		var sessionConfigs []SessionConfig
+		driver := &driverDelegate{newSession: func(_ context.Context, config SessionConfig) SessionWithContext {
+			sessionConfigs = append(sessionConfigs, config)
+			return &fakeSession{}
+		}}
+		return NewSessionPool(driver, SessionPoolConfig{
+			Presets:              presets,
+			MaxSessionsPerTenant: maxSessionsPerTenant,
+		}), &sessionConfigs
+	}
+
+	outer.Run("creates sessions from presets", func(t *testing.T) {
+		pool, sessionConfigs := newPool(0)
+
+		_, err := pool.Acquire(ctx, "tenant", "reads")
+		AssertNoError(t, err)
+		_, err = pool.Acquire(ctx, "tenant", "writes")
+		AssertNoError(t, err)
+
+		AssertDeepEquals(t, *sessionConfigs, []SessionConfig{presets["reads"], presets["writes"]})
+		AssertIntEqual(t, pool.InUse("tenant"), 2)
+	})
+
+	outer.Run("rejects unknown presets", func(t *testing.T) {
+		pool, sessionConfigs := newPool(0)
+
+		_, err := pool.Acquire(ctx, "tenant", "admin")
+
+		AssertSameType(t, err, &UsageError{})
+		AssertLen(t, *sessionConfigs, 0)
+	})
+
+	outer.Run("releases sessions", func(t *testing.T) {
+		pool, _ := newPool(0)
+		session, err := pool.Acquire(ctx, "tenant", "reads")
+		AssertNoError(t, err)
+
+		AssertNoError(t, pool.Release(ctx, session))
+		AssertIntEqual(t, pool.InUse("tenant"), 0)
+		AssertSameType(t, pool.Release(ctx, session), &UsageError{})
+	})
+
+	outer.Run("reports session close errors on release", func(t *testing.T) {
+		closeErr := errors.New("oopsie")
+		pool := NewSessionPool(&driverDelegate{newSession: func(context.Context, SessionConfig) SessionWithContext {
+			return &fakeSession{closeErr: closeErr}
+		}}, SessionPoolConfig{Presets: presets})
+		session, err := pool.Acquire(ctx, "tenant", "reads")
+		AssertNoError(t, err)
+
+		AssertDeepEquals(t, pool.Release(ctx, session), closeErr)
+	})
+
+	outer.Run("limits sessions per tenant", func(inner *testing.T) {
+		inner.Run("until the context is done", func(t *testing.T) {
+			pool, _ := newPool(1)
+			_, err := pool.Acquire(ctx, "tenant", "reads")
+			AssertNoError(t, err)
+			timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			defer cancel()
+
+			_, err = pool.Acquire(timeoutCtx, "tenant", "reads")
+
+			AssertTrue(t, errors.Is(err, context.DeadlineExceeded))
+			AssertIntEqual(t, pool.InUse("tenant"), 1)
+		})
+
+		inner.Run("independently for each tenant", func(t *testing.T) {
+			pool, _ := newPool(1)
+			_, err := pool.Acquire(ctx, "tenant", "reads")
+			AssertNoError(t, err)
+
+			_, err = pool.Acquire(ctx, "other tenant", "reads")
+
+			AssertNoError(t, err)
+		})
+
+		inner.Run("until a session is released", func(t *testing.T) {
+			pool, _ := newPool(1)
+			session, err := pool.Acquire(ctx, "tenant", "reads")
+			AssertNoError(t, err)
+			acquired := make(chan error)
+			go func() {
+				_, err := pool.Acquire(ctx, "tenant", "writes")
+				acquired <- err
+			}()
+
+			AssertNoError(t, pool.Release(ctx, session))
+
+			AssertNoError(t, <-acquired)
+			AssertIntEqual(t, pool.InUse("tenant"), 1)
+		})
+	})
+
+	outer.Run("closes remaining sessions on close", func(t *testing.T) {
+		closeErr := errors.New("oopsie")
+		pool := NewSessionPool(&driverDelegate{newSession: func(context.Context, SessionConfig) SessionWithContext {
+			return &fakeSession{closeErr: closeErr}
+		}}, SessionPoolConfig{Presets: presets})
+		_, err := pool.Acquire(ctx, "tenant", "reads")
+		AssertNoError(t, err)
+
+		AssertDeepEquals(t, pool.Close(ctx), closeErr)
+		AssertIntEqual(t, pool.InUse("tenant"), 0)
+		_, err = pool.Acquire(ctx, "tenant", "reads")
+		AssertSameType(t, err, &UsageError{})
+	})
+}
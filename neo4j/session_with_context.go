@@ -21,15 +21,21 @@ package neo4j
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/auth"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/collection"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/errorutil"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
 	"math"
+	"strings"
 	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/retry"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/router"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 )
 
@@ -53,6 +59,19 @@ type SessionWithContext interface {
 	// returned.
 	LastBookmarks() Bookmarks
 	lastBookmark() string
+	// SetBookmarks replaces the bookmarks of the session with the given ones, for instance with consistency tokens
+	// received mid-session from another system.
+	// The subsequent transactions of the session wait for the given bookmarks, instead of the ones received
+	// following the previously completed transactions. Empty bookmarks are ignored.
+	// A pending auto-commit result is buffered first, so that its bookmark does not override the given ones.
+	// SetBookmarks fails with a UsageError while an explicit transaction is open.
+	// The configured BookmarkManager, if any, is left untouched: its bookmarks are still sent alongside the session
+	// bookmarks, and it is only updated with the bookmarks of the transactions completed afterwards.
+	// Contexts terminating too early negatively affect connection pooling and degrade the driver performance.
+	SetBookmarks(ctx context.Context, bookmarks Bookmarks) error
+	// Stats returns the counters of the work done by the session so far: queries, records, bytes and retries.
+	// It can be called before and after Close, which lets frameworks attribute database usage per handler or tenant.
+	Stats() SessionStats
 	// BeginTransaction starts a new explicit transaction on this session
 	// Contexts terminating too early negatively affect connection pooling and degrade the driver performance.
 	BeginTransaction(ctx context.Context, configurers ...func(*TransactionConfig)) (ExplicitTransaction, error)
@@ -67,6 +86,15 @@ type SessionWithContext interface {
 	// Run executes an auto-commit statement and returns a result
 	// Contexts terminating too early negatively affect connection pooling and degrade the driver performance.
 	Run(ctx context.Context, cypher string, params map[string]any, configurers ...func(*TransactionConfig)) (ResultWithContext, error)
+	// RunBatch executes the given queries as independent auto-commit statements, back-to-back on a single connection,
+	// and returns their fully fetched results in the order of the queries.
+	// With Bolt 5 servers, the queries are pipelined, i.e. sent all at once, saving a network round trip per query,
+	// unless Config.TransactionInterceptors are configured.
+	// The execution stops at the first failing query: the results of the queries executed before are returned along
+	// with the error, and the following queries are not executed.
+	// Each executed query is reported as a separate auto-commit transaction to SessionConfig.OnTransactionEvent.
+	// Contexts terminating too early negatively affect connection pooling and degrade the driver performance.
+	RunBatch(ctx context.Context, queries []BatchQuery, configurers ...func(*TransactionConfig)) ([]*EagerResult, error)
 	// Close closes any open resources and marks this session as unusable
 	// Contexts terminating too early negatively affect connection pooling and degrade the driver performance.
 	Close(ctx context.Context) error
@@ -75,12 +103,25 @@ type SessionWithContext interface {
 	getServerInfo(ctx context.Context) (ServerInfo, error)
 }
 
+// BatchQuery is a query executed with SessionWithContext.RunBatch
+type BatchQuery struct {
+	Cypher string
+	Params map[string]any
+}
+
 // SessionConfig is used to configure a new session, its zero value uses safe defaults.
 type SessionConfig struct {
 	// AccessMode used when using Session.Run and explicit transactions. Used to route query to
 	// to read or write servers when running in a cluster. Session.ReadTransaction and Session.WriteTransaction
 	// does not rely on this mode.
+	// If left to its zero value, Config.DefaultAccessMode is used instead, unless ExplicitAccessMode is set.
 	AccessMode AccessMode
+	// ExplicitAccessMode makes the session use AccessMode as is, even when it is left to its zero value
+	// (i.e. AccessModeWrite), instead of falling back to Config.DefaultAccessMode.
+	// This lets sessions opt back into AccessModeWrite when Config.DefaultAccessMode is AccessModeRead.
+	//
+	// default: false
+	ExplicitAccessMode bool
 	// Bookmarks are the initial bookmarks used to ensure that the executing server is at least up
 	// to date to the point represented by the latest of the provided bookmarks. After running commands
 	// on the session the bookmark can be retrieved with Session.LastBookmark. All commands executing
@@ -89,6 +130,7 @@ type SessionConfig struct {
 	Bookmarks Bookmarks
 	// DatabaseName sets the target database name for the queries executed within the session created with this
 	// configuration.
+	// The name is normalized to lower case, see NormalizeDatabaseName.
 	// Usage of Cypher clauses like USE is not a replacement for this option.
 	// Drive​r sends Cypher to the server for processing.
 	// This option has no explicit value by default, but it is recommended to set one if the target database is known
@@ -153,6 +195,41 @@ type SessionConfig struct {
 	// Since 5.0
 	// default: nil (no-op)
 	BookmarkManager BookmarkManager
+	// OnTransactionEvent is called synchronously at the boundaries of the transactions of the session: when explicit
+	// and managed transactions begin, commit or roll back and when auto-commit transactions start and finish.
+	// It enables unit-of-work patterns and transaction lifecycle metrics, see TransactionEvent.
+	// The callback must not use the session.
+	// default: nil (no-op)
+	OnTransactionEvent func(context.Context, TransactionEvent)
+	// Auth sets the token the session authenticates as, instead of the token of the driver.
+	// This lets a multi-tenant service share a single driver among users. The connections borrowed by the session
+	// are re-authenticated with the token when the server supports it (Bolt 5.1+, HTTP), and replaced by connections
+	// dedicated to the token otherwise.
+	// Unlike ImpersonatedUser, this does not require the driver user to have impersonation privileges.
+	// default: nil (the token of the driver is used)
+	Auth *AuthToken
+	// DefaultTransactionConfigurers are applied to every auto-commit transaction, explicit transaction and
+	// transaction function of the session, after Config.DefaultTransactionConfigurers and before the configuration
+	// functions passed to the call, which therefore take precedence:
+	//
+	//	session := driver.NewSession(ctx, neo4j.SessionConfig{
+	//		DefaultTransactionConfigurers: []func(*neo4j.TransactionConfig){
+	//			neo4j.WithTxTimeout(5 * time.Second),
+	//			neo4j.WithTxMetadata(map[string]any{"app": "reporting"}),
+	//		},
+	//	})
+	//
+	// default: nil
+	DefaultTransactionConfigurers []func(*TransactionConfig)
+	// NotificationsMinSeverity overrides Config.NotificationsMinSeverity for the transactions of the session.
+	// It requires Neo4j 5.7+, running transactions on older servers fails when it is set.
+	// default: NotificationsServerDefault (the driver setting applies)
+	NotificationsMinSeverity NotificationMinSeverity
+	// NotificationsDisabledCategories overrides Config.NotificationsDisabledCategories for the transactions of the
+	// session: a nil slice keeps the driver setting, while an empty slice enables all categories.
+	// It requires Neo4j 5.7+, running transactions on older servers fails when it is set.
+	// default: nil (the driver setting applies)
+	NotificationsDisabledCategories []NotificationCategory
 }
 
 // FetchAll turns off fetching records in batches.
@@ -164,6 +241,7 @@ const FetchDefault = 0
 // Connection pool as seen by the session.
 type sessionPool interface {
 	Borrow(ctx context.Context, serverNames []string, wait bool, boltLogger log.BoltLogger, livenessCheckThreshold time.Duration) (idb.Connection, error)
+	Reborrow(ctx context.Context, c idb.Connection, boltLogger log.BoltLogger, livenessCheckThreshold time.Duration) idb.Connection
 	Return(ctx context.Context, c idb.Connection) error
 	CleanUp(ctx context.Context) error
 }
@@ -179,13 +257,37 @@ type sessionWithContext struct {
 	router           sessionRouter
 	explicitTx       *explicitTransaction
 	autocommitTx     *autocommitTransaction
-	sleep            func(d time.Duration)
-	now              func() time.Time
 	logId            string
 	log              log.Logger
+	clock            clock.Clock
 	throttleTime     time.Duration
 	fetchSize        int
 	boltLogger       log.BoltLogger
+	resultScope      resultScope
+	inFlight         inFlightResults
+	statistics       *queryStatisticsCollector
+	explainer        *queryExplainer
+	linter           *literalLinter
+	queryLogger      *queryLogger
+	retryBudget      *retry.Budget
+	writeFence       *writeFence
+	onTxEvent        func(context.Context, TransactionEvent)
+	// auth is the token set in SessionConfig.Auth, nil when the token of the driver is used
+	auth map[string]any
+	// defaultTxConfigurers are the configuration functions set in SessionConfig.DefaultTransactionConfigurers
+	defaultTxConfigurers []func(*TransactionConfig)
+	// notifications are the notification settings of SessionConfig, sent along with each transaction
+	notifications idb.NotificationConfig
+	// authManager is notified of the expired tokens, nil in tests
+	authManager auth.TokenManager
+	// last connection borrowed by the session, see Config.ConnectionAffinity
+	lastConn idb.Connection
+	// bytes received by the connections of the session while borrowed, see SessionStats.BytesReceived
+	bytesReceived int64
+	// bytes received by the current connection of the session before it was acquired
+	bytesReceivedBeforeAcquisition int64
+	// number of transaction function retries
+	retries int64
 }
 
 func newSessionWithContext(config *Config, sessConfig SessionConfig, router sessionRouter, pool sessionPool, logger log.Logger) *sessionWithContext {
@@ -197,22 +299,42 @@ func newSessionWithContext(config *Config, sessConfig SessionConfig, router sess
 		fetchSize = sessConfig.FetchSize
 	}
 
+	accessMode := config.DefaultAccessMode
+	if sessConfig.ExplicitAccessMode || sessConfig.AccessMode != AccessModeWrite {
+		accessMode = sessConfig.AccessMode
+	}
+
+	databaseName := NormalizeDatabaseName(sessConfig.DatabaseName)
+
+	sessionClock := config.Clock
+	if sessionClock == nil {
+		sessionClock = clock.System()
+	}
+
 	return &sessionWithContext{
 		config:           config,
 		router:           router,
 		pool:             pool,
-		defaultMode:      idb.AccessMode(sessConfig.AccessMode),
+		defaultMode:      idb.AccessMode(accessMode),
 		bookmarks:        newSessionBookmarks(sessConfig.BookmarkManager, sessConfig.Bookmarks),
-		databaseName:     sessConfig.DatabaseName,
+		databaseName:     databaseName,
 		impersonatedUser: sessConfig.ImpersonatedUser,
 		resolveHomeDb:    sessConfig.DatabaseName == "",
-		sleep:            time.Sleep,
-		now:              time.Now,
 		log:              logger,
 		logId:            logId,
+		clock:            sessionClock,
 		throttleTime:     time.Second * 1,
 		fetchSize:        fetchSize,
 		boltLogger:       sessConfig.BoltLogger,
+		resultScope:      newResultScope(config.ResultScopeBehavior),
+		inFlight:         newInFlightResults(config.MaxInFlightResults),
+		onTxEvent:        sessConfig.OnTransactionEvent,
+		auth:             sessionAuth(sessConfig.Auth),
+		statistics:       newQueryStatisticsCollector(),
+		queryLogger:      newQueryLogger(config),
+
+		defaultTxConfigurers: sessConfig.DefaultTransactionConfigurers,
+		notifications:        notificationConfig(sessConfig.NotificationsMinSeverity, sessConfig.NotificationsDisabledCategories),
 	}
 }
 
@@ -233,6 +355,17 @@ func (s *sessionWithContext) lastBookmark() string {
 	return s.bookmarks.lastBookmark()
 }
 
+func (s *sessionWithContext) Stats() SessionStats {
+	statistics := s.statistics.snapshot()
+	return SessionStats{
+		Queries:         statistics.Queries,
+		Failures:        statistics.Failures,
+		RecordsConsumed: statistics.RecordsDecoded,
+		BytesReceived:   s.bytesReceived,
+		Retries:         s.retries,
+	}
+}
+
 func (s *sessionWithContext) LastBookmarks() Bookmarks {
 	// Pick up bookmark from pending auto-commit if there is a bookmark on it
 	// Note: the bookmark manager should not be notified here because:
@@ -250,11 +383,30 @@ func (s *sessionWithContext) LastBookmarks() Bookmarks {
 	return s.bookmarks.currentBookmarks()
 }
 
+func (s *sessionWithContext) SetBookmarks(ctx context.Context, bookmarks Bookmarks) error {
+	if s.explicitTx != nil {
+		err := &UsageError{Message: "Trying to set bookmarks while in explicit transaction"}
+		s.logger(ctx).Error(log.Session, s.logId, err)
+		return err
+	}
+	// Buffer any pending auto-commit so that its bookmark does not replace the new ones afterwards
+	if s.autocommitTx != nil {
+		s.autocommitTx.done(ctx)
+	}
+	s.bookmarks.setBookmarks(bookmarks)
+	return nil
+}
+
 func (s *sessionWithContext) BeginTransaction(ctx context.Context, configurers ...func(*TransactionConfig)) (ExplicitTransaction, error) {
 	// Guard for more than one transaction per session
 	if s.explicitTx != nil {
 		err := &UsageError{Message: "Session already has a pending transaction"}
-		s.log.Error(log.Session, s.logId, err)
+		s.logger(ctx).Error(log.Session, s.logId, err)
+		return nil, err
+	}
+
+	if err := s.inFlight.check(1); err != nil {
+		s.logger(ctx).Error(log.Session, s.logId, err)
 		return nil, err
 	}
 
@@ -263,48 +415,76 @@ func (s *sessionWithContext) BeginTransaction(ctx context.Context, configurers .
 	}
 
 	// Apply configuration functions
-	config := defaultTransactionConfig()
-	for _, c := range configurers {
-		c(&config)
-	}
+	config := s.transactionConfig(configurers)
 	if err := validateTransactionConfig(config); err != nil {
 		return nil, err
 	}
 
 	// Get a connection from the pool. This could fail in clustered environment.
-	conn, err := s.getConnection(ctx, s.defaultMode, pool.DefaultLivenessCheckThreshold)
+	mode := s.transactionMode(config)
+	events := newTransactionEvents(s.onTxEvent, false)
+	acquisitionStart := time.Now()
+	conn, err := s.getConnection(ctx, mode, s.livenessCheckThreshold())
 	if err != nil {
-		return nil, wrapError(err)
+		err = wrapError(err)
+		events.begun(ctx, TransactionBegun, err)
+		return nil, err
 	}
+	connectionAcquisition := time.Since(acquisitionStart)
 
 	// Begin transaction
 	beginBookmarks, err := s.getBookmarks(ctx)
 	if err != nil {
-		s.pool.Return(ctx, conn)
-		return nil, wrapError(err)
+		s.returnConnection(ctx, conn)
+		err = wrapError(err)
+		events.begun(ctx, TransactionBegun, err)
+		return nil, err
 	}
-	txHandle, err := conn.TxBegin(ctx,
+	txHandle, metadata, err := s.beginTransaction(ctx, conn,
 		idb.TxConfig{
-			Mode:             s.defaultMode,
+			Mode:             mode,
 			Bookmarks:        beginBookmarks,
-			Timeout:          config.Timeout,
+			Timeout:          s.transactionTimeout(ctx, config),
 			Meta:             config.Metadata,
-			ImpersonatedUser: s.impersonatedUser,
-		})
+			ImpersonatedUser: s.transactionImpersonatedUser(config),
+			Notifications:    s.notifications,
+		}, false)
 	if err != nil {
-		s.pool.Return(ctx, conn)
-		return nil, wrapError(err)
+		s.refreshExpiredToken(ctx, conn, err)
+		s.returnConnection(ctx, conn)
+		err = wrapError(err)
+		events.begun(ctx, TransactionBegun, err)
+		return nil, err
 	}
+	events.begun(ctx, TransactionBegun, nil)
 
 	// Create transaction wrapper
 	s.explicitTx = &explicitTransaction{
-		conn:      conn,
-		fetchSize: s.fetchSize,
-		txHandle:  txHandle,
+		conn:                  conn,
+		fetchSize:             s.transactionFetchSize(config),
+		txHandle:              txHandle,
+		resultScope:           newResultScope(s.config.ResultScopeBehavior),
+		inFlight:              newInFlightResults(s.config.MaxInFlightResults),
+		unconsumed:            newUnconsumedResults(s.config.UnconsumedResultsOnCommit),
+		statistics:            s.statistics,
+		explainer:             s.explainer,
+		linter:                s.linter,
+		queryLogger:           s.queryLogger,
+		coerceParams:          s.config.CoerceParameterStrings,
+		annotator:             s.config.StatementAnnotator,
+		onDeprecationNotice:   s.config.OnDeprecationNotice,
+		interceptors:          s.config.TransactionInterceptors,
+		metadata:              metadata,
+		connectionAcquisition: connectionAcquisition,
+		events:                events,
 		onClosed: func(tx *explicitTransaction) {
 			// On transaction closed (rolled back or committed)
+			tx.resultScope.close()
 			bookmarkErr := s.retrieveBookmarks(ctx, conn, beginBookmarks)
-			poolErr := s.pool.Return(ctx, conn)
+			if tx.committed {
+				s.explainer.explainDeferred(ctx, conn, tx.explains, s.explainTxConfig(mode, config), tx.fetchSize)
+			}
+			poolErr := s.returnConnection(ctx, conn)
 			tx.err = errorutil.CombineAllErrors(tx.err, bookmarkErr, poolErr)
 			s.explicitTx = nil
 		},
@@ -336,29 +516,35 @@ func (s *sessionWithContext) runRetriable(
 		return nil, err
 	}
 
+	if err := s.inFlight.check(1); err != nil {
+		return nil, err
+	}
+
 	if s.autocommitTx != nil {
 		s.autocommitTx.done(ctx)
 	}
 
-	config := defaultTransactionConfig()
-	for _, c := range configurers {
-		c(&config)
-	}
+	config := s.transactionConfig(configurers)
 	if err := validateTransactionConfig(config); err != nil {
 		return nil, err
 	}
+	if config.accessMode != nil {
+		return nil, &UsageError{Message: "Access mode cannot be overridden for transaction functions, " +
+			"use ExecuteRead or ExecuteWrite instead"}
+	}
+	config.Metadata = metadataWithIdempotencyKey(config.Metadata, config.IdempotencyKey)
 
 	state := retry.State{
 		MaxTransactionRetryTime: s.config.MaxTransactionRetryTime,
 		Log:                     s.log,
 		LogName:                 log.Session,
 		LogId:                   s.logId,
-		Now:                     s.now,
-		Sleep:                   s.sleep,
+		Clock:                   s.clock,
 		Throttle:                retry.Throttler(s.throttleTime),
 		MaxDeadConnections:      s.config.MaxConnectionPoolSize,
 		Router:                  s.router,
 		DatabaseName:            s.databaseName,
+		Budget:                  s.retryBudget,
 		OnDeadConnection: func(server string) error {
 			if mode == idb.WriteMode {
 				if err := s.router.InvalidateWriter(ctx, s.databaseName, server); err != nil {
@@ -371,9 +557,22 @@ func (s *sessionWithContext) runRetriable(
 			}
 			return nil
 		},
+		RefreshExpiredToken: s.refreshExpiredToken,
+		IsRetryable:         s.config.IsRetryable,
 	}
-	for state.Continue() {
-		if tryAgain, result := s.executeTransactionFunction(ctx, mode, config, &state, work); tryAgain {
+	for attempt := 0; state.Continue(); attempt++ {
+		if attempt > 0 {
+			s.retries++
+		}
+		if err := s.writeFence.check(mode); err != nil {
+			return nil, err
+		}
+		attemptCtx := withTransactionAttempt(ctx, TransactionAttempt{Number: attempt + 1, RetryDelay: state.RetryDelay})
+		if tryAgain, result := s.executeTransactionFunction(attemptCtx, mode, config, &state, work); tryAgain {
+			if mode == idb.ReadMode && config.fallbackToWriters && errors.Is(state.LastErr, router.ErrNoReaders) {
+				s.logger(ctx).Infof(log.Session, s.logId, "No reader available, retrying transaction against writers")
+				mode = idb.WriteMode
+			}
 			continue
 		} else {
 			return result, nil
@@ -384,14 +583,14 @@ func (s *sessionWithContext) runRetriable(
 	// cause is only set when the retry logic could detect something strange.
 	if state.LastErrWasRetryable {
 		err := newTransactionExecutionLimit(state.Errs, state.Causes)
-		s.log.Error(log.Session, s.logId, err)
+		s.logger(ctx).Error(log.Session, s.logId, err)
 		return nil, err
 	}
 	// Wrap and log the error if it belongs to the driver
 	err := wrapError(state.LastErr)
 	switch err.(type) {
 	case *UsageError, *ConnectivityError:
-		s.log.Error(log.Session, s.logId, err)
+		s.logger(ctx).Error(log.Session, s.logId, err)
 	}
 	return nil, err
 }
@@ -403,99 +602,226 @@ func (s *sessionWithContext) executeTransactionFunction(
 	state *retry.State,
 	work ManagedTransactionWork) (bool, any) {
 
-	conn, err := s.getConnection(ctx, mode, pool.DefaultLivenessCheckThreshold)
+	events := newTransactionEvents(s.onTxEvent, true)
+	acquisitionStart := time.Now()
+	conn, err := s.getConnection(ctx, mode, s.livenessCheckThreshold())
 	if err != nil {
+		events.begun(ctx, TransactionBegun, wrapError(err))
 		state.OnFailure(ctx, conn, err, false)
 		return true, nil
 	}
+	connectionAcquisition := time.Since(acquisitionStart)
 
 	// handle transaction function panic as well
-	defer s.pool.Return(ctx, conn)
+	defer s.returnConnection(ctx, conn)
 
 	beginBookmarks, err := s.getBookmarks(ctx)
 	if err != nil {
+		events.begun(ctx, TransactionBegun, wrapError(err))
 		state.OnFailure(ctx, conn, err, false)
 		return true, nil
 	}
-	txHandle, err := conn.TxBegin(ctx,
+	txHandle, metadata, err := s.beginTransaction(ctx, conn,
 		idb.TxConfig{
 			Mode:             mode,
 			Bookmarks:        beginBookmarks,
-			Timeout:          config.Timeout,
+			Timeout:          s.transactionTimeout(ctx, config),
 			Meta:             config.Metadata,
-			ImpersonatedUser: s.impersonatedUser,
-		})
+			ImpersonatedUser: s.transactionImpersonatedUser(config),
+			Notifications:    s.notifications,
+		}, true)
 	if err != nil {
+		events.begun(ctx, TransactionBegun, wrapError(err))
 		state.OnFailure(ctx, conn, err, false)
 		return true, nil
 	}
-
-	tx := managedTransaction{conn: conn, fetchSize: s.fetchSize, txHandle: txHandle}
+	events.begun(ctx, TransactionBegun, nil)
+
+	tx := managedTransaction{
+		conn:                  conn,
+		fetchSize:             s.transactionFetchSize(config),
+		txHandle:              txHandle,
+		resultScope:           newResultScope(s.config.ResultScopeBehavior),
+		inFlight:              newInFlightResults(s.config.MaxInFlightResults),
+		statistics:            s.statistics,
+		explainer:             s.explainer,
+		linter:                s.linter,
+		queryLogger:           s.queryLogger,
+		coerceParams:          s.config.CoerceParameterStrings,
+		annotator:             s.config.StatementAnnotator,
+		onDeprecationNotice:   s.config.OnDeprecationNotice,
+		idempotencyKey:        config.IdempotencyKey,
+		interceptors:          s.config.TransactionInterceptors,
+		metadata:              metadata,
+		connectionAcquisition: connectionAcquisition,
+	}
+	tx.attempt, _ = TransactionAttemptFrom(ctx)
 	x, err := work(&tx)
+	tx.resultScope.close()
 	if err != nil {
+		tx.unlogged.flush(nil)
 		// If the client returns a client specific error that means that
 		// client wants to rollback. We don't do an explicit rollback here
 		// but instead rely on the pool invoking reset on the connection,
 		// that will do an implicit rollback.
+		events.end(ctx, TransactionRolledBack, err)
 		state.OnFailure(ctx, conn, err, false)
 		return true, nil
 	}
 
-	err = conn.TxCommit(ctx, txHandle)
+	commitOperation := &TxOperation{Type: TxCommitOperation, Managed: true, Metadata: metadata}
+	err = tx.interceptors.intercept(ctx, commitOperation, func(ctx context.Context, _ *TxOperation) error {
+		return conn.TxCommit(ctx, txHandle)
+	})
+	tx.unlogged.flush(wrapError(err))
 	if err != nil {
+		events.end(ctx, TransactionCommitted, wrapError(err))
 		state.OnFailure(ctx, conn, err, true)
 		return true, nil
 	}
+	events.end(ctx, TransactionCommitted, nil)
 
 	// transaction has been committed so let's ignore (ie just log) the error
 	if err = s.retrieveBookmarks(ctx, conn, beginBookmarks); err != nil {
-		s.log.Warnf(log.Session, s.logId, "could not retrieve bookmarks after successful commit: %s\n"+
+		s.logger(ctx).Warnf(log.Session, s.logId, "could not retrieve bookmarks after successful commit: %s\n"+
 			"the results of this transaction may not be visible to subsequent operations", err.Error())
 	}
+	s.explainer.explainDeferred(ctx, conn, tx.explains, s.explainTxConfig(mode, config), tx.fetchSize)
 	return false, x
 }
 
+// beginTransaction begins a transaction on the given connection through Config.TransactionInterceptors, and returns
+// its handle along with the metadata it was begun with
+func (s *sessionWithContext) beginTransaction(ctx context.Context, conn idb.Connection, txConfig idb.TxConfig,
+	managed bool) (idb.TxHandle, map[string]any, error) {
+
+	operation := &TxOperation{Type: TxBeginOperation, Managed: managed, Metadata: txConfig.Meta}
+	var txHandle idb.TxHandle
+	err := txInterceptors(s.config.TransactionInterceptors).intercept(ctx, operation,
+		func(ctx context.Context, operation *TxOperation) error {
+			txConfig.Meta = operation.Metadata
+			var err error
+			txHandle, err = conn.TxBegin(ctx, txConfig)
+			return err
+		})
+	return txHandle, operation.Metadata, err
+}
+
 func (s *sessionWithContext) getServers(ctx context.Context, mode idb.AccessMode) ([]string, error) {
 	if mode == idb.ReadMode {
-		return s.router.Readers(ctx, s.getBookmarks, s.databaseName, s.boltLogger)
+		return s.router.Readers(ctx, s.getBookmarks, s.databaseName, s.boltLoggerFor(ctx))
 	} else {
-		return s.router.Writers(ctx, s.getBookmarks, s.databaseName, s.boltLogger)
+		return s.router.Writers(ctx, s.getBookmarks, s.databaseName, s.boltLoggerFor(ctx))
 	}
 }
 
+// refreshExpiredToken notifies the token manager when err tells that the token of conn has expired, and returns
+// whether a fresh token can be used to try again. Static tokens cannot be refreshed.
+func (s *sessionWithContext) refreshExpiredToken(ctx context.Context, conn idb.Connection, err error) bool {
+	// session tokens are static and not managed by the token manager of the driver
+	if s.authManager == nil || s.auth != nil || !isTokenExpired(err) {
+		return false
+	}
+	var token auth.Token
+	if reAuthenticator, ok := conn.(idb.ReAuthenticator); ok {
+		token = AuthToken{tokens: reAuthenticator.AuthToken()}
+	} else if token, err = s.authManager.GetAuthToken(ctx); err != nil {
+		s.logger(ctx).Warnf(log.Session, s.logId, "Could not get the expired token: %s", err)
+		return false
+	}
+	if err := s.authManager.OnTokenExpired(ctx, token); err != nil {
+		s.logger(ctx).Warnf(log.Session, s.logId, "Token manager failed to handle expired token: %s", err)
+		return false
+	}
+	_, static := s.authManager.(AuthToken)
+	return !static
+}
+
+func sessionAuth(token *AuthToken) map[string]any {
+	if token == nil {
+		return nil
+	}
+	return token.tokens
+}
+
+func isTokenExpired(err error) bool {
+	var dbErr *db.Neo4jError
+	if errors.As(err, &dbErr) {
+		return dbErr.Code == tokenExpiredCode
+	}
+	var tokenErr *TokenExpiredError
+	return errors.As(err, &tokenErr)
+}
+
 func (s *sessionWithContext) getConnection(ctx context.Context, mode idb.AccessMode, livenessCheckThreshold time.Duration) (idb.Connection, error) {
-	if s.config.ConnectionAcquisitionTimeout > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, s.config.ConnectionAcquisitionTimeout)
-		if cancel != nil {
-			defer cancel()
-		}
-		s.log.Debugf(log.Session, s.logId, "connection acquisition timeout is: %s",
-			s.config.ConnectionAcquisitionTimeout.String())
-		if deadline, ok := ctx.Deadline(); ok {
-			s.log.Debugf(log.Session, s.logId, "connection acquisition resolved deadline is: %s",
-				deadline.String())
-		}
+	if err := s.writeFence.check(mode); err != nil {
+		return nil, err
+	}
+	if s.config.ConnectionAcquisitionTimeout <= 0 {
+		return s.acquireConnection(ctx, mode, livenessCheckThreshold)
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.config.ConnectionAcquisitionTimeout)
+	defer cancel()
+	s.logger(ctx).Debugf(log.Session, s.logId, "connection acquisition timeout is: %s",
+		s.config.ConnectionAcquisitionTimeout.String())
+	if deadline, ok := ctx.Deadline(); ok {
+		s.logger(ctx).Debugf(log.Session, s.logId, "connection acquisition resolved deadline is: %s",
+			deadline.String())
 	}
 
-	if err := s.resolveHomeDatabase(ctx); err != nil {
+	timings := &idb.AcquisitionTimings{}
+	start := time.Now()
+	conn, err := s.acquireConnection(idb.WithAcquisitionTimings(ctx, timings), mode, livenessCheckThreshold)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		elapsed := time.Since(start)
+		s.logger(ctx).Warnf(log.Session, s.logId, "connection acquisition timed out after %s (timeout: %s), time spent in %s",
+			elapsed, s.config.ConnectionAcquisitionTimeout, timings)
+		timeoutErr := newConnectionAcquisitionTimeoutError(s.config.ConnectionAcquisitionTimeout, elapsed, timings, err)
+		return nil, &ConnectivityError{inner: timeoutErr}
+	}
+	return conn, err
+}
+
+func (s *sessionWithContext) acquireConnection(ctx context.Context, mode idb.AccessMode, livenessCheckThreshold time.Duration) (idb.Connection, error) {
+	if s.auth != nil {
+		ctx = idb.WithSessionAuth(ctx, s.auth)
+	}
+	timings := idb.AcquisitionTimingsFrom(ctx)
+	// home database resolution and routing borrow connections of their own, which must not be charged to the
+	// dial and handshake of the acquired connection
+	untrackedCtx := idb.WithAcquisitionTimings(ctx, nil)
+	start := time.Now()
+	err := s.resolveHomeDatabase(untrackedCtx)
+	timings.Track(idb.HomeDatabaseResolutionPhase, start)
+	if err != nil {
 		return nil, wrapError(err)
 	}
-	servers, err := s.getServers(ctx, mode)
+	start = time.Now()
+	servers, err := s.getServers(untrackedCtx, mode)
+	timings.Track(idb.RoutingPhase, start)
 	if err != nil {
 		return nil, wrapError(err)
 	}
 
-	conn, err := s.pool.Borrow(ctx, servers, s.config.ConnectionAcquisitionTimeout != 0, s.boltLogger, livenessCheckThreshold)
+	start = time.Now()
+	conn := s.reborrowLastConnection(ctx, servers, livenessCheckThreshold)
+	if conn == nil {
+		conn, err = s.pool.Borrow(ctx, servers, s.config.ConnectionAcquisitionTimeout != 0, s.boltLoggerFor(ctx), livenessCheckThreshold)
+	}
+	timings.Track(idb.BorrowPhase, start)
 	if err != nil {
 		return nil, wrapError(err)
 	}
+	if s.config.ConnectionAffinity {
+		s.lastConn = conn
+	}
+	s.bytesReceivedBeforeAcquisition = bytesReceived(conn)
 
 	// Select database on server
 	if s.databaseName != idb.DefaultDatabase {
 		dbSelector, ok := conn.(idb.DatabaseSelector)
 		if !ok {
-			s.pool.Return(ctx, conn)
+			s.returnConnection(ctx, conn)
 			return nil, &UsageError{Message: "Database does not support multi-database"}
 		}
 		dbSelector.SelectDatabase(s.databaseName)
@@ -504,6 +830,34 @@ func (s *sessionWithContext) getConnection(ctx context.Context, mode idb.AccessM
 	return conn, nil
 }
 
+// returnConnection returns the connection acquired by the session to the pool
+func (s *sessionWithContext) returnConnection(ctx context.Context, conn idb.Connection) error {
+	s.bytesReceived += bytesReceived(conn) - s.bytesReceivedBeforeAcquisition
+	return s.pool.Return(ctx, conn)
+}
+
+func bytesReceived(conn idb.Connection) int64 {
+	if counter, ok := conn.(idb.BytesReceivedCounter); ok {
+		return counter.BytesReceived()
+	}
+	return 0
+}
+
+// reborrowLastConnection borrows the connection previously used by the session again, as long as connection affinity
+// is enabled and the connection targets one of the specified servers.
+// nil is returned if the connection cannot be reused.
+func (s *sessionWithContext) reborrowLastConnection(ctx context.Context, servers []string, livenessCheckThreshold time.Duration) idb.Connection {
+	if !s.config.ConnectionAffinity || s.lastConn == nil {
+		return nil
+	}
+	for _, server := range servers {
+		if server == s.lastConn.ServerName() {
+			return s.pool.Reborrow(ctx, s.lastConn, s.boltLoggerFor(ctx), livenessCheckThreshold)
+		}
+	}
+	return nil
+}
+
 func (s *sessionWithContext) retrieveBookmarks(ctx context.Context, conn idb.Connection, sentBookmarks Bookmarks) error {
 	if conn == nil {
 		return nil
@@ -523,7 +877,20 @@ func (s *sessionWithContext) Run(ctx context.Context,
 
 	if s.explicitTx != nil {
 		err := &UsageError{Message: "Trying to run auto-commit transaction while in explicit transaction"}
-		s.log.Error(log.Session, s.logId, err)
+		s.logger(ctx).Error(log.Session, s.logId, err)
+		return nil, err
+	}
+	s.linter.lint(cypher)
+	if s.config.CoerceParameterStrings {
+		var err error
+		if params, err = coerceParameters(params); err != nil {
+			s.logger(ctx).Error(log.Session, s.logId, err)
+			return nil, err
+		}
+	}
+
+	if err := s.inFlight.check(1); err != nil {
+		s.logger(ctx).Error(log.Session, s.logId, err)
 		return nil, err
 	}
 
@@ -531,53 +898,103 @@ func (s *sessionWithContext) Run(ctx context.Context,
 		s.autocommitTx.done(ctx)
 	}
 
-	config := defaultTransactionConfig()
-	for _, c := range configurers {
-		c(&config)
-	}
+	config := s.transactionConfig(configurers)
 	if err := validateTransactionConfig(config); err != nil {
 		return nil, err
 	}
 
-	conn, err := s.getConnection(ctx, s.defaultMode, pool.DefaultLivenessCheckThreshold)
+	mode := s.transactionMode(config)
+	events := newTransactionEvents(s.onTxEvent, false)
+	acquisitionStart := time.Now()
+	conn, err := s.getConnection(ctx, mode, s.livenessCheckThreshold())
 	if err != nil {
-		return nil, wrapError(err)
+		err = wrapError(err)
+		events.begun(ctx, AutoCommitStarted, err)
+		return nil, err
 	}
+	connectionAcquisition := time.Since(acquisitionStart)
 
 	runBookmarks, err := s.getBookmarks(ctx)
 	if err != nil {
-		s.pool.Return(ctx, conn)
-		return nil, wrapError(err)
+		s.returnConnection(ctx, conn)
+		err = wrapError(err)
+		events.begun(ctx, AutoCommitStarted, err)
+		return nil, err
 	}
-	stream, err := conn.Run(
-		ctx,
-		idb.Command{
-			Cypher:    cypher,
-			Params:    params,
-			FetchSize: s.fetchSize,
-		},
-		idb.TxConfig{
-			Mode:             s.defaultMode,
-			Bookmarks:        runBookmarks,
-			Timeout:          config.Timeout,
-			Meta:             config.Metadata,
-			ImpersonatedUser: s.impersonatedUser,
+	txConfig := idb.TxConfig{
+		Mode:             mode,
+		Bookmarks:        runBookmarks,
+		Timeout:          s.transactionTimeout(ctx, config),
+		Meta:             config.Metadata,
+		ImpersonatedUser: s.transactionImpersonatedUser(config),
+		Notifications:    s.notifications,
+	}
+	explained := s.explainer.explain(ctx, conn, cypher, params, func(explainCypher string) (idb.StreamHandle, error) {
+		return conn.Run(ctx, idb.Command{Cypher: explainCypher, Params: params, FetchSize: s.transactionFetchSize(config)}, txConfig)
+	})
+	if !explained {
+		// resetting the connection after the failed EXPLAIN also reverts it to the default database
+		conn.Reset(ctx)
+		s.selectDatabase(conn)
+	}
+	requestStart := time.Now()
+	runOperation := &TxOperation{
+		Type:       TxRunOperation,
+		AutoCommit: true,
+		Cypher:     cypher,
+		Params:     params,
+		Metadata:   config.Metadata,
+	}
+	var stream idb.StreamHandle
+	err = txInterceptors(s.config.TransactionInterceptors).intercept(ctx, runOperation,
+		func(ctx context.Context, operation *TxOperation) error {
+			cypher, params, txConfig.Meta = operation.Cypher, operation.Params, operation.Metadata
+			var err error
+			stream, err = conn.Run(
+				ctx,
+				idb.Command{
+					Cypher:    annotateStatement(ctx, cypher, s.config.StatementAnnotator),
+					Params:    params,
+					FetchSize: s.transactionFetchSize(config),
+				},
+				txConfig)
+			return err
 		})
+	request := time.Since(requestStart)
+	s.statistics.onQuery()
+	queryLog := s.queryLogger.start(ctx, conn.ServerName(), cypher, params)
 	if err != nil {
-		s.pool.Return(ctx, conn)
-		return nil, wrapError(err)
+		s.statistics.onFailure(err)
+		s.refreshExpiredToken(ctx, conn, err)
+		s.returnConnection(ctx, conn)
+		err = wrapError(err)
+		queryLog.done(ClientDurations{ConnectionAcquisition: connectionAcquisition, Request: request}, err)
+		events.begun(ctx, AutoCommitStarted, err)
+		return nil, err
 	}
+	events.begun(ctx, AutoCommitStarted, nil)
 
+	result := newResultWithContext(conn, stream, cypher, params, func() {
+		if err := s.retrieveBookmarks(ctx, conn, runBookmarks); err != nil {
+			s.logger(ctx).Warnf(log.Session, s.logId, "could not retrieve bookmarks after result consumption: %s\n"+
+				"the result of the initiating auto-commit transaction may not be visible to subsequent operations", err.Error())
+		}
+		events.end(ctx, AutoCommitFinished, nil)
+	})
+	result.durations.ConnectionAcquisition = connectionAcquisition
+	result.durations.Request = request
+	result.statistics = s.statistics
+	result.onDeprecationNotice = s.config.OnDeprecationNotice
+	result.queryLog = queryLog
+	s.resultScope.track(result)
+	s.inFlight.track(result)
 	s.autocommitTx = &autocommitTransaction{
 		conn: conn,
-		res: newResultWithContext(conn, stream, cypher, params, func() {
-			if err := s.retrieveBookmarks(ctx, conn, runBookmarks); err != nil {
-				s.log.Warnf(log.Session, s.logId, "could not retrieve bookmarks after result consumption: %s\n"+
-					"the result of the initiating auto-commit transaction may not be visible to subsequent operations", err.Error())
-			}
-		}),
+		res:  result,
 		onClosed: func() {
-			s.pool.Return(ctx, conn)
+			// no-op when the result has been successfully consumed
+			events.end(ctx, AutoCommitFinished, result.Err())
+			s.returnConnection(ctx, conn)
 			s.autocommitTx = nil
 		},
 	}
@@ -585,6 +1002,177 @@ func (s *sessionWithContext) Run(ctx context.Context,
 	return s.autocommitTx.res, nil
 }
 
+func (s *sessionWithContext) RunBatch(ctx context.Context,
+	queries []BatchQuery, configurers ...func(*TransactionConfig)) ([]*EagerResult, error) {
+
+	if s.explicitTx != nil {
+		err := &UsageError{Message: "Trying to run auto-commit transactions while in explicit transaction"}
+		s.logger(ctx).Error(log.Session, s.logId, err)
+		return nil, err
+	}
+
+	if err := s.inFlight.check(len(queries)); err != nil {
+		s.logger(ctx).Error(log.Session, s.logId, err)
+		return nil, err
+	}
+
+	if s.autocommitTx != nil {
+		s.autocommitTx.done(ctx)
+	}
+
+	config := s.transactionConfig(configurers)
+	if err := validateTransactionConfig(config); err != nil {
+		return nil, err
+	}
+
+	// batch holds the queries as they are run, i.e. with coerced parameters and as rewritten by the interceptors
+	batch := make([]BatchQuery, len(queries))
+	for i, query := range queries {
+		s.linter.lint(query.Cypher)
+		batch[i] = query
+		if s.config.CoerceParameterStrings {
+			var err error
+			if batch[i].Params, err = coerceParameters(query.Params); err != nil {
+				s.logger(ctx).Error(log.Session, s.logId, err)
+				return nil, err
+			}
+		}
+	}
+
+	// Each query is an auto-commit transaction, the queries following a failing one are not started
+	events := make([]*transactionEvents, len(queries))
+	for i := range events {
+		events[i] = newTransactionEvents(s.onTxEvent, false)
+	}
+	failToStart := func(i int, err error) error {
+		if i < len(events) {
+			events[i].begun(ctx, AutoCommitStarted, err)
+		}
+		return err
+	}
+	mode := s.transactionMode(config)
+	acquisitionStart := time.Now()
+	conn, err := s.getConnection(ctx, mode, s.livenessCheckThreshold())
+	if err != nil {
+		return nil, failToStart(0, wrapError(err))
+	}
+	defer s.returnConnection(ctx, conn)
+	connectionAcquisition := time.Since(acquisitionStart)
+
+	runBookmarks, err := s.getBookmarks(ctx)
+	if err != nil {
+		return nil, failToStart(0, wrapError(err))
+	}
+	txConfig := idb.TxConfig{
+		Mode:             mode,
+		Bookmarks:        runBookmarks,
+		Timeout:          s.transactionTimeout(ctx, config),
+		Meta:             config.Metadata,
+		ImpersonatedUser: s.transactionImpersonatedUser(config),
+		Notifications:    s.notifications,
+	}
+	command := func(query BatchQuery) idb.Command {
+		return idb.Command{
+			Cypher:    annotateStatement(ctx, query.Cypher, s.config.StatementAnnotator),
+			Params:    query.Params,
+			FetchSize: s.transactionFetchSize(config),
+		}
+	}
+	var streams []idb.StreamHandle
+	var runErr error
+	pipeliner, ok := conn.(idb.Pipeliner)
+	if ok && len(s.config.TransactionInterceptors) == 0 {
+		commands := make([]idb.Command, len(batch))
+		for i, query := range batch {
+			commands[i] = command(query)
+		}
+		streams, runErr = pipeliner.RunPipelined(ctx, commands, txConfig)
+	} else {
+		// Each query is intercepted on its own, running a query buffers the result of the previous one
+		for i := range batch {
+			runOperation := &TxOperation{
+				Type:       TxRunOperation,
+				AutoCommit: true,
+				Cypher:     batch[i].Cypher,
+				Params:     batch[i].Params,
+				Metadata:   config.Metadata,
+			}
+			var stream idb.StreamHandle
+			err := txInterceptors(s.config.TransactionInterceptors).intercept(ctx, runOperation,
+				func(ctx context.Context, operation *TxOperation) error {
+					batch[i].Cypher, batch[i].Params = operation.Cypher, operation.Params
+					queryTxConfig := txConfig
+					queryTxConfig.Meta = operation.Metadata
+					var err error
+					stream, err = conn.Run(ctx, command(batch[i]), queryTxConfig)
+					return err
+				})
+			if err != nil {
+				runErr = err
+				break
+			}
+			streams = append(streams, stream)
+		}
+	}
+	failedToRun := runErr != nil && len(streams) < len(batch)
+	if failedToRun {
+		// The failing query did not yield any stream
+		s.statistics.onQuery()
+		s.statistics.onFailure(runErr)
+		failed := batch[len(streams)]
+		s.queryLogger.start(ctx, conn.ServerName(), failed.Cypher, failed.Params).
+			done(ClientDurations{ConnectionAcquisition: connectionAcquisition}, wrapError(runErr))
+	}
+
+	results := make([]*EagerResult, 0, len(streams))
+	for i, stream := range streams {
+		s.statistics.onQuery()
+		result := newResultWithContext(conn, stream, batch[i].Cypher, batch[i].Params, nil)
+		result.durations.ConnectionAcquisition = connectionAcquisition
+		result.statistics = s.statistics
+		result.onDeprecationNotice = s.config.OnDeprecationNotice
+		result.queryLog = s.queryLogger.start(ctx, conn.ServerName(), batch[i].Cypher, batch[i].Params)
+		events[i].begun(ctx, AutoCommitStarted, nil)
+		eagerResult, err := collectEagerResult(ctx, result)
+		events[i].end(ctx, AutoCommitFinished, err)
+		if err != nil {
+			runErr = err
+			failedToRun = false
+			break
+		}
+		results = append(results, eagerResult)
+	}
+	if failedToRun {
+		failToStart(len(streams), wrapError(runErr))
+	}
+	if len(results) > 0 {
+		if err := s.retrieveBookmarks(ctx, conn, runBookmarks); err != nil {
+			s.logger(ctx).Warnf(log.Session, s.logId, "could not retrieve bookmarks after batch execution: %s\n"+
+				"the result of the batch may not be visible to subsequent operations", err.Error())
+		}
+	}
+	if runErr != nil {
+		return results, wrapError(runErr)
+	}
+	return results, nil
+}
+
+func collectEagerResult(ctx context.Context, result ResultWithContext) (*EagerResult, error) {
+	records, err := result.Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := result.Keys()
+	if err != nil {
+		return nil, err
+	}
+	summary, err := result.Consume(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &EagerResult{Keys: keys, Records: records, Summary: summary}, nil
+}
+
 func (s *sessionWithContext) Close(ctx context.Context) error {
 	var txErr error
 	if s.explicitTx != nil {
@@ -594,17 +1182,47 @@ func (s *sessionWithContext) Close(ctx context.Context) error {
 	if s.autocommitTx != nil {
 		s.autocommitTx.discard(ctx)
 	}
+	s.resultScope.close()
 
-	defer s.log.Debugf(log.Session, s.logId, "Closed")
+	defer s.logger(ctx).Debugf(log.Session, s.logId, "Closed")
+	cleanUpCtx := ctx
+	if timeout := s.config.SessionCloseTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		cleanUpCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 	poolErrChan := make(chan error, 1)
 	routerErrChan := make(chan error, 1)
 	go func() {
-		poolErrChan <- s.pool.CleanUp(ctx)
+		poolErrChan <- s.pool.CleanUp(cleanUpCtx)
 	}()
 	go func() {
-		routerErrChan <- s.router.CleanUp(ctx)
+		routerErrChan <- s.router.CleanUp(cleanUpCtx)
 	}()
-	return errorutil.CombineAllErrors(txErr, <-poolErrChan, <-routerErrChan)
+	return errorutil.CombineAllErrors(txErr,
+		s.awaitCleanUp(ctx, cleanUpCtx, "connection pool", poolErrChan),
+		s.awaitCleanUp(ctx, cleanUpCtx, "router", routerErrChan))
+}
+
+// awaitCleanUp waits for the clean up of the given component, unless the clean up context is done first.
+// The clean up is only reported as timed out when the caller's context is not done itself.
+func (s *sessionWithContext) awaitCleanUp(ctx, cleanUpCtx context.Context, component string, errChan <-chan error) error {
+	select {
+	case err := <-errChan:
+		return err
+	case <-cleanUpCtx.Done():
+		// the clean up may have completed concurrently
+		select {
+		case err := <-errChan:
+			return err
+		default:
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.logger(ctx).Warnf(log.Session, s.logId, "Clean up of the %s timed out", component)
+		return &SessionCloseTimeoutError{Component: component, Timeout: s.config.SessionCloseTimeout}
+	}
 }
 
 func (s *sessionWithContext) legacy() Session {
@@ -612,6 +1230,9 @@ func (s *sessionWithContext) legacy() Session {
 }
 
 func (s *sessionWithContext) getServerInfo(ctx context.Context) (ServerInfo, error) {
+	if s.auth != nil {
+		ctx = idb.WithSessionAuth(ctx, s.auth)
+	}
 	if err := s.resolveHomeDatabase(ctx); err != nil {
 		return nil, wrapError(err)
 	}
@@ -619,15 +1240,22 @@ func (s *sessionWithContext) getServerInfo(ctx context.Context) (ServerInfo, err
 	if err != nil {
 		return nil, wrapError(err)
 	}
-	conn, err := s.pool.Borrow(ctx, servers, s.config.ConnectionAcquisitionTimeout != 0, s.boltLogger, 0)
+	conn, err := s.pool.Borrow(ctx, servers, s.config.ConnectionAcquisitionTimeout != 0, s.boltLoggerFor(ctx), 0)
 	if err != nil {
 		return nil, wrapError(err)
 	}
 	defer s.pool.Return(ctx, conn)
+	var clockSkew time.Duration
+	if s.config.EstimateServerClockSkew {
+		if clockSkew, err = estimateServerClockSkew(ctx, conn, time.Now); err != nil {
+			s.logger(ctx).Warnf(log.Session, s.logId, "Could not estimate the clock skew of %s: %s", conn.ServerName(), err)
+		}
+	}
 	return &simpleServerInfo{
 		address:         conn.ServerName(),
 		agent:           conn.ServerVersion(),
 		protocolVersion: conn.Version(),
+		clockSkew:       clockSkew,
 	}, nil
 }
 
@@ -640,11 +1268,11 @@ func (s *sessionWithContext) resolveHomeDatabase(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	defaultDb, err := s.router.GetNameOfDefaultDatabase(ctx, bookmarks, s.impersonatedUser, s.boltLogger)
+	defaultDb, err := s.router.GetNameOfDefaultDatabase(ctx, bookmarks, s.impersonatedUser, s.boltLoggerFor(ctx))
 	if err != nil {
 		return err
 	}
-	s.log.Debugf(log.Session, s.logId, "Resolved home database, uses db '%s'", defaultDb)
+	s.logger(ctx).Debugf(log.Session, s.logId, "Resolved home database, uses db '%s'", defaultDb)
 	s.databaseName = defaultDb
 	s.resolveHomeDb = false
 	return nil
@@ -668,6 +1296,14 @@ func (s *erroredSessionWithContext) LastBookmarks() Bookmarks {
 	return nil
 }
 
+func (s *erroredSessionWithContext) SetBookmarks(context.Context, Bookmarks) error {
+	return s.err
+}
+
+func (s *erroredSessionWithContext) Stats() SessionStats {
+	return SessionStats{}
+}
+
 func (s *erroredSessionWithContext) lastBookmark() string {
 	return ""
 }
@@ -683,6 +1319,10 @@ func (s *erroredSessionWithContext) ExecuteWrite(context.Context, ManagedTransac
 func (s *erroredSessionWithContext) Run(context.Context, string, map[string]any, ...func(*TransactionConfig)) (ResultWithContext, error) {
 	return nil, s.err
 }
+func (s *erroredSessionWithContext) RunBatch(context.Context, []BatchQuery, ...func(*TransactionConfig)) ([]*EagerResult, error) {
+	return nil, s.err
+}
+
 func (s *erroredSessionWithContext) Close(context.Context) error {
 	return s.err
 }
@@ -693,14 +1333,134 @@ func (s *erroredSessionWithContext) getServerInfo(context.Context) (ServerInfo,
 	return nil, s.err
 }
 
+// validateSessionConfig reports all the invalid settings of the session configuration at once
+func validateSessionConfig(config SessionConfig) error {
+	var problems []string
+	if config.AccessMode != AccessModeWrite && config.AccessMode != AccessModeRead {
+		problems = append(problems, fmt.Sprintf("access mode must be AccessModeWrite or AccessModeRead, got %d", config.AccessMode))
+	}
+	if config.FetchSize < 0 && config.FetchSize != FetchAll {
+		problems = append(problems, fmt.Sprintf("fetch size must be positive, FetchDefault or FetchAll, got %d", config.FetchSize))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return &UsageError{Message: fmt.Sprintf("Invalid session configuration: %s", strings.Join(problems, "; "))}
+}
+
 func defaultTransactionConfig() TransactionConfig {
 	return TransactionConfig{Timeout: math.MinInt, Metadata: nil}
 }
 
+// transactionConfig applies the default configuration functions of the driver (Config.DefaultTransactionConfigurers),
+// then the ones of the session (SessionConfig.DefaultTransactionConfigurers) and finally the given ones
+func (s *sessionWithContext) transactionConfig(configurers []func(*TransactionConfig)) TransactionConfig {
+	config := defaultTransactionConfig()
+	for _, c := range s.config.DefaultTransactionConfigurers {
+		c(&config)
+	}
+	for _, c := range s.defaultTxConfigurers {
+		c(&config)
+	}
+	for _, c := range configurers {
+		c(&config)
+	}
+	return config
+}
+
+// transactionTimeout returns the timeout of the transaction about to begin.
+// Timeouts configured with WithTxTimeout or WithTimeoutFromContext take precedence over
+// Config.TransactionTimeoutProvider.
+func (s *sessionWithContext) transactionTimeout(ctx context.Context, config TransactionConfig) time.Duration {
+	if config.timeoutFromContext != nil {
+		return config.timeoutFromContext(ctx)
+	}
+	if config.Timeout != math.MinInt || s.config.TransactionTimeoutProvider == nil {
+		return config.Timeout
+	}
+	if timeout := s.config.TransactionTimeoutProvider(ctx); timeout >= 0 {
+		return timeout
+	}
+	return math.MinInt
+}
+
+// livenessCheckThreshold returns how long connections can stay idle in the pool before being checked on borrow, see
+// Config.TestConnectionOnBorrow
+func (s *sessionWithContext) livenessCheckThreshold() time.Duration {
+	if s.config.TestConnectionOnBorrow {
+		return 0
+	}
+	return pool.DefaultLivenessCheckThreshold
+}
+
 func validateTransactionConfig(config TransactionConfig) error {
 	if config.Timeout != math.MinInt && config.Timeout < 0 {
 		err := fmt.Sprintf("Negative transaction timeouts are not allowed. Given: %d", config.Timeout)
 		return &UsageError{Message: err}
 	}
+	if config.accessMode != nil && *config.accessMode != AccessModeWrite && *config.accessMode != AccessModeRead {
+		err := fmt.Sprintf("Transaction access mode must be AccessModeWrite or AccessModeRead, got %d", *config.accessMode)
+		return &UsageError{Message: err}
+	}
+	if config.fetchSize != nil && *config.fetchSize < 0 && *config.fetchSize != FetchAll {
+		err := fmt.Sprintf("Transaction fetch size must be positive, FetchDefault or FetchAll, got %d", *config.fetchSize)
+		return &UsageError{Message: err}
+	}
+	if config.metadataErr != nil {
+		return config.metadataErr
+	}
 	return nil
 }
+
+// logger returns the session logger, including the log fields attached to the given context (see log.WithFields)
+func (s *sessionWithContext) logger(ctx context.Context) log.Logger {
+	return log.ForContext(ctx, s.log)
+}
+
+// boltLoggerFor returns the session Bolt logger, including the log fields attached to the given context
+// (see log.WithFields)
+func (s *sessionWithContext) boltLoggerFor(ctx context.Context) log.BoltLogger {
+	return log.BoltForContext(ctx, s.boltLogger)
+}
+
+// explainTxConfig returns the configuration of the auto-commit EXPLAIN queries of a committed transaction, see
+// queryExplainer.explainDeferred
+func (s *sessionWithContext) explainTxConfig(mode idb.AccessMode, config TransactionConfig) idb.TxConfig {
+	return idb.TxConfig{
+		Mode:             mode,
+		ImpersonatedUser: s.transactionImpersonatedUser(config),
+		Notifications:    s.notifications,
+	}
+}
+
+// selectDatabase selects the session database again on a connection that has been reset
+func (s *sessionWithContext) selectDatabase(conn idb.Connection) {
+	if dbSelector, ok := conn.(idb.DatabaseSelector); ok && s.databaseName != idb.DefaultDatabase {
+		dbSelector.SelectDatabase(s.databaseName)
+	}
+}
+
+// transactionImpersonatedUser returns the user impersonated by WithTxImpersonatedUser, if any, or the session
+// impersonated user otherwise
+func (s *sessionWithContext) transactionImpersonatedUser(config TransactionConfig) string {
+	if config.impersonatedUser != nil {
+		return *config.impersonatedUser
+	}
+	return s.impersonatedUser
+}
+
+// transactionFetchSize returns the fetch size overridden by WithTxFetchSize, if any, or the session fetch size otherwise
+func (s *sessionWithContext) transactionFetchSize(config TransactionConfig) int {
+	if config.fetchSize != nil {
+		return *config.fetchSize
+	}
+	return s.fetchSize
+}
+
+// transactionMode returns the access mode overridden by WithTxAccessMode, if any, or the session default otherwise
+func (s *sessionWithContext) transactionMode(config TransactionConfig) idb.AccessMode {
+	if config.accessMode != nil {
+		return idb.AccessMode(*config.accessMode)
+	}
+	return s.defaultMode
+}
@@ -23,15 +23,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/auth"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"io"
+	"math"
 	"reflect"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/retry"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/router"
 	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 )
@@ -106,6 +111,200 @@ func TestSession(outer *testing.T) {
 			assertCleanSessionState(t, sess)
 		})
 
+		inner.Run("Retries with a fresh token once expired", func(t *testing.T) {
+			_, pool, sess := createSession()
+			fetched := 0
+			manager := auth.ExpirationBasedTokenManager(func(context.Context) (auth.Token, *time.Time, error) {
+				fetched++
+				return BearerAuth(fmt.Sprintf("token-%d", fetched)), nil, nil
+			})
+			sess.authManager = manager
+			token, _ := manager.GetAuthToken(context.Background())
+			pool.BorrowConn = &ConnFake{Alive: true, Auth: token.(AuthToken).tokens}
+			attempts := 0
+
+			_, err := sess.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (any, error) {
+				attempts++
+				if attempts == 1 {
+					return nil, tokenExpiredErr
+				}
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertIntEqual(t, attempts, 2)
+			fresh, _ := manager.GetAuthToken(context.Background())
+			AssertDeepEquals(t, fresh, BearerAuth("token-2"))
+		})
+
+		inner.Run("Does not retry when the token is static", func(t *testing.T) {
+			_, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true}
+			sess.authManager = BearerAuth("static")
+			attempts := 0
+
+			_, err := sess.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (any, error) {
+				attempts++
+				return nil, tokenExpiredErr
+			})
+
+			assertTokenExpiredError(t, err)
+			AssertIntEqual(t, attempts, 1)
+		})
+
+		inner.Run("Borrows connections with the session token", func(t *testing.T) {
+			token := BasicAuth("tenant", "secret", "")
+			_, pool, sess := createSessionFromConfig(SessionConfig{Auth: &token})
+			pool.BorrowConn = &ConnFake{Alive: true}
+
+			_, err := sess.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (any, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			auth, found := idb.SessionAuthFrom(pool.BorrowCtx)
+			AssertTrue(t, found)
+			AssertDeepEquals(t, auth, token.tokens)
+		})
+
+		inner.Run("Idempotency key is shared by all attempts", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+			metadata := map[string]any{"app": "payments"}
+			transientErr := &db.Neo4jError{Code: "Neo.TransientError.General.MemoryPoolOutOfMemoryError"}
+			var keys []string
+
+			_, err := sess.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (any, error) {
+				keys = append(keys, TxIdempotencyKey(tx))
+				if len(keys) == 1 {
+					return nil, transientErr
+				}
+				return nil, nil
+			}, WithTxMetadata(metadata), WithTxIdempotencyKey("key-42"))
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, keys, []string{"key-42", "key-42"})
+			AssertLen(t, conn.RecordedTxs, 2)
+			for _, rtx := range conn.RecordedTxs {
+				AssertDeepEquals(t, rtx.Meta, map[string]any{"app": "payments", IdempotencyKeyMetadataKey: "key-42"})
+			}
+			AssertDeepEquals(t, metadata, map[string]any{"app": "payments"})
+		})
+
+		inner.Run("Idempotency key is exposed through transaction helpers", func(t *testing.T) {
+			_, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true}
+			var key string
+
+			_, _, err := ExecuteWriteWithSummary[any](context.Background(), sess, func(tx ManagedTransaction) (any, error) {
+				key = TxIdempotencyKey(tx)
+				return nil, nil
+			}, WithTxIdempotencyKey("key-42"))
+
+			AssertNoError(t, err)
+			AssertStringEqual(t, key, "key-42")
+		})
+
+		inner.Run("Idempotency key is empty by default", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.ExecuteRead(context.Background(), func(tx ManagedTransaction) (any, error) {
+				AssertStringEqual(t, TxIdempotencyKey(tx), "")
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertLen(t, conn.RecordedTxs[0].Meta, 0)
+		})
+
+		inner.Run("Rejects access mode override", func(t *testing.T) {
+			_, _, sess := createSession()
+
+			_, err := sess.ExecuteWrite(context.Background(), func(ManagedTransaction) (any, error) {
+				t.Errorf("should not execute work with access mode override")
+				return nil, nil
+			}, WithTxAccessMode(AccessModeRead))
+
+			AssertSameType(t, err, &UsageError{})
+		})
+
+		inner.Run("Overrides impersonated user", func(t *testing.T) {
+			_, pool, sess := createSessionFromConfig(SessionConfig{ImpersonatedUser: "me", DatabaseName: "tenants"})
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+			work := func(ManagedTransaction) (any, error) { return nil, nil }
+
+			_, err := sess.ExecuteWrite(context.Background(), work, WithTxImpersonatedUser("tenant"))
+			AssertNoError(t, err)
+			_, err = sess.ExecuteWrite(context.Background(), work)
+			AssertNoError(t, err)
+
+			AssertLen(t, conn.RecordedTxs, 2)
+			AssertStringEqual(t, conn.RecordedTxs[0].ImpersonatedUser, "tenant")
+			AssertStringEqual(t, conn.RecordedTxs[1].ImpersonatedUser, "me")
+		})
+
+		inner.Run("Falls back to writers when no reader is available", func(t *testing.T) {
+			routerFake, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true}
+			routerFake.ReadersHook = func(func(context.Context) ([]string, error), string) ([]string, error) {
+				return nil, fmt.Errorf("unable to route: %w", router.ErrNoReaders)
+			}
+			routerFake.WritersRet = []string{"writer"}
+			routerFake.WritersHook = func(func(context.Context) ([]string, error), string) ([]string, error) {
+				return routerFake.WritersRet, nil
+			}
+
+			result, err := sess.ExecuteRead(context.Background(), func(ManagedTransaction) (any, error) {
+				return 42, nil
+			}, withWritersFallback())
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, result, 42)
+		})
+
+		inner.Run("Does not fall back to writers by default", func(t *testing.T) {
+			routerFake, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true}
+			routerFake.ReadersHook = func(func(context.Context) ([]string, error), string) ([]string, error) {
+				return nil, fmt.Errorf("unable to route: %w", router.ErrNoReaders)
+			}
+			routerFake.WritersHook = func(func(context.Context) ([]string, error), string) ([]string, error) {
+				t.Errorf("should not route to writers")
+				return nil, nil
+			}
+
+			_, err := sess.ExecuteRead(context.Background(), func(ManagedTransaction) (any, error) {
+				t.Errorf("should not execute work without readers")
+				return nil, nil
+			})
+
+			AssertTrue(t, IsTransactionExecutionLimit(err))
+			errL := err.(*TransactionExecutionLimit)
+			AssertTrue(t, errors.Is(errL.Errors[len(errL.Errors)-1], router.ErrNoReaders))
+		})
+
+		inner.Run("Retries with configured clock", func(t *testing.T) {
+			fakeClock := clock.NewFake(time.Now())
+			conf := Config{MaxTransactionRetryTime: time.Hour, Clock: fakeClock}
+			pool := &PoolFake{BorrowConn: &ConnFake{Alive: true}}
+			sess := newSessionWithContext(&conf, SessionConfig{}, &RouterFake{}, pool, logger)
+			transientErr := &db.Neo4jError{Code: "Neo.TransientError.General.MemoryPoolOutOfMemoryError"}
+
+			_, err := sess.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (any, error) {
+				return nil, transientErr
+			})
+
+			AssertTrue(t, IsTransactionExecutionLimit(err))
+			slept := fakeClock.Slept()
+			AssertTrue(t, len(slept) > 1)
+			AssertTrue(t, slept[1] > slept[0])
+		})
+
 		// Checks that session is in clean state after connection fails to rollback.
 		// "User" initiates rollback by letting the transaction function return a custom error.
 		inner.Run("Failed rollback", func(t *testing.T) {
@@ -138,8 +337,8 @@ func TestSession(outer *testing.T) {
 				t.Error("Should not retry on commit error")
 			}
 			// Should not be a TransactionExecutionLimitError here
-			AssertTrue(t, IsConnectivityError(err))
-			AssertSameType(t, err.(*ConnectivityError).inner, &retry.CommitFailedDeadError{})
+			AssertTrue(t, IsCommitAmbiguousError(err))
+			AssertSameType(t, err.(*CommitAmbiguousError).inner, &retry.CommitFailedDeadError{})
 			assertCleanSessionState(t, sess)
 		})
 
@@ -228,6 +427,46 @@ func TestSession(outer *testing.T) {
 			_, _, sess := createSession()
 			AssertLen(t, sess.LastBookmarks(), 0)
 		})
+
+		inner.Run("SetBookmarks replaces the bookmarks used by the next transactions", func(t *testing.T) {
+			_, pool, sess := createSessionWithBookmarks(BookmarksFromRawValues("b1"))
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			err := sess.SetBookmarks(context.Background(), BookmarksFromRawValues("", "b2", "b3"))
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, sess.LastBookmarks(), BookmarksFromRawValues("b2", "b3"))
+			_, err = sess.Run(context.Background(), "cypher", nil)
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertEqualsInAnyOrder(t, conn.RecordedTxs[0].Bookmarks, BookmarksFromRawValues("b2", "b3"))
+		})
+
+		inner.Run("SetBookmarks is not overridden by a pending auto-commit", func(t *testing.T) {
+			_, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true, Bookm: "autocommit"}
+			_, err := sess.Run(context.Background(), "cypher", nil)
+			AssertNoError(t, err)
+
+			err = sess.SetBookmarks(context.Background(), BookmarksFromRawValues("external"))
+
+			AssertNoError(t, err)
+			AssertNil(t, sess.autocommitTx)
+			AssertDeepEquals(t, sess.LastBookmarks(), BookmarksFromRawValues("external"))
+		})
+
+		inner.Run("SetBookmarks fails while in explicit transaction", func(t *testing.T) {
+			_, pool, sess := createSessionWithBookmarks(BookmarksFromRawValues("b1"))
+			pool.BorrowConn = &ConnFake{Alive: true}
+			_, err := sess.BeginTransaction(context.Background())
+			AssertNoError(t, err)
+
+			err = sess.SetBookmarks(context.Background(), BookmarksFromRawValues("b2"))
+
+			AssertSameType(t, err, &UsageError{})
+			AssertDeepEquals(t, sess.LastBookmarks(), BookmarksFromRawValues("b1"))
+		})
 	})
 
 	outer.Run("Run", func(inner *testing.T) {
@@ -264,6 +503,78 @@ func TestSession(outer *testing.T) {
 			AssertDeepEquals(t, BookmarksToRawValues(sess.LastBookmarks()), []string{"consume-1"})
 		})
 
+		inner.Run("Overrides impersonated user", func(t *testing.T) {
+			_, pool, sess := createSessionFromConfig(SessionConfig{DatabaseName: "tenants"})
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.Run(context.Background(), "cypher", nil, WithTxImpersonatedUser("tenant"))
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertStringEqual(t, conn.RecordedTxs[0].ImpersonatedUser, "tenant")
+		})
+
+		inner.Run("Applies default transaction configuration", func(t *testing.T) {
+			_, pool, sess := createSessionFromConfig(SessionConfig{
+				DefaultTransactionConfigurers: []func(*TransactionConfig){
+					WithTxMetadata(map[string]any{"app": "reporting"}),
+				},
+			})
+			sess.config.DefaultTransactionConfigurers = []func(*TransactionConfig){
+				WithTxTimeout(30 * time.Second),
+				WithTxMetadata(map[string]any{"app": "default"}),
+			}
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.Run(context.Background(), "cypher", nil)
+			AssertNoError(t, err)
+			_, err = sess.Run(context.Background(), "cypher", nil, WithTxTimeout(time.Second))
+			AssertNoError(t, err)
+
+			AssertLen(t, conn.RecordedTxs, 2)
+			AssertDeepEquals(t, conn.RecordedTxs[0].Timeout, 30*time.Second)
+			AssertDeepEquals(t, conn.RecordedTxs[0].Meta, map[string]any{"app": "reporting"})
+			AssertDeepEquals(t, conn.RecordedTxs[1].Timeout, time.Second)
+			AssertDeepEquals(t, conn.RecordedTxs[1].Meta, map[string]any{"app": "reporting"})
+		})
+
+		inner.Run("Overrides fetch size", func(t *testing.T) {
+			_, pool, sess := createSessionFromConfig(SessionConfig{FetchSize: 10})
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.Run(context.Background(), "cypher", nil, WithTxFetchSize(FetchAll))
+			AssertNoError(t, err)
+			_, err = sess.Run(context.Background(), "cypher", nil)
+			AssertNoError(t, err)
+
+			AssertLen(t, conn.RecordedTxs, 2)
+			AssertIntEqual(t, conn.RecordedTxs[0].FetchSize, FetchAll)
+			AssertIntEqual(t, conn.RecordedTxs[1].FetchSize, 10)
+		})
+
+		inner.Run("Rejects negative fetch size", func(t *testing.T) {
+			_, pool, sess := createSession()
+
+			_, err := sess.Run(context.Background(), "cypher", nil, WithTxFetchSize(-2))
+
+			AssertSameType(t, err, &UsageError{})
+			AssertNil(t, pool.BorrowCtx)
+		})
+
+		inner.Run("Coerces parameter strings", func(t *testing.T) {
+			_, pool, sess := createSession()
+			sess.config.CoerceParameterStrings = true
+			pool.BorrowConn = &ConnFake{Alive: true}
+
+			result, err := sess.Run(context.Background(), "cypher", map[string]any{"timeout": "PT1M"})
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, result.(*resultWithContext).params, map[string]any{"timeout": Duration{Seconds: 60}})
+		})
+
 		inner.Run("Pending and invoke tx function", func(t *testing.T) {
 			// Checks that a pending Run (not consumed or iterated) gets buffered and it's
 			// bookmark is used when starting a transaction.
@@ -494,6 +805,63 @@ func TestSession(outer *testing.T) {
 			AssertNoError(t, err)
 		})
 
+		inner.Run("Overrides access mode", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			tx, err := sess.BeginTransaction(context.Background(), WithTxAccessMode(AccessModeWrite))
+			AssertNoError(t, err)
+			AssertNoError(t, tx.Commit(context.Background()))
+			tx, err = sess.BeginTransaction(context.Background())
+			AssertNoError(t, err)
+			AssertNoError(t, tx.Commit(context.Background()))
+
+			AssertLen(t, conn.RecordedTxs, 2)
+			AssertIntEqual(t, int(conn.RecordedTxs[0].Mode), int(idb.WriteMode))
+			AssertIntEqual(t, int(conn.RecordedTxs[1].Mode), int(idb.ReadMode))
+		})
+
+		inner.Run("Overrides impersonated user", func(t *testing.T) {
+			_, pool, sess := createSessionFromConfig(SessionConfig{ImpersonatedUser: "me", DatabaseName: "tenants"})
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			tx, err := sess.BeginTransaction(context.Background(), WithTxImpersonatedUser(""))
+			AssertNoError(t, err)
+			AssertNoError(t, tx.Commit(context.Background()))
+
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertStringEqual(t, conn.RecordedTxs[0].ImpersonatedUser, "")
+		})
+
+		inner.Run("Overrides fetch size", func(t *testing.T) {
+			_, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true}
+
+			tx, err := sess.BeginTransaction(context.Background(), WithTxFetchSize(FetchAll))
+			AssertNoError(t, err)
+
+			AssertIntEqual(t, tx.(*explicitTransaction).fetchSize, FetchAll)
+			AssertNoError(t, tx.Commit(context.Background()))
+		})
+
+		inner.Run("Rejects negative fetch size", func(t *testing.T) {
+			_, _, sess := createSession()
+
+			_, err := sess.BeginTransaction(context.Background(), WithTxFetchSize(-2))
+
+			AssertSameType(t, err, &UsageError{})
+		})
+
+		inner.Run("Rejects invalid access mode", func(t *testing.T) {
+			_, _, sess := createSession()
+
+			_, err := sess.BeginTransaction(context.Background(), WithTxAccessMode(AccessMode(42)))
+
+			AssertSameType(t, err, &UsageError{})
+		})
+
 		inner.Run("Retrieves default database name for impersonated user", func(t *testing.T) {
 			sessConfig := SessionConfig{ImpersonatedUser: "me"}
 			router, pool, sess := createSessionFromConfig(sessConfig)
@@ -553,6 +921,42 @@ func TestSession(outer *testing.T) {
 			assertTokenExpiredError(t, err)
 		})
 
+		inner.Run("Connection lost during commit", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true, TxCommitErr: io.EOF}
+			conn.TxCommitHook = func() {
+				conn.Alive = false
+			}
+			pool.BorrowConn = conn
+
+			tx, err := sess.BeginTransaction(context.Background())
+			AssertNil(t, err)
+			err = tx.Commit(context.Background())
+
+			AssertTrue(t, IsCommitAmbiguousError(err))
+			AssertTrue(t, errors.Is(err, io.EOF))
+			AssertFalse(t, IsRetryable(err))
+		})
+
+		inner.Run("Connection liveness read before the connection is returned", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true, TxCommitErr: io.EOF}
+			conn.TxCommitHook = func() {
+				conn.Alive = false
+			}
+			pool.BorrowConn = conn
+			pool.ReturnHook = func() {
+				// the pool may hand the connection over to another session, which can change its state
+				conn.Alive = true
+			}
+
+			tx, err := sess.BeginTransaction(context.Background())
+			AssertNil(t, err)
+			err = tx.Commit(context.Background())
+
+			AssertTrue(t, IsCommitAmbiguousError(err))
+		})
+
 		inner.Run("Token expiration after transaction rollback", func(t *testing.T) {
 			_, pool, sess := createSession()
 			conn := &ConnFake{Alive: true, TxRollbackErr: tokenExpiredErr}
@@ -568,42 +972,954 @@ func TestSession(outer *testing.T) {
 		})
 	})
 
-	outer.Run("GetServerInfo", func(inner *testing.T) {
-
-		inner.Run("Retrieves info from first borrowed connection", func(t *testing.T) {
-			borrowCalled := false
-			ctx := context.Background()
-			_, pool, session := createSession()
-			defer session.Close(ctx)
-			pool.BorrowHook = func() (idb.Connection, error) {
-				if borrowCalled {
-					inner.Errorf("expected only 1 call to borrow, got more")
-				}
-				result := &ConnFake{
-					Name: "home",
-					ConnectionVersion: db.ProtocolVersion{
-						Major: 5,
-						Minor: 0,
-					},
-					Alive:              true,
-					DatabaseName:       "neo4j",
-					ServerVersionValue: "smith",
-				}
-				borrowCalled = true
-				return result, nil
-			}
+	outer.Run("Query plans", func(inner *testing.T) {
+		ctx := context.Background()
 
-			info, err := session.getServerInfo(ctx)
+		inner.Run("explains auto-commit queries before running them", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true, ConsumeSum: &db.Summary{}}
+			pool.BorrowConn = conn
+			var notices []QueryPlanNotice
+			sess.explainer = newQueryExplainer(func(notice QueryPlanNotice) {
+				notices = append(notices, notice)
+			})
 
+			_, err := sess.Run(ctx, "RETURN 1", nil)
 			AssertNoError(t, err)
-			AssertDeepEquals(t, info.ProtocolVersion().Major, 5)
-			AssertDeepEquals(t, info.ProtocolVersion().Minor, 0)
-			AssertDeepEquals(t, info.Agent(), "smith")
-			AssertDeepEquals(t, info.Address(), "home")
+			_, err = sess.Run(ctx, "RETURN 2", nil)
+			AssertNoError(t, err)
+
+			AssertLen(t, notices, 1)
+			AssertLen(t, conn.RecordedTxs, 3)
 		})
 
-		inner.Run("Fails if home DB resolution fails", func(t *testing.T) {
-			ctx := context.Background()
+		inner.Run("runs queries failing to be explained", func(t *testing.T) {
+			_, pool, sess := createSession()
+			explainErr := &db.Neo4jError{Code: "Neo.ClientError.Statement.NotSupported"}
+			conn := &ConnFake{Alive: true, ConsumeErr: explainErr}
+			pool.BorrowConn = conn
+			var notices []QueryPlanNotice
+			sess.explainer = newQueryExplainer(func(notice QueryPlanNotice) {
+				notices = append(notices, notice)
+			})
+
+			_, err := sess.Run(ctx, "RETURN 1", nil)
+
+			AssertNoError(t, err)
+			AssertLen(t, notices, 1)
+			AssertDeepEquals(t, notices[0].Err, explainErr)
+			AssertLen(t, conn.RecordedTxs, 2)
+		})
+
+		inner.Run("explains queries of explicit transactions once committed", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true, ConsumeSum: &db.Summary{}}
+			pool.BorrowConn = conn
+			var notices []QueryPlanNotice
+			sess.explainer = newQueryExplainer(func(notice QueryPlanNotice) {
+				notices = append(notices, notice)
+			})
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+
+			_, err = tx.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			AssertLen(t, notices, 0)
+			AssertNoError(t, tx.Commit(ctx))
+
+			AssertLen(t, notices, 1)
+			AssertLen(t, conn.RecordedTxs, 2)
+			AssertStringEqual(t, conn.RecordedTxs[1].Origin, "Run")
+		})
+
+		inner.Run("does not explain queries of rolled back transactions", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true, ConsumeSum: &db.Summary{}}
+			pool.BorrowConn = conn
+			sess.explainer = newQueryExplainer(func(QueryPlanNotice) {
+				t.Errorf("should not explain queries of rolled back transactions")
+			})
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+
+			_, err = tx.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			AssertNoError(t, tx.Rollback(ctx))
+
+			AssertLen(t, conn.RecordedTxs, 1)
+		})
+
+		inner.Run("explains queries of managed transactions once committed", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true, ConsumeSum: &db.Summary{}}
+			pool.BorrowConn = conn
+			var notices []QueryPlanNotice
+			sess.explainer = newQueryExplainer(func(notice QueryPlanNotice) {
+				notices = append(notices, notice)
+			})
+
+			_, err := sess.ExecuteWrite(ctx, func(tx ManagedTransaction) (any, error) {
+				_, err := tx.Run(ctx, "RETURN 1", nil)
+				AssertLen(t, notices, 0)
+				return nil, err
+			})
+
+			AssertNoError(t, err)
+			AssertLen(t, notices, 1)
+		})
+	})
+
+	outer.Run("Run batch", func(inner *testing.T) {
+		ctx := context.Background()
+
+		inner.Run("returns eager results", func(t *testing.T) {
+			_, pool, sess := createSession()
+			summary := &db.Summary{Bookmark: "bm"}
+			conn := &ConnFake{
+				Alive:      true,
+				Bookm:      "bm",
+				KeysRet:    []string{"n"},
+				Nexts:      []Next{{Record: &db.Record{Keys: []string{"n"}, Values: []any{int64(1)}}}, {Summary: summary}},
+				ConsumeSum: summary,
+			}
+			pool.BorrowConn = conn
+			returned := false
+			pool.ReturnHook = func() {
+				returned = true
+			}
+
+			results, err := sess.RunBatch(ctx, []BatchQuery{{Cypher: "RETURN 1 AS n"}})
+
+			AssertNoError(t, err)
+			AssertLen(t, results, 1)
+			AssertDeepEquals(t, results[0].Keys, []string{"n"})
+			AssertLen(t, results[0].Records, 1)
+			AssertStringEqual(t, results[0].Summary.Query().Text(), "RETURN 1 AS n")
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertTrue(t, returned)
+			AssertDeepEquals(t, sess.LastBookmarks(), Bookmarks{"bm"})
+		})
+
+		inner.Run("stops at first failure", func(t *testing.T) {
+			_, pool, sess := createSession()
+			runErr := &db.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError"}
+			conn := &ConnFake{Alive: true, RunErr: runErr}
+			pool.BorrowConn = conn
+
+			results, err := sess.RunBatch(ctx, []BatchQuery{{Cypher: "RETURN"}, {Cypher: "RETURN 2"}})
+
+			AssertLen(t, results, 0)
+			AssertDeepEquals(t, err, runErr)
+			AssertLen(t, conn.RecordedTxs, 1)
+		})
+
+		inner.Run("fails within explicit transaction", func(t *testing.T) {
+			_, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true}
+			_, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+
+			_, err = sess.RunBatch(ctx, []BatchQuery{{Cypher: "RETURN 1"}})
+
+			AssertSameType(t, err, &UsageError{})
+		})
+	})
+
+	outer.Run("Connection acquisition timeout diagnostics", func(inner *testing.T) {
+		newSession := func(logger log.Logger) (*RouterFake, *sessionWithContext) {
+			conf := Config{ConnectionAcquisitionTimeout: 10 * time.Millisecond}
+			router := RouterFake{}
+			pool := PoolFake{BorrowErr: errors.New("pool is busy")}
+			sess := newSessionWithContext(&conf, SessionConfig{}, &router, &pool, logger)
+			return &router, sess
+		}
+
+		inner.Run("logs time breakdown when acquisition times out", func(t *testing.T) {
+			logger := &warningRecorder{}
+			router, sess := newSession(logger)
+			router.WritersHook = func(func(context.Context) ([]string, error), string) ([]string, error) {
+				time.Sleep(20 * time.Millisecond)
+				return []string{"server"}, nil
+			}
+
+			_, err := sess.getConnection(context.Background(), idb.WriteMode, 0)
+
+			AssertNotNil(t, err)
+			AssertLen(t, logger.warnings, 1)
+			AssertStringContain(t, logger.warnings[0], "connection acquisition timed out after")
+			AssertStringContain(t, logger.warnings[0], "routing: ")
+			AssertStringContain(t, logger.warnings[0], "pool wait: ")
+		})
+
+		inner.Run("includes context log fields", func(t *testing.T) {
+			logger := &warningRecorder{}
+			router, sess := newSession(logger)
+			router.WritersHook = func(func(context.Context) ([]string, error), string) ([]string, error) {
+				time.Sleep(20 * time.Millisecond)
+				return []string{"server"}, nil
+			}
+			ctx := log.WithFields(context.Background(), map[string]string{"request_id": "42%"})
+
+			_, err := sess.getConnection(ctx, idb.WriteMode, 0)
+
+			AssertNotNil(t, err)
+			AssertLen(t, logger.warnings, 1)
+			AssertStringContain(t, logger.warnings[0], "[request_id=42%] connection acquisition timed out after")
+		})
+
+		inner.Run("returns time breakdown when acquisition times out", func(t *testing.T) {
+			router, sess := newSession(&log.Void{})
+			router.WritersHook = func(func(context.Context) ([]string, error), string) ([]string, error) {
+				time.Sleep(20 * time.Millisecond)
+				return []string{"server"}, nil
+			}
+
+			_, err := sess.getConnection(context.Background(), idb.WriteMode, 0)
+
+			AssertTrue(t, IsConnectivityError(err))
+			var timeoutErr *ConnectionAcquisitionTimeoutError
+			AssertTrue(t, errors.As(err, &timeoutErr))
+			AssertDeepEquals(t, timeoutErr.Timeout, 10*time.Millisecond)
+			AssertDeepEquals(t, timeoutErr.Phase, RoutingPhase)
+			AssertTrue(t, timeoutErr.PhaseDurations[RoutingPhase] >= 20*time.Millisecond)
+			AssertTrue(t, timeoutErr.Elapsed >= timeoutErr.PhaseDurations[RoutingPhase])
+			AssertStringContain(t, err.Error(), "mostly spent in routing")
+			AssertStringContain(t, err.Error(), "pool is busy")
+		})
+
+		inner.Run("does not log breakdown on other failures", func(t *testing.T) {
+			logger := &warningRecorder{}
+			_, sess := newSession(logger)
+
+			_, err := sess.getConnection(context.Background(), idb.WriteMode, 0)
+
+			AssertNotNil(t, err)
+			AssertLen(t, logger.warnings, 0)
+			var timeoutErr *ConnectionAcquisitionTimeoutError
+			AssertFalse(t, errors.As(err, &timeoutErr))
+		})
+	})
+
+	outer.Run("Transaction timeout provider", func(inner *testing.T) {
+		createSessionWithProvider := func(provider func(context.Context) time.Duration) (*ConnFake, *sessionWithContext) {
+			conf := Config{MaxTransactionRetryTime: 3 * time.Millisecond, TransactionTimeoutProvider: provider}
+			conn := &ConnFake{Alive: true}
+			pool := PoolFake{BorrowConn: conn}
+			sess := newSessionWithContext(&conf, SessionConfig{}, &RouterFake{}, &pool, logger)
+			return conn, sess
+		}
+
+		inner.Run("supplies timeout of explicit transactions", func(t *testing.T) {
+			conn, sess := createSessionWithProvider(func(context.Context) time.Duration {
+				return 3 * time.Second
+			})
+
+			_, err := sess.BeginTransaction(context.Background())
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertDeepEquals(t, conn.RecordedTxs[0].Timeout, 3*time.Second)
+		})
+
+		inner.Run("supplies timeout of transaction functions", func(t *testing.T) {
+			conn, sess := createSessionWithProvider(func(context.Context) time.Duration {
+				return 3 * time.Second
+			})
+
+			_, err := sess.ExecuteRead(context.Background(), func(ManagedTransaction) (any, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertDeepEquals(t, conn.RecordedTxs[0].Timeout, 3*time.Second)
+		})
+
+		inner.Run("receives the operation context", func(t *testing.T) {
+			type key struct{}
+			ctx := context.WithValue(context.Background(), key{}, 5*time.Second)
+			conn, sess := createSessionWithProvider(func(ctx context.Context) time.Duration {
+				return ctx.Value(key{}).(time.Duration)
+			})
+
+			_, err := sess.Run(ctx, "RETURN 42", nil)
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertDeepEquals(t, conn.RecordedTxs[0].Timeout, 5*time.Second)
+		})
+
+		inner.Run("does not override explicit timeout", func(t *testing.T) {
+			conn, sess := createSessionWithProvider(func(context.Context) time.Duration {
+				return 3 * time.Second
+			})
+
+			_, err := sess.BeginTransaction(context.Background(), WithTxTimeout(time.Second))
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertDeepEquals(t, conn.RecordedTxs[0].Timeout, time.Second)
+		})
+
+		inner.Run("falls back to server default with negative timeout", func(t *testing.T) {
+			conn, sess := createSessionWithProvider(func(context.Context) time.Duration {
+				return -1
+			})
+
+			_, err := sess.BeginTransaction(context.Background())
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertDeepEquals(t, conn.RecordedTxs[0].Timeout, time.Duration(math.MinInt))
+		})
+	})
+
+	outer.Run("Transaction timeout from context", func(inner *testing.T) {
+		inner.Run("derives timeout from deadline minus margin", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+
+			_, err := sess.BeginTransaction(ctx, WithTimeoutFromContext(10*time.Minute))
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			timeout := conn.RecordedTxs[0].Timeout
+			AssertTrue(t, timeout > 49*time.Minute && timeout <= 50*time.Minute)
+		})
+
+		inner.Run("uses server default without deadline", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.Run(context.Background(), "RETURN 42", nil, WithTimeoutFromContext(time.Second))
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertDeepEquals(t, conn.RecordedTxs[0].Timeout, time.Duration(math.MinInt))
+		})
+
+		inner.Run("uses minimal timeout when margin exceeds deadline", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+
+			_, err := sess.BeginTransaction(ctx, WithTimeoutFromContext(2*time.Hour))
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertDeepEquals(t, conn.RecordedTxs[0].Timeout, time.Millisecond)
+		})
+
+		inner.Run("is overridden by later explicit timeout", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+
+			_, err := sess.BeginTransaction(ctx, WithTimeoutFromContext(time.Second), WithTxTimeout(time.Second))
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertDeepEquals(t, conn.RecordedTxs[0].Timeout, time.Second)
+		})
+	})
+
+	outer.Run("Default access mode", func(inner *testing.T) {
+		createSessionWithDefaultAccessMode := func(defaultMode AccessMode, sessConfig SessionConfig) *sessionWithContext {
+			conf := Config{DefaultAccessMode: defaultMode}
+			return newSessionWithContext(&conf, sessConfig, &RouterFake{}, &PoolFake{}, logger)
+		}
+
+		inner.Run("applies to sessions without access mode", func(t *testing.T) {
+			sess := createSessionWithDefaultAccessMode(AccessModeRead, SessionConfig{})
+
+			AssertDeepEquals(t, sess.defaultMode, idb.ReadMode)
+		})
+
+		inner.Run("is overridden by session access mode", func(t *testing.T) {
+			sess := createSessionWithDefaultAccessMode(AccessModeWrite, SessionConfig{AccessMode: AccessModeRead})
+
+			AssertDeepEquals(t, sess.defaultMode, idb.ReadMode)
+		})
+
+		inner.Run("is overridden by explicit session write access mode", func(t *testing.T) {
+			sess := createSessionWithDefaultAccessMode(AccessModeRead, SessionConfig{
+				AccessMode:         AccessModeWrite,
+				ExplicitAccessMode: true,
+			})
+
+			AssertDeepEquals(t, sess.defaultMode, idb.WriteMode)
+		})
+
+		inner.Run("defaults to write", func(t *testing.T) {
+			sess := createSessionWithDefaultAccessMode(AccessModeWrite, SessionConfig{})
+
+			AssertDeepEquals(t, sess.defaultMode, idb.WriteMode)
+		})
+	})
+
+	outer.Run("Database name", func(inner *testing.T) {
+		inner.Run("is normalized before routing", func(t *testing.T) {
+			router, pool, sess := createSessionFromConfig(SessionConfig{DatabaseName: "Movies"})
+			pool.BorrowConn = &ConnFake{Alive: true}
+			var routedDatabase string
+			router.WritersHook = func(_ func(context.Context) ([]string, error), database string) ([]string, error) {
+				routedDatabase = database
+				return []string{"writer"}, nil
+			}
+
+			_, err := sess.ExecuteWrite(context.Background(), func(ManagedTransaction) (any, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertStringEqual(t, routedDatabase, "movies")
+		})
+
+		inner.Run("leaves validation to the server", func(t *testing.T) {
+			router, pool, sess := createSessionFromConfig(SessionConfig{DatabaseName: "My_Movies"})
+			pool.BorrowConn = &ConnFake{Alive: true}
+			var routedDatabase string
+			router.WritersHook = func(_ func(context.Context) ([]string, error), database string) ([]string, error) {
+				routedDatabase = database
+				return []string{"writer"}, nil
+			}
+
+			_, err := sess.ExecuteWrite(context.Background(), func(ManagedTransaction) (any, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertStringEqual(t, routedDatabase, "my_movies")
+		})
+	})
+
+	outer.Run("Connection affinity", func(inner *testing.T) {
+		createSessionWithAffinity := func(affinity bool) (*RouterFake, *PoolFake, *sessionWithContext) {
+			conf := Config{ConnectionAffinity: affinity}
+			router := RouterFake{}
+			pool := PoolFake{}
+			sess := newSessionWithContext(&conf, SessionConfig{}, &router, &pool, logger)
+			return &router, &pool, sess
+		}
+
+		inner.Run("reborrows last connection", func(t *testing.T) {
+			router, pool, sess := createSessionWithAffinity(true)
+			router.WritersRet = []string{"server1", "server2"}
+			conn := &ConnFake{Name: "server2", Alive: true}
+			pool.BorrowConn = conn
+			pool.ReborrowRet = conn
+			_, err := sess.BeginTransaction(context.Background())
+			AssertNoError(t, err)
+			pool.BorrowConn, pool.BorrowErr = nil, errors.New("should reborrow instead")
+			AssertNoError(t, sess.explicitTx.Commit(context.Background()))
+
+			_, err = sess.BeginTransaction(context.Background())
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 2)
+		})
+
+		inner.Run("does not reborrow connection to server with other role", func(t *testing.T) {
+			router, pool, sess := createSessionWithAffinity(true)
+			router.WritersRet = []string{"server1"}
+			oldConn := &ConnFake{Name: "server1", Alive: true}
+			pool.BorrowConn = oldConn
+			pool.ReborrowRet = oldConn
+			_, err := sess.BeginTransaction(context.Background())
+			AssertNoError(t, err)
+			AssertNoError(t, sess.explicitTx.Commit(context.Background()))
+			router.WritersRet = []string{"server2"}
+			newConn := &ConnFake{Name: "server2", Alive: true}
+			pool.BorrowConn = newConn
+
+			_, err = sess.BeginTransaction(context.Background())
+
+			AssertNoError(t, err)
+			AssertLen(t, oldConn.RecordedTxs, 1)
+			AssertLen(t, newConn.RecordedTxs, 1)
+		})
+
+		inner.Run("does not reborrow when disabled", func(t *testing.T) {
+			router, pool, sess := createSessionWithAffinity(false)
+			router.WritersRet = []string{"server1"}
+			oldConn := &ConnFake{Name: "server1", Alive: true}
+			pool.BorrowConn = oldConn
+			pool.ReborrowRet = oldConn
+			_, err := sess.BeginTransaction(context.Background())
+			AssertNoError(t, err)
+			AssertNoError(t, sess.explicitTx.Commit(context.Background()))
+			newConn := &ConnFake{Name: "server1", Alive: true}
+			pool.BorrowConn = newConn
+
+			_, err = sess.BeginTransaction(context.Background())
+
+			AssertNoError(t, err)
+			AssertLen(t, oldConn.RecordedTxs, 1)
+			AssertLen(t, newConn.RecordedTxs, 1)
+		})
+	})
+
+	outer.Run("Connection test on borrow", func(inner *testing.T) {
+		borrowWith := func(t *testing.T, conf Config) time.Duration {
+			poolFake := PoolFake{BorrowConn: &ConnFake{Alive: true}}
+			sess := newSessionWithContext(&conf, SessionConfig{}, &RouterFake{}, &poolFake, logger)
+
+			_, err := sess.ExecuteWrite(context.Background(), func(ManagedTransaction) (any, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			return poolFake.LivenessCheckThreshold
+		}
+
+		inner.Run("checks every borrowed connection when enabled", func(t *testing.T) {
+			threshold := borrowWith(t, Config{TestConnectionOnBorrow: true})
+
+			AssertDeepEquals(t, threshold, time.Duration(0))
+		})
+
+		inner.Run("does not check borrowed connections by default", func(t *testing.T) {
+			threshold := borrowWith(t, Config{})
+
+			AssertDeepEquals(t, threshold, time.Duration(pool.DefaultLivenessCheckThreshold))
+		})
+	})
+
+	outer.Run("In-flight results limit", func(inner *testing.T) {
+		ctx := context.Background()
+		createSessionWithLimit := func(limit int) *sessionWithContext {
+			conf := Config{MaxInFlightResults: limit}
+			poolFake := PoolFake{BorrowConn: &ConnFake{Alive: true, ConsumeSum: &db.Summary{}}}
+			return newSessionWithContext(&conf, SessionConfig{}, &RouterFake{}, &poolFake, logger)
+		}
+
+		inner.Run("fails to begin a transaction while an auto-commit result is open", func(t *testing.T) {
+			sess := createSessionWithLimit(1)
+			_, err := sess.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+
+			_, err = sess.BeginTransaction(ctx)
+
+			AssertTrue(t, IsInFlightResultsLimitError(err))
+			AssertIntEqual(t, err.(*InFlightResultsLimitError).Limit, 1)
+			AssertStringContain(t, err.(*InFlightResultsLimitError).OpenResultStack, "session_with_context_test.go")
+		})
+
+		inner.Run("begins a transaction once the auto-commit result is consumed", func(t *testing.T) {
+			sess := createSessionWithLimit(1)
+			result, err := sess.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			_, err = result.Consume(ctx)
+			AssertNoError(t, err)
+
+			_, err = sess.BeginTransaction(ctx)
+
+			AssertNoError(t, err)
+		})
+
+		inner.Run("fails to run a query while too many transaction results are open", func(t *testing.T) {
+			sess := createSessionWithLimit(2)
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			_, err = tx.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			_, err = tx.Run(ctx, "RETURN 2", nil)
+			AssertNoError(t, err)
+
+			_, err = tx.Run(ctx, "RETURN 3", nil)
+
+			AssertTrue(t, IsInFlightResultsLimitError(err))
+		})
+
+		inner.Run("fails to run a query while too many managed transaction results are open", func(t *testing.T) {
+			sess := createSessionWithLimit(1)
+
+			_, err := sess.ExecuteWrite(ctx, func(tx ManagedTransaction) (any, error) {
+				if _, err := tx.Run(ctx, "RETURN 1", nil); err != nil {
+					return nil, err
+				}
+				return tx.Run(ctx, "RETURN 2", nil)
+			})
+
+			AssertTrue(t, IsInFlightResultsLimitError(err))
+		})
+
+		inner.Run("fails to run a batch of more queries than the limit", func(t *testing.T) {
+			sess := createSessionWithLimit(2)
+
+			_, err := sess.RunBatch(ctx, []BatchQuery{{Cypher: "RETURN 1"}, {Cypher: "RETURN 2"}, {Cypher: "RETURN 3"}})
+
+			AssertTrue(t, IsInFlightResultsLimitError(err))
+			AssertIntEqual(t, err.(*InFlightResultsLimitError).Limit, 2)
+			AssertStringEqual(t, err.(*InFlightResultsLimitError).OpenResultStack, "")
+		})
+
+		inner.Run("fails to run a batch while auto-commit results count towards the limit", func(t *testing.T) {
+			sess := createSessionWithLimit(2)
+			_, err := sess.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+
+			_, err = sess.RunBatch(ctx, []BatchQuery{{Cypher: "RETURN 2"}, {Cypher: "RETURN 3"}})
+
+			AssertTrue(t, IsInFlightResultsLimitError(err))
+			AssertStringContain(t, err.(*InFlightResultsLimitError).OpenResultStack, "session_with_context_test.go")
+		})
+
+		inner.Run("runs a batch within the limit", func(t *testing.T) {
+			summary := &db.Summary{}
+			conf := Config{MaxInFlightResults: 1}
+			poolFake := PoolFake{BorrowConn: &ConnFake{Alive: true, Nexts: []Next{{Summary: summary}}, ConsumeSum: summary}}
+			sess := newSessionWithContext(&conf, SessionConfig{}, &RouterFake{}, &poolFake, logger)
+
+			results, err := sess.RunBatch(ctx, []BatchQuery{{Cypher: "RETURN 1"}})
+
+			AssertNoError(t, err)
+			AssertLen(t, results, 1)
+		})
+
+		inner.Run("buffers open results by default", func(t *testing.T) {
+			sess := createSessionWithLimit(0)
+			_, err := sess.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+
+			_, err = sess.BeginTransaction(ctx)
+
+			AssertNoError(t, err)
+		})
+	})
+
+	outer.Run("Notification filtering", func(inner *testing.T) {
+		ctx := context.Background()
+
+		inner.Run("sends the session settings along with transactions", func(t *testing.T) {
+			_, pool, sess := createSessionFromConfig(SessionConfig{
+				NotificationsMinSeverity:        NotificationsWarning,
+				NotificationsDisabledCategories: []NotificationCategory{NotificationCategoryHint, NotificationCategoryGeneric},
+			})
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			_, err = sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+
+			expected := idb.NotificationConfig{MinSeverity: "WARNING", DisabledCategories: []string{"HINT", "GENERIC"}}
+			AssertLen(t, conn.RecordedTxs, 2)
+			AssertDeepEquals(t, conn.RecordedTxs[0].Notifications, expected)
+			AssertDeepEquals(t, conn.RecordedTxs[1].Notifications, expected)
+		})
+
+		inner.Run("lets the driver settings apply by default", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.Run(ctx, "RETURN 1", nil)
+
+			AssertNoError(t, err)
+			AssertTrue(t, conn.RecordedTxs[0].Notifications.IsDefault())
+		})
+
+		inner.Run("keeps empty disabled categories apart from nil ones", func(t *testing.T) {
+			_, pool, sess := createSessionFromConfig(SessionConfig{NotificationsDisabledCategories: []NotificationCategory{}})
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.Run(ctx, "RETURN 1", nil)
+
+			AssertNoError(t, err)
+			AssertFalse(t, conn.RecordedTxs[0].Notifications.IsDefault())
+			AssertLen(t, conn.RecordedTxs[0].Notifications.DisabledCategories, 0)
+		})
+	})
+
+	outer.Run("Unconsumed results on commit", func(inner *testing.T) {
+		ctx := context.Background()
+		createSessionWithPolicy := func(policy UnconsumedResultsPolicy) (*sessionWithContext, *ConnFake) {
+			conf := Config{UnconsumedResultsOnCommit: policy}
+			conn := &ConnFake{Alive: true}
+			poolFake := PoolFake{BorrowConn: conn}
+			return newSessionWithContext(&conf, SessionConfig{}, &RouterFake{}, &poolFake, logger), conn
+		}
+		runAndCommit := func(t *testing.T, sess *sessionWithContext, conn *ConnFake) (ResultWithContext, *int, *int, error) {
+			buffered, committed := 0, 0
+			conn.BufferHook = func() { buffered++ }
+			conn.TxCommitHook = func() { committed++ }
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			result, err := tx.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			return result, &buffered, &committed, tx.Commit(ctx)
+		}
+
+		inner.Run("discards unconsumed results by default", func(t *testing.T) {
+			sess, conn := createSessionWithPolicy(UnconsumedResultsDiscard)
+
+			_, buffered, committed, err := runAndCommit(t, sess, conn)
+
+			AssertNoError(t, err)
+			AssertIntEqual(t, *buffered, 0)
+			AssertIntEqual(t, *committed, 1)
+		})
+
+		inner.Run("buffers unconsumed results before committing", func(t *testing.T) {
+			sess, conn := createSessionWithPolicy(UnconsumedResultsBuffer)
+
+			_, buffered, committed, err := runAndCommit(t, sess, conn)
+
+			AssertNoError(t, err)
+			AssertIntEqual(t, *buffered, 1)
+			AssertIntEqual(t, *committed, 1)
+		})
+
+		inner.Run("fails to commit with unconsumed results", func(t *testing.T) {
+			sess, conn := createSessionWithPolicy(UnconsumedResultsFail)
+
+			_, buffered, committed, err := runAndCommit(t, sess, conn)
+
+			AssertTrue(t, IsUnconsumedResultsError(err))
+			AssertIntEqual(t, err.(*UnconsumedResultsError).Count, 1)
+			AssertIntEqual(t, *buffered, 0)
+			AssertIntEqual(t, *committed, 0)
+			AssertNotNil(t, sess.explicitTx)
+		})
+
+		inner.Run("commits once the results are consumed", func(t *testing.T) {
+			sess, conn := createSessionWithPolicy(UnconsumedResultsFail)
+			conn.ConsumeSum = &db.Summary{}
+			result, _, committed, err := runAndCommit(t, sess, conn)
+			AssertTrue(t, IsUnconsumedResultsError(err))
+			_, err = result.Consume(ctx)
+			AssertNoError(t, err)
+
+			err = sess.explicitTx.Commit(ctx)
+
+			AssertNoError(t, err)
+			AssertIntEqual(t, *committed, 1)
+		})
+	})
+
+	outer.Run("Transaction events", func(inner *testing.T) {
+		ctx := context.Background()
+		createSessionWithConn := func(conn *ConnFake) (*sessionWithContext, *[]TransactionEvent) {
+			events := &[]TransactionEvent{}
+			sessConfig := SessionConfig{OnTransactionEvent: func(_ context.Context, event TransactionEvent) {
+				*events = append(*events, event)
+			}}
+			poolFake := PoolFake{BorrowConn: conn}
+			return newSessionWithContext(&Config{MaxTransactionRetryTime: 3 * time.Millisecond}, sessConfig, &RouterFake{}, &poolFake, logger), events
+		}
+		eventTypes := func(events []TransactionEvent) []TransactionEventType {
+			types := make([]TransactionEventType, len(events))
+			for i, event := range events {
+				types[i] = event.Type
+			}
+			return types
+		}
+
+		inner.Run("reports explicit transaction commit", func(t *testing.T) {
+			sess, events := createSessionWithConn(&ConnFake{Alive: true})
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+
+			AssertNoError(t, tx.Commit(ctx))
+
+			AssertDeepEquals(t, eventTypes(*events), []TransactionEventType{TransactionBegun, TransactionCommitted})
+			AssertFalse(t, (*events)[1].Managed)
+			AssertNoError(t, (*events)[1].Err)
+		})
+
+		inner.Run("reports explicit transaction rollback", func(t *testing.T) {
+			sess, events := createSessionWithConn(&ConnFake{Alive: true})
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+
+			AssertNoError(t, tx.Close(ctx))
+
+			AssertDeepEquals(t, eventTypes(*events), []TransactionEventType{TransactionBegun, TransactionRolledBack})
+		})
+
+		inner.Run("reports explicit transaction rolled back by failed query", func(t *testing.T) {
+			runErr := errors.New("run failed")
+			sess, events := createSessionWithConn(&ConnFake{Alive: true, RunTxErr: runErr})
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			_, err = tx.Run(ctx, "RETURN 1", nil)
+			AssertError(t, err)
+
+			AssertNoError(t, tx.Close(ctx))
+
+			AssertDeepEquals(t, eventTypes(*events), []TransactionEventType{TransactionBegun, TransactionRolledBack})
+			AssertDeepEquals(t, (*events)[1].Err, runErr)
+		})
+
+		inner.Run("reports explicit transaction failing to begin", func(t *testing.T) {
+			beginErr := errors.New("begin failed")
+			sess, events := createSessionWithConn(&ConnFake{Alive: true, TxBeginErr: beginErr})
+
+			_, err := sess.BeginTransaction(ctx)
+
+			AssertDeepEquals(t, err, beginErr)
+			AssertDeepEquals(t, eventTypes(*events), []TransactionEventType{TransactionBegun})
+			AssertDeepEquals(t, (*events)[0].Err, beginErr)
+		})
+
+		inner.Run("reports managed transaction commit", func(t *testing.T) {
+			sess, events := createSessionWithConn(&ConnFake{Alive: true})
+
+			_, err := sess.ExecuteWrite(ctx, func(tx ManagedTransaction) (any, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, eventTypes(*events), []TransactionEventType{TransactionBegun, TransactionCommitted})
+			AssertTrue(t, (*events)[0].Managed)
+			AssertTrue(t, (*events)[1].Managed)
+		})
+
+		inner.Run("reports managed transaction rolled back by failed work", func(t *testing.T) {
+			workErr := errors.New("work failed")
+			sess, events := createSessionWithConn(&ConnFake{Alive: true})
+
+			_, err := sess.ExecuteWrite(ctx, func(tx ManagedTransaction) (any, error) {
+				return nil, workErr
+			})
+
+			AssertDeepEquals(t, err, workErr)
+			AssertDeepEquals(t, eventTypes(*events), []TransactionEventType{TransactionBegun, TransactionRolledBack})
+			AssertDeepEquals(t, (*events)[1].Err, workErr)
+		})
+
+		inner.Run("reports auto-commit transaction once its result is consumed", func(t *testing.T) {
+			sess, events := createSessionWithConn(&ConnFake{Alive: true, ConsumeSum: &db.Summary{}})
+			result, err := sess.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			AssertDeepEquals(t, eventTypes(*events), []TransactionEventType{AutoCommitStarted})
+
+			_, err = result.Consume(ctx)
+			AssertNoError(t, err)
+			AssertNoError(t, sess.Close(ctx))
+
+			AssertDeepEquals(t, eventTypes(*events), []TransactionEventType{AutoCommitStarted, AutoCommitFinished})
+			AssertNoError(t, (*events)[1].Err)
+		})
+
+		inner.Run("reports failed auto-commit transaction when the session closes", func(t *testing.T) {
+			consumeErr := errors.New("consume failed")
+			sess, events := createSessionWithConn(&ConnFake{Alive: true, ConsumeErr: consumeErr})
+			result, err := sess.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			_, err = result.Consume(ctx)
+			AssertDeepEquals(t, err, consumeErr)
+
+			AssertNoError(t, sess.Close(ctx))
+
+			AssertDeepEquals(t, eventTypes(*events), []TransactionEventType{AutoCommitStarted, AutoCommitFinished})
+			AssertDeepEquals(t, (*events)[1].Err, consumeErr)
+		})
+
+		inner.Run("reports each batched query as an auto-commit transaction", func(t *testing.T) {
+			summary := &db.Summary{}
+			sess, events := createSessionWithConn(&ConnFake{Alive: true, Nexts: []Next{{Summary: summary}}, ConsumeSum: summary})
+
+			_, err := sess.RunBatch(ctx, []BatchQuery{{Cypher: "RETURN 1"}, {Cypher: "RETURN 2"}})
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, eventTypes(*events), []TransactionEventType{
+				AutoCommitStarted, AutoCommitFinished, AutoCommitStarted, AutoCommitFinished})
+			for _, event := range *events {
+				AssertFalse(t, event.Managed)
+				AssertNoError(t, event.Err)
+			}
+		})
+
+		inner.Run("reports the batched query failing to start", func(t *testing.T) {
+			runErr := errors.New("run failed")
+			sess, events := createSessionWithConn(&ConnFake{Alive: true, RunErr: runErr})
+
+			_, err := sess.RunBatch(ctx, []BatchQuery{{Cypher: "RETURN 1"}, {Cypher: "RETURN 2"}})
+
+			AssertDeepEquals(t, err, runErr)
+			AssertDeepEquals(t, eventTypes(*events), []TransactionEventType{AutoCommitStarted})
+			AssertDeepEquals(t, (*events)[0].Err, runErr)
+		})
+	})
+
+	outer.Run("GetServerInfo", func(inner *testing.T) {
+
+		inner.Run("Retrieves info from first borrowed connection", func(t *testing.T) {
+			borrowCalled := false
+			ctx := context.Background()
+			_, pool, session := createSession()
+			defer session.Close(ctx)
+			pool.BorrowHook = func() (idb.Connection, error) {
+				if borrowCalled {
+					inner.Errorf("expected only 1 call to borrow, got more")
+				}
+				result := &ConnFake{
+					Name: "home",
+					ConnectionVersion: db.ProtocolVersion{
+						Major: 5,
+						Minor: 0,
+					},
+					Alive:              true,
+					DatabaseName:       "neo4j",
+					ServerVersionValue: "smith",
+				}
+				borrowCalled = true
+				return result, nil
+			}
+
+			info, err := session.getServerInfo(ctx)
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, info.ProtocolVersion().Major, 5)
+			AssertDeepEquals(t, info.ProtocolVersion().Minor, 0)
+			AssertDeepEquals(t, info.Agent(), "smith")
+			AssertDeepEquals(t, info.Address(), "home")
+			AssertDeepEquals(t, info.ClockSkew(), time.Duration(0))
+		})
+
+		inner.Run("Estimates the server clock skew when enabled", func(t *testing.T) {
+			ctx := context.Background()
+			_, pool, session := createSession()
+			defer session.Close(ctx)
+			session.config.EstimateServerClockSkew = true
+			serverTime := time.Now().Add(time.Hour).UnixMilli()
+			pool.BorrowConn = &ConnFake{Alive: true, Nexts: []Next{{Record: &db.Record{Values: []any{serverTime}}}}}
+
+			info, err := session.getServerInfo(ctx)
+
+			AssertNoError(t, err)
+			skew := info.ClockSkew()
+			AssertTrue(t, skew > 59*time.Minute && skew <= time.Hour)
+		})
+
+		inner.Run("Ignores failed clock skew estimations", func(t *testing.T) {
+			ctx := context.Background()
+			_, pool, session := createSession()
+			defer session.Close(ctx)
+			session.config.EstimateServerClockSkew = true
+			pool.BorrowConn = &ConnFake{Alive: true, Name: "home", RunErr: errors.New("no time for you")}
+
+			info, err := session.getServerInfo(ctx)
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, info.Address(), "home")
+			AssertDeepEquals(t, info.ClockSkew(), time.Duration(0))
+		})
+
+		inner.Run("Fails if home DB resolution fails", func(t *testing.T) {
+			ctx := context.Background()
 			router, _, session := createSession()
 			defer session.Close(ctx)
 			expectedErr := fmt.Errorf("home db err")
@@ -641,6 +1957,456 @@ func TestSession(outer *testing.T) {
 
 			assertErrorEq(t, err, expectedErr)
 		})
+
+		inner.Run("Authenticates with the session token", func(t *testing.T) {
+			ctx := context.Background()
+			_, pool, session := createSessionFromConfig(SessionConfig{Auth: &AuthToken{tokens: map[string]any{"scheme": "none"}}})
+			defer session.Close(ctx)
+			pool.BorrowConn = &ConnFake{Alive: true}
+
+			_, err := session.getServerInfo(ctx)
+
+			AssertNoError(t, err)
+			auth, ok := idb.SessionAuthFrom(pool.BorrowCtx)
+			AssertTrue(t, ok)
+			AssertDeepEquals(t, auth, map[string]any{"scheme": "none"})
+		})
+	})
+
+	outer.Run("Writes paused", func(inner *testing.T) {
+		createPausedSession := func(accessMode AccessMode) (*PoolFake, *sessionWithContext) {
+			_, pool, sess := createSessionFromConfig(SessionConfig{AccessMode: accessMode})
+			pool.BorrowConn = &ConnFake{Alive: true}
+			sess.writeFence = &writeFence{}
+			sess.writeFence.setPaused(true)
+			return pool, sess
+		}
+
+		inner.Run("Fails write transaction functions fast", func(t *testing.T) {
+			pool, sess := createPausedSession(AccessModeWrite)
+
+			_, err := sess.ExecuteWrite(context.Background(), func(ManagedTransaction) (any, error) {
+				t.Errorf("should not execute work while writes are paused")
+				return nil, nil
+			})
+
+			AssertSameType(t, err, &WritesPausedError{})
+			AssertTrue(t, IsWritesPausedError(err))
+			AssertTrue(t, pool.BorrowCtx == nil)
+		})
+
+		inner.Run("Fails explicit and auto-commit write transactions fast", func(t *testing.T) {
+			pool, sess := createPausedSession(AccessModeWrite)
+
+			_, err := sess.BeginTransaction(context.Background())
+			AssertSameType(t, err, &WritesPausedError{})
+			_, err = sess.Run(context.Background(), "CREATE ()", nil)
+			AssertSameType(t, err, &WritesPausedError{})
+			AssertTrue(t, pool.BorrowCtx == nil)
+		})
+
+		inner.Run("Fails read queries of write sessions fast", func(t *testing.T) {
+			pool, sess := createPausedSession(AccessModeWrite)
+
+			_, err := sess.Run(context.Background(), "MATCH (n) RETURN n", nil)
+
+			AssertSameType(t, err, &WritesPausedError{})
+			AssertTrue(t, pool.BorrowCtx == nil)
+		})
+
+		inner.Run("Lets read transactions through", func(t *testing.T) {
+			_, sess := createPausedSession(AccessModeRead)
+
+			_, err := sess.ExecuteRead(context.Background(), func(ManagedTransaction) (any, error) {
+				return nil, nil
+			})
+			AssertNoError(t, err)
+			tx, err := sess.BeginTransaction(context.Background())
+			AssertNoError(t, err)
+			AssertNoError(t, tx.Commit(context.Background()))
+		})
+
+		inner.Run("Lets writes through once resumed", func(t *testing.T) {
+			_, sess := createPausedSession(AccessModeWrite)
+			sess.writeFence.setPaused(false)
+
+			_, err := sess.ExecuteWrite(context.Background(), func(ManagedTransaction) (any, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+		})
+	})
+
+	outer.Run("Stats", func(inner *testing.T) {
+		inner.Run("Counts queries and records", func(t *testing.T) {
+			_, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true, Nexts: []Next{
+				{Record: &db.Record{Keys: []string{"n"}, Values: []any{1}}},
+				{Summary: &db.Summary{}},
+			}}
+
+			result, err := sess.Run(context.Background(), "RETURN 1 AS n", nil)
+			AssertNoError(t, err)
+			_, err = result.Collect(context.Background())
+			AssertNoError(t, err)
+			AssertNoError(t, sess.Close(context.Background()))
+
+			stats := sess.Stats()
+			AssertDeepEquals(t, stats.Queries, int64(1))
+			AssertDeepEquals(t, stats.RecordsConsumed, int64(1))
+			AssertDeepEquals(t, stats.Failures, int64(0))
+		})
+
+		inner.Run("Counts retries and bytes received while borrowed", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true, Received: 100}
+			pool.BorrowConn = conn
+			transientErr := &db.Neo4jError{Code: "Neo.TransientError.General.MemoryPoolOutOfMemoryError"}
+			attempts := 0
+
+			_, err := sess.ExecuteWrite(context.Background(), func(ManagedTransaction) (any, error) {
+				conn.Received += 10
+				attempts++
+				if attempts == 1 {
+					return nil, transientErr
+				}
+				return nil, nil
+			})
+			conn.Received += 1000
+
+			AssertNoError(t, err)
+			stats := sess.Stats()
+			AssertDeepEquals(t, stats.Retries, int64(1))
+			AssertDeepEquals(t, stats.BytesReceived, int64(20))
+		})
+	})
+
+	outer.Run("Transaction attempt", func(inner *testing.T) {
+		transientErr := &db.Neo4jError{Code: "Neo.TransientError.General.MemoryPoolOutOfMemoryError"}
+
+		inner.Run("is exposed to transaction functions", func(t *testing.T) {
+			_, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true}
+			var attempts []TransactionAttempt
+
+			_, err := sess.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (any, error) {
+				attempts = append(attempts, TxAttempt(tx))
+				if len(attempts) == 1 {
+					return nil, transientErr
+				}
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertLen(t, attempts, 2)
+			AssertDeepEquals(t, attempts[0], TransactionAttempt{Number: 1})
+			AssertIntEqual(t, attempts[1].Number, 2)
+			AssertTrue(t, attempts[1].RetryDelay > 0)
+		})
+
+		inner.Run("is exposed through transaction helpers", func(t *testing.T) {
+			_, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true}
+			var attempts []TransactionAttempt
+
+			_, _, err := ExecuteReadWithSummary[any](context.Background(), sess, func(tx ManagedTransaction) (any, error) {
+				attempts = append(attempts, TxAttempt(tx))
+				if len(attempts) == 1 {
+					return nil, transientErr
+				}
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertLen(t, attempts, 2)
+			AssertIntEqual(t, attempts[0].Number, 1)
+			AssertIntEqual(t, attempts[1].Number, 2)
+		})
+
+		inner.Run("is carried by the context of transaction events", func(t *testing.T) {
+			var numbers []int
+			_, pool, sess := createSessionFromConfig(SessionConfig{
+				OnTransactionEvent: func(ctx context.Context, event TransactionEvent) {
+					attempt, ok := TransactionAttemptFrom(ctx)
+					AssertTrue(t, ok)
+					numbers = append(numbers, attempt.Number)
+				},
+			})
+			pool.BorrowConn = &ConnFake{Alive: true}
+			first := true
+
+			_, err := sess.ExecuteRead(context.Background(), func(ManagedTransaction) (any, error) {
+				if first {
+					first = false
+					return nil, transientErr
+				}
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, numbers, []int{1, 1, 2, 2})
+		})
+
+		inner.Run("is not carried outside transaction functions", func(t *testing.T) {
+			_, ok := TransactionAttemptFrom(context.Background())
+
+			AssertFalse(t, ok)
+		})
+	})
+
+	outer.Run("Query logger", func(inner *testing.T) {
+		ctx := context.Background()
+
+		inner.Run("logs consumed queries", func(t *testing.T) {
+			var entries []QueryLogEntry
+			_, pool, sess := createSession()
+			sess.config.QueryLogger = func(entry QueryLogEntry) { entries = append(entries, entry) }
+			sess.queryLogger = newQueryLogger(sess.config)
+			pool.BorrowConn = &ConnFake{Name: "server:7687", Alive: true, ConsumeSum: &db.Summary{}}
+
+			result, err := sess.Run(ctx, "RETURN $name", map[string]any{"name": "Alice"})
+			AssertNoError(t, err)
+			AssertLen(t, entries, 0)
+			_, err = result.Consume(ctx)
+			AssertNoError(t, err)
+
+			AssertLen(t, entries, 1)
+			AssertStringEqual(t, entries[0].Query, "RETURN $name")
+			AssertStringEqual(t, entries[0].Server, "server:7687")
+			AssertDeepEquals(t, entries[0].Params, map[string]any{"name": RedactedParameterValue})
+			AssertNoError(t, entries[0].Err)
+		})
+
+		inner.Run("logs failed queries", func(t *testing.T) {
+			var entries []QueryLogEntry
+			runErr := &db.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError"}
+			_, pool, sess := createSession()
+			sess.config.QueryLogger = func(entry QueryLogEntry) { entries = append(entries, entry) }
+			sess.queryLogger = newQueryLogger(sess.config)
+			pool.BorrowConn = &ConnFake{Alive: true, RunTxErr: runErr}
+
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			_, err = tx.Run(ctx, "RETURN", nil)
+
+			AssertLen(t, entries, 1)
+			AssertDeepEquals(t, entries[0].Err, err)
+		})
+
+		inner.Run("logs queries of committed transactions with unconsumed results", func(t *testing.T) {
+			var entries []QueryLogEntry
+			_, pool, sess := createSession()
+			sess.config.QueryLogger = func(entry QueryLogEntry) { entries = append(entries, entry) }
+			sess.queryLogger = newQueryLogger(sess.config)
+			pool.BorrowConn = &ConnFake{Alive: true}
+
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			_, err = tx.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			AssertLen(t, entries, 0)
+			AssertNoError(t, tx.Commit(ctx))
+
+			AssertLen(t, entries, 1)
+			AssertStringEqual(t, entries[0].Query, "RETURN 1")
+			AssertNoError(t, entries[0].Err)
+		})
+
+		inner.Run("logs queries of rolled back transactions with unconsumed results", func(t *testing.T) {
+			var entries []QueryLogEntry
+			_, pool, sess := createSession()
+			sess.config.QueryLogger = func(entry QueryLogEntry) { entries = append(entries, entry) }
+			sess.queryLogger = newQueryLogger(sess.config)
+			pool.BorrowConn = &ConnFake{Alive: true}
+
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			_, err = tx.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			AssertNoError(t, sess.Close(ctx))
+
+			AssertLen(t, entries, 1)
+			AssertStringEqual(t, entries[0].Query, "RETURN 1")
+		})
+
+		inner.Run("logs queries of transaction functions with unconsumed results", func(t *testing.T) {
+			var entries []QueryLogEntry
+			_, pool, sess := createSession()
+			sess.config.QueryLogger = func(entry QueryLogEntry) { entries = append(entries, entry) }
+			sess.queryLogger = newQueryLogger(sess.config)
+			pool.BorrowConn = &ConnFake{Alive: true}
+
+			_, err := sess.ExecuteWrite(ctx, func(tx ManagedTransaction) (any, error) {
+				_, err := tx.Run(ctx, "RETURN 1", nil)
+				return nil, err
+			})
+			AssertNoError(t, err)
+
+			AssertLen(t, entries, 1)
+			AssertStringEqual(t, entries[0].Query, "RETURN 1")
+			AssertNoError(t, entries[0].Err)
+		})
+
+		inner.Run("logs buffered auto-commit queries", func(t *testing.T) {
+			var entries []QueryLogEntry
+			_, pool, sess := createSession()
+			sess.config.QueryLogger = func(entry QueryLogEntry) { entries = append(entries, entry) }
+			sess.queryLogger = newQueryLogger(sess.config)
+			pool.BorrowConn = &ConnFake{Alive: true}
+
+			_, err := sess.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			_, err = sess.Run(ctx, "RETURN 2", nil)
+			AssertNoError(t, err)
+
+			AssertLen(t, entries, 1)
+			AssertStringEqual(t, entries[0].Query, "RETURN 1")
+			AssertNoError(t, entries[0].Err)
+		})
+	})
+
+	outer.Run("Transaction interceptors", func(inner *testing.T) {
+		ctx := context.Background()
+		recording := func(operations *[]TxOperation) TxInterceptor {
+			return func(ctx context.Context, operation *TxOperation, next func(context.Context, *TxOperation) error) error {
+				*operations = append(*operations, *operation)
+				return next(ctx, operation)
+			}
+		}
+		tagging := func(ctx context.Context, operation *TxOperation, next func(context.Context, *TxOperation) error) error {
+			if operation.Type == TxBeginOperation || operation.AutoCommit {
+				operation.Metadata = map[string]any{"tag": "audited"}
+			}
+			if operation.Type == TxRunOperation {
+				operation.Cypher = "/* audited */ " + operation.Cypher
+			}
+			return next(ctx, operation)
+		}
+
+		inner.Run("intercepts explicit transactions", func(t *testing.T) {
+			var operations []TxOperation
+			_, pool, sess := createSession()
+			sess.config.TransactionInterceptors = []TxInterceptor{tagging, recording(&operations)}
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			result, err := tx.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			AssertNoError(t, tx.Commit(ctx))
+
+			AssertStringEqual(t, result.(*resultWithContext).cypher, "/* audited */ RETURN 1")
+			AssertDeepEquals(t, conn.RecordedTxs[0].Meta, map[string]any{"tag": "audited"})
+			AssertDeepEquals(t, operations, []TxOperation{
+				{Type: TxBeginOperation, Metadata: map[string]any{"tag": "audited"}},
+				{Type: TxRunOperation, Cypher: "/* audited */ RETURN 1", Metadata: map[string]any{"tag": "audited"}},
+				{Type: TxCommitOperation, Metadata: map[string]any{"tag": "audited"}},
+			})
+		})
+
+		inner.Run("intercepts explicit transaction rollbacks", func(t *testing.T) {
+			var operations []TxOperation
+			_, pool, sess := createSession()
+			sess.config.TransactionInterceptors = []TxInterceptor{recording(&operations)}
+			pool.BorrowConn = &ConnFake{Alive: true}
+
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			AssertNoError(t, tx.Close(ctx))
+
+			AssertLen(t, operations, 2)
+			AssertDeepEquals(t, operations[1].Type, TxRollbackOperation)
+		})
+
+		inner.Run("intercepts transaction functions", func(t *testing.T) {
+			var operations []TxOperation
+			_, pool, sess := createSession()
+			sess.config.TransactionInterceptors = []TxInterceptor{recording(&operations)}
+			pool.BorrowConn = &ConnFake{Alive: true}
+
+			_, err := sess.ExecuteWrite(ctx, func(tx ManagedTransaction) (any, error) {
+				return tx.Run(ctx, "RETURN 1", nil)
+			}, WithTxMetadata(map[string]any{"app": "test"}))
+
+			AssertNoError(t, err)
+			metadata := map[string]any{"app": "test"}
+			AssertDeepEquals(t, operations, []TxOperation{
+				{Type: TxBeginOperation, Managed: true, Metadata: metadata},
+				{Type: TxRunOperation, Managed: true, Cypher: "RETURN 1", Metadata: metadata},
+				{Type: TxCommitOperation, Managed: true, Metadata: metadata},
+			})
+		})
+
+		inner.Run("intercepts auto-commit transactions", func(t *testing.T) {
+			_, pool, sess := createSession()
+			sess.config.TransactionInterceptors = []TxInterceptor{tagging}
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			result, err := sess.Run(ctx, "RETURN 1", nil)
+
+			AssertNoError(t, err)
+			AssertStringEqual(t, result.(*resultWithContext).cypher, "/* audited */ RETURN 1")
+			AssertDeepEquals(t, conn.RecordedTxs[0].Meta, map[string]any{"tag": "audited"})
+		})
+
+		inner.Run("intercepts batched queries", func(t *testing.T) {
+			var operations []TxOperation
+			_, pool, sess := createSession()
+			sess.config.TransactionInterceptors = []TxInterceptor{tagging, recording(&operations)}
+			summary := &db.Summary{}
+			conn := &ConnFake{Alive: true, Nexts: []Next{{Summary: summary}}, ConsumeSum: summary}
+			pool.BorrowConn = conn
+
+			results, err := sess.RunBatch(ctx, []BatchQuery{{Cypher: "RETURN 1"}, {Cypher: "RETURN 2"}})
+
+			AssertNoError(t, err)
+			AssertLen(t, results, 2)
+			AssertStringEqual(t, results[1].Summary.Query().Text(), "/* audited */ RETURN 2")
+			AssertLen(t, conn.RecordedTxs, 2)
+			AssertDeepEquals(t, conn.RecordedTxs[1].Meta, map[string]any{"tag": "audited"})
+			metadata := map[string]any{"tag": "audited"}
+			AssertDeepEquals(t, operations, []TxOperation{
+				{Type: TxRunOperation, AutoCommit: true, Cypher: "/* audited */ RETURN 1", Metadata: metadata},
+				{Type: TxRunOperation, AutoCommit: true, Cypher: "/* audited */ RETURN 2", Metadata: metadata},
+			})
+		})
+
+		inner.Run("aborts batched queries rejected by interceptors", func(t *testing.T) {
+			rejectErr := errors.New("writes are audited")
+			_, pool, sess := createSession()
+			sess.config.TransactionInterceptors = []TxInterceptor{
+				func(context.Context, *TxOperation, func(context.Context, *TxOperation) error) error {
+					return rejectErr
+				},
+			}
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.RunBatch(ctx, []BatchQuery{{Cypher: "RETURN 1"}})
+
+			AssertDeepEquals(t, err, rejectErr)
+			AssertLen(t, conn.RecordedTxs, 0)
+		})
+
+		inner.Run("aborts operations rejected by interceptors", func(t *testing.T) {
+			rejectErr := errors.New("writes are audited")
+			_, pool, sess := createSession()
+			sess.config.TransactionInterceptors = []TxInterceptor{
+				func(context.Context, *TxOperation, func(context.Context, *TxOperation) error) error {
+					return rejectErr
+				},
+			}
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.Run(ctx, "RETURN 1", nil)
+
+			AssertDeepEquals(t, err, rejectErr)
+			AssertLen(t, conn.RecordedTxs, 0)
+		})
 	})
 
 	outer.Run("Close", func(ct *testing.T) {
@@ -664,6 +2430,36 @@ func TestSession(outer *testing.T) {
 			sess.Close(context.Background())
 			wg.Wait()
 		})
+		ct.Run("Times out stuck clean up", func(t *testing.T) {
+			router, _, sess := createSession()
+			sess.config.SessionCloseTimeout = 5 * time.Millisecond
+			release := make(chan struct{})
+			defer close(release)
+			router.CleanUpHook = func() {
+				<-release
+			}
+
+			err := sess.Close(context.Background())
+
+			AssertTrue(t, IsSessionCloseTimeoutError(err))
+			AssertDeepEquals(t, err, &SessionCloseTimeoutError{Component: "router", Timeout: 5 * time.Millisecond})
+		})
+		ct.Run("Reports cancellation of the caller's context", func(t *testing.T) {
+			_, pool, sess := createSession()
+			sess.config.SessionCloseTimeout = time.Minute
+			ctx, cancel := context.WithCancel(context.Background())
+			release := make(chan struct{})
+			defer close(release)
+			pool.CleanUpHook = func() {
+				cancel()
+				<-release
+			}
+
+			err := sess.Close(ctx)
+
+			AssertFalse(t, IsSessionCloseTimeoutError(err))
+			AssertTrue(t, errors.Is(err, context.Canceled))
+		})
 	})
 }
 
@@ -673,3 +2469,12 @@ func assertTokenExpiredError(t *testing.T, err error) {
 	AssertErrorMessageContains(t, err, "Neo.ClientError.Security.TokenExpired")
 	AssertErrorMessageContains(t, err, "oopsie whoopsie")
 }
+
+type warningRecorder struct {
+	log.Void
+	warnings []string
+}
+
+func (l *warningRecorder) Warnf(_, _ string, msg string, args ...any) {
+	l.warnings = append(l.warnings, fmt.Sprintf(msg, args...))
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// annotateStatement prepends the annotations supplied by the annotator to the Cypher query, as a block comment.
+// Annotations are rendered as sorted key='value' pairs, where keys and values are URL-encoded so that they can never
+// terminate the comment early.
+// Comments are ignored by the Cypher parser, so this is also safe for queries starting with EXPLAIN or PROFILE.
+func annotateStatement(ctx context.Context, cypher string, annotator func(context.Context) map[string]string) string {
+	if annotator == nil {
+		return cypher
+	}
+	annotations := annotator(ctx)
+	if len(annotations) == 0 {
+		return cypher
+	}
+	pairs := make([]string, 0, len(annotations))
+	for key, value := range annotations {
+		pairs = append(pairs, fmt.Sprintf("%s='%s'", url.QueryEscape(key), url.QueryEscape(value)))
+	}
+	sort.Strings(pairs)
+	return fmt.Sprintf("/* %s */ %s", strings.Join(pairs, ","), cypher)
+}
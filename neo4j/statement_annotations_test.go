@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+type traceIdKey struct{}
+
+func TestAnnotateStatement(outer *testing.T) {
+	ctx := context.WithValue(context.Background(), traceIdKey{}, "4bf92f")
+	annotator := func(ctx context.Context) map[string]string {
+		return map[string]string{"trace_id": ctx.Value(traceIdKey{}).(string), "service": "billing"}
+	}
+
+	outer.Run("sends queries as is without annotator", func(t *testing.T) {
+		AssertStringEqual(t, annotateStatement(ctx, "RETURN 42", nil), "RETURN 42")
+	})
+
+	outer.Run("sends queries as is without annotations", func(t *testing.T) {
+		noAnnotations := func(context.Context) map[string]string { return nil }
+
+		AssertStringEqual(t, annotateStatement(ctx, "RETURN 42", noAnnotations), "RETURN 42")
+	})
+
+	outer.Run("prepends sorted annotations", func(t *testing.T) {
+		actual := annotateStatement(ctx, "EXPLAIN MATCH (n) RETURN n", annotator)
+
+		AssertStringEqual(t, actual, "/* service='billing',trace_id='4bf92f' */ EXPLAIN MATCH (n) RETURN n")
+	})
+
+	outer.Run("encodes annotations", func(t *testing.T) {
+		malicious := func(context.Context) map[string]string {
+			return map[string]string{"end*/": "*/ MATCH (n) DETACH DELETE n //'"}
+		}
+
+		actual := annotateStatement(ctx, "RETURN 42", malicious)
+
+		AssertStringEqual(t, actual, "/* end%2A%2F='%2A%2F+MATCH+%28n%29+DETACH+DELETE+n+%2F%2F%27' */ RETURN 42")
+	})
+}
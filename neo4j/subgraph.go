@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"sort"
+)
+
+// Subgraph holds the distinct nodes and relationships found in one or more records.
+//
+// Nodes and relationships are deduplicated by element ID and ordered by first appearance: records are visited in
+// order, the values of each record are visited in key order, lists are visited in index order, maps are visited in
+// lexicographic key order and paths are visited nodes first, then relationships.
+// This ordering is stable, i.e. the same records always yield the same subgraph.
+type Subgraph struct {
+	Nodes         []Node
+	Relationships []Relationship
+}
+
+// Node returns the node of the subgraph with the given element ID, if any
+func (s Subgraph) Node(elementId string) (Node, bool) {
+	for _, node := range s.Nodes {
+		if node.ElementId == elementId {
+			return node, true
+		}
+	}
+	return Node{}, false
+}
+
+// StartNode resolves the start node of the given relationship among the nodes of the subgraph.
+// false is returned if the start node was not part of the records the subgraph was collected from.
+func (s Subgraph) StartNode(relationship Relationship) (Node, bool) {
+	return s.Node(relationship.StartElementId)
+}
+
+// EndNode resolves the end node of the given relationship among the nodes of the subgraph.
+// false is returned if the end node was not part of the records the subgraph was collected from.
+func (s Subgraph) EndNode(relationship Relationship) (Node, bool) {
+	return s.Node(relationship.EndElementId)
+}
+
+// NodesByElementId indexes the given nodes by element ID.
+// This is useful to resolve the start and end nodes of many relationships, for instance:
+//
+//	nodes := neo4j.NodesByElementId(subgraph.Nodes)
+//	for _, relationship := range subgraph.Relationships {
+//		start, end := nodes[relationship.StartElementId], nodes[relationship.EndElementId]
+//		// [...]
+//	}
+func NodesByElementId(nodes []Node) map[string]Node {
+	result := make(map[string]Node, len(nodes))
+	for _, node := range nodes {
+		result[node.ElementId] = node
+	}
+	return result
+}
+
+// SubgraphCollector accumulates the distinct nodes and relationships of records as they are streamed.
+// The zero value is ready to use.
+// See Subgraph for the ordering guarantees.
+type SubgraphCollector struct {
+	subgraph        Subgraph
+	nodeIds         map[string]struct{}
+	relationshipIds map[string]struct{}
+}
+
+// Add collects the nodes and relationships of the given record that have not been seen yet
+func (c *SubgraphCollector) Add(record *Record) {
+	if record == nil {
+		return
+	}
+	for _, value := range record.Values {
+		c.addValue(value)
+	}
+}
+
+// Subgraph returns the distinct nodes and relationships collected so far
+func (c *SubgraphCollector) Subgraph() Subgraph {
+	return c.subgraph
+}
+
+func (c *SubgraphCollector) addValue(value any) {
+	switch v := value.(type) {
+	case Node:
+		c.addNode(v)
+	case Relationship:
+		c.addRelationship(v)
+	case Path:
+		for _, node := range v.Nodes {
+			c.addNode(node)
+		}
+		for _, relationship := range v.Relationships {
+			c.addRelationship(relationship)
+		}
+	case []any:
+		for _, item := range v {
+			c.addValue(item)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			c.addValue(v[key])
+		}
+	}
+}
+
+func (c *SubgraphCollector) addNode(node Node) {
+	if c.nodeIds == nil {
+		c.nodeIds = make(map[string]struct{})
+	}
+	if _, found := c.nodeIds[node.ElementId]; found {
+		return
+	}
+	c.nodeIds[node.ElementId] = struct{}{}
+	c.subgraph.Nodes = append(c.subgraph.Nodes, node)
+}
+
+func (c *SubgraphCollector) addRelationship(relationship Relationship) {
+	if c.relationshipIds == nil {
+		c.relationshipIds = make(map[string]struct{})
+	}
+	if _, found := c.relationshipIds[relationship.ElementId]; found {
+		return
+	}
+	c.relationshipIds[relationship.ElementId] = struct{}{}
+	c.subgraph.Relationships = append(c.subgraph.Relationships, relationship)
+}
+
+// SubgraphOf returns the distinct nodes and relationships found in the given records.
+// This is useful with eagerly fetched results, such as EagerResult.Records.
+// See Subgraph for the ordering guarantees.
+func SubgraphOf(records []*Record) Subgraph {
+	var collector SubgraphCollector
+	for _, record := range records {
+		collector.Add(record)
+	}
+	return collector.Subgraph()
+}
+
+// CollectSubgraph consumes the remaining records of the given result and returns their distinct nodes and
+// relationships.
+// Records are streamed, so only the distinct nodes and relationships are retained in memory.
+// See Subgraph for the ordering guarantees.
+func CollectSubgraph(ctx context.Context, result ResultWithContext) (Subgraph, error) {
+	var collector SubgraphCollector
+	var record *Record
+	for result.NextRecord(ctx, &record) {
+		collector.Add(record)
+	}
+	if err := result.Err(); err != nil {
+		return Subgraph{}, err
+	}
+	return collector.Subgraph(), nil
+}
+
+// DistinctNodes returns the distinct nodes found in the given records.
+// See Subgraph for the ordering guarantees.
+func DistinctNodes(records []*Record) []Node {
+	return SubgraphOf(records).Nodes
+}
+
+// DistinctRelationships returns the distinct relationships found in the given records.
+// See Subgraph for the ordering guarantees.
+func DistinctRelationships(records []*Record) []Relationship {
+	return SubgraphOf(records).Relationships
+}
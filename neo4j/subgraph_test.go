@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestSubgraph(outer *testing.T) {
+	ctx := context.Background()
+	alice := Node{ElementId: "n1", Labels: []string{"Person"}}
+	bob := Node{ElementId: "n2", Labels: []string{"Person"}}
+	carol := Node{ElementId: "n3", Labels: []string{"Person"}}
+	knows1 := Relationship{ElementId: "r1", StartElementId: "n1", EndElementId: "n2", Type: "KNOWS"}
+	knows2 := Relationship{ElementId: "r2", StartElementId: "n2", EndElementId: "n3", Type: "KNOWS"}
+	records := []*Record{
+		{Keys: []string{"a", "r", "b"}, Values: []any{alice, knows1, bob}},
+		{Keys: []string{"p"}, Values: []any{Path{Nodes: []Node{bob, carol}, Relationships: []Relationship{knows2}}}},
+		{Keys: []string{"m", "l"}, Values: []any{
+			map[string]any{"z": alice, "y": carol},
+			[]any{knows2, []any{bob, knows1}, "not a graph entity", nil},
+		}},
+	}
+
+	outer.Run("deduplicates entities by element id in order of first appearance", func(t *testing.T) {
+		subgraph := SubgraphOf(records)
+
+		AssertDeepEquals(t, subgraph.Nodes, []Node{alice, bob, carol})
+		AssertDeepEquals(t, subgraph.Relationships, []Relationship{knows1, knows2})
+		AssertDeepEquals(t, DistinctNodes(records), []Node{alice, bob, carol})
+		AssertDeepEquals(t, DistinctRelationships(records), []Relationship{knows1, knows2})
+	})
+
+	outer.Run("visits map values in key order", func(t *testing.T) {
+		record := &Record{Keys: []string{"m"}, Values: []any{map[string]any{"z": alice, "y": carol, "x": bob}}}
+
+		for i := 0; i < 10; i++ {
+			AssertDeepEquals(t, DistinctNodes([]*Record{record}), []Node{bob, carol, alice})
+		}
+	})
+
+	outer.Run("returns empty subgraph without graph entities", func(t *testing.T) {
+		subgraph := SubgraphOf([]*Record{{Keys: []string{"x"}, Values: []any{int64(1)}}, nil})
+
+		AssertLen(t, subgraph.Nodes, 0)
+		AssertLen(t, subgraph.Relationships, 0)
+	})
+
+	outer.Run("resolves relationship endpoints", func(t *testing.T) {
+		subgraph := SubgraphOf(records[:1])
+
+		start, found := subgraph.StartNode(knows1)
+		AssertTrue(t, found)
+		AssertDeepEquals(t, start, alice)
+		end, found := subgraph.EndNode(knows1)
+		AssertTrue(t, found)
+		AssertDeepEquals(t, end, bob)
+		_, found = subgraph.EndNode(knows2)
+		AssertFalse(t, found)
+	})
+
+	outer.Run("indexes nodes by element id", func(t *testing.T) {
+		nodes := NodesByElementId(DistinctNodes(records))
+
+		AssertDeepEquals(t, nodes, map[string]Node{"n1": alice, "n2": bob, "n3": carol})
+	})
+
+	outer.Run("collects subgraph from result", func(t *testing.T) {
+		conn := &ConnFake{Nexts: []Next{
+			{Record: &db.Record{Values: []any{alice, knows1, bob}}},
+			{Record: &db.Record{Values: []any{bob, knows2, carol}}},
+			{Summary: &db.Summary{}},
+		}}
+		result := newResultWithContext(conn, idb.StreamHandle(0), "", nil, nil)
+
+		subgraph, err := CollectSubgraph(ctx, result)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, subgraph, Subgraph{
+			Nodes:         []Node{alice, bob, carol},
+			Relationships: []Relationship{knows1, knows2},
+		})
+	})
+
+	outer.Run("fails to collect subgraph from failing result", func(t *testing.T) {
+		fetchErr := errors.New("oopsie")
+		conn := &ConnFake{Nexts: []Next{{Record: &db.Record{Values: []any{alice}}}, {Err: fetchErr}}}
+		result := newResultWithContext(conn, idb.StreamHandle(0), "", nil, nil)
+
+		_, err := CollectSubgraph(ctx, result)
+
+		AssertDeepEquals(t, err, fetchErr)
+	})
+}
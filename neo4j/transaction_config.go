@@ -19,7 +19,17 @@
 
 package neo4j
 
-import "time"
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"time"
+)
+
+// IdempotencyKeyMetadataKey is the transaction metadata entry under which the idempotency key configured with
+// WithTxIdempotencyKey is sent to the server.
+const IdempotencyKeyMetadataKey = "idempotencyKey"
 
 // TransactionConfig holds the settings for explicit and auto-commit transactions. Actual configuration is expected
 // to be done using configuration functions that are predefined, i.e. 'WithTxTimeout' and 'WithTxMetadata', or one
@@ -29,6 +39,23 @@ type TransactionConfig struct {
 	Timeout time.Duration
 	// Metadata is the configured transaction metadata that will be attached to the underlying transaction.
 	Metadata map[string]any
+	// IdempotencyKey identifies the unit of work of a transaction function across all its attempts.
+	// See WithTxIdempotencyKey.
+	IdempotencyKey string
+	// timeoutFromContext computes the transaction timeout from the context of the operation beginning the
+	// transaction, when set by WithTimeoutFromContext.
+	timeoutFromContext func(context.Context) time.Duration
+	// accessMode overrides the access mode of the session, when set by WithTxAccessMode.
+	accessMode *AccessMode
+	// fallbackToWriters makes read transaction functions retry against writers when no reader is available, when set
+	// by ExecuteQueryWithWritersFallback.
+	fallbackToWriters bool
+	// metadataErr is the error of the invalid metadata set by WithTxMetadataBuilder, if any.
+	metadataErr error
+	// impersonatedUser overrides the impersonated user of the session, when set by WithTxImpersonatedUser.
+	impersonatedUser *string
+	// fetchSize overrides the fetch size of the session, when set by WithTxFetchSize.
+	fetchSize *int
 }
 
 // WithTxTimeout returns a transaction configuration function that applies a timeout to a transaction.
@@ -47,6 +74,37 @@ type TransactionConfig struct {
 func WithTxTimeout(timeout time.Duration) func(*TransactionConfig) {
 	return func(config *TransactionConfig) {
 		config.Timeout = timeout
+		config.timeoutFromContext = nil
+	}
+}
+
+// WithTimeoutFromContext returns a transaction configuration function that derives the transaction timeout from the
+// deadline of the context passed to the operation beginning the transaction, minus the given margin.
+// The margin leaves room for the server-side termination to be reported back before the context expires.
+// If the context has no deadline, the server-side default timeout applies.
+// If the deadline minus the margin is already (almost) over, the timeout is set to 1 millisecond, the smallest
+// timeout the server accepts.
+//
+// To align the timeout of a write transaction function with the context deadline:
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	session.ExecuteWrite(ctx, DoWork, WithTimeoutFromContext(500*time.Millisecond))
+//
+// For transaction functions, the timeout is recomputed before each attempt.
+func WithTimeoutFromContext(margin time.Duration) func(*TransactionConfig) {
+	return func(config *TransactionConfig) {
+		config.Timeout = math.MinInt
+		config.timeoutFromContext = func(ctx context.Context) time.Duration {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return math.MinInt
+			}
+			timeout := time.Until(deadline) - margin
+			if timeout < time.Millisecond {
+				return time.Millisecond
+			}
+			return timeout
+		}
 	}
 }
 
@@ -66,5 +124,119 @@ func WithTxTimeout(timeout time.Duration) func(*TransactionConfig) {
 func WithTxMetadata(metadata map[string]any) func(*TransactionConfig) {
 	return func(config *TransactionConfig) {
 		config.Metadata = metadata
+		// the replaced metadata may have been invalid
+		config.metadataErr = nil
+	}
+}
+
+// WithTxIdempotencyKey returns a transaction configuration function that attaches an idempotency key to a
+// transaction function.
+//
+// Transaction functions are executed at least once: when the outcome of a commit is unknown, e.g. when the
+// connection breaks while committing, the work may have been applied even though an error is returned, and running
+// it again applies it twice.
+// The idempotency key identifies the unit of work across all its attempts, including the ones made by the application
+// after such an error, so that the work can deduplicate itself, for instance:
+//
+//	key := neo4j.NewIdempotencyKey() // generated once per logical operation, before any attempt
+//	session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+//		return tx.Run(ctx, "MERGE (p:Payment {key: $key}) ON CREATE SET p.amount = $amount",
+//			map[string]any{"key": neo4j.TxIdempotencyKey(tx), "amount": 42})
+//	}, neo4j.WithTxIdempotencyKey(key))
+//
+// The key is made available to the work function with TxIdempotencyKey and is sent to the server in the transaction
+// metadata, under IdempotencyKeyMetadataKey, so that it also shows up in the server query logs.
+// The key is only used by transaction functions (SessionWithContext.ExecuteRead and SessionWithContext.ExecuteWrite).
+func WithTxIdempotencyKey(key string) func(*TransactionConfig) {
+	return func(config *TransactionConfig) {
+		config.IdempotencyKey = key
+	}
+}
+
+// WithTxAccessMode returns a transaction configuration function that overrides the access mode of the session for a
+// single explicit or auto-commit transaction.
+// This allows a single session to interleave read-routed and write-routed transactions, while keeping the causal
+// chaining of the session bookmarks.
+//
+// To begin a read transaction from a session defaulting to writes:
+//	session.BeginTransaction(ctx, WithTxAccessMode(AccessModeRead))
+//
+// To run a read auto-commit transaction from a session defaulting to writes:
+//	session.Run(ctx, "MATCH (n) RETURN n", nil, WithTxAccessMode(AccessModeRead))
+//
+// Transaction functions reject this configuration function, since their access mode is already determined by
+// SessionWithContext.ExecuteRead and SessionWithContext.ExecuteWrite.
+func WithTxAccessMode(mode AccessMode) func(*TransactionConfig) {
+	return func(config *TransactionConfig) {
+		config.accessMode = &mode
+	}
+}
+
+// WithTxImpersonatedUser returns a transaction configuration function that overrides the user impersonated by the
+// session (see SessionConfig.ImpersonatedUser) for a single transaction. An empty user disables impersonation.
+// This allows a single session to serve the requests of several tenants.
+//
+// To run a write transaction function on behalf of a tenant:
+//	session.ExecuteWrite(ctx, DoWork, WithTxImpersonatedUser(tenant))
+//
+// To run an auto-commit transaction on behalf of a tenant:
+//	session.Run(ctx, "MATCH (n) RETURN n", nil, WithTxImpersonatedUser(tenant))
+//
+// The database of the session is not resolved again for the impersonated user: when the impersonated users have
+// different home databases, set SessionConfig.DatabaseName.
+func WithTxImpersonatedUser(user string) func(*TransactionConfig) {
+	return func(config *TransactionConfig) {
+		config.impersonatedUser = &user
+	}
+}
+
+// WithTxFetchSize returns a transaction configuration function that overrides the fetch size of the session (see
+// SessionConfig.FetchSize) for a single transaction.
+// This allows a single session to stream a large result without batching while keeping the default batching for
+// the other queries.
+//
+// To fetch all the records of an auto-commit transaction at once:
+//
+//	session.Run(ctx, "MATCH (n) RETURN n", nil, WithTxFetchSize(FetchAll))
+//
+// To fetch the records of a read transaction function in batches of 100 records:
+//
+//	session.ExecuteRead(ctx, DoWork, WithTxFetchSize(100))
+//
+// FetchDefault lets the driver decide the batch size for the transaction, regardless of the session fetch size.
+// Negative sizes other than FetchAll are rejected with a UsageError when the transaction starts.
+func WithTxFetchSize(size int) func(*TransactionConfig) {
+	return func(config *TransactionConfig) {
+		config.fetchSize = &size
+	}
+}
+
+// withWritersFallback returns a transaction configuration function that makes read transaction functions retry
+// against writers when the routing table does not contain any reader.
+func withWritersFallback() func(*TransactionConfig) {
+	return func(config *TransactionConfig) {
+		config.fallbackToWriters = true
+	}
+}
+
+// NewIdempotencyKey generates a random idempotency key, suitable for WithTxIdempotencyKey
+func NewIdempotencyKey() string {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buffer)
+}
+
+// metadataWithIdempotencyKey returns a copy of the given metadata including the given idempotency key, if any
+func metadataWithIdempotencyKey(metadata map[string]any, key string) map[string]any {
+	if key == "" {
+		return metadata
+	}
+	result := make(map[string]any, len(metadata)+1)
+	for k, v := range metadata {
+		result[k] = v
 	}
+	result[IdempotencyKeyMetadataKey] = key
+	return result
 }
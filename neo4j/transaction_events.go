@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"time"
+)
+
+// TransactionEventType identifies the transaction boundary reported to SessionConfig.OnTransactionEvent
+type TransactionEventType int
+
+const (
+	// TransactionBegun is emitted when an explicit transaction, or an attempt of a transaction function, begins
+	TransactionBegun TransactionEventType = iota
+	// TransactionCommitted is emitted when an explicit transaction, or an attempt of a transaction function, commits
+	TransactionCommitted
+	// TransactionRolledBack is emitted when an explicit transaction, or an attempt of a transaction function, is
+	// rolled back, either explicitly or because it failed
+	TransactionRolledBack
+	// AutoCommitStarted is emitted when an auto-commit transaction (see SessionWithContext.Run) starts, each query of
+	// SessionWithContext.RunBatch being a separate auto-commit transaction
+	AutoCommitStarted
+	// AutoCommitFinished is emitted when the result of an auto-commit transaction is fully consumed, or when the
+	// session moves on to another transaction or is closed
+	AutoCommitFinished
+)
+
+func (t TransactionEventType) String() string {
+	switch t {
+	case TransactionBegun:
+		return "begun"
+	case TransactionCommitted:
+		return "committed"
+	case TransactionRolledBack:
+		return "rolled back"
+	case AutoCommitStarted:
+		return "auto-commit started"
+	case AutoCommitFinished:
+		return "auto-commit finished"
+	default:
+		return "unknown"
+	}
+}
+
+// TransactionEvent describes a transaction boundary of a session, see SessionConfig.OnTransactionEvent
+type TransactionEvent struct {
+	Type TransactionEventType
+	// Managed is true for the transactions of transaction functions (see SessionWithContext.ExecuteRead and
+	// SessionWithContext.ExecuteWrite), each retried attempt being reported as a separate transaction
+	Managed bool
+	// Duration is the time spent beginning the transaction, connection acquisition included, for TransactionBegun
+	// and AutoCommitStarted events, and the time elapsed since the transaction began for the other events
+	Duration time.Duration
+	// Err is the reason why the transaction failed to begin, to commit, or was rolled back, nil otherwise
+	Err error
+}
+
+// transactionEvents times the boundaries of a transaction and reports them to SessionConfig.OnTransactionEvent
+// All methods are no-ops on nil events and the callback is skipped when none is configured.
+type transactionEvents struct {
+	onEvent func(context.Context, TransactionEvent)
+	managed bool
+	start   time.Time
+	begunAt time.Time
+	ended   bool
+}
+
+func newTransactionEvents(onEvent func(context.Context, TransactionEvent), managed bool) *transactionEvents {
+	return &transactionEvents{onEvent: onEvent, managed: managed, start: time.Now()}
+}
+
+// begun reports the outcome of the beginning of the transaction, started when the events were created
+func (e *transactionEvents) begun(ctx context.Context, eventType TransactionEventType, err error) {
+	if e == nil {
+		return
+	}
+	e.begunAt = time.Now()
+	e.notify(ctx, eventType, e.begunAt.Sub(e.start), err)
+}
+
+// end reports the end of the transaction, only once
+func (e *transactionEvents) end(ctx context.Context, eventType TransactionEventType, err error) {
+	if e == nil || e.ended {
+		return
+	}
+	e.ended = true
+	e.notify(ctx, eventType, time.Since(e.begunAt), err)
+}
+
+func (e *transactionEvents) notify(ctx context.Context, eventType TransactionEventType, duration time.Duration, err error) {
+	if e.onEvent == nil {
+		return
+	}
+	e.onEvent(ctx, TransactionEvent{Type: eventType, Managed: e.managed, Duration: duration, Err: err})
+}
@@ -51,6 +51,88 @@ func ExecuteWrite[T any](ctx context.Context, session SessionWithContext,
 	return castGeneric[T](session.ExecuteWrite(ctx, transactionWorkAdapter(work), configurers...))
 }
 
+// ExecuteReadWithSummary executes the given unit of work in a read transaction with
+// retry logic in place, via the provided session.
+//
+// This behaves like ExecuteRead but additionally returns the ResultSummary of the last result
+// produced by the unit of work, so that counters and notifications can be inspected without
+// passing them through the returned value.
+// The last result is consumed, if needed, before the transaction is committed.
+//
+// The returned ResultSummary is nil if the unit of work does not run any query.
+// If an error occurs, the zero value of T and a nil ResultSummary are returned.
+func ExecuteReadWithSummary[T any](ctx context.Context, session SessionWithContext,
+	work ManagedTransactionWorkT[T],
+	configurers ...func(config *TransactionConfig)) (T, ResultSummary, error) {
+
+	return executeWithSummary(ctx, session.ExecuteRead, work, configurers...)
+}
+
+// ExecuteWriteWithSummary executes the given unit of work in a write transaction with
+// retry logic in place, via the provided session.
+//
+// This behaves like ExecuteWrite but additionally returns the ResultSummary of the last result
+// produced by the unit of work, so that counters and notifications can be inspected without
+// passing them through the returned value.
+// The last result is consumed, if needed, before the transaction is committed.
+//
+// The returned ResultSummary is nil if the unit of work does not run any query.
+// If an error occurs, the zero value of T and a nil ResultSummary are returned.
+func ExecuteWriteWithSummary[T any](ctx context.Context, session SessionWithContext,
+	work ManagedTransactionWorkT[T],
+	configurers ...func(config *TransactionConfig)) (T, ResultSummary, error) {
+
+	return executeWithSummary(ctx, session.ExecuteWrite, work, configurers...)
+}
+
+type valueWithSummary[T any] struct {
+	value   T
+	summary ResultSummary
+}
+
+func executeWithSummary[T any](ctx context.Context, txFunction transactionFunction,
+	work ManagedTransactionWorkT[T],
+	configurers ...func(config *TransactionConfig)) (T, ResultSummary, error) {
+
+	result, err := castGeneric[*valueWithSummary[T]](txFunction(ctx, func(tx ManagedTransaction) (any, error) {
+		trackingTx := &resultTrackingTransaction{delegate: tx}
+		value, err := work(trackingTx)
+		if err != nil {
+			return nil, err
+		}
+		var summary ResultSummary
+		if trackingTx.lastResult != nil {
+			if summary, err = trackingTx.lastResult.Consume(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return &valueWithSummary[T]{value: value, summary: summary}, nil
+	}, configurers...))
+	if err != nil {
+		return *new(T), nil, err
+	}
+	return result.value, result.summary, nil
+}
+
+// resultTrackingTransaction keeps track of the last result created by the decorated transaction
+type resultTrackingTransaction struct {
+	delegate   ManagedTransaction
+	lastResult ResultWithContext
+}
+
+func (tx *resultTrackingTransaction) Run(ctx context.Context, cypher string, params map[string]any) (ResultWithContext, error) {
+	result, err := tx.delegate.Run(ctx, cypher, params)
+	if err != nil {
+		return nil, err
+	}
+	tx.lastResult = result
+	return result, nil
+}
+
+func (tx *resultTrackingTransaction) legacy() Transaction {
+	return tx.delegate.legacy()
+}
+
 func transactionWorkAdapter[T any](work ManagedTransactionWorkT[T]) ManagedTransactionWork {
 	return func(tx ManagedTransaction) (any, error) {
 		return work(tx)
@@ -77,8 +77,71 @@ func TestExecuteWrite(outer *testing.T) {
 	})
 }
 
+func TestExecuteWithSummary(outer *testing.T) {
+	outer.Parallel()
+
+	ctx := context.Background()
+	executors := map[string]func(neo4j.SessionWithContext, neo4j.ManagedTransactionWorkT[int]) (int, neo4j.ResultSummary, error){
+		"read": func(session neo4j.SessionWithContext, work neo4j.ManagedTransactionWorkT[int]) (int, neo4j.ResultSummary, error) {
+			return neo4j.ExecuteReadWithSummary[int](ctx, session, work)
+		},
+		"write": func(session neo4j.SessionWithContext, work neo4j.ManagedTransactionWorkT[int]) (int, neo4j.ResultSummary, error) {
+			return neo4j.ExecuteWriteWithSummary[int](ctx, session, work)
+		},
+	}
+
+	for name, execute := range executors {
+		outer.Run(fmt.Sprintf("%s returns value with summary of last result", name), func(t *testing.T) {
+			firstSummary := &fakeSummary{}
+			lastSummary := &fakeSummary{}
+			tx := &fakeSummaryTransaction{summaries: []neo4j.ResultSummary{firstSummary, lastSummary}}
+			session := &fakeSession{transaction: tx}
+
+			result, summary, err := execute(session, func(tx neo4j.ManagedTransaction) (int, error) {
+				if _, err := tx.Run(ctx, "RETURN 1", nil); err != nil {
+					return 0, err
+				}
+				if _, err := tx.Run(ctx, "RETURN 2", nil); err != nil {
+					return 0, err
+				}
+				return 42, nil
+			})
+
+			AssertNoError(t, err)
+			AssertIntEqual(t, result, 42)
+			AssertTrue(t, summary == lastSummary)
+		})
+
+		outer.Run(fmt.Sprintf("%s returns nil summary when no query is run", name), func(t *testing.T) {
+			session := &fakeSession{transaction: &fakeSummaryTransaction{}}
+
+			result, summary, err := execute(session, func(tx neo4j.ManagedTransaction) (int, error) {
+				return 42, nil
+			})
+
+			AssertNoError(t, err)
+			AssertIntEqual(t, result, 42)
+			AssertNil(t, summary)
+		})
+
+		outer.Run(fmt.Sprintf("%s returns work error", name), func(t *testing.T) {
+			session := &fakeSession{transaction: &fakeSummaryTransaction{summaries: []neo4j.ResultSummary{&fakeSummary{}}}}
+
+			result, summary, err := execute(session, func(tx neo4j.ManagedTransaction) (int, error) {
+				_, _ = tx.Run(ctx, "RETURN 1", nil)
+				return -1, fmt.Errorf("nope")
+			})
+
+			AssertErrorMessageContains(t, err, "nope")
+			AssertIntEqual(t, result, 0)
+			AssertNil(t, summary)
+		})
+	}
+}
+
 type fakeSession struct {
 	neo4j.SessionWithContext
+	transaction neo4j.ManagedTransaction
 }
 
 func (f *fakeSession) LastBookmarks() neo4j.Bookmarks {
@@ -95,11 +158,18 @@ func (f *fakeSession) BeginTransaction(ctx context.Context, configurers ...func(
 }
 
 func (f *fakeSession) ExecuteRead(_ context.Context, work neo4j.ManagedTransactionWork, _ ...func(*neo4j.TransactionConfig)) (any, error) {
-	return work(&FakeTransaction{})
+	return work(f.managedTransaction())
 }
 
 func (f *fakeSession) ExecuteWrite(_ context.Context, work neo4j.ManagedTransactionWork, _ ...func(*neo4j.TransactionConfig)) (any, error) {
-	return work(&FakeTransaction{})
+	return work(f.managedTransaction())
+}
+
+func (f *fakeSession) managedTransaction() neo4j.ManagedTransaction {
+	if f.transaction != nil {
+		return f.transaction
+	}
+	return &FakeTransaction{}
 }
 
 func (f *fakeSession) Run(context.Context, string, map[string]any, ...func(*neo4j.TransactionConfig)) (neo4j.ResultWithContext, error) {
@@ -132,3 +202,28 @@ func (f *FakeTransaction) Run(ctx context.Context, cypher string, params map[str
 func (f *FakeTransaction) legacy() neo4j.Transaction {
 	panic("implement me")
 }
+
+type fakeSummaryTransaction struct {
+	neo4j.ManagedTransaction
+	summaries []neo4j.ResultSummary
+	runs      int
+}
+
+func (f *fakeSummaryTransaction) Run(context.Context, string, map[string]any) (neo4j.ResultWithContext, error) {
+	result := &fakeSummaryResult{summary: f.summaries[f.runs]}
+	f.runs++
+	return result, nil
+}
+
+type fakeSummaryResult struct {
+	neo4j.ResultWithContext
+	summary neo4j.ResultSummary
+}
+
+func (f *fakeSummaryResult) Consume(context.Context) (neo4j.ResultSummary, error) {
+	return f.summary, nil
+}
+
+type fakeSummary struct {
+	neo4j.ResultSummary
+}
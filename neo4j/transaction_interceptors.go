@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import "context"
+
+// TxOperationType identifies the transaction operation intercepted by a TxInterceptor
+type TxOperationType int
+
+const (
+	// TxBeginOperation begins an explicit transaction, or an attempt of a transaction function
+	TxBeginOperation TxOperationType = iota
+	// TxRunOperation runs a query in an explicit transaction, a transaction function or an auto-commit transaction
+	TxRunOperation
+	// TxCommitOperation commits an explicit transaction, or an attempt of a transaction function
+	TxCommitOperation
+	// TxRollbackOperation rolls back an explicit transaction
+	TxRollbackOperation
+)
+
+func (t TxOperationType) String() string {
+	switch t {
+	case TxBeginOperation:
+		return "begin"
+	case TxRunOperation:
+		return "run"
+	case TxCommitOperation:
+		return "commit"
+	case TxRollbackOperation:
+		return "rollback"
+	default:
+		return "unknown"
+	}
+}
+
+// TxOperation describes a transaction operation intercepted by a TxInterceptor
+type TxOperation struct {
+	Type TxOperationType
+	// Managed is true for the operations of transaction functions (see SessionWithContext.ExecuteRead and
+	// SessionWithContext.ExecuteWrite)
+	Managed bool
+	// AutoCommit is true for the queries of auto-commit transactions (see SessionWithContext.Run and
+	// SessionWithContext.RunBatch)
+	AutoCommit bool
+	// Cypher is the query of TxRunOperation operations, interceptors can rewrite it before calling the next one
+	Cypher string
+	// Params are the parameters of TxRunOperation operations, interceptors can replace them before calling the next
+	// one
+	Params map[string]any
+	// Metadata is the metadata of the transaction.
+	// Interceptors can replace it before calling the next one for TxBeginOperation operations and the
+	// TxRunOperation operations of auto-commit transactions, the metadata being sent along with these operations.
+	Metadata map[string]any
+}
+
+// TxInterceptor intercepts the operations of transactions, see Config.TransactionInterceptors.
+// It is expected to call next to proceed with the operation, and to return its error, e.g. to log queries:
+//
+//	func auditQueries(ctx context.Context, operation *neo4j.TxOperation,
+//		next func(context.Context, *neo4j.TxOperation) error) error {
+//		if operation.Type == neo4j.TxRunOperation {
+//			audit.Printf("running %s", operation.Cypher)
+//		}
+//		return next(ctx, operation)
+//	}
+//
+// Returning an error without calling next aborts the operation.
+type TxInterceptor func(ctx context.Context, operation *TxOperation, next func(context.Context, *TxOperation) error) error
+
+// txInterceptors chains the interceptors of Config.TransactionInterceptors, the first one being the outermost
+type txInterceptors []TxInterceptor
+
+// intercept calls the interceptors with the given operation, the innermost one calling perform with the operation as
+// possibly changed by the interceptors
+func (interceptors txInterceptors) intercept(ctx context.Context, operation *TxOperation,
+	perform func(context.Context, *TxOperation) error) error {
+
+	if len(interceptors) == 0 {
+		return perform(ctx, operation)
+	}
+	performed := false
+	next := func(ctx context.Context, operation *TxOperation) error {
+		performed = true
+		return perform(ctx, operation)
+	}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := interceptors[i], next
+		next = func(ctx context.Context, operation *TxOperation) error {
+			return interceptor(ctx, operation, inner)
+		}
+	}
+	if err := next(ctx, operation); err != nil {
+		return err
+	}
+	if !performed {
+		return &UsageError{Message: "a transaction interceptor neither proceeded with the " +
+			operation.Type.String() + " operation nor returned an error"}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"testing"
+)
+
+func TestTxInterceptors(outer *testing.T) {
+	outer.Parallel()
+
+	ctx := context.Background()
+	tracing := func(name string, calls *[]string) TxInterceptor {
+		return func(ctx context.Context, operation *TxOperation, next func(context.Context, *TxOperation) error) error {
+			*calls = append(*calls, name+" before")
+			err := next(ctx, operation)
+			*calls = append(*calls, name+" after")
+			return err
+		}
+	}
+
+	outer.Run("calls interceptors in order around the operation", func(t *testing.T) {
+		var calls []string
+		interceptors := txInterceptors{tracing("first", &calls), tracing("second", &calls)}
+
+		err := interceptors.intercept(ctx, &TxOperation{Type: TxRunOperation}, func(context.Context, *TxOperation) error {
+			calls = append(calls, "operation")
+			return nil
+		})
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, calls, []string{"first before", "second before", "operation", "second after", "first after"})
+	})
+
+	outer.Run("performs the operation as changed by the interceptors", func(t *testing.T) {
+		interceptors := txInterceptors{
+			func(ctx context.Context, operation *TxOperation, next func(context.Context, *TxOperation) error) error {
+				operation.Cypher = "/* audited */ " + operation.Cypher
+				return next(ctx, operation)
+			},
+		}
+		var performed string
+
+		err := interceptors.intercept(ctx, &TxOperation{Type: TxRunOperation, Cypher: "RETURN 1"},
+			func(_ context.Context, operation *TxOperation) error {
+				performed = operation.Cypher
+				return nil
+			})
+
+		AssertNoError(t, err)
+		AssertStringEqual(t, performed, "/* audited */ RETURN 1")
+	})
+
+	outer.Run("returns the error of the operation", func(t *testing.T) {
+		var calls []string
+		operationErr := errors.New("boom")
+
+		err := txInterceptors{tracing("first", &calls)}.intercept(ctx, &TxOperation{},
+			func(context.Context, *TxOperation) error {
+				return operationErr
+			})
+
+		AssertDeepEquals(t, err, operationErr)
+	})
+
+	outer.Run("aborts the operation when an interceptor fails", func(t *testing.T) {
+		vetoErr := errors.New("forbidden")
+		interceptors := txInterceptors{
+			func(context.Context, *TxOperation, func(context.Context, *TxOperation) error) error {
+				return vetoErr
+			},
+		}
+		performed := false
+
+		err := interceptors.intercept(ctx, &TxOperation{}, func(context.Context, *TxOperation) error {
+			performed = true
+			return nil
+		})
+
+		AssertDeepEquals(t, err, vetoErr)
+		AssertFalse(t, performed)
+	})
+
+	outer.Run("fails when an interceptor neither proceeds nor fails", func(t *testing.T) {
+		interceptors := txInterceptors{
+			func(context.Context, *TxOperation, func(context.Context, *TxOperation) error) error {
+				return nil
+			},
+		}
+
+		err := interceptors.intercept(ctx, &TxOperation{Type: TxCommitOperation}, func(context.Context, *TxOperation) error {
+			return nil
+		})
+
+		AssertSameType(t, err, &UsageError{})
+		AssertErrorMessageContains(t, err, "commit operation")
+	})
+}
@@ -22,6 +22,7 @@ package neo4j
 import (
 	"context"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"time"
 )
 
 // ManagedTransaction represents a transaction managed by the driver and operated on by the user, via transaction functions
@@ -55,26 +56,90 @@ type ExplicitTransaction interface {
 
 // Transaction implementation when explicit transaction started
 type explicitTransaction struct {
-	conn      db.Connection
-	fetchSize int
-	txHandle  db.TxHandle
-	done      bool
-	runFailed bool
-	err       error
-	onClosed  func(*explicitTransaction)
+	conn                db.Connection
+	fetchSize           int
+	txHandle            db.TxHandle
+	done                bool
+	committed           bool
+	runFailed           bool
+	err                 error
+	onClosed            func(*explicitTransaction)
+	resultScope         resultScope
+	inFlight            inFlightResults
+	unconsumed          unconsumedResults
+	statistics          *queryStatisticsCollector
+	explainer           *queryExplainer
+	explains            []deferredExplain
+	linter              *literalLinter
+	queryLogger         *queryLogger
+	unlogged            unloggedQueries
+	coerceParams        bool
+	annotator           func(context.Context) map[string]string
+	onDeprecationNotice func(DeprecationNotice)
+	interceptors        txInterceptors
+	// metadata is the metadata the transaction was begun with
+	metadata map[string]any
+	// connectionAcquisition is the time spent acquiring the connection of the transaction
+	connectionAcquisition time.Duration
+	events                *transactionEvents
 }
 
 func (tx *explicitTransaction) Run(ctx context.Context, cypher string,
 	params map[string]any) (ResultWithContext, error) {
-	stream, err := tx.conn.RunTx(ctx, tx.txHandle, db.Command{Cypher: cypher, Params: params, FetchSize: tx.fetchSize})
+	if err := tx.inFlight.check(1); err != nil {
+		return nil, err
+	}
+	tx.linter.lint(cypher)
+	if tx.coerceParams {
+		var err error
+		if params, err = coerceParameters(params); err != nil {
+			return nil, err
+		}
+	}
+	tx.explains = tx.explainer.deferExplain(tx.explains, cypher, params)
+	start := time.Now()
+	stream, err := tx.run(ctx, &cypher, &params)
+	request := time.Since(start)
+	tx.statistics.onQuery()
+	queryLog := tx.queryLogger.start(ctx, tx.conn.ServerName(), cypher, params)
 	if err != nil {
+		tx.statistics.onFailure(err)
+		queryLog.done(ClientDurations{ConnectionAcquisition: tx.connectionAcquisition, Request: request}, wrapError(err))
 		tx.err = err
 		tx.runFailed = true
 		tx.onClosed(tx)
+		tx.events.end(ctx, TransactionRolledBack, wrapError(tx.err))
 		return nil, wrapError(tx.err)
 	}
 	// no result consumption hook here since bookmarks are sent after commit, not after pulling results
-	return newResultWithContext(tx.conn, stream, cypher, params, nil), nil
+	result := newResultWithContext(tx.conn, stream, cypher, params, nil)
+	result.durations.ConnectionAcquisition = tx.connectionAcquisition
+	result.durations.Request = request
+	result.statistics = tx.statistics
+	result.onDeprecationNotice = tx.onDeprecationNotice
+	result.queryLog = queryLog
+	tx.resultScope.track(result)
+	tx.inFlight.track(result)
+	tx.unconsumed.track(result)
+	tx.unlogged.track(result)
+	return result, nil
+}
+
+// run runs the query through Config.TransactionInterceptors, which may rewrite the query and its parameters
+func (tx *explicitTransaction) run(ctx context.Context, cypher *string, params *map[string]any) (db.StreamHandle, error) {
+	operation := &TxOperation{Type: TxRunOperation, Cypher: *cypher, Params: *params, Metadata: tx.metadata}
+	var stream db.StreamHandle
+	err := tx.interceptors.intercept(ctx, operation, func(ctx context.Context, operation *TxOperation) error {
+		*cypher, *params = operation.Cypher, operation.Params
+		var err error
+		stream, err = tx.conn.RunTx(ctx, tx.txHandle, db.Command{
+			Cypher:    annotateStatement(ctx, *cypher, tx.annotator),
+			Params:    *params,
+			FetchSize: tx.fetchSize,
+		})
+		return err
+	})
+	return stream, err
 }
 
 func (tx *explicitTransaction) Commit(ctx context.Context) error {
@@ -85,10 +150,28 @@ func (tx *explicitTransaction) Commit(ctx context.Context) error {
 	if tx.done {
 		return transactionAlreadyCompletedError()
 	}
-	tx.err = tx.conn.TxCommit(ctx, tx.txHandle)
+	if err := tx.unconsumed.beforeCommit(ctx); err != nil {
+		return err
+	}
+	commitOperation := &TxOperation{Type: TxCommitOperation, Metadata: tx.metadata}
+	tx.err = tx.interceptors.intercept(ctx, commitOperation, func(ctx context.Context, _ *TxOperation) error {
+		return tx.conn.TxCommit(ctx, tx.txHandle)
+	})
+	tx.unlogged.flush(wrapError(tx.err))
 	tx.done = true
+	tx.committed = tx.err == nil
+	// the connection must not be used once returned to the pool, where other sessions may borrow it
+	alive := tx.conn.IsAlive()
 	tx.onClosed(tx)
-	return wrapError(tx.err)
+	var err error
+	if tx.err != nil && !alive {
+		// the commit may or may not have reached the server before the connection died
+		err = &CommitAmbiguousError{inner: tx.err}
+	} else {
+		err = wrapError(tx.err)
+	}
+	tx.events.end(ctx, TransactionCommitted, err)
+	return err
 }
 
 func (tx *explicitTransaction) Close(ctx context.Context) error {
@@ -107,14 +190,18 @@ func (tx *explicitTransaction) Rollback(ctx context.Context) error {
 	if tx.done {
 		return transactionAlreadyCompletedError()
 	}
-	if !tx.conn.IsAlive() || tx.conn.HasFailed() {
-		// tx implicitly rolled back by having failed
-		tx.err = nil
-	} else {
-		tx.err = tx.conn.TxRollback(ctx, tx.txHandle)
-	}
+	rollbackOperation := &TxOperation{Type: TxRollbackOperation, Metadata: tx.metadata}
+	tx.err = tx.interceptors.intercept(ctx, rollbackOperation, func(ctx context.Context, _ *TxOperation) error {
+		if !tx.conn.IsAlive() || tx.conn.HasFailed() {
+			// tx implicitly rolled back by having failed
+			return nil
+		}
+		return tx.conn.TxRollback(ctx, tx.txHandle)
+	})
+	tx.unlogged.flush(nil)
 	tx.done = true
 	tx.onClosed(tx)
+	tx.events.end(ctx, TransactionRolledBack, wrapError(tx.err))
 	return wrapError(tx.err)
 }
 
@@ -126,18 +213,148 @@ func (tx *explicitTransaction) legacy() Transaction {
 
 // ManagedTransaction implementation used as parameter to transactional functions
 type managedTransaction struct {
-	conn      db.Connection
-	fetchSize int
-	txHandle  db.TxHandle
+	conn                db.Connection
+	fetchSize           int
+	txHandle            db.TxHandle
+	resultScope         resultScope
+	inFlight            inFlightResults
+	statistics          *queryStatisticsCollector
+	explainer           *queryExplainer
+	explains            []deferredExplain
+	linter              *literalLinter
+	queryLogger         *queryLogger
+	unlogged            unloggedQueries
+	coerceParams        bool
+	annotator           func(context.Context) map[string]string
+	onDeprecationNotice func(DeprecationNotice)
+	idempotencyKey      string
+	attempt             TransactionAttempt
+	interceptors        txInterceptors
+	// metadata is the metadata the transaction was begun with
+	metadata map[string]any
+	// connectionAcquisition is the time spent acquiring the connection of the transaction
+	connectionAcquisition time.Duration
+}
+
+// TxIdempotencyKey returns the idempotency key configured with WithTxIdempotencyKey for the transaction function
+// running the given transaction, or an empty string if none was configured.
+func TxIdempotencyKey(tx ManagedTransaction) string {
+	if managedTx := unwrapManagedTransaction(tx); managedTx != nil {
+		return managedTx.idempotencyKey
+	}
+	return ""
+}
+
+// unwrapManagedTransaction returns the transaction of the transaction function, which the given transaction may
+// decorate (see ExecuteReadWithSummary), or nil if the given transaction is not run by a transaction function
+func unwrapManagedTransaction(tx ManagedTransaction) *managedTransaction {
+	for {
+		switch concreteTx := tx.(type) {
+		case *managedTransaction:
+			return concreteTx
+		case *resultTrackingTransaction:
+			tx = concreteTx.delegate
+		default:
+			return nil
+		}
+	}
+}
+
+// TransactionAttempt describes the current attempt of a transaction function
+type TransactionAttempt struct {
+	// Number is the number of the attempt, starting at 1 for the first execution of the transaction function
+	Number int
+	// RetryDelay is the total time the driver waited before retrying the transaction function so far
+	RetryDelay time.Duration
+}
+
+type transactionAttemptKey struct{}
+
+func withTransactionAttempt(ctx context.Context, attempt TransactionAttempt) context.Context {
+	return context.WithValue(ctx, transactionAttemptKey{}, attempt)
+}
+
+// TransactionAttemptFrom returns the attempt of the transaction function carried by the given context, and false
+// outside transaction functions.
+// The attempt is carried by the contexts the driver passes to the listeners of transaction events (see
+// SessionConfig.OnTransactionEvent) and to Config.StatementAnnotator for the queries run by transaction functions.
+// Transaction functions themselves can use TxAttempt instead.
+func TransactionAttemptFrom(ctx context.Context) (TransactionAttempt, bool) {
+	attempt, ok := ctx.Value(transactionAttemptKey{}).(TransactionAttempt)
+	return attempt, ok
+}
+
+// TxAttempt returns the attempt of the transaction function running the given transaction, for instance to log
+// retries:
+//
+//	neo4j.ExecuteWrite(ctx, session, func(tx neo4j.ManagedTransaction) (any, error) {
+//		if attempt := neo4j.TxAttempt(tx); attempt.Number > 1 {
+//			logger.Printf("attempt %d after waiting %s", attempt.Number, attempt.RetryDelay)
+//		}
+//		...
+//	})
+//
+// The zero TransactionAttempt is returned for transactions not run by transaction functions.
+func TxAttempt(tx ManagedTransaction) TransactionAttempt {
+	if managedTx := unwrapManagedTransaction(tx); managedTx != nil {
+		return managedTx.attempt
+	}
+	return TransactionAttempt{}
 }
 
 func (tx *managedTransaction) Run(ctx context.Context, cypher string, params map[string]any) (ResultWithContext, error) {
-	stream, err := tx.conn.RunTx(ctx, tx.txHandle, db.Command{Cypher: cypher, Params: params, FetchSize: tx.fetchSize})
+	if tx.attempt.Number > 0 {
+		ctx = withTransactionAttempt(ctx, tx.attempt)
+	}
+	if err := tx.inFlight.check(1); err != nil {
+		return nil, err
+	}
+	tx.linter.lint(cypher)
+	if tx.coerceParams {
+		var err error
+		if params, err = coerceParameters(params); err != nil {
+			return nil, err
+		}
+	}
+	tx.explains = tx.explainer.deferExplain(tx.explains, cypher, params)
+	start := time.Now()
+	stream, err := tx.run(ctx, &cypher, &params)
+	request := time.Since(start)
+	tx.statistics.onQuery()
+	queryLog := tx.queryLogger.start(ctx, tx.conn.ServerName(), cypher, params)
 	if err != nil {
+		tx.statistics.onFailure(err)
+		queryLog.done(ClientDurations{ConnectionAcquisition: tx.connectionAcquisition, Request: request}, wrapError(err))
 		return nil, wrapError(err)
 	}
 	// no result consumption hook here since bookmarks are sent after commit, not after pulling results
-	return newResultWithContext(tx.conn, stream, cypher, params, nil), nil
+	result := newResultWithContext(tx.conn, stream, cypher, params, nil)
+	result.durations.ConnectionAcquisition = tx.connectionAcquisition
+	result.durations.Request = request
+	result.statistics = tx.statistics
+	result.onDeprecationNotice = tx.onDeprecationNotice
+	result.queryLog = queryLog
+	tx.resultScope.track(result)
+	tx.inFlight.track(result)
+	tx.unlogged.track(result)
+	return result, nil
+}
+
+// run runs the query through Config.TransactionInterceptors, which may rewrite the query and its parameters
+func (tx *managedTransaction) run(ctx context.Context, cypher *string, params *map[string]any) (db.StreamHandle, error) {
+	operation := &TxOperation{Type: TxRunOperation, Managed: true, Cypher: *cypher, Params: *params, Metadata: tx.metadata}
+	var stream db.StreamHandle
+	err := tx.interceptors.intercept(ctx, operation, func(ctx context.Context, operation *TxOperation) error {
+		*cypher, *params = operation.Cypher, operation.Params
+		var err error
+		stream, err = tx.conn.RunTx(ctx, tx.txHandle, db.Command{
+			Cypher:    annotateStatement(ctx, *cypher, tx.annotator),
+			Params:    *params,
+			FetchSize: tx.fetchSize,
+		})
+		return err
+	})
+	return stream, err
 }
 
 // legacy interop only - remove in 6.0
@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Common transaction metadata keys, which show up in the server query logs and in the listing of the running
+// transactions
+const (
+	// TxMetadataApp is the transaction metadata key of the name of the application running the transaction
+	TxMetadataApp = "app"
+	// TxMetadataTxType is the transaction metadata key of the kind of work the transaction performs
+	TxMetadataTxType = "txType"
+)
+
+// TxMetadataMaxSize is the maximum size, in bytes, of the transaction metadata built by TxMetadataBuilder.
+// The size is approximated as the size of the keys and values once encoded.
+const TxMetadataMaxSize = 16 * 1024
+
+// TxMetadataBuilder builds transaction metadata that is checked on the client side, instead of failing late on the
+// server. Keys must not be empty and values must be nil, booleans, numbers, strings, byte slices, or lists and
+// string-keyed maps of those.
+//
+//	metadata := neo4j.NewTxMetadataBuilder().
+//		App("billing").
+//		TxType("invoice-export").
+//		With("requestId", requestId)
+//	session.ExecuteWrite(ctx, DoWork, neo4j.WithTxMetadataBuilder(metadata))
+//
+// The first invalid entry is reported by Build, or as a UsageError by the operation configured with
+// WithTxMetadataBuilder, before anything is sent to the server.
+type TxMetadataBuilder struct {
+	metadata map[string]any
+	err      error
+}
+
+// NewTxMetadataBuilder returns a builder of empty transaction metadata
+func NewTxMetadataBuilder() *TxMetadataBuilder {
+	return &TxMetadataBuilder{metadata: map[string]any{}}
+}
+
+// App sets the name of the application running the transaction, under TxMetadataApp
+func (b *TxMetadataBuilder) App(name string) *TxMetadataBuilder {
+	return b.With(TxMetadataApp, name)
+}
+
+// TxType sets the kind of work the transaction performs, under TxMetadataTxType
+func (b *TxMetadataBuilder) TxType(txType string) *TxMetadataBuilder {
+	return b.With(TxMetadataTxType, txType)
+}
+
+// With sets the metadata entry of the given key, replacing any previous value
+func (b *TxMetadataBuilder) With(key string, value any) *TxMetadataBuilder {
+	if b.err != nil {
+		return b
+	}
+	if key == "" {
+		b.err = &UsageError{Message: "transaction metadata keys must not be empty"}
+		return b
+	}
+	if err := validateTxMetadataValue(value); err != nil {
+		b.err = &UsageError{Message: fmt.Sprintf("invalid transaction metadata %q: %s", key, err)}
+		return b
+	}
+	b.metadata[key] = value
+	return b
+}
+
+// Build returns a copy of the metadata built so far, or the error of the first invalid entry.
+// An error is also returned when the metadata exceeds TxMetadataMaxSize.
+func (b *TxMetadataBuilder) Build() (map[string]any, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	size := 0
+	metadata := make(map[string]any, len(b.metadata))
+	for key, value := range b.metadata {
+		size += len(key) + txMetadataValueSize(value)
+		metadata[key] = value
+	}
+	if size > TxMetadataMaxSize {
+		return nil, &UsageError{Message: fmt.Sprintf(
+			"transaction metadata of %d bytes exceeds the limit of %d bytes (keys: %v)", size, TxMetadataMaxSize, sortedKeys(metadata))}
+	}
+	return metadata, nil
+}
+
+// WithTxMetadataBuilder returns a transaction configuration function that attaches the metadata built by the given
+// builder to a transaction, see WithTxMetadata.
+// Invalid metadata fails the operation with a UsageError before any request is sent to the server.
+func WithTxMetadataBuilder(builder *TxMetadataBuilder) func(*TransactionConfig) {
+	return func(config *TransactionConfig) {
+		config.Metadata, config.metadataErr = builder.Build()
+	}
+}
+
+func validateTxMetadataValue(value any) error {
+	switch v := value.(type) {
+	case nil, bool, string, []byte,
+		int, int8, int16, int32, int64, uint8, uint16, uint32, float32, float64:
+		return nil
+	case []string, []int64, []float64:
+		return nil
+	case []any:
+		for i, element := range v {
+			if err := validateTxMetadataValue(element); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return nil
+	case map[string]string:
+		return nil
+	case map[string]any:
+		for key, element := range v {
+			if err := validateTxMetadataValue(element); err != nil {
+				return fmt.Errorf("entry %q: %w", key, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %T", value)
+	}
+}
+
+// txMetadataValueSize approximates the encoded size of a validated metadata value
+func txMetadataValueSize(value any) int {
+	switch v := value.(type) {
+	case string:
+		return len(v)
+	case []byte:
+		return len(v)
+	case []string:
+		size := 0
+		for _, element := range v {
+			size += len(element)
+		}
+		return size
+	case []int64:
+		return 8 * len(v)
+	case []float64:
+		return 8 * len(v)
+	case []any:
+		size := 0
+		for _, element := range v {
+			size += txMetadataValueSize(element)
+		}
+		return size
+	case map[string]string:
+		size := 0
+		for key, element := range v {
+			size += len(key) + len(element)
+		}
+		return size
+	case map[string]any:
+		size := 0
+		for key, element := range v {
+			size += len(key) + txMetadataValueSize(element)
+		}
+		return size
+	default:
+		return 8
+	}
+}
+
+func sortedKeys(metadata map[string]any) []string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
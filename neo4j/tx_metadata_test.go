@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+)
+
+func TestTxMetadataBuilder(outer *testing.T) {
+	outer.Run("builds metadata with common keys", func(t *testing.T) {
+		metadata, err := NewTxMetadataBuilder().
+			App("billing").
+			TxType("invoice-export").
+			With("attempt", 2).
+			With("tags", []any{"eu", map[string]any{"priority": true}}).
+			Build()
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, metadata, map[string]any{
+			"app":     "billing",
+			"txType":  "invoice-export",
+			"attempt": 2,
+			"tags":    []any{"eu", map[string]any{"priority": true}},
+		})
+	})
+
+	outer.Run("rejects empty keys", func(t *testing.T) {
+		_, err := NewTxMetadataBuilder().With("", "value").Build()
+
+		AssertSameType(t, err, &UsageError{})
+		AssertErrorMessageContains(t, err, "must not be empty")
+	})
+
+	outer.Run("rejects unsupported values", func(t *testing.T) {
+		_, err := NewTxMetadataBuilder().
+			With("nested", map[string]any{"channel": make(chan int)}).
+			App("billing").
+			Build()
+
+		AssertSameType(t, err, &UsageError{})
+		AssertErrorMessageContains(t, err, `invalid transaction metadata "nested": entry "channel": unsupported type chan int`)
+	})
+
+	outer.Run("rejects oversized metadata", func(t *testing.T) {
+		_, err := NewTxMetadataBuilder().With("payload", strings.Repeat("x", TxMetadataMaxSize)).Build()
+
+		AssertSameType(t, err, &UsageError{})
+		AssertErrorMessageContains(t, err, "exceeds the limit")
+	})
+
+	outer.Run("fails transactions before contacting the server", func(t *testing.T) {
+		pool := &PoolFake{}
+		sess := newSessionWithContext(&Config{}, SessionConfig{}, &RouterFake{}, pool, &log.Void{})
+		builder := NewTxMetadataBuilder().With("invalid", struct{}{})
+
+		_, err := sess.Run(context.Background(), "RETURN 1", nil, WithTxMetadataBuilder(builder))
+
+		AssertSameType(t, err, &UsageError{})
+		AssertTrue(t, pool.BorrowCtx == nil)
+	})
+
+	outer.Run("forgets invalid metadata once replaced", func(t *testing.T) {
+		invalid := WithTxMetadataBuilder(NewTxMetadataBuilder().With("invalid", struct{}{}))
+		pool := &PoolFake{BorrowConn: &ConnFake{Alive: true}}
+		sessConfig := SessionConfig{DefaultTransactionConfigurers: []func(*TransactionConfig){invalid}}
+		sess := newSessionWithContext(&Config{}, sessConfig, &RouterFake{}, pool, &log.Void{})
+
+		_, err := sess.Run(context.Background(), "RETURN 1", nil, WithTxMetadata(map[string]any{"valid": true}))
+
+		AssertNoError(t, err)
+	})
+}
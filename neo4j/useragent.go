@@ -20,3 +20,20 @@
 package neo4j
 
 const UserAgent = "Go Driver/5.0"
+
+// AppUserAgent composes a user agent suffix (see Config.UserAgentSuffix) from the name and version of an application,
+// e.g. "inventory-service/1.4.2". The version is omitted when empty.
+func AppUserAgent(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return name + "/" + version
+}
+
+// userAgentOf returns the user agent sent by the driver, with the configured suffix appended, if any
+func userAgentOf(config *Config) string {
+	if config.UserAgentSuffix == "" {
+		return config.UserAgent
+	}
+	return config.UserAgent + " " + config.UserAgentSuffix
+}
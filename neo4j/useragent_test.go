@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"testing"
+)
+
+func TestUserAgent(outer *testing.T) {
+	outer.Run("composes application user agents", func(t *testing.T) {
+		AssertStringEqual(t, AppUserAgent("inventory-service", "1.4.2"), "inventory-service/1.4.2")
+		AssertStringEqual(t, AppUserAgent("inventory-service", ""), "inventory-service")
+	})
+
+	outer.Run("appends the suffix to the user agent", func(t *testing.T) {
+		config := defaultConfig()
+		config.UserAgentSuffix = AppUserAgent("inventory-service", "1.4.2")
+
+		AssertStringEqual(t, userAgentOf(config), UserAgent+" inventory-service/1.4.2")
+	})
+
+	outer.Run("appends the suffix to a custom user agent", func(t *testing.T) {
+		config := defaultConfig()
+		config.UserAgent = "Custom/1.0"
+		config.UserAgentSuffix = "app"
+
+		AssertStringEqual(t, userAgentOf(config), "Custom/1.0 app")
+	})
+
+	outer.Run("leaves the user agent untouched without suffix", func(t *testing.T) {
+		AssertStringEqual(t, userAgentOf(defaultConfig()), UserAgent)
+	})
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// NumberCoercion defines how GetRecordValue and GetProperty convert the integers (int64) and floats (float64) sent by
+// the server when another numeric type is requested.
+// The policy is passed as the optional last argument of these functions, for instance:
+//
+//	age, isNil, err := neo4j.GetRecordValue[int](record, "age", neo4j.LosslessNumbers)
+type NumberCoercion int
+
+const (
+	// StrictNumbers requires the requested type to exactly match the value type: int64 for integers and float64 for
+	// floats. This is the default.
+	StrictNumbers NumberCoercion = iota
+	// LosslessNumbers converts between int, int64 and float64 when the value is exactly representable in the
+	// requested type, and returns an error otherwise (e.g. for 1.5 requested as int64, or for 2^53+1 requested as
+	// float64).
+	LosslessNumbers
+	// LossyNumbers converts between int, int64 and float64 even when precision is lost: floats are truncated toward
+	// zero when requested as integers and large integers are rounded when requested as floats.
+	// Values that cannot be represented at all, like NaN or out-of-range floats requested as integers, still result
+	// in an error.
+	LossyNumbers
+)
+
+// maxExactFloatInteger is the largest integer magnitude float64 represents without loss
+const maxExactFloatInteger = 1 << 53
+
+func coerceValue[T any](rawValue any, coercion []NumberCoercion) (T, error) {
+	if value, ok := rawValue.(T); ok {
+		return value, nil
+	}
+	zeroValue := *new(T)
+	policy := StrictNumbers
+	if len(coercion) > 0 {
+		policy = coercion[0]
+	}
+	var converted any
+	var err error
+	switch any(zeroValue).(type) {
+	case int:
+		converted, err = coerceToInt(rawValue, policy)
+	case int64:
+		converted, err = coerceToInt64(rawValue, policy)
+	case float64:
+		converted, err = coerceToFloat64(rawValue, policy)
+	default:
+		err = errNumberCoercion
+	}
+	if err == errNumberCoercion || policy == StrictNumbers {
+		return zeroValue, fmt.Errorf("expected value to have type %T but found type %T", zeroValue, rawValue)
+	}
+	if err != nil {
+		return zeroValue, err
+	}
+	return converted.(T), nil
+}
+
+// errNumberCoercion signals that the value is not a number that can be coerced
+var errNumberCoercion = errors.New("not coercible")
+
+func coerceToInt(rawValue any, policy NumberCoercion) (int, error) {
+	value, err := coerceToInt64(rawValue, policy)
+	if err != nil {
+		return 0, err
+	}
+	if value < math.MinInt || value > math.MaxInt {
+		return 0, fmt.Errorf("cannot convert %T value %v to int: out of range", rawValue, rawValue)
+	}
+	return int(value), nil
+}
+
+func coerceToInt64(rawValue any, policy NumberCoercion) (int64, error) {
+	switch value := rawValue.(type) {
+	case int64:
+		return value, nil
+	case float64:
+		if math.IsNaN(value) || value < math.MinInt64 || value >= math.MaxInt64 {
+			return 0, fmt.Errorf("cannot convert float64 value %v to an integer: out of range", value)
+		}
+		if policy == LosslessNumbers && value != math.Trunc(value) {
+			return 0, fmt.Errorf("cannot convert float64 value %v to an integer without losing precision", value)
+		}
+		return int64(value), nil
+	}
+	return 0, errNumberCoercion
+}
+
+func coerceToFloat64(rawValue any, policy NumberCoercion) (float64, error) {
+	switch value := rawValue.(type) {
+	case float64:
+		return value, nil
+	case int64:
+		if policy == LosslessNumbers && (value > maxExactFloatInteger || value < -maxExactFloatInteger) {
+			return 0, fmt.Errorf("cannot convert int64 value %d to float64 without losing precision", value)
+		}
+		return float64(value), nil
+	}
+	return 0, errNumberCoercion
+}
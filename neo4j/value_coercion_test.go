@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j_test
+
+import (
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"math"
+	"testing"
+)
+
+func TestNumberCoercion(outer *testing.T) {
+	outer.Parallel()
+
+	outer.Run("strict by default", func(t *testing.T) {
+		_, _, err := neo4j.GetRecordValue[int](record("k", int64(42)), "k")
+		AssertStringEqual(t, err.Error(), "expected value to have type int but found type int64")
+
+		_, _, err = neo4j.GetRecordValue[float64](record("k", int64(42)), "k", neo4j.StrictNumbers)
+		AssertStringEqual(t, err.Error(), "expected value to have type float64 but found type int64")
+	})
+
+	outer.Run("lossless", func(inner *testing.T) {
+		inner.Run("converts integers to int", func(t *testing.T) {
+			value, _, err := neo4j.GetRecordValue[int](record("k", int64(42)), "k", neo4j.LosslessNumbers)
+			AssertNoError(t, err)
+			AssertIntEqual(t, value, 42)
+		})
+
+		inner.Run("converts integral floats to integers", func(t *testing.T) {
+			value, _, err := neo4j.GetRecordValue[int64](record("k", 42.0), "k", neo4j.LosslessNumbers)
+			AssertNoError(t, err)
+			AssertDeepEquals(t, value, int64(42))
+		})
+
+		inner.Run("converts small integers to floats", func(t *testing.T) {
+			value, _, err := neo4j.GetRecordValue[float64](record("k", int64(1<<53)), "k", neo4j.LosslessNumbers)
+			AssertNoError(t, err)
+			AssertDeepEquals(t, value, float64(1<<53))
+		})
+
+		inner.Run("rejects fractional floats as integers", func(t *testing.T) {
+			_, _, err := neo4j.GetRecordValue[int](record("k", 1.5), "k", neo4j.LosslessNumbers)
+			AssertStringEqual(t, err.Error(), "cannot convert float64 value 1.5 to an integer without losing precision")
+		})
+
+		inner.Run("rejects large integers as floats", func(t *testing.T) {
+			_, _, err := neo4j.GetRecordValue[float64](record("k", int64(1<<53+1)), "k", neo4j.LosslessNumbers)
+			AssertStringEqual(t, err.Error(), "cannot convert int64 value 9007199254740993 to float64 without losing precision")
+		})
+
+		inner.Run("does not convert other types", func(t *testing.T) {
+			_, _, err := neo4j.GetRecordValue[int](record("k", "42"), "k", neo4j.LosslessNumbers)
+			AssertStringEqual(t, err.Error(), "expected value to have type int but found type string")
+		})
+	})
+
+	outer.Run("lossy", func(inner *testing.T) {
+		inner.Run("truncates floats", func(t *testing.T) {
+			value, _, err := neo4j.GetRecordValue[int](record("k", -1.9), "k", neo4j.LossyNumbers)
+			AssertNoError(t, err)
+			AssertIntEqual(t, value, -1)
+		})
+
+		inner.Run("rounds large integers", func(t *testing.T) {
+			value, _, err := neo4j.GetRecordValue[float64](record("k", int64(1<<53+1)), "k", neo4j.LossyNumbers)
+			AssertNoError(t, err)
+			AssertDeepEquals(t, value, float64(1<<53))
+		})
+
+		inner.Run("rejects unrepresentable floats", func(t *testing.T) {
+			_, _, err := neo4j.GetRecordValue[int64](record("k", math.NaN()), "k", neo4j.LossyNumbers)
+			AssertError(t, err)
+			_, _, err = neo4j.GetRecordValue[int64](record("k", 1e19), "k", neo4j.LossyNumbers)
+			AssertError(t, err)
+		})
+	})
+
+	outer.Run("applies to properties", func(t *testing.T) {
+		node := neo4j.Node{Props: map[string]any{"k": int64(42)}}
+
+		value, err := neo4j.GetProperty[int](node, "k", neo4j.LosslessNumbers)
+
+		AssertNoError(t, err)
+		AssertIntEqual(t, value, 42)
+	})
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"sync/atomic"
+
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+)
+
+// writeFence is shared by all the sessions of a driver, see DriverWithContext.SetWritesPaused.
+// It is safe for concurrent use and a nil fence never pauses writes.
+type writeFence struct {
+	paused int32
+}
+
+func (f *writeFence) setPaused(paused bool) {
+	var value int32
+	if paused {
+		value = 1
+	}
+	atomic.StoreInt32(&f.paused, value)
+}
+
+// check returns a WritesPausedError if writes are paused and the given access mode is the write mode.
+// Only the access mode is checked, the queries of the transaction are not inspected.
+func (f *writeFence) check(mode idb.AccessMode) error {
+	if f == nil || mode != idb.WriteMode || atomic.LoadInt32(&f.paused) == 0 {
+		return nil
+	}
+	return &WritesPausedError{}
+}
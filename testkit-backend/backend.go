@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net"
 	"net/url"
 	"regexp"
 	"strings"
@@ -54,6 +55,10 @@ type backend struct {
 	suppliedBookmarks    map[string]neo4j.Bookmarks
 	consumedBookmarks    map[string]struct{}
 	bookmarkManagers     map[string]neo4j.BookmarkManager
+	skips                *skipList
+	owners               map[string]string // ID of the object each tracked object was created from
+	freedIds             map[string]struct{}
+	clock                backendClock
 }
 
 // To implement transactional functions a bit of extra state is needed on the
@@ -72,10 +77,13 @@ const (
 
 var ctx = context.Background()
 
-func newBackend(rd *bufio.Reader, wr io.Writer) *backend {
+func newBackend(rd *bufio.Reader, wr io.Writer, skips *skipList) *backend {
 	return &backend{
 		rd:                   rd,
 		wr:                   wr,
+		skips:                skips,
+		owners:               make(map[string]string),
+		freedIds:             make(map[string]struct{}),
 		drivers:              make(map[string]neo4j.DriverWithContext),
 		sessionStates:        make(map[string]*sessionState),
 		results:              make(map[string]neo4j.ResultWithContext),
@@ -141,7 +149,9 @@ func (b *backend) writeError(err error) {
 		neo4j.IsNeo4jError(err) ||
 		neo4j.IsUsageError(err) ||
 		neo4j.IsConnectivityError(err) ||
-		neo4j.IsTransactionExecutionLimit(err)
+		neo4j.IsCommitAmbiguousError(err) ||
+		neo4j.IsTransactionExecutionLimit(err) ||
+		neo4j.IsResultOutOfScopeError(err)
 
 	if isDriverError {
 		id := b.setError(err)
@@ -297,6 +307,7 @@ func (b *backend) handleTransactionFunc(isRead bool, data map[string]any) {
 		// Instruct client to start doing its work
 		txId := b.nextId()
 		b.managedTransactions[txId] = tx
+		b.own(txId, sid)
 		b.writeResponse("RetryableTry", map[string]any{"id": txId})
 		// Process all things that the client might do within the transaction
 		for {
@@ -433,10 +444,21 @@ func (b *backend) handleRequest(req map[string]any) {
 				parameters)
 		}
 		// Parse URI (or rather type cast)
-		uri := data["uri"].(string)
+		uri, err := toSecureUri(data["uri"].(string), data)
+		if err != nil {
+			b.writeError(err)
+			return
+		}
+		tlsConfig, err := toTlsConfig(data)
+		if err != nil {
+			b.writeError(err)
+			return
+		}
 		driver, err := neo4j.NewDriverWithContext(uri, authToken, func(c *neo4j.Config) {
 			// Setup custom logger that redirects log entries back to frontend
 			c.Log = &streamLog{writeLine: b.writeLineLocked}
+			// Testkit expects results to fail when accessed out of their scope
+			c.ResultScopeBehavior = neo4j.ResultScopeStrict
 			// Optional custom user agent from frontend
 			userAgentX := data["userAgent"]
 			if userAgentX != nil {
@@ -460,6 +482,10 @@ func (b *backend) handleRequest(req map[string]any) {
 			if data["connectionTimeoutMs"] != nil {
 				c.SocketConnectTimeout = time.Millisecond * time.Duration(asInt64(data["connectionTimeoutMs"].(json.Number)))
 			}
+			if tlsConfig != nil {
+				c.TlsConfig = tlsConfig
+			}
+			c.Clock = &b.clock
 		})
 		if err != nil {
 			b.writeError(err)
@@ -471,12 +497,23 @@ func (b *backend) handleRequest(req map[string]any) {
 
 	case "DriverClose":
 		driverId := data["driverId"].(string)
-		driver := b.drivers[driverId]
+		driver, found := b.drivers[driverId]
+		if !found {
+			if b.isFreed(driverId) {
+				b.writeResponse("Driver", map[string]any{"id": driverId})
+				return
+			}
+			b.writeError(unknownIdError("driver", driverId))
+			return
+		}
 		err := driver.Close(ctx)
 		if err != nil {
 			b.writeError(err)
 			return
 		}
+		// sessions, transactions and results cannot be used anymore once their driver is closed
+		b.releaseOwnedBy(driverId)
+		b.release(driverId)
 		b.writeResponse("Driver", map[string]any{"id": driverId})
 
 	case "GetServerInfo":
@@ -489,7 +526,7 @@ func (b *backend) handleRequest(req map[string]any) {
 		}
 		protocolVersion := serverInfo.ProtocolVersion()
 		b.writeResponse("ServerInfo", map[string]any{
-			"address":         serverInfo.Address(),
+			"address":         resolveAddress(serverInfo.Address()),
 			"agent":           serverInfo.Agent(),
 			"protocolVersion": fmt.Sprintf("%d.%d", protocolVersion.Major, protocolVersion.Minor),
 		})
@@ -596,6 +633,7 @@ func (b *backend) handleRequest(req map[string]any) {
 		session := driver.NewSession(ctx, sessionConfig)
 		idKey := b.nextId()
 		b.sessionStates[idKey] = &sessionState{session: session}
+		b.own(idKey, data["driverId"].(string))
 		b.writeResponse("Session", map[string]any{"id": idKey})
 
 	case "NewBookmarkManager":
@@ -615,16 +653,26 @@ func (b *backend) handleRequest(req map[string]any) {
 
 	case "SessionClose":
 		sessionId := data["sessionId"].(string)
-		sessionState := b.sessionStates[sessionId]
+		sessionState, found := b.sessionStates[sessionId]
+		if !found {
+			if b.isFreed(sessionId) {
+				b.writeResponse("Session", map[string]any{"id": sessionId})
+				return
+			}
+			b.writeError(unknownIdError("session", sessionId))
+			return
+		}
 		err := sessionState.session.Close(ctx)
 		if err != nil {
 			b.writeError(err)
 			return
 		}
+		b.release(sessionId)
 		b.writeResponse("Session", map[string]any{"id": sessionId})
 
 	case "SessionRun":
-		sessionState := b.sessionStates[data["sessionId"].(string)]
+		sessionId := data["sessionId"].(string)
+		sessionState := b.sessionStates[sessionId]
 		cypher, params, err := b.toCypherAndParams(data)
 		if err != nil {
 			b.writeError(err)
@@ -642,10 +690,12 @@ func (b *backend) handleRequest(req map[string]any) {
 		}
 		idKey := b.nextId()
 		b.results[idKey] = result
+		b.own(idKey, sessionId)
 		b.writeResponse("Result", map[string]any{"id": idKey, "keys": keys})
 
 	case "SessionBeginTransaction":
-		sessionState := b.sessionStates[data["sessionId"].(string)]
+		sessionId := data["sessionId"].(string)
+		sessionState := b.sessionStates[sessionId]
 		tx, err := sessionState.session.BeginTransaction(ctx, b.toTransactionConfigApply(data))
 		if err != nil {
 			b.writeError(err)
@@ -653,6 +703,7 @@ func (b *backend) handleRequest(req map[string]any) {
 		}
 		idKey := b.nextId()
 		b.explicitTransactions[idKey] = tx
+		b.own(idKey, sessionId)
 		b.writeResponse("Transaction", map[string]any{"id": idKey})
 
 	case "SessionLastBookmarks":
@@ -689,6 +740,7 @@ func (b *backend) handleRequest(req map[string]any) {
 		}
 		idKey := b.nextId()
 		b.results[idKey] = result
+		b.own(idKey, transactionId)
 		b.writeResponse("Result", map[string]any{"id": idKey, "keys": keys})
 
 	case "TransactionCommit":
@@ -713,12 +765,21 @@ func (b *backend) handleRequest(req map[string]any) {
 
 	case "TransactionClose":
 		txId := data["txId"].(string)
-		tx := b.explicitTransactions[txId]
+		tx, found := b.explicitTransactions[txId]
+		if !found {
+			if b.isFreed(txId) {
+				b.writeResponse("Transaction", map[string]any{"id": txId})
+				return
+			}
+			b.writeError(unknownIdError("transaction", txId))
+			return
+		}
 		err := tx.Close(ctx)
 		if err != nil {
 			b.writeError(err)
 			return
 		}
+		b.release(txId)
 		b.writeResponse("Transaction", map[string]any{"id": txId})
 
 	case "SessionReadTransaction":
@@ -807,10 +868,23 @@ func (b *backend) handleRequest(req map[string]any) {
 		}
 		b.writeResponse("Driver", map[string]any{"id": driverId})
 
+	case "FakeTimeInstall":
+		b.clock.install()
+		b.writeResponse("FakeTimeAck", nil)
+
+	case "FakeTimeTick":
+		b.clock.tick(time.Millisecond * time.Duration(asInt64(data["incrementMs"].(json.Number))))
+		b.writeResponse("FakeTimeAck", nil)
+
+	case "FakeTimeUninstall":
+		b.clock.uninstall()
+		b.writeResponse("FakeTimeAck", nil)
+
 	case "GetFeatures":
 		b.writeResponse("FeatureList", map[string]any{
 			"features": []string{
 				"ConfHint:connection.recv_timeout_seconds",
+				"Backend:MockTime",
 				"Detail:ClosedDriverIsEncrypted",
 				"Feature:API:BookmarkManager",
 				"Feature:API:ConnectionAcquisitionTimeout",
@@ -821,6 +895,8 @@ func (b *backend) handleRequest(req map[string]any) {
 				"Feature:API:Liveness.Check",
 				"Feature:API:Result.List",
 				"Feature:API:Result.Peek",
+				"Feature:API:SSLClientCertificate",
+				"Feature:API:SSLConfig",
 				"Feature:API:Type.Spatial",
 				"Feature:API:Type.Temporal",
 				"Feature:Auth:Custom",
@@ -847,7 +923,7 @@ func (b *backend) handleRequest(req map[string]any) {
 
 	case "StartTest":
 		testName := data["testName"].(string)
-		if reason, ok := mustSkip(testName); ok {
+		if reason, ok := b.skips.mustSkip(testName); ok {
 			b.writeResponse("SkipTest", map[string]any{"reason": reason})
 			return
 		}
@@ -902,16 +978,6 @@ func (b *backend) writeRecord(result neo4j.ResultWithContext, record *neo4j.Reco
 	}
 }
 
-func mustSkip(testName string) (string, bool) {
-	skippedTests := testSkips()
-	for testPattern, exclusionReason := range skippedTests {
-		if matches(testPattern, testName) {
-			return exclusionReason, true
-		}
-	}
-	return "", false
-}
-
 func mustSkipSubTest(testName string, arguments map[string]any) (string, bool) {
 	if strings.Contains(testName, "test_should_echo_all_timezone_ids") {
 		return mustSkipTimeZoneSubTest(arguments)
@@ -964,16 +1030,18 @@ func serializeNotifications(slice []neo4j.Notification) []map[string]any {
 	var res []map[string]any
 	for i, notification := range slice {
 		res = append(res, map[string]any{
-			"code":        notification.Code(),
-			"title":       notification.Title(),
-			"description": notification.Description(),
-			"severity":    notification.Severity(),
+			"code":             notification.Code(),
+			"title":            notification.Title(),
+			"description":      notification.Description(),
+			"severity":         notification.Severity(),
+			"rawSeverityLevel": notification.RawSeverityLevel(),
+			"rawCategory":      notification.RawCategory(),
 		})
-		if notification.Position() != nil {
+		if position, ok := notification.TypedPosition().Get(); ok {
 			res[i]["position"] = map[string]any{
-				"offset": notification.Position().Offset(),
-				"line":   notification.Position().Line(),
-				"column": notification.Position().Column(),
+				"offset": position.Offset,
+				"line":   position.Line,
+				"column": position.Column,
 			}
 		}
 	}
@@ -988,7 +1056,7 @@ func serializeSummary(summary neo4j.ResultSummary) map[string]any {
 		"serverInfo": map[string]any{
 			"protocolVersion": fmt.Sprintf("%d.%d", protocolVersion.Major, protocolVersion.Minor),
 			"agent":           serverInfo.Agent(),
-			"address":         serverInfo.Address(),
+			"address":         resolveAddress(serverInfo.Address()),
 		},
 		"counters": map[string]any{
 			"constraintsAdded":      counters.ConstraintsAdded(),
@@ -1029,6 +1097,29 @@ func serializeSummary(summary neo4j.ResultSummary) map[string]any {
 	return response
 }
 
+// resolveAddress resolves the host of the given host:port address to its canonical IPv4 address, the way TestKit
+// reports server addresses.
+// The address is returned as is if it cannot be resolved.
+func resolveAddress(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || net.ParseIP(host) != nil {
+		return address
+	}
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return address
+	}
+	for _, ip := range ips {
+		if ipv4 := ip.To4(); ipv4 != nil {
+			return net.JoinHostPort(ipv4.String(), port)
+		}
+	}
+	if len(ips) > 0 {
+		return net.JoinHostPort(ips[0].String(), port)
+	}
+	return address
+}
+
 func serializePlans(children []neo4j.Plan) []map[string]any {
 	result := make([]map[string]any, len(children))
 	for i, child := range children {
@@ -1097,37 +1188,6 @@ func firstRecordInvalidValue(record *db.Record) *neo4j.InvalidValue {
 	return nil
 }
 
-// you can use '*' as wildcards anywhere in the qualified test name (useful to exclude a whole class e.g.)
-func testSkips() map[string]string {
-	return map[string]string{
-		"stub.disconnects.test_disconnects.TestDisconnects.test_fail_on_reset":                                                   "It is not resetting driver when put back to pool",
-		"stub.routing.test_routing_v3.RoutingV3.test_should_use_resolver_during_rediscovery_when_existing_routers_fail":          "It needs investigation - custom resolver does not seem to be called",
-		"stub.routing.test_routing_v4x1.RoutingV4x1.test_should_use_resolver_during_rediscovery_when_existing_routers_fail":      "It needs investigation - custom resolver does not seem to be called",
-		"stub.routing.test_routing_v4x3.RoutingV4x3.test_should_use_resolver_during_rediscovery_when_existing_routers_fail":      "It needs investigation - custom resolver does not seem to be called",
-		"stub.routing.test_routing_v4x4.RoutingV4x4.test_should_use_resolver_during_rediscovery_when_existing_routers_fail":      "It needs investigation - custom resolver does not seem to be called",
-		"stub.routing.test_routing_v5x0.RoutingV5x0.test_should_use_resolver_during_rediscovery_when_existing_routers_fail":      "It needs investigation - custom resolver does not seem to be called",
-		"stub.routing.test_routing_v3.RoutingV3.test_should_revert_to_initial_router_if_known_router_throws_protocol_errors":     "It needs investigation - custom resolver does not seem to be called",
-		"stub.routing.test_routing_v4x1.RoutingV4x1.test_should_revert_to_initial_router_if_known_router_throws_protocol_errors": "It needs investigation - custom resolver does not seem to be called",
-		"stub.routing.test_routing_v4x3.RoutingV4x3.test_should_revert_to_initial_router_if_known_router_throws_protocol_errors": "It needs investigation - custom resolver does not seem to be called",
-		"stub.routing.test_routing_v4x4.RoutingV4x4.test_should_revert_to_initial_router_if_known_router_throws_protocol_errors": "It needs investigation - custom resolver does not seem to be called",
-		"stub.routing.test_routing_v5x0.RoutingV5x0.test_should_revert_to_initial_router_if_known_router_throws_protocol_errors": "It needs investigation - custom resolver does not seem to be called",
-		"stub.configuration_hints.test_connection_recv_timeout_seconds.TestRoutingConnectionRecvTimeout.*":                       "No GetRoutingTable support - too tricky to implement in Go",
-		"stub.homedb.test_homedb.TestHomeDb.test_session_should_cache_home_db_despite_new_rt":                                    "Driver does not remove servers from RT when connection breaks.",
-		"stub.iteration.test_result_scope.TestResultScope.*":                                                                     "Results are always valid but don't return records when out of scope",
-		"stub.*.test_0_timeout":        "Driver omits 0 as tx timeout value",
-		"stub.*.test_negative_timeout": "Driver omits negative tx timeout values",
-		"stub.routing.*.*.test_should_request_rt_from_all_initial_routers_until_successful_on_unknown_failure":                                     "Add DNS resolver TestKit message and connection timeout support",
-		"stub.routing.*.*.test_should_request_rt_from_all_initial_routers_until_successful_on_authorization_expired":                               "Add DNS resolver TestKit message and connection timeout support",
-		"stub.summary.test_summary.TestSummary.test_server_info":                                                                                   "Needs some kind of server address DNS resolution",
-		"stub.summary.test_summary.TestSummary.test_invalid_query_type":                                                                            "Driver does not verify query type returned from server.",
-		"stub.routing.*.test_should_drop_connections_failing_liveness_check":                                                                       "Needs support for GetConnectionPoolMetrics",
-		"stub.connectivity_check.test_get_server_info.TestGetServerInfo.test_routing_fail_when_no_reader_are_available":                            "Won't fix - Go driver retries routing table when no readers are available",
-		"stub.connectivity_check.test_verify_connectivity.TestVerifyConnectivity.test_routing_fail_when_no_reader_are_available":                   "Won't fix - Go driver retries routing table when no readers are available",
-		"stub.driver_parameters.test_connection_acquisition_timeout_ms.TestConnectionAcquisitionTimeoutMs.test_does_not_encompass_router_*":        "Won't fix - ConnectionAcquisitionTimeout spans the whole process including db resolution, RT updates, connection acquisition from the pool, and creation of new connections.",
-		"stub.driver_parameters.test_connection_acquisition_timeout_ms.TestConnectionAcquisitionTimeoutMs.test_router_handshake_has_own_timeout_*": "Won't fix - ConnectionAcquisitionTimeout spans the whole process including db resolution, RT updates, connection acquisition from the pool, and creation of new connections.",
-	}
-}
-
 func mustSkipTimeZoneSubTest(arguments map[string]any) (string, bool) {
 	rawDateTime := arguments["dt"].(map[string]any)
 	dateTimeData := rawDateTime["data"].(map[string]any)
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/clock"
+)
+
+// backendClock is the clock of every driver created by the backend.
+// It follows the system clock until the frontend installs fake time, and the fake time from then on until the
+// frontend uninstalls it.
+type backendClock struct {
+	mut  sync.Mutex
+	fake *clock.Fake
+}
+
+func (c *backendClock) current() clock.Clock {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.fake == nil {
+		return clock.System()
+	}
+	return c.fake
+}
+
+func (c *backendClock) Now() time.Time {
+	return c.current().Now()
+}
+
+func (c *backendClock) Sleep(d time.Duration) {
+	c.current().Sleep(d)
+}
+
+func (c *backendClock) install() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.fake = clock.NewFake(time.Now())
+}
+
+func (c *backendClock) tick(d time.Duration) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.fake != nil {
+		c.fake.Advance(d)
+	}
+}
+
+func (c *backendClock) uninstall() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.fake = nil
+}
@@ -23,9 +23,15 @@ package main
 import (
 	"bufio"
 	"net"
+	"os"
 )
 
 func main() {
+	skips, err := loadSkipList(os.Getenv(skipsFileEnvVar), os.Getenv(serverVersionEnvVar))
+	if err != nil {
+		panic(err)
+	}
+
 	l, err := net.Listen("tcp", ":9876")
 	if err != nil {
 		panic(err)
@@ -39,7 +45,7 @@ func main() {
 			panic(err)
 		}
 		// Hand over connection to backend
-		backend := newBackend(bufio.NewReader(conn), conn)
+		backend := newBackend(bufio.NewReader(conn), conn, skips)
 		backend.serve()
 	}
 }
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package main
+
+import "fmt"
+
+// own records that the object identified by id was created from the object identified by ownerId, e.g. a session
+// created by a driver, so that it can be released when its owner is released
+func (b *backend) own(id, ownerId string) {
+	b.owners[id] = ownerId
+}
+
+// release forgets about the object identified by id, subsequent close requests for it are acknowledged without doing
+// anything
+func (b *backend) release(id string) {
+	delete(b.drivers, id)
+	delete(b.sessionStates, id)
+	delete(b.explicitTransactions, id)
+	delete(b.managedTransactions, id)
+	delete(b.results, id)
+	b.freedIds[id] = struct{}{}
+}
+
+// releaseOwnedBy releases all the objects transitively created from the object identified by ownerId
+func (b *backend) releaseOwnedBy(ownerId string) {
+	for id, owner := range b.owners {
+		if owner != ownerId {
+			continue
+		}
+		delete(b.owners, id)
+		b.releaseOwnedBy(id)
+		b.release(id)
+	}
+}
+
+// isFreed returns true if the object identified by id has already been released
+func (b *backend) isFreed(id string) bool {
+	_, found := b.freedIds[id]
+	return found
+}
+
+func unknownIdError(kind, id string) error {
+	return fmt.Errorf("unknown %s ID: %s", kind, id)
+}
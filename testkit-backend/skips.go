@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const (
+	// skipsFileEnvVar names the environment variable pointing to an optional JSON file of extra test skips
+	skipsFileEnvVar = "TEST_BACKEND_SKIPS_FILE"
+	// serverVersionEnvVar names the environment variable holding the version of the server the tests run against
+	serverVersionEnvVar = "TEST_NEO4J_VERSION"
+)
+
+// skipList holds the tests the backend must skip, along with the reason why.
+// Skips apply to all server versions, unless they are registered for specific server versions with
+// addServerVersionSkips.
+// You can use '*' as wildcards anywhere in the qualified test name or server version.
+type skipList struct {
+	serverVersion string
+	skips         map[string]string
+}
+
+// skipsFile is the format of the file referenced by skipsFileEnvVar, for instance:
+//
+//	{
+//		"skips": {
+//			"stub.routing.*.test_some_routing_test": "reason"
+//		},
+//		"serverVersionSkips": {
+//			"4.4*": {
+//				"neo4j.test_some_integration_test": "reason"
+//			}
+//		}
+//	}
+type skipsFile struct {
+	Skips              map[string]string            `json:"skips"`
+	ServerVersionSkips map[string]map[string]string `json:"serverVersionSkips"`
+}
+
+func newSkipList(serverVersion string) *skipList {
+	return &skipList{serverVersion: serverVersion, skips: defaultTestSkips()}
+}
+
+// loadSkipList creates the skip list made of the default skips and, if path is not empty, the skips defined in the
+// file at that path
+func loadSkipList(path, serverVersion string) (*skipList, error) {
+	skips := newSkipList(serverVersion)
+	if path == "" {
+		return skips, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read skips file %s: %w", path, err)
+	}
+	var file skipsFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("could not parse skips file %s: %w", path, err)
+	}
+	skips.addSkips(file.Skips)
+	for versionPattern, versionSkips := range file.ServerVersionSkips {
+		skips.addServerVersionSkips(versionPattern, versionSkips)
+	}
+	return skips, nil
+}
+
+// addSkips registers skips that apply regardless of the server version
+func (s *skipList) addSkips(skips map[string]string) {
+	for testPattern, reason := range skips {
+		s.skips[testPattern] = reason
+	}
+}
+
+// addServerVersionSkips registers skips that only apply when the server version matches versionPattern
+func (s *skipList) addServerVersionSkips(versionPattern string, skips map[string]string) {
+	if s.serverVersion == "" || !matches(versionPattern, s.serverVersion) {
+		return
+	}
+	s.addSkips(skips)
+}
+
+func (s *skipList) mustSkip(testName string) (string, bool) {
+	for testPattern, exclusionReason := range s.skips {
+		if matches(testPattern, testName) {
+			return exclusionReason, true
+		}
+	}
+	return "", false
+}
+
+// you can use '*' as wildcards anywhere in the qualified test name (useful to exclude a whole class e.g.)
+func defaultTestSkips() map[string]string {
+	return map[string]string{
+		"stub.disconnects.test_disconnects.TestDisconnects.test_fail_on_reset":                                                   "It is not resetting driver when put back to pool",
+		"stub.routing.test_routing_v3.RoutingV3.test_should_use_resolver_during_rediscovery_when_existing_routers_fail":          "It needs investigation - custom resolver does not seem to be called",
+		"stub.routing.test_routing_v4x1.RoutingV4x1.test_should_use_resolver_during_rediscovery_when_existing_routers_fail":      "It needs investigation - custom resolver does not seem to be called",
+		"stub.routing.test_routing_v4x3.RoutingV4x3.test_should_use_resolver_during_rediscovery_when_existing_routers_fail":      "It needs investigation - custom resolver does not seem to be called",
+		"stub.routing.test_routing_v4x4.RoutingV4x4.test_should_use_resolver_during_rediscovery_when_existing_routers_fail":      "It needs investigation - custom resolver does not seem to be called",
+		"stub.routing.test_routing_v5x0.RoutingV5x0.test_should_use_resolver_during_rediscovery_when_existing_routers_fail":      "It needs investigation - custom resolver does not seem to be called",
+		"stub.routing.test_routing_v3.RoutingV3.test_should_revert_to_initial_router_if_known_router_throws_protocol_errors":     "It needs investigation - custom resolver does not seem to be called",
+		"stub.routing.test_routing_v4x1.RoutingV4x1.test_should_revert_to_initial_router_if_known_router_throws_protocol_errors": "It needs investigation - custom resolver does not seem to be called",
+		"stub.routing.test_routing_v4x3.RoutingV4x3.test_should_revert_to_initial_router_if_known_router_throws_protocol_errors": "It needs investigation - custom resolver does not seem to be called",
+		"stub.routing.test_routing_v4x4.RoutingV4x4.test_should_revert_to_initial_router_if_known_router_throws_protocol_errors": "It needs investigation - custom resolver does not seem to be called",
+		"stub.routing.test_routing_v5x0.RoutingV5x0.test_should_revert_to_initial_router_if_known_router_throws_protocol_errors": "It needs investigation - custom resolver does not seem to be called",
+		"stub.configuration_hints.test_connection_recv_timeout_seconds.TestRoutingConnectionRecvTimeout.*":                       "No GetRoutingTable support - too tricky to implement in Go",
+		"stub.homedb.test_homedb.TestHomeDb.test_session_should_cache_home_db_despite_new_rt":                                    "Driver does not remove servers from RT when connection breaks.",
+		"stub.*.test_0_timeout":        "Driver omits 0 as tx timeout value",
+		"stub.*.test_negative_timeout": "Driver omits negative tx timeout values",
+		"stub.routing.*.*.test_should_request_rt_from_all_initial_routers_until_successful_on_unknown_failure":                                     "Add DNS resolver TestKit message and connection timeout support",
+		"stub.routing.*.*.test_should_request_rt_from_all_initial_routers_until_successful_on_authorization_expired":                               "Add DNS resolver TestKit message and connection timeout support",
+		"stub.routing.*.test_should_drop_connections_failing_liveness_check":                                                                       "Needs support for GetConnectionPoolMetrics",
+		"stub.connectivity_check.test_get_server_info.TestGetServerInfo.test_routing_fail_when_no_reader_are_available":                            "Won't fix - Go driver retries routing table when no readers are available",
+		"stub.connectivity_check.test_verify_connectivity.TestVerifyConnectivity.test_routing_fail_when_no_reader_are_available":                   "Won't fix - Go driver retries routing table when no readers are available",
+		"stub.driver_parameters.test_connection_acquisition_timeout_ms.TestConnectionAcquisitionTimeoutMs.test_does_not_encompass_router_*":        "Won't fix - ConnectionAcquisitionTimeout spans the whole process including db resolution, RT updates, connection acquisition from the pool, and creation of new connections.",
+		"stub.driver_parameters.test_connection_acquisition_timeout_ms.TestConnectionAcquisitionTimeoutMs.test_router_handshake_has_own_timeout_*": "Won't fix - ConnectionAcquisitionTimeout spans the whole process including db resolution, RT updates, connection acquisition from the pool, and creation of new connections.",
+	}
+}
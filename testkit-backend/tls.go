@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// Directory where TestKit mounts the custom CA certificates listed in trustedCertificates
+const trustedCertificatesDir = "/usr/local/share/custom-ca-certificates/"
+
+// toSecureUri honors the encrypted and trustedCertificates settings of a NewDriver request.
+// Encryption is driven by the URI scheme in the driver, so the scheme is upgraded to its secure variant when
+// encryption is requested. An empty list of trusted certificates means that all certificates are trusted, which
+// maps to the self-signed certificate variant of the scheme.
+func toSecureUri(rawUri string, data map[string]any) (string, error) {
+	encrypted, _ := data["encrypted"].(bool)
+	if !encrypted {
+		return rawUri, nil
+	}
+	parsedUri, err := url.Parse(rawUri)
+	if err != nil {
+		return "", err
+	}
+	switch parsedUri.Scheme {
+	case "bolt", "neo4j":
+	default:
+		return "", fmt.Errorf("cannot configure encryption for URI scheme %s", parsedUri.Scheme)
+	}
+	trustedCertificates, ok := data["trustedCertificates"].([]any)
+	if ok && len(trustedCertificates) == 0 {
+		parsedUri.Scheme += "+ssc"
+	} else {
+		parsedUri.Scheme += "+s"
+	}
+	return parsedUri.String(), nil
+}
+
+// toTlsConfig builds the TLS configuration of a NewDriver request, loading the trusted CA certificates and the client
+// certificate if any.
+// nil is returned when the request does not customize TLS, so that the driver defaults apply.
+func toTlsConfig(data map[string]any) (*tls.Config, error) {
+	trustedCertificates, _ := data["trustedCertificates"].([]any)
+	clientCertificate, _ := data["clientCertificate"].(map[string]any)
+	if len(trustedCertificates) == 0 && clientCertificate == nil {
+		return nil, nil
+	}
+	config := &tls.Config{}
+	if len(trustedCertificates) > 0 {
+		rootCAs := x509.NewCertPool()
+		for _, trustedCertificate := range trustedCertificates {
+			path := filepath.Join(trustedCertificatesDir, trustedCertificate.(string))
+			pem, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			if !rootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("could not load any certificate from %s", path)
+			}
+		}
+		config.RootCAs = rootCAs
+	}
+	if clientCertificate != nil {
+		certificateData := clientCertificate["data"].(map[string]any)
+		certificate, err := tls.LoadX509KeyPair(certificateData["certfile"].(string), certificateData["keyfile"].(string))
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}